@@ -35,6 +35,11 @@ func Dump(db *genji.DB, w io.Writer, tables ...string) error {
 
 		return dumpTable(tx, w, query, name)
 	})
+	// views and triggers are dumped after the tables they use,
+	// and triggers after the data so that loading the dump doesn't fire them
+	if err == nil && len(tables) == 0 {
+		err = dumpRelations(tx, w, i > 0, "view", "trigger")
+	}
 	if err != nil {
 		_, er := fmt.Fprintln(w, "ROLLBACK;")
 		return multierr.Append(err, er)
@@ -84,7 +89,7 @@ func DumpSchema(db *genji.DB, w io.Writer, tables ...string) error {
 	defer tx.Rollback()
 
 	i := 0
-	return QueryTables(tx, tables, func(name, query string) error {
+	err = QueryTables(tx, tables, func(name, query string) error {
 		// Blank separation between tables.
 		if i > 0 {
 			if _, err := fmt.Fprintln(w, ""); err != nil {
@@ -95,6 +100,50 @@ func DumpSchema(db *genji.DB, w io.Writer, tables ...string) error {
 
 		return dumpSchema(tx, w, query, name)
 	})
+	if err != nil || len(tables) > 0 {
+		return err
+	}
+
+	return dumpRelations(tx, w, i > 0, "view", "trigger")
+}
+
+// dumpRelations displays the CREATE statements of all the relations
+// of the given types, e.g. views, in that order.
+// If separate is true, a blank line is written before the first statement.
+func dumpRelations(tx *genji.Tx, w io.Writer, separate bool, relTypes ...string) error {
+	for _, tp := range relTypes {
+		err := func() error {
+			res, err := tx.Query("SELECT sql FROM __genji_catalog WHERE type = ?", tp)
+			if err != nil {
+				return err
+			}
+			defer res.Close()
+
+			return res.Iterate(func(d types.Document) error {
+				var q string
+
+				err = document.Scan(d, &q)
+				if err != nil {
+					return err
+				}
+
+				if separate {
+					if _, err := fmt.Fprintln(w, ""); err != nil {
+						return err
+					}
+					separate = false
+				}
+
+				_, err = fmt.Fprintf(w, "%s;\n", q)
+				return err
+			})
+		}()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // dumpSchema displays the schema of the given table as SQL statements.
@@ -83,6 +83,22 @@ func TestDump(t *testing.T) {
 				assert.NoError(t, err)
 				writeToBuf(q + "\n")
 			}
+
+			// views and triggers are only dumped with the whole database
+			q := `CREATE VIEW v AS SELECT a FROM tblA;`
+			err = db.Exec(q)
+			assert.NoError(t, err)
+			if len(tt.tables) == 0 {
+				want.WriteString("\n" + q + "\n")
+			}
+
+			q = `CREATE TRIGGER tr AFTER DELETE ON tblA DELETE FROM tblA WHERE b = old.a;`
+			err = db.Exec(q)
+			assert.NoError(t, err)
+			if len(tt.tables) == 0 {
+				want.WriteString(q + "\n")
+			}
+
 			want.WriteString("COMMIT;\n")
 
 			var got bytes.Buffer
@@ -32,6 +32,13 @@ func DocString(rawExpr string) (string, error) {
 		return scanFuncDocString(s)
 	}
 	docstr, ok := tokenDocs[tok]
+	// some keywords are also the names of builtin functions, i.e. EXISTS
+	if fdoc, err := funcDocString("", strings.ToLower(tok.String())); err == nil {
+		if ok && docstr != "TODO" {
+			return fdoc + "\n" + docstr, nil
+		}
+		return fdoc, nil
+	}
 	if ok {
 		return docstr, nil
 	}
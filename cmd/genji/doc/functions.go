@@ -9,15 +9,24 @@ var packageDocs = map[string]functionDocs{
 }
 
 var builtinDocs = functionDocs{
-	"pk":       "The pk() function returns the primary key for the current document",
-	"count":    "Returns a count of the number of times that arg1 is not NULL in a group. The count(*) function (with no arguments) returns the total number of rows in the group.",
-	"min":      "Returns the minimum value of the arg1 expression in a group.",
-	"max":      "Returns the maximum value of the arg1 expressein in a group.",
-	"sum":      "The sum function returns the sum of all values taken by the arg1 expression in a group.",
-	"avg":      "The avg function returns the average of all values taken by the arg1 expression in a group.",
-	"typeof":   "The typeof function returns the type of arg1.",
-	"len":      "The len function returns length of the arg1 expression if arg1 evals to string, array or document, either returns NULL.",
-	"coalesce": "The coalesce function returns the first non-null argument. NULL is returned if all arguments are null.",
+	"pk":           "The pk() function returns the primary key for the current document",
+	"count":        "Returns a count of the number of times that arg1 is not NULL in a group. The count(*) function (with no arguments) returns the total number of rows in the group.",
+	"min":          "Returns the minimum value of the arg1 expression in a group.",
+	"max":          "Returns the maximum value of the arg1 expressein in a group.",
+	"sum":          "The sum function returns the sum of all values taken by the arg1 expression in a group.",
+	"avg":          "The avg function returns the average of all values taken by the arg1 expression in a group.",
+	"typeof":       "The typeof function returns the type of arg1.",
+	"len":          "The len function returns length of the arg1 expression if arg1 evals to string, array or document, either returns NULL.",
+	"coalesce":     "The coalesce function returns the first non-null argument. NULL is returned if all arguments are null.",
+	"nullif":       "The nullif function returns NULL if arg1 is equal to arg2, otherwise it returns arg1.",
+	"exists":       "The exists function returns true if the arg1 path is present in the current document, even if its value is NULL.",
+	"array_length": "The array_length function returns the number of elements of arg1 if arg1 evals to an array, either returns NULL.",
+	"now":          "The now function returns the current timestamp.",
+	"to_bool":      "The to_bool function converts arg1 to a boolean, following the rules of CAST. It returns NULL if arg1 cannot be converted.",
+	"to_integer":   "The to_integer function converts arg1 to an integer, following the rules of CAST. It returns NULL if arg1 cannot be converted.",
+	"to_double":    "The to_double function converts arg1 to a double, following the rules of CAST. It returns NULL if arg1 cannot be converted.",
+	"to_text":      "The to_text function converts arg1 to a text, following the rules of CAST. It returns NULL if arg1 cannot be converted.",
+	"to_timestamp": "The to_timestamp function converts arg1 to a timestamp, following the rules of CAST. It returns NULL if arg1 cannot be converted.",
 }
 
 var mathDocs = functionDocs{
@@ -28,15 +37,21 @@ var mathDocs = functionDocs{
 	"asinh":  "Returns the inverse hyperbolic sine of arg1.",
 	"atan":   "Returns the arctangent, in radians, of arg1.",
 	"atan2":  "Returns the arctangent of arg1/arg2, using the signs of the two to determine the quadrant of the return value.",
+	"ceil":   "Returns the smallest integer value greater than or equal to arg1.",
 	"floor":  "Returns the greatest integer value less than or equal to arg1.",
 	"random": "The random function returns a random number between math.MinInt64 and math.MaxInt64.",
+	"round":  "Returns arg1 rounded to arg2 decimal digits, or to the nearest integer if arg2 is omitted. Halfway values are rounded away from zero.",
 	"sqrt":   "The sqrt function returns the square root of arg1.",
+	"pow":    "Returns arg1 raised to the power of arg2, or NULL if the result is not a finite number.",
 }
 
 var stringsDocs = functionDocs{
-	"lower": "The lower function returns arg1 to lower-case if arg1 evals to string",
-	"upper": "The upper function returns arg1 to upper-case if arg1 evals to string",
-	"trim":  "The trim function returns arg1 with leading and trailing characters removed. space by default or arg2",
-	"ltrim": "The ltrim function returns arg1 with leading characters removed. space by default or arg2",
-	"rtrim": "The rtrim function returns arg1 with trailing characters removed. space by default or arg2",
+	"lower":  "The lower function returns arg1 to lower-case if arg1 evals to string",
+	"upper":  "The upper function returns arg1 to upper-case if arg1 evals to string",
+	"trim":   "The trim function returns arg1 with leading and trailing characters removed. space by default or arg2",
+	"ltrim":  "The ltrim function returns arg1 with leading characters removed. space by default or arg2",
+	"rtrim":  "The rtrim function returns arg1 with trailing characters removed. space by default or arg2",
+	"substr": "The substr function returns the characters of arg1 starting at position arg2, counted from 1, up to arg3 characters if given. A negative arg2 is counted from the end of arg1",
+	"length": "The length function returns the number of characters of arg1 if arg1 evals to string, the number of bytes if arg1 evals to blob, either returns NULL",
+	"concat": "The concat function returns the concatenation of the text representation of its arguments, ignoring NULL values",
 }
@@ -13,5 +13,6 @@ func init() {
 	}
 
 	tokenDocs[scanner.BY] = "See GROUP BY, ORDER BY"
+	tokenDocs[scanner.EXISTS] = "IF EXISTS and IF NOT EXISTS make a statement ignore objects that do or don't exist"
 	tokenDocs[scanner.FROM] = "FROM [TABLE] selects documents in the table named [TABLE]"
 }
@@ -55,6 +55,16 @@ var commands = []command{
 		DisplayName: ".indexes",
 		Description: "Display all indexes or the indexes of the given table name.",
 	},
+	{
+		Name:        ".views",
+		DisplayName: ".views",
+		Description: "List names of views.",
+	},
+	{
+		Name:        ".triggers",
+		DisplayName: ".triggers",
+		Description: "List names of triggers.",
+	},
 	{
 		Name:        ".dump",
 		Options:     "[table_name]",
@@ -150,6 +160,44 @@ func runTablesCmd(db *genji.DB, w io.Writer) error {
 	})
 }
 
+// runViewsCmd displays all views.
+func runViewsCmd(db *genji.DB, w io.Writer) error {
+	res, err := db.Query("SELECT name FROM __genji_catalog WHERE type = 'view'")
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	return res.Iterate(func(d types.Document) error {
+		var viewName string
+		err = document.Scan(d, &viewName)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, viewName)
+		return err
+	})
+}
+
+// runTriggersCmd displays all triggers.
+func runTriggersCmd(db *genji.DB, w io.Writer) error {
+	res, err := db.Query("SELECT name FROM __genji_catalog WHERE type = 'trigger'")
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	return res.Iterate(func(d types.Document) error {
+		var triggerName string
+		err = document.Scan(d, &triggerName)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, triggerName)
+		return err
+	})
+}
+
 // runIndexesCmd displays a list of indexes. If table is non-empty, it only
 // displays that table's indexes. If not, it displays all indexes.
 func runIndexesCmd(db *genji.DB, tableName string, w io.Writer) error {
@@ -334,6 +334,18 @@ func (sh *Shell) runCommand(ctx context.Context, in string, out io.Writer) error
 		}
 
 		return runIndexesCmd(sh.db, tableName, out)
+	case ".views":
+		if len(cmd) > 1 {
+			return fmt.Errorf(getUsage(".views"))
+		}
+
+		return runViewsCmd(sh.db, out)
+	case ".triggers":
+		if len(cmd) > 1 {
+			return fmt.Errorf(getUsage(".triggers"))
+		}
+
+		return runTriggersCmd(sh.db, out)
 	case ".dump":
 		return dbutil.Dump(sh.db, out, cmd[1:]...)
 	case ".save":
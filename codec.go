@@ -0,0 +1,28 @@
+package genji
+
+import (
+	"github.com/genjidb/genji/internal/database"
+)
+
+// RegisterFieldCodec registers functions that transform the value of a top-level field
+// of a table when it is written to disk (encode) and when it is read back (decode),
+// e.g. to encrypt sensitive data.
+// Codecs only apply to TEXT and BLOB values, which keep their type. Constraints,
+// such as the maximum length of a VARCHAR, are checked against the decoded values.
+//
+// Codecs belong to the table of this database: they follow it if it is renamed
+// but are not persisted, they must be registered again every time the database is opened.
+// Documents written before the registration are not transformed.
+//
+// Indexes over a field with a codec store the encoded values, so only equality
+// lookups on that field return correct results when they use an index:
+// range queries and ordering by such an index compare encoded bytes.
+// Codecs are not applied to primary keys, which are always stored as is.
+func (db *DB) RegisterFieldCodec(table, field string, encode, decode func([]byte) ([]byte, error)) error {
+	return db.Update(func(tx *Tx) error {
+		return tx.tx.CatalogWriter().SetFieldCodec(tx.tx, table, field, &database.FieldCodec{
+			Encode: encode,
+			Decode: decode,
+		})
+	})
+}
@@ -0,0 +1,167 @@
+package genji_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/genjidb/genji"
+	"github.com/genjidb/genji/internal/testutil"
+	"github.com/genjidb/genji/internal/testutil/assert"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterFieldCodec(t *testing.T) {
+	xor := func(b []byte) ([]byte, error) {
+		out := make([]byte, len(b))
+		for i := range b {
+			out[i] = b[i] ^ 0x2A
+		}
+		return out, nil
+	}
+
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec(`
+		CREATE TABLE secrets(id INT PRIMARY KEY, secret TEXT, data BLOB);
+		CREATE INDEX on secrets(secret);
+	`)
+	assert.NoError(t, err)
+
+	err = db.RegisterFieldCodec("secrets", "secret", xor, xor)
+	assert.NoError(t, err)
+
+	err = db.Exec(`INSERT INTO secrets (id, secret) VALUES (1, 'hunter2'), (2, 'correct horse')`)
+	assert.NoError(t, err)
+
+	t.Run("Stored bytes are encoded", func(t *testing.T) {
+		tx, err := db.DB.Begin(false)
+		assert.NoError(t, err)
+		defer tx.Rollback()
+
+		tb, err := tx.Catalog.GetTable(tx, "secrets")
+		assert.NoError(t, err)
+
+		var count int
+		err = tb.Tree.IterateOnRange(nil, false, func(k *tree.Key, enc []byte) error {
+			count++
+			require.False(t, bytes.Contains(enc, []byte("hunter2")))
+			require.False(t, bytes.Contains(enc, []byte("correct horse")))
+			return nil
+		})
+		assert.NoError(t, err)
+		require.Equal(t, 2, count)
+	})
+
+	t.Run("Reads are decoded", func(t *testing.T) {
+		res, err := db.Query("SELECT * FROM secrets")
+		assert.NoError(t, err)
+		defer res.Close()
+
+		testutil.RequireStreamEq(t, `
+			{"id": 1, "secret": "hunter2"}
+			{"id": 2, "secret": "correct horse"}
+		`, res, false)
+	})
+
+	t.Run("Equality lookup using the index", func(t *testing.T) {
+		d, err := db.QueryDocument("SELECT id FROM secrets WHERE secret = 'correct horse'")
+		assert.NoError(t, err)
+		testutil.RequireDocJSONEq(t, d, `{"id": 2}`)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		err := db.Exec("UPDATE secrets SET secret = 'swordfish' WHERE id = 1")
+		assert.NoError(t, err)
+
+		d, err := db.QueryDocument("SELECT id, secret FROM secrets WHERE secret = 'swordfish'")
+		assert.NoError(t, err)
+		testutil.RequireDocJSONEq(t, d, `{"id": 1, "secret": "swordfish"}`)
+
+		_, err = db.QueryDocument("SELECT id FROM secrets WHERE secret = 'hunter2'")
+		require.True(t, genji.IsNotFoundError(err))
+	})
+
+	t.Run("Rename", func(t *testing.T) {
+		err := db.Exec("ALTER TABLE secrets RENAME TO hidden")
+		assert.NoError(t, err)
+		defer func() {
+			err := db.Exec("ALTER TABLE hidden RENAME TO secrets")
+			assert.NoError(t, err)
+		}()
+
+		// documents written before the rename are still decoded
+		d, err := db.QueryDocument("SELECT secret FROM hidden WHERE id = 1")
+		assert.NoError(t, err)
+		testutil.RequireDocJSONEq(t, d, `{"secret": "swordfish"}`)
+	})
+
+	t.Run("Other databases", func(t *testing.T) {
+		other, err := genji.Open(":memory:")
+		assert.NoError(t, err)
+		defer other.Close()
+
+		err = other.Exec(`
+			CREATE TABLE secrets(id INT PRIMARY KEY, secret TEXT);
+			INSERT INTO secrets (id, secret) VALUES (1, 'hunter2');
+		`)
+		assert.NoError(t, err)
+
+		tx, err := other.DB.Begin(false)
+		assert.NoError(t, err)
+		defer tx.Rollback()
+
+		tb, err := tx.Catalog.GetTable(tx, "secrets")
+		assert.NoError(t, err)
+
+		err = tb.Tree.IterateOnRange(nil, false, func(k *tree.Key, enc []byte) error {
+			require.True(t, bytes.Contains(enc, []byte("hunter2")))
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Maximum length", func(t *testing.T) {
+		// the encoded value is longer than the declared maximum length
+		pad := func(b []byte) ([]byte, error) {
+			return append(bytes.Repeat([]byte{'#'}, 10), b...), nil
+		}
+		unpad := func(b []byte) ([]byte, error) {
+			return b[10:], nil
+		}
+
+		err := db.Exec("CREATE TABLE codes(id INT PRIMARY KEY, code VARCHAR(5))")
+		assert.NoError(t, err)
+
+		err = db.RegisterFieldCodec("codes", "code", pad, unpad)
+		assert.NoError(t, err)
+
+		err = db.Exec("INSERT INTO codes (id, code) VALUES (1, 'abcde')")
+		assert.NoError(t, err)
+
+		err = db.Exec("UPDATE codes SET code = 'edcba'")
+		assert.NoError(t, err)
+
+		d, err := db.QueryDocument("SELECT code FROM codes")
+		assert.NoError(t, err)
+		testutil.RequireDocJSONEq(t, d, `{"code": "edcba"}`)
+
+		err = db.Exec("INSERT INTO codes (id, code) VALUES (2, 'abcdef')")
+		assert.Error(t, err)
+	})
+
+	t.Run("Unknown table or field", func(t *testing.T) {
+		err := db.RegisterFieldCodec("unknown", "secret", xor, xor)
+		assert.Error(t, err)
+
+		err = db.RegisterFieldCodec("secrets", "unknown", xor, xor)
+		assert.Error(t, err)
+	})
+
+	t.Run("Missing functions", func(t *testing.T) {
+		err := db.RegisterFieldCodec("secrets", "data", xor, nil)
+		assert.Error(t, err)
+	})
+}
@@ -4,9 +4,12 @@ Package genji implements a document-oriented, embedded SQL database.
 package genji
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"reflect"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/document"
@@ -14,11 +17,14 @@ import (
 	"github.com/genjidb/genji/internal/database/catalogstore"
 	"github.com/genjidb/genji/internal/environment"
 	errs "github.com/genjidb/genji/internal/errors"
+	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/query"
 	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/sql/parser"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/docs"
+	"github.com/genjidb/genji/internal/stream/index"
+	"github.com/genjidb/genji/internal/stream/table"
 	"github.com/genjidb/genji/types"
 )
 
@@ -26,25 +32,72 @@ import (
 type DB struct {
 	DB  *database.Database
 	ctx context.Context
+
+	parserOpts *parser.Options
 }
 
 // Open creates a Genji database at the given path.
 // If path is equal to ":memory:" it will open an in-memory database,
 // otherwise it will create an on-disk database.
-func Open(path string) (*DB, error) {
-	db, err := database.Open(path, &database.Options{
-		CatalogLoader: catalogstore.LoadCatalog,
-	})
+func Open(path string, opts ...Option) (*DB, error) {
+	o := options{
+		db: database.Options{
+			CatalogLoader: catalogstore.LoadCatalog,
+		},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	db, err := database.Open(path, &o.db)
 	if err != nil {
 		return nil, err
 	}
 
 	return &DB{
-		DB: db,
+		DB:         db,
+		parserOpts: &o.parser,
 	}, nil
 }
 
+type options struct {
+	db     database.Options
+	parser parser.Options
+}
+
+// An Option configures the database opened by Open.
+type Option func(opts *options)
+
+// WithSortBufferSize sets the size in bytes of the documents sorted in memory by ORDER BY.
+// Larger results are sorted in runs which are written to disk and merged when read.
+// Defaults to 1MB.
+func WithSortBufferSize(size int) Option {
+	return func(opts *options) {
+		opts.db.SortBufferSize = size
+	}
+}
+
+// WithCaseSensitiveLike makes LIKE and NOT LIKE compare characters case-sensitively.
+// By default, LIKE is case-insensitive.
+func WithCaseSensitiveLike() Option {
+	return func(opts *options) {
+		opts.parser.CaseSensitiveLike = true
+	}
+}
+
+// WithCaseInsensitiveIdentifiers folds table, index and field names to lower case
+// when queries are parsed, so that they match regardless of their case.
+// Identifiers quoted with backticks are not folded.
+// Since names are folded when parsed, the option must be used consistently
+// every time the database is opened.
+func WithCaseInsensitiveIdentifiers() Option {
+	return func(opts *options) {
+		opts.parser.CaseInsensitiveIdentifiers = true
+	}
+}
+
 // WithContext creates a new database handle using the given context for every operation.
+// Statements stop reading documents as soon as the context is canceled or past its deadline.
 func (db DB) WithContext(ctx context.Context) *DB {
 	db.ctx = ctx
 	return &db
@@ -130,9 +183,19 @@ func (db *DB) Exec(q string, args ...interface{}) error {
 	return stmt.Exec(args...)
 }
 
+// ExecScript runs a list of statements separated by semicolons
+// within a single read-write transaction.
+// If any of the statements fails, the transaction is rolled back
+// and none of the changes are persisted.
+func (db *DB) ExecScript(q string, args ...interface{}) error {
+	return db.Update(func(tx *Tx) error {
+		return tx.Exec(q, args...)
+	})
+}
+
 // Prepare parses the query and returns a prepared statement.
 func (db *DB) Prepare(q string) (*Statement, error) {
-	pq, err := parser.ParseQuery(q)
+	pq, err := parser.NewParserWithOptions(strings.NewReader(q), db.parserOpts).ParseQuery()
 	if err != nil {
 		return nil, err
 	}
@@ -168,7 +231,37 @@ func (tx *Tx) Commit() error {
 	return tx.tx.Commit()
 }
 
-// Query the database withing the transaction and returns the result.
+// Savepoint creates a savepoint with the given name.
+// Changes made after the savepoint can be undone using RollbackTo,
+// without aborting the whole transaction.
+// Calling this method on read-only transactions will return an error.
+func (tx *Tx) Savepoint(name string) error {
+	return tx.tx.Savepoint(name)
+}
+
+// RollbackTo undoes every change made after the most recent savepoint with the given name.
+// The savepoint remains active and can be rolled back to again.
+func (tx *Tx) RollbackTo(name string) error {
+	return tx.tx.RollbackTo(name)
+}
+
+// ReleaseSavepoint destroys the most recent savepoint with the given name
+// and every savepoint created after it. Changes made since then are kept.
+func (tx *Tx) ReleaseSavepoint(name string) error {
+	return tx.tx.ReleaseSavepoint(name)
+}
+
+// SetStrictFields configures how statements run by the transaction handle
+// documents containing fields that are not declared in the schema of the table
+// they are written to.
+// By default, these fields are silently ignored. If strict is true,
+// writing such a document returns an error naming all the unknown fields.
+// Tables declared with "..." accept any field in both modes.
+func (tx *Tx) SetStrictFields(strict bool) {
+	tx.tx.StrictFields = strict
+}
+
+// Query the database within the transaction and returns the result.
 // Closing the returned result after usage is not mandatory.
 func (tx *Tx) Query(q string, args ...interface{}) (*Result, error) {
 	stmt, err := tx.Prepare(q)
@@ -202,7 +295,7 @@ func (tx *Tx) Exec(q string, args ...interface{}) (err error) {
 
 // Prepare parses the query and returns a prepared statement.
 func (tx *Tx) Prepare(q string) (*Statement, error) {
-	pq, err := parser.ParseQuery(q)
+	pq, err := parser.NewParserWithOptions(strings.NewReader(q), tx.db.parserOpts).ParseQuery()
 	if err != nil {
 		return nil, err
 	}
@@ -346,6 +439,70 @@ func (r *Result) Iterate(fn func(d types.Document) error) error {
 	})
 }
 
+// ScanAll iterates over the result and decodes every document into a new element
+// appended to the slice pointed to by dest, using document.StructScan.
+// dest must be a pointer to a slice of structs or of struct pointers.
+// The length of the slice is reset to 0 before scanning, so that its buffer is reused.
+func (r *Result) ScanAll(dest interface{}) error {
+	ref := reflect.ValueOf(dest)
+	if !ref.IsValid() || ref.Kind() != reflect.Ptr || ref.IsNil() || ref.Elem().Kind() != reflect.Slice {
+		return errors.Errorf("target must be a pointer to a slice of structs, got %T", dest)
+	}
+
+	sref := ref.Elem()
+	elem := sref.Type().Elem()
+	isPtr := elem.Kind() == reflect.Ptr
+	if isPtr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return errors.Errorf("target must be a pointer to a slice of structs, got %T", dest)
+	}
+
+	sref.SetLen(0)
+
+	err := r.Iterate(func(d types.Document) error {
+		v := reflect.New(elem)
+		err := document.StructScan(d, v.Interface())
+		if err != nil {
+			return err
+		}
+
+		if !isPtr {
+			v = v.Elem()
+		}
+		sref = reflect.Append(sref, v)
+		return nil
+	})
+
+	// keep the documents scanned before the error, if any
+	ref.Elem().Set(sref)
+	return err
+}
+
+// Err returns the error returned by the last call to Iterate, if any.
+func (r *Result) Err() error {
+	return r.result.Err()
+}
+
+// RowsProcessed returns the number of documents processed by the last call to Iterate.
+// For statements modifying the database, such as INSERT, UPDATE or DELETE, it is the
+// number of documents that were written before Iterate returned.
+func (r *Result) RowsProcessed() int {
+	return r.result.RowsProcessed()
+}
+
+// FailedRow returns the zero-based index of the document that was being processed
+// when the last call to Iterate failed.
+// If Iterate didn't return an error, it returns false.
+func (r *Result) FailedRow() (int, bool) {
+	if r.result.Err() == nil {
+		return 0, false
+	}
+
+	return r.result.RowsProcessed(), true
+}
+
 func (r *Result) Fields() []string {
 	if r.result.Iterator == nil {
 		return nil
@@ -356,25 +513,167 @@ func (r *Result) Fields() []string {
 		return nil
 	}
 
-	// Search for the ProjectOperator. If found, extract the projected expression list
+	po := lastProjection(stmt)
+
+	// if there are no projected expression, it's a wildcard
+	if po == nil || len(po.Exprs) == 0 {
+		// the stream will output documents in a single field
+		return []string{"*"}
+	}
+
+	fields := make([]string, len(po.Exprs))
+	for i := range po.Exprs {
+		// aliased expressions are returned under their alias
+		if ne, ok := po.Exprs[i].(*expr.NamedExpr); ok {
+			fields[i] = ne.Name()
+			continue
+		}
+
+		fields[i] = po.Exprs[i].String()
+	}
+
+	return fields
+}
+
+// MarshalJSON iterates over the result and encodes every document
+// as a JSON object, within a JSON array.
+// It implements the json.Marshaler interface.
+func (r *Result) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('[')
+
+	var notFirst bool
+	err := r.Iterate(func(d types.Document) error {
+		if notFirst {
+			buf.WriteString(", ")
+		}
+		notFirst = true
+
+		data, err := document.MarshalJSON(d)
+		if err != nil {
+			return err
+		}
+
+		_, err = buf.Write(data)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteByte(']')
+
+	return buf.Bytes(), nil
+}
+
+// Types returns the type of each field returned by Fields.
+// A type can only be determined for paths to fields declared in the schema
+// of the queried table, otherwise types.AnyValue is returned.
+func (r *Result) Types() []types.ValueType {
+	fields := r.Fields()
+	if fields == nil {
+		return nil
+	}
+
+	tps := make([]types.ValueType, len(fields))
+
+	// the types are read from the same projection as the fields
+	stmt := r.result.Iterator.(*statement.StreamStmtIterator)
+	po := lastProjection(stmt)
+	if po == nil {
+		return tps
+	}
+
+	ti := resultTableInfo(stmt, po)
+	if ti == nil {
+		return tps
+	}
+
+	for i := range po.Exprs {
+		if i >= len(tps) {
+			break
+		}
+
+		ne, ok := po.Exprs[i].(*expr.NamedExpr)
+		if !ok {
+			continue
+		}
+
+		p, ok := ne.Expr.(expr.Path)
+		if !ok {
+			continue
+		}
+
+		if fc := ti.GetFieldConstraintForPath(document.Path(p)); fc != nil {
+			tps[i] = fc.Type
+		}
+	}
+
+	return tps
+}
+
+// lastProjection returns the last ProjectOperator of the stream, which determines the shape
+// of the returned documents, like the RETURNING clause of an INSERT ... SELECT.
+func lastProjection(stmt *statement.StreamStmtIterator) *docs.ProjectOperator {
+	var po *docs.ProjectOperator
 	for op := stmt.Stream.First(); op != nil; op = op.GetNext() {
-		if po, ok := op.(*docs.ProjectOperator); ok {
-			// if there are no projected expression, it's a wildcard
-			if len(po.Exprs) == 0 {
-				break
-			}
-
-			fields := make([]string, len(po.Exprs))
-			for i := range po.Exprs {
-				fields[i] = po.Exprs[i].String()
-			}
-
-			return fields
+		if p, ok := op.(*docs.ProjectOperator); ok {
+			po = p
 		}
 	}
 
-	// the stream will output documents in a single field
-	return []string{"*"}
+	return po
+}
+
+// resultTableInfo returns the info of the table whose documents are projected by po, if any.
+func resultTableInfo(stmt *statement.StreamStmtIterator, po *docs.ProjectOperator) *database.TableInfo {
+	if stmt.Context == nil || stmt.Context.Tx == nil {
+		return nil
+	}
+
+	catalog := stmt.Context.Tx.Catalog
+
+	// documents projected after being written, e.g. by the RETURNING clause
+	// of an INSERT statement, belong to the written table
+	var tableName string
+	for op := stmt.Stream.First(); op != nil && op != po; op = op.GetNext() {
+		switch t := op.(type) {
+		case *table.InsertOperator:
+			tableName = t.Name
+		case *table.ReplaceOperator:
+			tableName = t.Name
+		}
+	}
+	if tableName != "" {
+		ti, err := catalog.GetTableInfo(tableName)
+		if err != nil {
+			return nil
+		}
+		return ti
+	}
+
+	switch op := stmt.Stream.First().(type) {
+	case *table.ScanOperator:
+		tableName = op.TableName
+	case *table.UnionOperator:
+		tableName = op.TableName
+	case *index.ScanOperator:
+		info, err := catalog.GetIndexInfo(op.IndexName)
+		if err != nil {
+			return nil
+		}
+		tableName = info.Owner.TableName
+	default:
+		return nil
+	}
+
+	ti, err := catalog.GetTableInfo(tableName)
+	if err != nil {
+		return nil
+	}
+
+	return ti
 }
 
 // Close the result stream.
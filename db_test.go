@@ -2,17 +2,23 @@ package genji_test
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/genjidb/genji"
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/testutil"
 	"github.com/genjidb/genji/internal/testutil/assert"
+	"github.com/genjidb/genji/internal/tree"
 	"github.com/genjidb/genji/types"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
@@ -143,6 +149,119 @@ func TestOpen(t *testing.T) {
 	testutil.RequireDocJSONEq(t, d, `{"name": "seqD", "seq": 500}`)
 }
 
+func TestOpenViews(t *testing.T) {
+	dir, err := ioutil.TempDir("", "genji")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	db, err := genji.Open(filepath.Join(dir, "testdb"))
+	assert.NoError(t, err)
+
+	err = db.Exec(`
+		CREATE TABLE test(id INT PRIMARY KEY, a INT);
+		INSERT INTO test (id, a) VALUES (1, 10), (2, NULL);
+		CREATE VIEW nulls AS SELECT id FROM test WHERE a IS NULL;
+		CREATE VIEW not_nulls AS SELECT id FROM test WHERE a IS NOT NULL;
+	`)
+	assert.NoError(t, err)
+
+	err = db.Close()
+	assert.NoError(t, err)
+
+	// ensure views are reloaded with the same meaning
+	db, err = genji.Open(filepath.Join(dir, "testdb"))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	d, err := db.QueryDocument("SELECT sql FROM __genji_catalog WHERE name = 'nulls'")
+	assert.NoError(t, err)
+	testutil.RequireDocJSONEq(t, d, `{"sql": "CREATE VIEW nulls AS SELECT id FROM test WHERE a IS NULL"}`)
+
+	d, err = db.QueryDocument("SELECT * FROM nulls")
+	assert.NoError(t, err)
+	testutil.RequireDocJSONEq(t, d, `{"id": 2}`)
+
+	d, err = db.QueryDocument("SELECT * FROM not_nulls")
+	assert.NoError(t, err)
+	testutil.RequireDocJSONEq(t, d, `{"id": 1}`)
+}
+
+func TestOpenWithOptions(t *testing.T) {
+	db, err := genji.Open(":memory:", genji.WithSortBufferSize(64))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	require.Equal(t, 64, db.DB.SortBufferSize)
+
+	err = db.Exec("CREATE TABLE test(a INT)")
+	assert.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		err = db.Exec("INSERT INTO test (a) VALUES (?)", (i*37)%100)
+		assert.NoError(t, err)
+	}
+
+	res, err := db.Query("SELECT a FROM test ORDER BY a DESC")
+	assert.NoError(t, err)
+	defer res.Close()
+
+	want := 99
+	err = res.Iterate(func(d types.Document) error {
+		var a int
+		err := document.Scan(d, &a)
+		assert.NoError(t, err)
+		require.Equal(t, want, a)
+		want--
+		return nil
+	})
+	assert.NoError(t, err)
+	require.Equal(t, -1, want)
+}
+
+func TestOpenWithCaseOptions(t *testing.T) {
+	t.Run("LIKE", func(t *testing.T) {
+		like := func(opts ...genji.Option) bool {
+			db, err := genji.Open(":memory:", opts...)
+			assert.NoError(t, err)
+			defer db.Close()
+
+			d, err := db.QueryDocument("SELECT 'Hello' LIKE 'h%' AS ok")
+			assert.NoError(t, err)
+
+			var ok bool
+			err = document.Scan(d, &ok)
+			assert.NoError(t, err)
+			return ok
+		}
+
+		require.True(t, like())
+		require.False(t, like(genji.WithCaseSensitiveLike()))
+	})
+
+	t.Run("Identifiers", func(t *testing.T) {
+		db, err := genji.Open(":memory:", genji.WithCaseInsensitiveIdentifiers())
+		assert.NoError(t, err)
+		defer db.Close()
+
+		err = db.Exec("CREATE TABLE Foo(A INT); INSERT INTO FOO (a) VALUES (1)")
+		assert.NoError(t, err)
+
+		d, err := db.QueryDocument("SELECT A FROM foo")
+		assert.NoError(t, err)
+		testutil.RequireDocJSONEq(t, d, `{"a": 1}`)
+
+		// quoted identifiers are not folded
+		err = db.Exec("CREATE TABLE `Bar`(`B` INT); INSERT INTO `Bar` (`B`) VALUES (1)")
+		assert.NoError(t, err)
+
+		d, err = db.QueryDocument("SELECT `B` FROM `Bar`")
+		assert.NoError(t, err)
+		testutil.RequireDocJSONEq(t, d, `{"B": 1}`)
+
+		_, err = db.QueryDocument("SELECT * FROM Bar")
+		assert.Error(t, err)
+	})
+}
+
 func TestQueryDocument(t *testing.T) {
 	db, err := genji.Open(":memory:")
 	assert.NoError(t, err)
@@ -195,6 +314,686 @@ func TestQueryDocument(t *testing.T) {
 	})
 }
 
+func TestQueryAndExec(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec("CREATE TABLE test(a INT, b TEXT)")
+	assert.NoError(t, err)
+
+	t.Run("DB.Exec with positional params", func(t *testing.T) {
+		err = db.Exec("INSERT INTO test (a, b) VALUES (?, ?), (?, ?)", 1, "foo", 2, "bar")
+		assert.NoError(t, err)
+
+		err = db.Exec("INSERT INTO test (a, b) VALUES (?, ?)", 3)
+		assert.Error(t, err)
+	})
+
+	t.Run("Tx.Query with positional params", func(t *testing.T) {
+		tx, err := db.Begin(false)
+		assert.NoError(t, err)
+		defer tx.Rollback()
+
+		res, err := tx.Query("SELECT a, b FROM test WHERE a >= ? ORDER BY a DESC", 1)
+		assert.NoError(t, err)
+		defer res.Close()
+
+		testutil.RequireStreamEq(t, `
+			{"a": 2, "b": "bar"}
+			{"a": 1, "b": "foo"}
+		`, res, false)
+	})
+
+	t.Run("Tx.Query with named params", func(t *testing.T) {
+		tx, err := db.Begin(false)
+		assert.NoError(t, err)
+		defer tx.Rollback()
+
+		res, err := tx.Query("SELECT b FROM test WHERE a = $a", sql.Named("a", 2))
+		assert.NoError(t, err)
+		defer res.Close()
+
+		testutil.RequireStreamEq(t, `{"b": "bar"}`, res, false)
+	})
+}
+
+func TestQueryContext(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec("CREATE TABLE test(a INT PRIMARY KEY, b INT)")
+	assert.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		err = db.Exec("INSERT INTO test (a, b) VALUES (?, ?)", i, i%10)
+		assert.NoError(t, err)
+	}
+
+	t.Run("canceled while reading", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// every document is filtered out, the context
+		// must be checked while reading the table
+		res, err := db.WithContext(ctx).Query("SELECT * FROM test WHERE b = 100")
+		assert.NoError(t, err)
+		defer res.Close()
+
+		cancel()
+		err = res.Iterate(func(d types.Document) error {
+			return nil
+		})
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("past its deadline", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+
+		for _, q := range []string{
+			"SELECT * FROM test WHERE b = 100",
+			"UPDATE test SET b = 0 WHERE b = 100",
+			"DELETE FROM test WHERE b = 100",
+		} {
+			err := db.WithContext(ctx).Exec(q)
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+		}
+	})
+}
+
+func TestExecScript(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.ExecScript(`
+		CREATE TABLE test(a INT PRIMARY KEY, b TEXT);
+		INSERT INTO test (a, b) VALUES (?, ?);;
+	`, 1, "foo")
+	assert.NoError(t, err)
+
+	d, err := db.QueryDocument("SELECT * FROM test")
+	assert.NoError(t, err)
+	testutil.RequireDocJSONEq(t, d, `{"a": 1, "b": "foo"}`)
+
+	t.Run("All or nothing", func(t *testing.T) {
+		err = db.ExecScript(`
+			CREATE TABLE other;
+			INSERT INTO test (a, b) VALUES (1, 'bar');
+		`)
+		assert.Error(t, err)
+
+		err = db.Exec("SELECT * FROM other")
+		assert.Error(t, err)
+
+		d, err := db.QueryDocument("SELECT COUNT(*) FROM test")
+		assert.NoError(t, err)
+		testutil.RequireDocJSONEq(t, d, `{"COUNT(*)": 1}`)
+	})
+}
+
+func TestRegisterFunc(t *testing.T) {
+	err := genji.RegisterFunc("twice", func(args []types.Value) (types.Value, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("twice(arg1) takes 1 argument, not %d", len(args))
+		}
+		if args[0].Type() != types.IntegerValue {
+			return types.NewNullValue(), nil
+		}
+		return types.NewIntegerValue(types.As[int64](args[0]) * 2), nil
+	})
+	assert.NoError(t, err)
+
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec(`
+		CREATE TABLE test(a INT PRIMARY KEY);
+		INSERT INTO test (a) VALUES (1), (2), (3);
+	`)
+	assert.NoError(t, err)
+
+	res, err := db.Query("SELECT twice(a) AS d FROM test WHERE TWICE(a) > 2")
+	assert.NoError(t, err)
+	defer res.Close()
+	testutil.RequireStreamEq(t, `{"d": 4} {"d": 6}`, res, false)
+
+	t.Run("Invalid arguments", func(t *testing.T) {
+		_, err := db.QueryDocument("SELECT twice(a, a) FROM test")
+		require.EqualError(t, err, "twice(arg1) takes 1 argument, not 2")
+	})
+
+	t.Run("Builtin", func(t *testing.T) {
+		err := genji.RegisterFunc("COALESCE", func(args []types.Value) (types.Value, error) {
+			return nil, nil
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Keyword", func(t *testing.T) {
+		err := genji.RegisterFunc("double", func(args []types.Value) (types.Value, error) {
+			return nil, nil
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestTriggerFunc(t *testing.T) {
+	var calls [][]string
+	err := genji.RegisterFunc("record_change", func(args []types.Value) (types.Value, error) {
+		var call []string
+		for _, a := range args {
+			call = append(call, types.As[string](a))
+		}
+		if len(call) > 0 && call[0] == "fail" {
+			return nil, errors.New("rejected")
+		}
+
+		calls = append(calls, call)
+		return nil, nil
+	})
+	assert.NoError(t, err)
+
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec(`
+		CREATE TABLE test(a INT PRIMARY KEY, b TEXT);
+		CREATE TRIGGER on_update AFTER UPDATE ON test EXECUTE FUNCTION record_change(old.b, new.b);
+		CREATE TRIGGER on_insert BEFORE INSERT ON test EXECUTE FUNCTION record_change(new.b);
+		INSERT INTO test (a, b) VALUES (1, 'foo');
+		UPDATE test SET b = 'bar';
+	`)
+	assert.NoError(t, err)
+
+	require.Len(t, calls, 2)
+	require.Equal(t, []string{"foo"}, calls[0])
+	require.Equal(t, []string{"foo", "bar"}, calls[1])
+
+	t.Run("Error", func(t *testing.T) {
+		err := db.Exec(`INSERT INTO test (a, b) VALUES (2, 'fail')`)
+		require.EqualError(t, err, "trigger on_insert: rejected")
+
+		n, err := db.QueryDocument("SELECT COUNT(*) AS n FROM test")
+		assert.NoError(t, err)
+		testutil.RequireDocJSONEq(t, n, `{"n": 1}`)
+	})
+}
+
+func TestOpenTriggers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "genji")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	db, err := genji.Open(filepath.Join(dir, "testdb"))
+	assert.NoError(t, err)
+
+	err = db.Exec(`
+		CREATE TABLE test(a INT PRIMARY KEY, b TEXT);
+		CREATE TABLE log(a INT PRIMARY KEY, b TEXT);
+		INSERT INTO test (a, b) VALUES (1, 'foo');
+		INSERT INTO log (a, b) VALUES (1, NULL), (2, 'x');
+		CREATE TRIGGER mark AFTER UPDATE ON test UPDATE log SET b = 'set' WHERE b IS NULL;
+		CREATE TRIGGER audit AFTER INSERT ON test EXECUTE FUNCTION open_triggers_unregistered(new.a);
+	`)
+	assert.NoError(t, err)
+
+	err = db.Close()
+	assert.NoError(t, err)
+
+	// functions are looked up when the trigger fires,
+	// the database can be opened before they are registered
+	db, err = genji.Open(filepath.Join(dir, "testdb"))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec("INSERT INTO test (a, b) VALUES (2, 'bar')")
+	require.ErrorContains(t, err, "no such function")
+
+	err = db.Exec("UPDATE test SET b = 'baz'")
+	assert.NoError(t, err)
+
+	res, err := db.Query("SELECT * FROM log")
+	assert.NoError(t, err)
+	defer res.Close()
+	testutil.RequireStreamEq(t, `
+		{"a": 1, "b": "set"}
+		{"a": 2, "b": "x"}
+	`, res, false)
+
+	err = db.Exec("DROP TRIGGER audit; INSERT INTO test (a, b) VALUES (2, 'bar')")
+	assert.NoError(t, err)
+}
+
+func TestRegisterAggregate(t *testing.T) {
+	err := genji.RegisterAggregate("median", genji.Aggregate{
+		Step: func(state any, args []types.Value) (any, error) {
+			if len(args) != 1 {
+				return nil, fmt.Errorf("median(arg1) takes 1 argument, not %d", len(args))
+			}
+			values, _ := state.([]float64)
+			if args[0].Type() != types.IntegerValue && args[0].Type() != types.DoubleValue {
+				return values, nil
+			}
+			v, err := document.CastAsDouble(args[0])
+			if err != nil {
+				return nil, err
+			}
+			return append(values, types.As[float64](v)), nil
+		},
+		Finalize: func(state any) (types.Value, error) {
+			values, _ := state.([]float64)
+			if len(values) == 0 {
+				return types.NewNullValue(), nil
+			}
+			sort.Float64s(values)
+			n := len(values)
+			if n%2 == 1 {
+				return types.NewDoubleValue(values[n/2]), nil
+			}
+			return types.NewDoubleValue((values[n/2-1] + values[n/2]) / 2), nil
+		},
+	})
+	assert.NoError(t, err)
+
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec(`
+		CREATE TABLE test(a INT PRIMARY KEY, b TEXT);
+		INSERT INTO test (a, b) VALUES (1, 'x'), (2, 'x'), (10, 'x'), (3, 'y'), (5, 'y');
+	`)
+	assert.NoError(t, err)
+
+	t.Run("GROUP BY", func(t *testing.T) {
+		res, err := db.Query("SELECT b, MEDIAN(a) AS m FROM test GROUP BY b")
+		assert.NoError(t, err)
+		defer res.Close()
+		testutil.RequireStreamEq(t, `{"b": "x", "m": 2.0} {"b": "y", "m": 4.0}`, res, false)
+	})
+
+	t.Run("No GROUP BY", func(t *testing.T) {
+		d, err := db.QueryDocument("SELECT median(a), median(a * 2) FROM test")
+		assert.NoError(t, err)
+		testutil.RequireDocJSONEq(t, d, `{"median(a)": 3.0, "median(a * 2)": 6.0}`)
+
+		d, err = db.QueryDocument("SELECT median(a) AS m FROM test WHERE a > 100")
+		assert.NoError(t, err)
+		testutil.RequireDocJSONEq(t, d, `{"m": null}`)
+	})
+
+	t.Run("Init", func(t *testing.T) {
+		err := genji.RegisterAggregate("count_x", genji.Aggregate{
+			Init: func() any { return int64(0) },
+			Step: func(state any, args []types.Value) (any, error) {
+				if args[0].Type() == types.TextValue && types.As[string](args[0]) == "x" {
+					return state.(int64) + 1, nil
+				}
+				return state, nil
+			},
+			Finalize: func(state any) (types.Value, error) {
+				return types.NewIntegerValue(state.(int64)), nil
+			},
+		})
+		assert.NoError(t, err)
+
+		res, err := db.Query("SELECT a % 2 AS odd, count_x(b) AS n FROM test GROUP BY a % 2")
+		assert.NoError(t, err)
+		defer res.Close()
+		testutil.RequireStreamEq(t, `{"odd": 0, "n": 2} {"odd": 1, "n": 1}`, res, false)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		_, err := db.QueryDocument("SELECT median(a, b) FROM test")
+		require.EqualError(t, err, "median(arg1) takes 1 argument, not 2")
+	})
+
+	t.Run("Builtin", func(t *testing.T) {
+		err := genji.RegisterAggregate("SUM", genji.Aggregate{
+			Step: func(state any, args []types.Value) (any, error) {
+				return nil, nil
+			},
+			Finalize: func(state any) (types.Value, error) {
+				return nil, nil
+			},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Missing callbacks", func(t *testing.T) {
+		err := genji.RegisterAggregate("foo", genji.Aggregate{})
+		assert.Error(t, err)
+	})
+}
+
+func TestParseError(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec("CREATE TABLE test(a INT")
+
+	var perr *genji.ParseError
+	require.True(t, errors.As(err, &perr))
+	require.Equal(t, "EOF", perr.Found)
+
+	err = db.Exec("SELECT * FROM test")
+	require.False(t, errors.As(err, &perr))
+}
+
+func TestConstraintViolationError(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec("CREATE TABLE test(a INT CHECK (a > 0), b INT, CONSTRAINT a_lt_b CHECK (a < b))")
+	assert.NoError(t, err)
+
+	var cerr *genji.ConstraintViolationError
+
+	err = db.Exec("INSERT INTO test (a, b) VALUES (0, 10)")
+	require.True(t, errors.As(err, &cerr))
+	require.Equal(t, "CHECK", cerr.Constraint)
+	require.Equal(t, "test_check", cerr.Name)
+	require.Equal(t, []document.Path{document.NewPath("a")}, cerr.Paths)
+
+	err = db.Exec("INSERT INTO test (a, b) VALUES (20, 10)")
+	require.True(t, errors.As(err, &cerr))
+	require.Equal(t, "a_lt_b", cerr.Name)
+	require.Equal(t, `document violates check constraint "a_lt_b"`, cerr.Error())
+
+	err = db.Exec("INSERT INTO test (a, b) VALUES (1, 10)")
+	assert.NoError(t, err)
+
+	err = db.Exec("UPDATE test SET b = 0")
+	require.True(t, errors.As(err, &cerr))
+	require.Equal(t, "a_lt_b", cerr.Name)
+}
+
+func TestResultRowsProcessed(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec("CREATE TABLE test(a INT PRIMARY KEY, b INT NOT NULL)")
+	assert.NoError(t, err)
+
+	t.Run("Success", func(t *testing.T) {
+		res, err := db.Query("INSERT INTO test (a, b) VALUES (1, 1), (2, 2)")
+		assert.NoError(t, err)
+		defer res.Close()
+
+		err = res.Iterate(func(d types.Document) error { return nil })
+		assert.NoError(t, err)
+		require.Equal(t, 2, res.RowsProcessed())
+		_, failed := res.FailedRow()
+		require.False(t, failed)
+	})
+
+	t.Run("Partial failure", func(t *testing.T) {
+		res, err := db.Query("INSERT INTO test (a, b) VALUES (3, 3), (4, 4), (5, NULL), (6, 6)")
+		assert.NoError(t, err)
+		defer res.Close()
+
+		err = res.Iterate(func(d types.Document) error { return nil })
+		assert.Error(t, err)
+		require.Equal(t, err, res.Err())
+		require.Equal(t, 2, res.RowsProcessed())
+		idx, failed := res.FailedRow()
+		require.True(t, failed)
+		require.Equal(t, 2, idx)
+	})
+
+	t.Run("Select", func(t *testing.T) {
+		res, err := db.Query("SELECT * FROM test")
+		assert.NoError(t, err)
+		defer res.Close()
+
+		err = res.Iterate(func(d types.Document) error { return nil })
+		assert.NoError(t, err)
+		require.Equal(t, 2, res.RowsProcessed())
+	})
+
+	t.Run("Update and delete", func(t *testing.T) {
+		tests := []struct {
+			query string
+			count int
+		}{
+			{"UPDATE test SET b = 10 WHERE a = 1", 1},
+			{"UPDATE test SET b = 10 WHERE a > 100", 0},
+			{"UPDATE test SET b = b + 1", 2},
+			{"DELETE FROM test WHERE b = 11", 1},
+			{"DELETE FROM test", 1},
+		}
+
+		for _, test := range tests {
+			res, err := db.Query(test.query)
+			assert.NoError(t, err)
+
+			err = res.Iterate(func(d types.Document) error { return nil })
+			assert.NoError(t, err)
+			require.Equal(t, test.count, res.RowsProcessed(), test.query)
+			assert.NoError(t, res.Close())
+		}
+	})
+}
+
+func TestResultScanAll(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec(`
+		CREATE TABLE user;
+		INSERT INTO user (id, name, age) VALUES (1, 'foo', 10), (2, 'bar', 20), (3, 'baz', 30);
+	`)
+	assert.NoError(t, err)
+
+	t.Run("Slice of structs", func(t *testing.T) {
+		res, err := db.Query("SELECT id, name, age FROM user WHERE age > 10")
+		assert.NoError(t, err)
+		defer res.Close()
+
+		users := []User{{ID: 100}}
+		err = res.ScanAll(&users)
+		assert.NoError(t, err)
+		require.Equal(t, []User{{ID: 2, Name: "bar", Age: 20}, {ID: 3, Name: "baz", Age: 30}}, users)
+	})
+
+	t.Run("Slice of struct pointers", func(t *testing.T) {
+		res, err := db.Query("SELECT id, name FROM user WHERE id = 1")
+		assert.NoError(t, err)
+		defer res.Close()
+
+		var users []*User
+		err = res.ScanAll(&users)
+		assert.NoError(t, err)
+		require.Equal(t, []*User{{ID: 1, Name: "foo"}}, users)
+	})
+
+	t.Run("No results", func(t *testing.T) {
+		res, err := db.Query("SELECT * FROM user WHERE id > 10")
+		assert.NoError(t, err)
+		defer res.Close()
+
+		var users []User
+		err = res.ScanAll(&users)
+		assert.NoError(t, err)
+		require.Empty(t, users)
+	})
+
+	t.Run("Invalid target", func(t *testing.T) {
+		res, err := db.Query("SELECT * FROM user")
+		assert.NoError(t, err)
+		defer res.Close()
+
+		var users []User
+		for _, dest := range []interface{}{nil, users, &User{}, &[]int{}} {
+			err = res.ScanAll(dest)
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestPKRoundTrip(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec(`
+		CREATE TABLE test(a INT, b TEXT, c INT, PRIMARY KEY (a, b));
+		CREATE TABLE nopk;
+		INSERT INTO test (a, b, c) VALUES (1, 'foo', 10), (2, 'bar', 20);
+		INSERT INTO nopk (c) VALUES (10), (20);
+	`)
+	assert.NoError(t, err)
+
+	for _, tableName := range []string{"test", "nopk"} {
+		t.Run(tableName, func(t *testing.T) {
+			d, err := db.QueryDocument("SELECT pk() AS k FROM " + tableName + " WHERE c = 20")
+			assert.NoError(t, err)
+
+			v, err := d.GetByField("k")
+			assert.NoError(t, err)
+
+			var vs []types.Value
+			err = types.As[types.Array](v).Iterate(func(i int, v types.Value) error {
+				vs = append(vs, v)
+				return nil
+			})
+			assert.NoError(t, err)
+
+			// the values returned by pk() form the key of the document
+			tx, err := db.DB.Begin(false)
+			assert.NoError(t, err)
+			defer tx.Rollback()
+
+			tb, err := tx.Catalog.GetTable(tx, tableName)
+			assert.NoError(t, err)
+
+			doc, err := tb.GetDocument(tree.NewKey(vs...))
+			assert.NoError(t, err)
+
+			var c int
+			err = document.ScanField(doc, "c", &c)
+			assert.NoError(t, err)
+			require.Equal(t, 20, c)
+		})
+	}
+}
+
+func TestSavepoint(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tx, err := db.Begin(true)
+	assert.NoError(t, err)
+	defer tx.Rollback()
+
+	err = tx.Exec(`CREATE TABLE test(a int primary key); INSERT INTO test (a) VALUES (1), (2)`)
+	assert.NoError(t, err)
+
+	err = tx.Savepoint("sp")
+	assert.NoError(t, err)
+
+	err = tx.Exec(`INSERT INTO test (a) VALUES (3); DELETE FROM test WHERE a = 1; CREATE TABLE other`)
+	assert.NoError(t, err)
+
+	err = tx.RollbackTo("sp")
+	assert.NoError(t, err)
+
+	err = tx.Exec(`INSERT INTO test (a) VALUES (4)`)
+	assert.NoError(t, err)
+
+	err = tx.Commit()
+	assert.NoError(t, err)
+
+	res, err := db.Query("SELECT a FROM test")
+	assert.NoError(t, err)
+	defer res.Close()
+
+	var got []int
+	err = res.Iterate(func(d types.Document) error {
+		var a int
+		err := document.Scan(d, &a)
+		got = append(got, a)
+		return err
+	})
+	assert.NoError(t, err)
+	require.Equal(t, []int{1, 2, 4}, got)
+
+	err = db.Exec("SELECT * FROM other")
+	require.True(t, genji.IsNotFoundError(err))
+
+	t.Run("read-only", func(t *testing.T) {
+		tx, err := db.Begin(false)
+		assert.NoError(t, err)
+		defer tx.Rollback()
+
+		err = tx.Savepoint("sp")
+		require.Error(t, err)
+	})
+
+	t.Run("unknown savepoint", func(t *testing.T) {
+		tx, err := db.Begin(true)
+		assert.NoError(t, err)
+		defer tx.Rollback()
+
+		err = tx.RollbackTo("sp")
+		require.Error(t, err)
+	})
+}
+
+func TestStrictFields(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec(`
+		CREATE TABLE test(a INT PRIMARY KEY, d (x INT));
+		CREATE TABLE variadic(a INT PRIMARY KEY, ...);
+	`)
+	assert.NoError(t, err)
+
+	t.Run("Lenient", func(t *testing.T) {
+		tx, err := db.Begin(true)
+		assert.NoError(t, err)
+		defer tx.Rollback()
+
+		err = tx.Exec(`INSERT INTO test VALUES {a: 1, b: 2, d: {x: 3, y: 4}}`)
+		assert.NoError(t, err)
+
+		d, err := tx.QueryDocument(`SELECT * FROM test`)
+		assert.NoError(t, err)
+		testutil.RequireDocJSONEq(t, d, `{"a": 1, "d": {"x": 3}}`)
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		tx, err := db.Begin(true)
+		assert.NoError(t, err)
+		defer tx.Rollback()
+
+		tx.SetStrictFields(true)
+
+		err = tx.Exec(`INSERT INTO test VALUES {a: 1, b: 2}`)
+		require.EqualError(t, err, "field b not in schema")
+
+		err = tx.Exec(`INSERT INTO test VALUES {a: 1, b: 2, c: 3, d: {x: 3, y: 4}}`)
+		require.EqualError(t, err, "fields b, c, d.y not in schema")
+
+		err = tx.Exec(`INSERT INTO test VALUES {a: 1, d: {x: 3}}`)
+		assert.NoError(t, err)
+
+		err = tx.Exec(`INSERT INTO variadic VALUES {a: 1, b: 2}`)
+		assert.NoError(t, err)
+	})
+}
+
 func TestPrepareThreadSafe(t *testing.T) {
 	db, err := genji.Open(":memory:")
 	assert.NoError(t, err)
@@ -227,6 +1026,79 @@ func TestPrepareThreadSafe(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestPrepareConcurrentResults(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec("CREATE TABLE test(a int PRIMARY KEY, b int); CREATE INDEX ON test(b)")
+	assert.NoError(t, err)
+	for i := 1; i <= 50; i++ {
+		err = db.Exec("INSERT INTO test(a, b) VALUES (?, ?)", i, i%10)
+		assert.NoError(t, err)
+	}
+
+	// the same statement is run concurrently with different parameters,
+	// each run must only see its own parameters and produce its own results.
+	stmt, err := db.Prepare("SELECT a, b * 2 AS c FROM test WHERE a >= ? AND b * 2 > 0 AND b * 2 < 100 ORDER BY a DESC")
+	assert.NoError(t, err)
+
+	g, _ := errgroup.WithContext(context.Background())
+
+	for i := 1; i <= 20; i++ {
+		arg := i
+		g.Go(func() error {
+			for n := 0; n < 10; n++ {
+				res, err := stmt.Query(arg)
+				if err != nil {
+					return err
+				}
+
+				var count int
+				prev := int64(51)
+				err = res.Iterate(func(d types.Document) error {
+					var r struct {
+						A int64
+						C int64
+					}
+					if err := document.StructScan(d, &r); err != nil {
+						return err
+					}
+					if r.A < int64(arg) || r.A >= prev {
+						return fmt.Errorf("unexpected document %d after %d for arg %d", r.A, prev, arg)
+					}
+					if r.C != (r.A%10)*2 {
+						return fmt.Errorf("unexpected value %d for document %d", r.C, r.A)
+					}
+					prev = r.A
+					count++
+					return nil
+				})
+				res.Close()
+				if err != nil {
+					return err
+				}
+
+				// documents whose a is a multiple of 10 have b = 0 and are filtered out
+				expected := 0
+				for a := arg; a <= 50; a++ {
+					if a%10 != 0 {
+						expected++
+					}
+				}
+				if count != expected {
+					return fmt.Errorf("expected %d documents for arg %d, got %d", expected, arg, count)
+				}
+			}
+
+			return nil
+		})
+	}
+
+	err = g.Wait()
+	assert.NoError(t, err)
+}
+
 func TestIterateDeepCopy(t *testing.T) {
 	db, err := genji.Open(":memory:")
 	assert.NoError(t, err)
@@ -360,3 +1232,117 @@ func BenchmarkSelectPk(b *testing.B) {
 		})
 	}
 }
+
+func TestResultMarshalJSON(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec(`
+		CREATE TABLE test(a INT, b DOUBLE, c TEXT, d BLOB, e BOOL, f TIMESTAMP, g ARRAY, h DOCUMENT);
+		INSERT INTO test (a, b, c, d, e, f, g, h) VALUES (1, 1.5, 'foo', '\xaabb', true, '2022-01-01T10:00:00Z', [1, 'bar'], {i: 2});
+		INSERT INTO test (a) VALUES (2);
+	`)
+	assert.NoError(t, err)
+
+	t.Run("Documents", func(t *testing.T) {
+		res, err := db.Query("SELECT * FROM test")
+		assert.NoError(t, err)
+		defer res.Close()
+
+		data, err := json.Marshal(res)
+		assert.NoError(t, err)
+		require.JSONEq(t, `[
+			{"a": 1, "b": 1.5, "c": "foo", "d": "qrs=", "e": true, "f": "2022-01-01T10:00:00Z", "g": [1, "bar"], "h": {"i": 2}},
+			{"a": 2}
+		]`, string(data))
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		res, err := db.Query("SELECT a, b FROM test WHERE a = 2")
+		assert.NoError(t, err)
+		defer res.Close()
+
+		data, err := res.MarshalJSON()
+		assert.NoError(t, err)
+		require.JSONEq(t, `[{"a": 2, "b": null}]`, string(data))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		res, err := db.Query("SELECT * FROM test WHERE a > 10")
+		assert.NoError(t, err)
+		defer res.Close()
+
+		data, err := res.MarshalJSON()
+		assert.NoError(t, err)
+		require.Equal(t, `[]`, string(data))
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		res, err := db.Query("SELECT a, c, g FROM test WHERE a = 1")
+		assert.NoError(t, err)
+		defer res.Close()
+
+		data, err := res.MarshalJSON()
+		assert.NoError(t, err)
+
+		var docs []map[string]interface{}
+		err = json.Unmarshal(data, &docs)
+		assert.NoError(t, err)
+		require.Equal(t, []map[string]interface{}{
+			{"a": float64(1), "c": "foo", "g": []interface{}{float64(1), "bar"}},
+		}, docs)
+	})
+}
+
+func TestTransactionStatements(t *testing.T) {
+	dir, err := ioutil.TempDir("", "genji")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	db, err := genji.Open(filepath.Join(dir, "testdb"))
+	assert.NoError(t, err)
+
+	// write statements run outside of an explicit transaction are committed automatically
+	err = db.Exec("CREATE TABLE test(a INT PRIMARY KEY)")
+	assert.NoError(t, err)
+
+	err = db.Exec("BEGIN TRANSACTION")
+	assert.NoError(t, err)
+	err = db.Exec("INSERT INTO test (a) VALUES (1), (2)")
+	assert.NoError(t, err)
+	err = db.Exec("COMMIT")
+	assert.NoError(t, err)
+
+	err = db.Exec("BEGIN; INSERT INTO test (a) VALUES (3); ROLLBACK")
+	assert.NoError(t, err)
+
+	err = db.Exec("BEGIN")
+	assert.NoError(t, err)
+	err = db.Exec("DELETE FROM test")
+	assert.NoError(t, err)
+	err = db.Exec("ROLLBACK")
+	assert.NoError(t, err)
+
+	err = db.Close()
+	assert.NoError(t, err)
+
+	// ensure committed changes are durable and rolled back ones are discarded
+	db, err = genji.Open(filepath.Join(dir, "testdb"))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	res, err := db.Query("SELECT a FROM test")
+	assert.NoError(t, err)
+	defer res.Close()
+
+	var got []int
+	err = res.Iterate(func(d types.Document) error {
+		var a int
+		err := document.Scan(d, &a)
+		got = append(got, a)
+		return err
+	})
+	assert.NoError(t, err)
+	require.Equal(t, []int{1, 2}, got)
+}
@@ -6,10 +6,26 @@ import (
 )
 
 // Diff returns the operations needed to transform the first document into the second.
+// Fields are compared by name, regardless of their order in the documents.
+// Fields added to or modified in the second document are returned as set operations,
+// fields missing from the second document as delete operations.
+// Values of different types are never considered equal, even if they are both numbers.
 func Diff(d1, d2 types.Document) ([]Op, error) {
 	return diff(nil, d1, d2)
 }
 
+// Equal reports whether both documents have the same fields with the same values,
+// regardless of the order of their fields.
+// Values of different types are never considered equal, even if they are both numbers.
+func Equal(d1, d2 types.Document) (bool, error) {
+	ops, err := Diff(d1, d2)
+	if err != nil {
+		return false, err
+	}
+
+	return len(ops) == 0, nil
+}
+
 func diff(path Path, d1, d2 types.Document) ([]Op, error) {
 	var ops []Op
 	f1, err := types.Fields(d1)
@@ -22,28 +38,29 @@ func diff(path Path, d1, d2 types.Document) ([]Op, error) {
 		return nil, err
 	}
 
+	// both lists of fields are sorted
 	var i, j int
-	for {
-		for i < len(f1) && (j >= len(f2) || f1[i] < f2[j]) {
-			v, err := d1.GetByField(f1[j])
+	for i < len(f1) || j < len(f2) {
+		// the field only exists in the first document
+		if j >= len(f2) || (i < len(f1) && f1[i] < f2[j]) {
+			v, err := d1.GetByField(f1[i])
 			if err != nil {
 				return nil, err
 			}
 			ops = append(ops, NewDeleteOp(path.ExtendField(f1[i]), v))
 			i++
+			continue
 		}
 
-		for j < len(f2) && (i >= len(f1) || f1[i] > f2[j]) {
+		// the field only exists in the second document
+		if i >= len(f1) || f1[i] > f2[j] {
 			v, err := d2.GetByField(f2[j])
 			if err != nil {
 				return nil, err
 			}
 			ops = append(ops, NewSetOp(path.ExtendField(f2[j]), v))
 			j++
-		}
-
-		if i == len(f1) && j == len(f2) {
-			break
+			continue
 		}
 
 		v1, err := d1.GetByField(f1[i])
@@ -57,21 +74,17 @@ func diff(path Path, d1, d2 types.Document) ([]Op, error) {
 		}
 
 		if v1.Type() != v2.Type() {
-			v, err := d2.GetByField(f2[j])
-			if err != nil {
-				return nil, err
-			}
-			ops = append(ops, NewSetOp(path.ExtendField(f2[j]), v))
+			ops = append(ops, NewSetOp(path.ExtendField(f2[j]), v2))
 		} else {
 			switch v1.Type() {
 			case types.DocumentValue:
-				subOps, err := diff(append(path, PathFragment{FieldName: f1[i]}), types.As[types.Document](v1), types.As[types.Document](v2))
+				subOps, err := diff(path.ExtendField(f1[i]), types.As[types.Document](v1), types.As[types.Document](v2))
 				if err != nil {
 					return nil, err
 				}
 				ops = append(ops, subOps...)
 			case types.ArrayValue:
-				subOps, err := arrayDiff(append(path, PathFragment{FieldName: f1[i]}), types.As[types.Array](v1), types.As[types.Array](v2))
+				subOps, err := arrayDiff(path.ExtendField(f1[i]), types.As[types.Array](v1), types.As[types.Array](v2))
 				if err != nil {
 					return nil, err
 				}
@@ -133,13 +146,13 @@ func arrayDiff(path Path, a1, a2 types.Array) ([]Op, error) {
 
 		switch v1.Type() {
 		case types.DocumentValue:
-			subOps, err := diff(append(path, PathFragment{ArrayIndex: i}), types.As[types.Document](v1), types.As[types.Document](v2))
+			subOps, err := diff(path.ExtendIndex(i), types.As[types.Document](v1), types.As[types.Document](v2))
 			if err != nil {
 				return nil, err
 			}
 			ops = append(ops, subOps...)
 		case types.ArrayValue:
-			subOps, err := arrayDiff(append(path, PathFragment{ArrayIndex: i}), types.As[types.Array](v1), types.As[types.Array](v2))
+			subOps, err := arrayDiff(path.ExtendIndex(i), types.As[types.Array](v1), types.As[types.Array](v2))
 			if err != nil {
 				return nil, err
 			}
@@ -59,6 +59,29 @@ func TestDiff(t *testing.T) {
 				{"set", document.NewPath("a"), types.NewTextValue("hello")},
 			},
 		},
+		{
+			name: "reordered fields",
+			d1:   `{"a": 1, "b": {"c": 2, "d": 3}}`,
+			d2:   `{"b": {"d": 3, "c": 2}, "a": 1}`,
+			want: nil,
+		},
+		{
+			name: "add and remove fields",
+			d1:   `{"b": 1, "c": 2}`,
+			d2:   `{"a": 0, "c": 2}`,
+			want: []document.Op{
+				{"set", document.NewPath("a"), types.NewIntegerValue(0)},
+				{"delete", document.NewPath("b"), types.NewIntegerValue(1)},
+			},
+		},
+		{
+			name: "replace field: different numeric type",
+			d1:   `{"a": 1}`,
+			d2:   `{"a": 1.0}`,
+			want: []document.Op{
+				{"set", document.NewPath("a"), types.NewDoubleValue(1)},
+			},
+		},
 		{
 			name: "nested document: replace field",
 			d1:   `{"a": {"b": 1}}`,
@@ -155,3 +178,32 @@ func TestDiff(t *testing.T) {
 		})
 	}
 }
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name   string
+		d1, d2 string
+		want   bool
+	}{
+		{"empty", `{}`, `{}`, true},
+		{"same", `{"a": 1, "b": "foo"}`, `{"a": 1, "b": "foo"}`, true},
+		{"reordered fields", `{"a": 1, "b": "foo"}`, `{"b": "foo", "a": 1}`, true},
+		{"missing field", `{"a": 1, "b": "foo"}`, `{"a": 1}`, false},
+		{"different value", `{"a": 1}`, `{"a": 2}`, false},
+		{"different type", `{"a": 1}`, `{"a": 1.0}`, false},
+		{"nested document: reordered fields", `{"a": {"b": 1, "c": [1, {"d": 2, "e": 3}]}}`, `{"a": {"c": [1, {"e": 3, "d": 2}], "b": 1}}`, true},
+		{"nested document: different value", `{"a": {"b": 1, "c": 2}}`, `{"a": {"c": 2, "b": 3}}`, false},
+		{"nested array: different order", `{"a": [1, 2]}`, `{"a": [2, 1]}`, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d1 := testutil.MakeDocument(t, test.d1)
+			d2 := testutil.MakeDocument(t, test.d2)
+
+			got, err := document.Equal(d1, d2)
+			require.NoError(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/internal/stringutil"
 	"github.com/genjidb/genji/types"
 )
 
@@ -36,6 +37,7 @@ type PathFragment struct {
 }
 
 // String representation of all the fragments of the path.
+// Field names are quoted if needed, so that the result can be parsed again.
 // It implements the Stringer interface.
 func (p Path) String() string {
 	var b strings.Builder
@@ -45,7 +47,7 @@ func (p Path) String() string {
 			if i != 0 {
 				b.WriteRune('.')
 			}
-			b.WriteString(p[i].FieldName)
+			b.WriteString(stringutil.NormalizeIdentifier(p[i].FieldName, '`'))
 		} else {
 			b.WriteString("[" + strconv.Itoa(p[i].ArrayIndex) + "]")
 		}
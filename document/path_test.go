@@ -47,3 +47,27 @@ func TestPath(t *testing.T) {
 		})
 	}
 }
+
+func TestPathString(t *testing.T) {
+	tests := []struct {
+		path     document.Path
+		expected string
+	}{
+		{document.NewPath("a", "b"), "a.b"},
+		{document.NewPath("a", "1", "b"), "a[1].b"},
+		{document.NewPath("my field"), "`my field`"},
+		{document.NewPath("a", "b-c"), "a.`b-c`"},
+		{document.Path{document.PathFragment{FieldName: "a`b"}}, "`a\\`b`"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			require.Equal(t, test.expected, test.path.String())
+
+			// the representation can be parsed again
+			p, err := parser.ParsePath(test.path.String())
+			assert.NoError(t, err)
+			require.Equal(t, test.path, p)
+		})
+	}
+}
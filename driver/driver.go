@@ -5,8 +5,13 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"io"
+	"net/url"
+	"reflect"
 	"runtime"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji"
@@ -32,8 +37,16 @@ func (d sqlDriver) Open(name string) (driver.Conn, error) {
 	return nil, errors.New("requires go1.10 or greater")
 }
 
+// OpenConnector opens the database located at the path described by name.
+// Options can be passed as query parameters, e.g. "path/to/db?sort_buffer_size=1048576".
+// The supported parameters are sort_buffer_size, case_sensitive_like and case_insensitive_identifiers.
 func (d sqlDriver) OpenConnector(name string) (driver.Connector, error) {
-	db, err := genji.Open(name)
+	path, opts, err := parseDataSourceName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := genji.Open(path, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -47,6 +60,52 @@ func (d sqlDriver) OpenConnector(name string) (driver.Connector, error) {
 	return c, nil
 }
 
+// parseDataSourceName returns the path and the options described by the data source name.
+func parseDataSourceName(name string) (string, []genji.Option, error) {
+	path, query, ok := strings.Cut(name, "?")
+	if !ok {
+		return name, nil, nil
+	}
+
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "invalid data source name %q", name)
+	}
+
+	var opts []genji.Option
+	for k, v := range params {
+		// if a parameter is repeated, the last value wins
+		value := v[len(v)-1]
+
+		var err error
+		switch k {
+		case "sort_buffer_size":
+			var size int
+			size, err = strconv.Atoi(value)
+			opts = append(opts, genji.WithSortBufferSize(size))
+		case "case_sensitive_like":
+			var enabled bool
+			enabled, err = strconv.ParseBool(value)
+			if enabled {
+				opts = append(opts, genji.WithCaseSensitiveLike())
+			}
+		case "case_insensitive_identifiers":
+			var enabled bool
+			enabled, err = strconv.ParseBool(value)
+			if enabled {
+				opts = append(opts, genji.WithCaseInsensitiveIdentifiers())
+			}
+		default:
+			return "", nil, errors.Errorf("unknown option %q", k)
+		}
+		if err != nil {
+			return "", nil, errors.Errorf("invalid value for %s: %q", k, value)
+		}
+	}
+
+	return path, opts, nil
+}
+
 var (
 	_ driver.Connector = (*connector)(nil)
 	_ io.Closer        = (*connector)(nil)
@@ -197,19 +256,35 @@ func (s stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver
 	default:
 	}
 
-	return result{}, s.stmt.Exec(driverNamedValueToParams(args)...)
+	res, err := s.stmt.Query(driverNamedValueToParams(args)...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = res.Iterate(func(d types.Document) error { return nil })
+	if err != nil {
+		_ = res.Close()
+		return nil, err
+	}
+
+	r := result{rowsAffected: int64(res.RowsProcessed())}
+	return r, res.Close()
 }
 
-type result struct{}
+type result struct {
+	rowsAffected int64
+}
 
 // LastInsertId is not supported and returns an error.
 func (r result) LastInsertId() (int64, error) {
 	return 0, errors.New("not supported")
 }
 
-// RowsAffected is not supported and returns an error.
+// RowsAffected returns the number of documents processed by the statement,
+// i.e. the number of documents inserted, updated or deleted by write statements.
+// It returns 0 for statements that don't operate on documents, such as CREATE TABLE.
 func (r result) RowsAffected() (int64, error) {
-	return 0, errors.New("not supported")
+	return r.rowsAffected, nil
 }
 
 func (s stmt) Query(args []driver.Value) (driver.Rows, error) {
@@ -260,6 +335,9 @@ type documentStream struct {
 	c        chan doc
 	wg       sync.WaitGroup
 	fields   []string
+	types    []types.ValueType
+	// number of rows returned by Next so far
+	row int
 }
 
 type doc struct {
@@ -325,6 +403,51 @@ func (rs *documentStream) Columns() []string {
 	return rs.res.Fields()
 }
 
+// ColumnTypeDatabaseTypeName returns the type of the field at the given index,
+// as declared in the table schema, or "ANY" if it is unknown.
+// It implements the driver.RowsColumnTypeDatabaseTypeName interface.
+func (rs *documentStream) ColumnTypeDatabaseTypeName(index int) string {
+	return strings.ToUpper(rs.columnType(index).String())
+}
+
+// ColumnTypeScanType returns the Go type that can be used to scan
+// the field at the given index.
+// It implements the driver.RowsColumnTypeScanType interface.
+func (rs *documentStream) ColumnTypeScanType(index int) reflect.Type {
+	switch rs.columnType(index) {
+	case types.BooleanValue:
+		return reflect.TypeOf(false)
+	case types.IntegerValue:
+		return reflect.TypeOf(int64(0))
+	case types.DoubleValue:
+		return reflect.TypeOf(float64(0))
+	case types.TimestampValue:
+		return reflect.TypeOf(time.Time{})
+	case types.TextValue:
+		return reflect.TypeOf("")
+	case types.BlobValue:
+		return reflect.TypeOf([]byte(nil))
+	case types.ArrayValue:
+		return reflect.TypeOf((*types.Array)(nil)).Elem()
+	case types.DocumentValue:
+		return reflect.TypeOf((*types.Document)(nil)).Elem()
+	}
+
+	return reflect.TypeOf((*interface{})(nil)).Elem()
+}
+
+func (rs *documentStream) columnType(index int) types.ValueType {
+	if rs.types == nil {
+		rs.types = rs.res.Types()
+	}
+
+	if index >= len(rs.types) {
+		return types.AnyValue
+	}
+
+	return rs.types[index]
+}
+
 // Close closes the rows iterator.
 func (rs *documentStream) Close() error {
 	rs.cancelFn()
@@ -344,6 +467,8 @@ func (rs *documentStream) Next(dest []driver.Value) error {
 		return doc.err
 	}
 
+	rs.row++
+
 	for i := range rs.fields {
 		if rs.fields[i] == "*" {
 			dest[i] = doc.d
@@ -353,7 +478,7 @@ func (rs *documentStream) Next(dest []driver.Value) error {
 
 		f, err := doc.d.GetByField(rs.fields[i])
 		if err != nil {
-			return err
+			return errors.Wrapf(err, "scanning column %q (row %d)", rs.fields[i], rs.row)
 		}
 
 		dest[i] = f.V()
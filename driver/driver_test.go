@@ -3,10 +3,12 @@ package driver
 import (
 	"context"
 	"database/sql"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/genjidb/genji/internal/testutil/assert"
+	"github.com/genjidb/genji/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -26,7 +28,7 @@ func TestDriver(t *testing.T) {
 	res, err := db.Exec("CREATE TABLE test")
 	assert.NoError(t, err)
 	n, err := res.RowsAffected()
-	assert.Error(t, err)
+	assert.NoError(t, err)
 	require.EqualValues(t, 0, n)
 
 	for i := 0; i < 10; i++ {
@@ -282,3 +284,264 @@ func TestDriverWithTimeValues(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, now, tt)
 }
+
+func TestDriverOptions(t *testing.T) {
+	c, err := sqlDriver{}.OpenConnector(":memory:?sort_buffer_size=64")
+	assert.NoError(t, err)
+	defer c.(*connector).Close()
+	require.Equal(t, 64, c.(*connector).db.DB.SortBufferSize)
+
+	db := sql.OpenDB(c)
+	_, err = db.Exec("CREATE TABLE test(a INT); INSERT INTO test (a) VALUES (2), (3), (1)")
+	assert.NoError(t, err)
+
+	rows, err := db.Query("SELECT a FROM test ORDER BY a")
+	assert.NoError(t, err)
+	defer rows.Close()
+
+	var got []int
+	for rows.Next() {
+		var a int
+		assert.NoError(t, rows.Scan(&a))
+		got = append(got, a)
+	}
+	assert.NoError(t, rows.Err())
+	require.Equal(t, []int{1, 2, 3}, got)
+
+	c, err = sqlDriver{}.OpenConnector(":memory:?case_sensitive_like=true&case_insensitive_identifiers=1")
+	assert.NoError(t, err)
+	defer c.(*connector).Close()
+
+	db = sql.OpenDB(c)
+	_, err = db.Exec("CREATE TABLE Foo(A TEXT); INSERT INTO foo (a) VALUES ('Hello')")
+	assert.NoError(t, err)
+
+	var n int
+	err = db.QueryRow("SELECT COUNT(*) FROM FOO WHERE A LIKE 'h%'").Scan(&n)
+	assert.NoError(t, err)
+	require.Equal(t, 0, n)
+
+	_, err = sql.Open("genji", ":memory:?case_sensitive_like=foo")
+	assert.Error(t, err)
+
+	_, err = sql.Open("genji", ":memory:?sort_buffer_size=foo")
+	assert.Error(t, err)
+
+	_, err = sql.Open("genji", ":memory:?foo=1")
+	assert.Error(t, err)
+}
+
+func TestDriverColumnTypes(t *testing.T) {
+	db, err := sql.Open("genji", ":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE test(a INTEGER, b DOUBLE, c TEXT, d BLOB, e BOOL, f TIMESTAMP, g (h INTEGER), i ARRAY, ...);
+		CREATE TABLE schemaless;
+		CREATE INDEX test_a ON test(a);
+		INSERT INTO test (a, b, c, d, e, f, g, i, j) VALUES (1, 1.5, 'foo', '\xaa', true, '2022-01-01', {h: 1}, [1], 'extra');
+		INSERT INTO schemaless (a) VALUES (1);
+	`)
+	assert.NoError(t, err)
+
+	anyType := reflect.TypeOf((*interface{})(nil)).Elem()
+
+	tests := []struct {
+		name      string
+		query     string
+		typeNames []string
+		scanTypes []reflect.Type
+	}{
+		{"Schemaful", "SELECT a, b, c, d, e, f, g, g.h, i FROM test",
+			[]string{"INTEGER", "DOUBLE", "TEXT", "BLOB", "BOOLEAN", "TIMESTAMP", "DOCUMENT", "INTEGER", "ARRAY"},
+			[]reflect.Type{
+				reflect.TypeOf(int64(0)), reflect.TypeOf(float64(0)), reflect.TypeOf(""), reflect.TypeOf([]byte(nil)),
+				reflect.TypeOf(false), reflect.TypeOf(time.Time{}), reflect.TypeOf((*types.Document)(nil)).Elem(),
+				reflect.TypeOf(int64(0)), reflect.TypeOf((*types.Array)(nil)).Elem(),
+			},
+		},
+		{"Schemaful with index", "SELECT a, c FROM test WHERE a = 1",
+			[]string{"INTEGER", "TEXT"},
+			[]reflect.Type{reflect.TypeOf(int64(0)), reflect.TypeOf("")},
+		},
+		{"Extra field and expression", "SELECT j, a + 1 FROM test",
+			[]string{"ANY", "ANY"},
+			[]reflect.Type{anyType, anyType},
+		},
+		{"Wildcard", "SELECT * FROM test",
+			[]string{"ANY"},
+			[]reflect.Type{anyType},
+		},
+		{"Schemaless", "SELECT a FROM schemaless",
+			[]string{"ANY"},
+			[]reflect.Type{anyType},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rows, err := db.Query(test.query)
+			assert.NoError(t, err)
+			defer rows.Close()
+
+			cts, err := rows.ColumnTypes()
+			assert.NoError(t, err)
+			require.Len(t, cts, len(test.typeNames))
+
+			for i, ct := range cts {
+				require.Equal(t, test.typeNames[i], ct.DatabaseTypeName())
+				require.Equal(t, test.scanTypes[i], ct.ScanType())
+			}
+		})
+	}
+}
+
+func TestDriverScanErrors(t *testing.T) {
+	db, err := sql.Open("genji", ":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE test;
+		INSERT INTO test (a, b) VALUES (1, 'foo');
+		INSERT INTO test (a) VALUES (2);
+	`)
+	assert.NoError(t, err)
+
+	rows, err := db.Query("SELECT ONLY a, b FROM test")
+	assert.NoError(t, err)
+	defer rows.Close()
+
+	var count int
+	for rows.Next() {
+		var a int
+		var b string
+		err = rows.Scan(&a, &b)
+		assert.NoError(t, err)
+		count++
+	}
+	require.Equal(t, 1, count)
+	require.EqualError(t, rows.Err(), `scanning column "b" (row 2): field "b" not found`)
+}
+
+func TestDriverReturning(t *testing.T) {
+	db, err := sql.Open("genji", ":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE test(id INTEGER PRIMARY KEY, name TEXT);
+		CREATE TABLE src(id INTEGER, name TEXT);
+		INSERT INTO src (id, name) VALUES (2, 'bar'), (3, 'baz');
+	`)
+	assert.NoError(t, err)
+
+	t.Run("INSERT ... VALUES", func(t *testing.T) {
+		rows, err := db.QueryContext(context.Background(), "INSERT INTO test (id, name) VALUES (?, ?) RETURNING id", 1, "foo")
+		assert.NoError(t, err)
+		defer rows.Close()
+
+		cols, err := rows.Columns()
+		assert.NoError(t, err)
+		require.Equal(t, []string{"id"}, cols)
+
+		require.True(t, rows.Next())
+		var id int
+		err = rows.Scan(&id)
+		assert.NoError(t, err)
+		require.Equal(t, 1, id)
+		require.False(t, rows.Next())
+		assert.NoError(t, rows.Err())
+	})
+
+	t.Run("INSERT ... SELECT", func(t *testing.T) {
+		rows, err := db.QueryContext(context.Background(), "INSERT INTO test SELECT id, name FROM src RETURNING id, name")
+		assert.NoError(t, err)
+		defer rows.Close()
+
+		cols, err := rows.Columns()
+		assert.NoError(t, err)
+		require.Equal(t, []string{"id", "name"}, cols)
+
+		var ids []int
+		var names []string
+		for rows.Next() {
+			var id int
+			var name string
+			err = rows.Scan(&id, &name)
+			assert.NoError(t, err)
+			ids = append(ids, id)
+			names = append(names, name)
+		}
+		assert.NoError(t, rows.Err())
+		require.Equal(t, []int{2, 3}, ids)
+		require.Equal(t, []string{"bar", "baz"}, names)
+	})
+
+	t.Run("INSERT ... SELECT column types", func(t *testing.T) {
+		rows, err := db.QueryContext(context.Background(), "INSERT INTO test SELECT id + 10 AS id, name FROM src RETURNING name")
+		assert.NoError(t, err)
+		defer rows.Close()
+
+		cts, err := rows.ColumnTypes()
+		assert.NoError(t, err)
+		require.Len(t, cts, 1)
+		require.Equal(t, "TEXT", cts[0].DatabaseTypeName())
+		require.Equal(t, reflect.TypeOf(""), cts[0].ScanType())
+
+		for rows.Next() {
+		}
+		assert.NoError(t, rows.Err())
+	})
+}
+
+func TestDriverRowsAffected(t *testing.T) {
+	db, err := sql.Open("genji", ":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("CREATE TABLE test(a INT PRIMARY KEY, b INT)")
+	assert.NoError(t, err)
+
+	exec := func(q string) int64 {
+		t.Helper()
+
+		res, err := db.Exec(q)
+		assert.NoError(t, err)
+		n, err := res.RowsAffected()
+		assert.NoError(t, err)
+		return n
+	}
+
+	require.EqualValues(t, 5, exec("INSERT INTO test (a, b) VALUES (1, 1), (2, 1), (3, 2), (4, 2), (5, 2)"))
+	require.EqualValues(t, 2, exec("UPDATE test SET b = 10 WHERE b = 1"))
+	require.EqualValues(t, 0, exec("UPDATE test SET b = 10 WHERE b = 100"))
+	require.EqualValues(t, 5, exec("UPDATE test SET b = b + 1"))
+	require.EqualValues(t, 3, exec("DELETE FROM test WHERE b = 3"))
+	require.EqualValues(t, 2, exec("DELETE FROM test"))
+}
+
+func TestDriverNoTable(t *testing.T) {
+	db, err := sql.Open("genji", ":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	rows, err := db.Query("SELECT 1 + 2, UPPER('hi') AS greeting")
+	assert.NoError(t, err)
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	assert.NoError(t, err)
+	require.Equal(t, []string{"1 + 2", "greeting"}, cols)
+
+	require.True(t, rows.Next())
+	var n int
+	var greeting string
+	err = rows.Scan(&n, &greeting)
+	assert.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, "HI", greeting)
+	require.False(t, rows.Next())
+	assert.NoError(t, rows.Err())
+}
@@ -4,8 +4,18 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/database"
 	errs "github.com/genjidb/genji/internal/errors"
+	"github.com/genjidb/genji/internal/sql/parser"
 )
 
+// ParseError is returned when a query is not valid SQL.
+// It can be retrieved from the errors returned by the database using errors.As.
+type ParseError = parser.ParseError
+
+// ConstraintViolationError is returned when a document doesn't satisfy
+// a constraint of its table, such as NOT NULL, UNIQUE or CHECK.
+// It can be retrieved from the errors returned by the database using errors.As.
+type ConstraintViolationError = database.ConstraintViolationError
+
 // IsNotFoundError determines if the given error is a NotFoundError.
 // NotFoundError is returned when the requested table, index, document or sequence
 // doesn't exist.
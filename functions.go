@@ -0,0 +1,44 @@
+package genji
+
+import (
+	"github.com/genjidb/genji/internal/expr/functions"
+	"github.com/genjidb/genji/types"
+)
+
+// RegisterFunc registers a scalar function that can be called from SQL
+// queries by its name, without package, e.g. SELECT twice(a) FROM foo.
+// The function receives the evaluated arguments of the call and may be called
+// with any number of arguments, it is up to fn to validate them.
+// Registered functions can also be called by triggers, with access to the
+// modified documents, e.g. CREATE TRIGGER t AFTER INSERT ON foo EXECUTE FUNCTION audit(new).
+// Returning an error from such a function aborts the statement that fired the trigger.
+// It returns an error if name is already used by a builtin function
+// or is a reserved keyword.
+// RegisterFunc is safe for concurrent use, but must be called before
+// parsing the queries that use the function.
+func RegisterFunc(name string, fn func(args []types.Value) (types.Value, error)) error {
+	return functions.RegisterFunc(name, fn)
+}
+
+// An Aggregate defines an aggregate function registered with RegisterAggregate.
+// Every group of documents is aggregated into its own state:
+// Init returns the initial state of a group, Step is called with
+// the evaluated arguments of the call for every document of the group
+// and returns the new state, and Finalize returns the result of the group
+// from its last state.
+// Init may be nil, in which case the initial state is nil.
+type Aggregate = functions.UserAggregate
+
+// RegisterAggregate registers an aggregate function that can be called from SQL
+// queries by its name, without package, e.g. SELECT median(a) FROM foo GROUP BY b.
+// Like the builtin aggregates, it computes one result per group,
+// or a single result if the query has no GROUP BY clause.
+// It returns an error if name is already used by a builtin function
+// or is a reserved keyword.
+// Registering an aggregate with the name of a function registered with RegisterFunc
+// replaces it, and vice versa.
+// RegisterAggregate is safe for concurrent use, but must be called before
+// parsing the queries that use the aggregate.
+func RegisterAggregate(name string, agg Aggregate) error {
+	return functions.RegisterAggregate(name, agg)
+}
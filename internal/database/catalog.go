@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/cockroachdb/errors"
@@ -18,6 +19,7 @@ import (
 const (
 	CatalogTableName  = InternalPrefix + "catalog"
 	SequenceTableName = InternalPrefix + "sequence"
+	StatsTableName    = InternalPrefix + "stats"
 )
 
 // Relation types
@@ -25,6 +27,8 @@ const (
 	RelationTableType    = "table"
 	RelationIndexType    = "index"
 	RelationSequenceType = "sequence"
+	RelationViewType     = "view"
+	RelationTriggerType  = "trigger"
 )
 
 // System sequences
@@ -37,11 +41,12 @@ const (
 	CatalogTableNamespace    tree.Namespace = 1
 	SequenceTableNamespace   tree.Namespace = 2
 	RollbackSegmentNamespace tree.Namespace = 3
+	StatsTableNamespace      tree.Namespace = 4
 	MinTransientNamespace    tree.Namespace = math.MaxInt64 - 1<<24
 	MaxTransientNamespace    tree.Namespace = math.MaxInt64
 )
 
-// Catalog manages all database objects such as tables, indexes and sequences.
+// Catalog manages all database objects such as tables, indexes, sequences, views and triggers.
 // It stores all these objects in memory for fast access. Any modification
 // is persisted into the __genji_catalog table.
 type Catalog struct {
@@ -99,7 +104,15 @@ func (c *Catalog) GetIndex(tx *Transaction, indexName string) (*Index, error) {
 		return nil, err
 	}
 
-	return NewIndex(tree.New(tx.Session, info.StoreNamespace, info.KeySortOrder), *info), nil
+	idx := NewIndex(tree.New(tx.Session, info.StoreNamespace, info.KeySortOrder), *info)
+
+	ti, err := c.GetTableInfo(info.Owner.TableName)
+	if err != nil {
+		return nil, err
+	}
+	idx.codecs = indexFieldCodecs(ti, info)
+
+	return idx, nil
 }
 
 // GetIndexInfo returns an index info by name.
@@ -190,8 +203,9 @@ func (c *CatalogWriter) Init(tx *Transaction) error {
 func (c *CatalogWriter) ensureTableExists(tx *Transaction, info *TableInfo) error {
 	err := c.CreateTable(tx, info.TableName, info)
 	if err != nil {
+		var cerr *ConstraintViolationError
 		switch {
-		case IsConstraintViolationError(err) && err.(*ConstraintViolationError).Constraint == "PRIMARY KEY":
+		case errors.As(err, &cerr) && cerr.Constraint == "PRIMARY KEY":
 		case errs.IsAlreadyExistsError(err):
 		default:
 			return err
@@ -204,8 +218,9 @@ func (c *CatalogWriter) ensureTableExists(tx *Transaction, info *TableInfo) erro
 func (c *CatalogWriter) ensureSequenceExists(tx *Transaction, seq *SequenceInfo) error {
 	err := c.CreateSequence(tx, seq)
 	if err != nil {
+		var cerr *ConstraintViolationError
 		switch {
-		case IsConstraintViolationError(err) && err.(*ConstraintViolationError).Constraint == "PRIMARY KEY":
+		case errors.As(err, &cerr) && cerr.Constraint == "PRIMARY KEY":
 		case errs.IsAlreadyExistsError(err):
 		default:
 			return err
@@ -248,6 +263,19 @@ func (c *CatalogWriter) CreateTable(tx *Transaction, tableName string, info *Tab
 		return errors.WithStack(errs.AlreadyExistsError{Name: tableName})
 	}
 
+	if info.TTLField != "" {
+		fc := info.FieldConstraints.ByField[info.TTLField]
+		if fc == nil || fc.Type != types.TimestampValue {
+			return errors.Errorf("ttl field %q must be a TIMESTAMP field", info.TTLField)
+		}
+
+		// the expired documents are deleted in the background
+		// once the table is created
+		if tx.db != nil {
+			tx.OnCommitHooks = append(tx.OnCommitHooks, tx.db.startReaper)
+		}
+	}
+
 	if info.StoreNamespace == 0 {
 		info.StoreNamespace, err = c.generateStoreNamespace(tx)
 		if err != nil {
@@ -287,6 +315,13 @@ func (c *CatalogWriter) DropTable(tx *Transaction, tableName string) error {
 		}
 	}
 
+	for _, t := range c.Cache.GetTableTriggers(tableName) {
+		err = c.DropTrigger(tx, t.TriggerName)
+		if err != nil {
+			return err
+		}
+	}
+
 	_, err = c.Cache.Delete(tx, RelationTableType, tableName)
 	if err != nil {
 		return err
@@ -297,6 +332,11 @@ func (c *CatalogWriter) DropTable(tx *Transaction, tableName string) error {
 		return err
 	}
 
+	err = c.DeleteTableStats(tx, tableName)
+	if err != nil {
+		return err
+	}
+
 	return tree.New(tx.Session, ti.StoreNamespace, ti.PrimaryKeySortOrder()).Truncate()
 }
 
@@ -310,7 +350,11 @@ func (c *CatalogWriter) CreateIndex(tx *Transaction, info *IndexInfo) (*IndexInf
 	}
 
 	// check if the indexed fields exist
-	for _, p := range info.Paths {
+	for i, p := range info.Paths {
+		if info.ColumnExpr(i) != nil {
+			continue
+		}
+
 		fc := ti.GetFieldConstraintForPath(p)
 		if fc == nil {
 			return nil, errors.Errorf("field %q does not exist for table %q", p, ti.TableName)
@@ -398,6 +442,219 @@ func (c *CatalogWriter) AddFieldConstraint(tx *Transaction, tableName string, fc
 	return c.CatalogTable.Replace(tx, tableName, cloneRel)
 }
 
+// DropFieldConstraint removes a top-level field from the constraints of a table.
+// Unique constraints and indexes on the field, or on paths nested in it, are dropped as well.
+// It returns an error if the field is part of the primary key.
+func (c *CatalogWriter) DropFieldConstraint(tx *Transaction, tableName, field string) error {
+	r, err := c.Cache.Get(RelationTableType, tableName)
+	if err != nil {
+		return err
+	}
+	ti := r.(*TableInfoRelation).Info
+
+	if ti.ReadOnly {
+		return errors.New("cannot write to read-only table")
+	}
+
+	if _, ok := ti.FieldConstraints.ByField[field]; !ok && !ti.FieldConstraints.AllowExtraFields {
+		return errors.Errorf("field %q does not exist for table %q", field, tableName)
+	}
+
+	clone := ti.Clone()
+
+	// field constraints are copied to avoid modifying
+	// the positions of the ones of the current table
+	clone.FieldConstraints.Ordered = nil
+	clone.FieldConstraints.ByField = nil
+	for _, fc := range ti.FieldConstraints.Ordered {
+		if fc.Field == field {
+			continue
+		}
+
+		cp := *fc
+		err = clone.FieldConstraints.Add(&cp)
+		if err != nil {
+			return err
+		}
+	}
+
+	clone.TableConstraints = nil
+	for _, tc := range ti.TableConstraints {
+		if !pathsHaveField(tc.Paths, field) {
+			clone.TableConstraints = append(clone.TableConstraints, tc)
+			continue
+		}
+
+		if tc.PrimaryKey {
+			return errors.Errorf("cannot drop field %q because it is part of the primary key of table %q", field, tableName)
+		}
+	}
+
+	if ti.TTLField == field {
+		return errors.Errorf("cannot drop field %q because it is the ttl field of table %q", field, tableName)
+	}
+
+	if _, ok := ti.FieldCodecs[field]; ok {
+		clone.FieldCodecs = make(map[string]*FieldCodec, len(ti.FieldCodecs))
+		for f, fc := range ti.FieldCodecs {
+			if f != field {
+				clone.FieldCodecs[f] = fc
+			}
+		}
+	}
+
+	for _, idx := range c.Cache.GetTableIndexes(tableName) {
+		if !pathsHaveField(idx.Paths, field) {
+			continue
+		}
+
+		_, err = c.Cache.Delete(tx, RelationIndexType, idx.IndexName)
+		if err != nil {
+			return err
+		}
+
+		err = c.dropIndex(tx, idx)
+		if err != nil {
+			return err
+		}
+	}
+
+	cloneRel := &TableInfoRelation{Info: clone}
+	err = c.Cache.Replace(tx, cloneRel)
+	if err != nil {
+		return err
+	}
+
+	return c.CatalogTable.Replace(tx, tableName, cloneRel)
+}
+
+// RenameFieldConstraint renames a top-level field of a table.
+// The paths of the table constraints and of the indexes referencing
+// the field, or paths nested in it, are renamed as well.
+func (c *CatalogWriter) RenameFieldConstraint(tx *Transaction, tableName, oldName, newName string) error {
+	r, err := c.Cache.Get(RelationTableType, tableName)
+	if err != nil {
+		return err
+	}
+	ti := r.(*TableInfoRelation).Info
+
+	if ti.ReadOnly {
+		return errors.New("cannot write to read-only table")
+	}
+
+	if _, ok := ti.FieldConstraints.ByField[oldName]; !ok && !ti.FieldConstraints.AllowExtraFields {
+		return errors.Errorf("field %q does not exist for table %q", oldName, tableName)
+	}
+
+	if _, ok := ti.FieldConstraints.ByField[newName]; ok {
+		return errors.Errorf("field %q already exists for table %q", newName, tableName)
+	}
+
+	clone := ti.Clone()
+
+	clone.FieldConstraints.Ordered = nil
+	clone.FieldConstraints.ByField = nil
+	for _, fc := range ti.FieldConstraints.Ordered {
+		cp := *fc
+		if cp.Field == oldName {
+			cp.Field = newName
+		}
+
+		err = clone.FieldConstraints.Add(&cp)
+		if err != nil {
+			return err
+		}
+	}
+
+	if clone.TTLField == oldName {
+		clone.TTLField = newName
+	}
+
+	if _, ok := ti.FieldCodecs[oldName]; ok {
+		clone.FieldCodecs = make(map[string]*FieldCodec, len(ti.FieldCodecs))
+		for f, fc := range ti.FieldCodecs {
+			if f == oldName {
+				f = newName
+			}
+			clone.FieldCodecs[f] = fc
+		}
+	}
+
+	clone.TableConstraints = nil
+	for _, tc := range ti.TableConstraints {
+		if pathsHaveField(tc.Paths, oldName) {
+			cp := *tc
+			cp.Paths = renamePathsField(tc.Paths, oldName, newName)
+			tc = &cp
+		}
+
+		clone.TableConstraints = append(clone.TableConstraints, tc)
+	}
+
+	cloneRel := &TableInfoRelation{Info: clone}
+	err = c.Cache.Replace(tx, cloneRel)
+	if err != nil {
+		return err
+	}
+
+	err = c.CatalogTable.Replace(tx, tableName, cloneRel)
+	if err != nil {
+		return err
+	}
+
+	// indexes store values only, renaming their paths is enough
+	for _, idx := range c.Cache.GetTableIndexes(tableName) {
+		if !pathsHaveField(idx.Paths, oldName) && !pathsHaveField(idx.Owner.Paths, oldName) {
+			continue
+		}
+
+		idxClone := idx.Clone()
+		idxClone.Paths = renamePathsField(idx.Paths, oldName, newName)
+		idxClone.Owner.Paths = renamePathsField(idx.Owner.Paths, oldName, newName)
+
+		idxRel := &IndexInfoRelation{Info: idxClone}
+		err = c.Cache.Replace(tx, idxRel)
+		if err != nil {
+			return err
+		}
+
+		err = c.CatalogTable.Replace(tx, idxClone.IndexName, idxRel)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetFieldCodec sets the codec of a top-level field of a table,
+// replacing the previous one if any.
+// Codecs are not stored in the catalog, they only apply until the database is closed.
+func (c *CatalogWriter) SetFieldCodec(tx *Transaction, tableName, field string, codec *FieldCodec) error {
+	if codec == nil || codec.Encode == nil || codec.Decode == nil {
+		return errors.Errorf("missing codec functions for field %s of table %s", field, tableName)
+	}
+
+	r, err := c.Cache.Get(RelationTableType, tableName)
+	if err != nil {
+		return err
+	}
+	ti := r.(*TableInfoRelation).Info
+
+	if _, ok := ti.FieldConstraints.ByField[field]; !ok && !ti.FieldConstraints.AllowExtraFields {
+		return errors.Errorf("field %q does not exist for table %q", field, tableName)
+	}
+
+	clone := ti.Clone()
+	clone.FieldCodecs = make(map[string]*FieldCodec, len(ti.FieldCodecs)+1)
+	for f, fc := range ti.FieldCodecs {
+		clone.FieldCodecs[f] = fc
+	}
+	clone.FieldCodecs[field] = codec
+
+	return c.Cache.Replace(tx, &TableInfoRelation{Info: clone})
+}
+
 // RenameTable renames a table.
 // If it doesn't exist, it returns errs.ErrTableNotFound.
 func (c *CatalogWriter) RenameTable(tx *Transaction, oldName, newName string) error {
@@ -433,6 +690,13 @@ func (c *CatalogWriter) RenameTable(tx *Transaction, oldName, newName string) er
 		return err
 	}
 
+	// statistics are stored by table name, they are
+	// dropped until the table is analyzed again
+	err = c.DeleteTableStats(tx, oldName)
+	if err != nil {
+		return err
+	}
+
 	for _, idx := range c.Cache.GetTableIndexes(oldName) {
 		r, err := c.Cache.Delete(tx, RelationIndexType, idx.IndexName)
 		if err != nil {
@@ -455,6 +719,27 @@ func (c *CatalogWriter) RenameTable(tx *Transaction, oldName, newName string) er
 		}
 	}
 
+	for _, t := range c.Cache.GetTableTriggers(oldName) {
+		r, err := c.Cache.Delete(tx, RelationTriggerType, t.TriggerName)
+		if err != nil {
+			return err
+		}
+
+		triggerClone := r.(*TriggerInfoRelation).Info.Clone()
+		triggerClone.TableName = newName
+
+		cloneRel := &TriggerInfoRelation{Info: triggerClone}
+		err = c.Cache.Add(tx, cloneRel)
+		if err != nil {
+			return err
+		}
+
+		err = c.CatalogTable.Replace(tx, t.TriggerName, cloneRel)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, seqName := range c.ListSequences() {
 		seq, err := c.GetSequence(seqName)
 		if err != nil {
@@ -589,6 +874,35 @@ func (r *IndexInfoRelation) Clone() Relation {
 	return &clone
 }
 
+// pathsHaveField returns whether one of the paths starts with the given field.
+func pathsHaveField(paths []document.Path, field string) bool {
+	for _, p := range paths {
+		if len(p) > 0 && p[0].FieldName == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// renamePathsField returns a copy of the paths where
+// the paths starting with oldName start with newName instead.
+func renamePathsField(paths []document.Path, oldName, newName string) document.Paths {
+	if paths == nil {
+		return nil
+	}
+
+	renamed := make(document.Paths, len(paths))
+	for i, p := range paths {
+		renamed[i] = p.Clone()
+		if len(p) > 0 && p[0].FieldName == oldName {
+			renamed[i][0].FieldName = newName
+		}
+	}
+
+	return renamed
+}
+
 func pathsToIndexName(paths []document.Path) string {
 	var s strings.Builder
 
@@ -597,7 +911,24 @@ func pathsToIndexName(paths []document.Path) string {
 			s.WriteRune('_')
 		}
 
-		s.WriteString(p.String())
+		// the paths of expression columns are empty
+		if len(p) == 0 {
+			s.WriteString("expr")
+			continue
+		}
+
+		// field names are written as is, without quotes
+		for j, f := range p {
+			if f.FieldName == "" {
+				s.WriteString("[" + strconv.Itoa(f.ArrayIndex) + "]")
+				continue
+			}
+
+			if j > 0 {
+				s.WriteRune('.')
+			}
+			s.WriteString(f.FieldName)
+		}
 	}
 
 	return s.String()
@@ -607,6 +938,10 @@ type catalogCache struct {
 	tables    map[string]Relation
 	indexes   map[string]Relation
 	sequences map[string]Relation
+	views     map[string]Relation
+	triggers  map[string]Relation
+	// statistics collected by ANALYZE, by table name
+	stats map[string]*TableStats
 }
 
 func newCatalogCache() *catalogCache {
@@ -614,10 +949,13 @@ func newCatalogCache() *catalogCache {
 		tables:    make(map[string]Relation),
 		indexes:   make(map[string]Relation),
 		sequences: make(map[string]Relation),
+		views:     make(map[string]Relation),
+		triggers:  make(map[string]Relation),
+		stats:     make(map[string]*TableStats),
 	}
 }
 
-func (c *catalogCache) Load(tables []TableInfo, indexes []IndexInfo, sequences []Sequence) {
+func (c *catalogCache) Load(tables []TableInfo, indexes []IndexInfo, sequences []Sequence, views []ViewInfo, triggers []TriggerInfo) {
 	for i := range tables {
 		c.tables[tables[i].TableName] = &TableInfoRelation{Info: &tables[i]}
 	}
@@ -629,6 +967,14 @@ func (c *catalogCache) Load(tables []TableInfo, indexes []IndexInfo, sequences [
 	for i := range sequences {
 		c.sequences[sequences[i].Info.Name] = &sequences[i]
 	}
+
+	for i := range views {
+		c.views[views[i].ViewName] = &ViewInfoRelation{Info: &views[i]}
+	}
+
+	for i := range triggers {
+		c.triggers[triggers[i].TriggerName] = &TriggerInfoRelation{Info: &triggers[i]}
+	}
 }
 
 func (c *catalogCache) Clone() *catalogCache {
@@ -643,6 +989,15 @@ func (c *catalogCache) Clone() *catalogCache {
 	for k, v := range c.sequences {
 		clone.sequences[k] = v
 	}
+	for k, v := range c.views {
+		clone.views[k] = v
+	}
+	for k, v := range c.triggers {
+		clone.triggers[k] = v
+	}
+	for k, v := range c.stats {
+		clone.stats[k] = v
+	}
 
 	return clone
 }
@@ -663,6 +1018,16 @@ func (c *catalogCache) objectExists(name string) bool {
 		return true
 	}
 
+	// checking if view exists with the same name
+	if _, ok := c.views[name]; ok {
+		return true
+	}
+
+	// checking if trigger exists with the same name
+	if _, ok := c.triggers[name]; ok {
+		return true
+	}
+
 	return false
 }
 
@@ -689,6 +1054,10 @@ func (c *catalogCache) getMapByType(tp string) map[string]Relation {
 		return c.indexes
 	case RelationSequenceType:
 		return c.sequences
+	case RelationViewType:
+		return c.views
+	case RelationTriggerType:
+		return c.triggers
 	}
 
 	panic(fmt.Sprintf("unknown catalog object type %q", tp))
@@ -788,6 +1157,19 @@ func (c *catalogCache) GetTableIndexes(tableName string) []*IndexInfo {
 	return indexes
 }
 
+func (c *catalogCache) GetTableTriggers(tableName string) []*TriggerInfo {
+	var triggers []*TriggerInfo
+	for _, o := range c.triggers {
+		t := o.(*TriggerInfoRelation).Info
+		if t.TableName != tableName {
+			continue
+		}
+		triggers = append(triggers, t)
+	}
+
+	return triggers
+}
+
 type CatalogStore struct {
 	info *TableInfo
 }
@@ -908,6 +1290,10 @@ func relationToDocument(r Relation) types.Document {
 		return indexInfoToDocument(t.Info)
 	case *Sequence:
 		return sequenceInfoToDocument(t.Info)
+	case *ViewInfoRelation:
+		return viewInfoToDocument(t.Info)
+	case *TriggerInfoRelation:
+		return triggerInfoToDocument(t.Info)
 	}
 
 	panic(fmt.Sprintf("objectToDocument: unknown type %q", r.Type()))
@@ -568,6 +568,155 @@ func TestCatalogCreateSequence(t *testing.T) {
 	})
 }
 
+type viewQuery string
+
+func (q viewQuery) String() string { return string(q) }
+
+func TestCatalogCreateView(t *testing.T) {
+	t.Run("Should create a view and add it to the catalog table", func(t *testing.T) {
+		db := testutil.NewTestDB(t)
+
+		updateCatalog(t, db, func(tx *database.Transaction, catalog *database.CatalogWriter) error {
+			err := catalog.CreateView(tx, &database.ViewInfo{ViewName: "v", Query: viewQuery("SELECT * FROM test")})
+			if err != nil {
+				return err
+			}
+
+			info, err := catalog.GetViewInfo("v")
+			assert.NoError(t, err)
+			require.Equal(t, "CREATE VIEW v AS SELECT * FROM test", info.String())
+			require.Equal(t, []string{"v"}, catalog.ListViews())
+
+			tb := db.Catalog().CatalogTable.Table(tx)
+			d, err := tb.GetDocument(tree.NewKey(types.NewTextValue("v")))
+			assert.NoError(t, err)
+			testutil.RequireDocJSONEq(t, d, `{"name":"v", "type":"view", "sql":"CREATE VIEW v AS SELECT * FROM test"}`)
+			return nil
+		})
+	})
+
+	t.Run("Should fail if a relation with the same name exists", func(t *testing.T) {
+		db := testutil.NewTestDB(t)
+
+		updateCatalog(t, db, func(tx *database.Transaction, catalog *database.CatalogWriter) error {
+			return catalog.CreateTable(tx, "test", nil)
+		})
+
+		updateCatalog(t, db, func(tx *database.Transaction, catalog *database.CatalogWriter) error {
+			err := catalog.CreateView(tx, &database.ViewInfo{ViewName: "test", Query: viewQuery("SELECT 1")})
+			assert.ErrorIs(t, err, errs.AlreadyExistsError{Name: "test"})
+
+			err = catalog.CreateView(tx, &database.ViewInfo{ViewName: "v", Query: viewQuery("SELECT 1")})
+			assert.NoError(t, err)
+
+			err = catalog.CreateTable(tx, "v", nil)
+			assert.ErrorIs(t, err, errs.AlreadyExistsError{Name: "v"})
+			return nil
+		})
+	})
+
+	t.Run("Drop and rollback", func(t *testing.T) {
+		db := testutil.NewTestDB(t)
+
+		updateCatalog(t, db, func(tx *database.Transaction, catalog *database.CatalogWriter) error {
+			return catalog.CreateView(tx, &database.ViewInfo{ViewName: "v", Query: viewQuery("SELECT 1")})
+		})
+
+		updateCatalog(t, db, func(tx *database.Transaction, catalog *database.CatalogWriter) error {
+			err := catalog.DropView(tx, "v")
+			assert.NoError(t, err)
+
+			_, err = catalog.GetViewInfo("v")
+			if !errs.IsNotFoundError(err) {
+				assert.ErrorIs(t, err, errs.NotFoundError{Name: "v"})
+			}
+			return errDontCommit
+		})
+
+		_, err := db.Catalog().GetViewInfo("v")
+		assert.NoError(t, err)
+	})
+}
+
+func TestCatalogCreateTrigger(t *testing.T) {
+	newTrigger := func(name, tableName string) *database.TriggerInfo {
+		return &database.TriggerInfo{
+			TriggerName: name,
+			TableName:   tableName,
+			Timing:      database.TriggerAfter,
+			Event:       database.TriggerInsert,
+			Action:      viewQuery("DELETE FROM log"),
+		}
+	}
+
+	t.Run("Should create a trigger and add it to the catalog table", func(t *testing.T) {
+		db := testutil.NewTestDB(t)
+
+		updateCatalog(t, db, func(tx *database.Transaction, catalog *database.CatalogWriter) error {
+			err := catalog.CreateTable(tx, "test", nil)
+			if err != nil {
+				return err
+			}
+
+			err = catalog.CreateTrigger(tx, newTrigger("tr", "test"))
+			if err != nil {
+				return err
+			}
+
+			info, err := catalog.GetTriggerInfo("tr")
+			assert.NoError(t, err)
+			require.Equal(t, "CREATE TRIGGER tr AFTER INSERT ON test DELETE FROM log", info.String())
+			require.Equal(t, []string{"tr"}, catalog.ListTriggers("test"))
+			require.Empty(t, catalog.ListTriggers("other"))
+
+			tb := db.Catalog().CatalogTable.Table(tx)
+			d, err := tb.GetDocument(tree.NewKey(types.NewTextValue("tr")))
+			assert.NoError(t, err)
+			testutil.RequireDocJSONEq(t, d, `{"name":"tr", "type":"trigger", "sql":"CREATE TRIGGER tr AFTER INSERT ON test DELETE FROM log", "owner": {"table_name": "test"}}`)
+			return nil
+		})
+	})
+
+	t.Run("Should fail if the table doesn't exist", func(t *testing.T) {
+		db := testutil.NewTestDB(t)
+
+		updateCatalog(t, db, func(tx *database.Transaction, catalog *database.CatalogWriter) error {
+			err := catalog.CreateTrigger(tx, newTrigger("tr", "test"))
+			if !errs.IsNotFoundError(err) {
+				assert.ErrorIs(t, err, errs.NotFoundError{Name: "test"})
+			}
+			return nil
+		})
+	})
+
+	t.Run("Should be dropped with its table", func(t *testing.T) {
+		db := testutil.NewTestDB(t)
+
+		updateCatalog(t, db, func(tx *database.Transaction, catalog *database.CatalogWriter) error {
+			err := catalog.CreateTable(tx, "test", nil)
+			if err != nil {
+				return err
+			}
+
+			return catalog.CreateTrigger(tx, newTrigger("tr", "test"))
+		})
+
+		updateCatalog(t, db, func(tx *database.Transaction, catalog *database.CatalogWriter) error {
+			err := catalog.DropTable(tx, "test")
+			assert.NoError(t, err)
+
+			_, err = catalog.GetTriggerInfo("tr")
+			if !errs.IsNotFoundError(err) {
+				assert.ErrorIs(t, err, errs.NotFoundError{Name: "tr"})
+			}
+			return errDontCommit
+		})
+
+		_, err := db.Catalog().GetTriggerInfo("tr")
+		assert.NoError(t, err)
+	})
+}
+
 func TestCatalogConcurrency(t *testing.T) {
 	db, err := genji.Open(":memory:")
 	assert.NoError(t, err)
@@ -19,7 +19,7 @@ func LoadCatalog(tx *database.Transaction) error {
 		return err
 	}
 
-	tables, indexes, sequences, err := loadCatalogStore(tx, tx.Catalog.CatalogTable)
+	tables, indexes, sequences, views, triggers, err := loadCatalogStore(tx, tx.Catalog.CatalogTable)
 	if err != nil {
 		return errors.Wrap(err, "failed to load catalog store")
 	}
@@ -31,8 +31,8 @@ func LoadCatalog(tx *database.Transaction) error {
 	ti.ReadOnly = true
 	tables = append(tables, *ti)
 
-	// load tables and indexes first
-	tx.Catalog.Cache.Load(tables, indexes, nil)
+	// load tables, indexes, views and triggers first
+	tx.Catalog.Cache.Load(tables, indexes, nil, views, triggers)
 
 	if len(sequences) > 0 {
 		var seqList []database.Sequence
@@ -41,7 +41,12 @@ func LoadCatalog(tx *database.Transaction) error {
 			return errors.Wrap(err, "failed to load sequences")
 		}
 
-		tx.Catalog.Cache.Load(nil, nil, seqList)
+		tx.Catalog.Cache.Load(nil, nil, seqList, nil, nil)
+	}
+
+	err = tx.Catalog.LoadTableStats(tx)
+	if err != nil {
+		return errors.Wrap(err, "failed to load table statistics")
 	}
 
 	return nil
@@ -78,7 +83,7 @@ func loadSequences(tx *database.Transaction, info []database.SequenceInfo) ([]da
 	return sequences, nil
 }
 
-func loadCatalogStore(tx *database.Transaction, s *database.CatalogStore) (tables []database.TableInfo, indexes []database.IndexInfo, sequences []database.SequenceInfo, err error) {
+func loadCatalogStore(tx *database.Transaction, s *database.CatalogStore) (tables []database.TableInfo, indexes []database.IndexInfo, sequences []database.SequenceInfo, views []database.ViewInfo, triggers []database.TriggerInfo, err error) {
 	tb := s.Table(tx)
 
 	err = tb.IterateOnRange(nil, false, func(key *tree.Key, d types.Document) error {
@@ -107,6 +112,18 @@ func loadCatalogStore(tx *database.Transaction, s *database.CatalogStore) (table
 				return errors.Wrap(err, "failed to decode sequence info")
 			}
 			sequences = append(sequences, *i)
+		case database.RelationViewType:
+			v, err := viewInfoFromDocument(d)
+			if err != nil {
+				return errors.Wrap(err, "failed to decode view info")
+			}
+			views = append(views, *v)
+		case database.RelationTriggerType:
+			t, err := triggerInfoFromDocument(d)
+			if err != nil {
+				return errors.Wrap(err, "failed to decode trigger info")
+			}
+			triggers = append(triggers, *t)
 		}
 
 		return nil
@@ -217,6 +234,36 @@ func sequenceInfoFromDocument(d types.Document) (*database.SequenceInfo, error)
 	return &i, nil
 }
 
+func viewInfoFromDocument(d types.Document) (*database.ViewInfo, error) {
+	s, err := d.GetByField("sql")
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := parser.NewParser(strings.NewReader(types.As[string](s))).ParseStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	v := stmt.(*statement.CreateViewStmt).Info
+	return &v, nil
+}
+
+func triggerInfoFromDocument(d types.Document) (*database.TriggerInfo, error) {
+	s, err := d.GetByField("sql")
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := parser.NewParser(strings.NewReader(types.As[string](s))).ParseStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	t := stmt.(*statement.CreateTriggerStmt).Info
+	return &t, nil
+}
+
 func ownerFromDocument(d types.Document) (*database.Owner, error) {
 	var owner database.Owner
 
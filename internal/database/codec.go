@@ -0,0 +1,110 @@
+package database
+
+import (
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/types"
+)
+
+// A FieldCodec transforms the value of a field before it is written to disk
+// and after it is read back.
+// It only applies to TEXT and BLOB values, which keep their type:
+// the bytes of the value are passed to Encode and Decode.
+type FieldCodec struct {
+	Encode func([]byte) ([]byte, error)
+	Decode func([]byte) ([]byte, error)
+}
+
+// indexFieldCodecs returns the codecs of each path indexed by info,
+// or nil if none of the indexed fields of the table has a codec.
+func indexFieldCodecs(table *TableInfo, info *IndexInfo) []*FieldCodec {
+	if len(table.FieldCodecs) == 0 {
+		return nil
+	}
+
+	var found bool
+	cs := make([]*FieldCodec, len(info.Paths))
+	for i, p := range info.Paths {
+		if len(p) == 1 && p[0].FieldName != "" {
+			cs[i] = table.FieldCodecs[p[0].FieldName]
+			found = found || cs[i] != nil
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	return cs
+}
+
+// applyCodec transforms the bytes of v with fn.
+// Values that are not TEXT or BLOB are returned as is.
+func applyCodec(v types.Value, fn func([]byte) ([]byte, error)) (types.Value, error) {
+	switch v.Type() {
+	case types.TextValue:
+		b, err := fn([]byte(types.As[string](v)))
+		if err != nil {
+			return nil, err
+		}
+		return types.NewTextValue(string(b)), nil
+	case types.BlobValue:
+		b, err := fn(types.As[[]byte](v))
+		if err != nil {
+			return nil, err
+		}
+		return types.NewBlobValue(b), nil
+	}
+
+	return v, nil
+}
+
+// DecodeFields returns a document that decodes the fields of d
+// using the codecs of the given table.
+// If the table has no codecs, d is returned as is.
+func DecodeFields(info *TableInfo, d types.Document) types.Document {
+	if len(info.FieldCodecs) == 0 {
+		return d
+	}
+
+	return &decodedDocument{Document: d, codecs: info.FieldCodecs}
+}
+
+// decodedDocument decodes the fields of a stored document
+// that have a codec.
+type decodedDocument struct {
+	types.Document
+
+	codecs map[string]*FieldCodec
+}
+
+func (d *decodedDocument) decode(field string, v types.Value) (types.Value, error) {
+	c, ok := d.codecs[field]
+	if !ok {
+		return v, nil
+	}
+
+	return applyCodec(v, c.Decode)
+}
+
+func (d *decodedDocument) GetByField(field string) (types.Value, error) {
+	v, err := d.Document.GetByField(field)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.decode(field, v)
+}
+
+func (d *decodedDocument) Iterate(fn func(field string, value types.Value) error) error {
+	return d.Document.Iterate(func(field string, value types.Value) error {
+		v, err := d.decode(field, value)
+		if err != nil {
+			return err
+		}
+
+		return fn(field, v)
+	})
+}
+
+func (d *decodedDocument) MarshalJSON() ([]byte, error) {
+	return document.MarshalJSON(d)
+}
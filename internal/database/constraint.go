@@ -2,6 +2,7 @@ package database
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/cockroachdb/errors"
@@ -19,17 +20,37 @@ type FieldConstraint struct {
 	IsNotNull     bool
 	DefaultValue  TableExpression
 	AnonymousType *AnonymousType
+	// If greater than 0, the maximum number of
+	// characters of text values.
+	MaxLength int
+	// Name of the type declared with MaxLength,
+	// i.e. VARCHAR or CHARACTER. Defaults to VARCHAR.
+	LengthType string
 }
 
 func (f *FieldConstraint) IsEmpty() bool {
 	return f.Field == "" && f.Type.IsAny() && !f.IsNotNull && f.DefaultValue == nil
 }
 
+// lengthTypeString returns the declared type of a field with a maximum length,
+// e.g. VARCHAR(10).
+func (f *FieldConstraint) lengthTypeString() string {
+	tp := f.LengthType
+	if tp == "" {
+		tp = "VARCHAR"
+	}
+
+	return tp + "(" + strconv.Itoa(f.MaxLength) + ")"
+}
+
 func (f *FieldConstraint) String() string {
 	var s strings.Builder
 
 	s.WriteString(f.Field)
-	if f.Type != types.DocumentValue {
+	if f.MaxLength > 0 {
+		s.WriteString(" ")
+		s.WriteString(f.lengthTypeString())
+	} else if f.Type != types.DocumentValue {
 		s.WriteString(" ")
 		s.WriteString(strings.ToUpper(f.Type.String()))
 	} else if f.AnonymousType != nil {
@@ -194,6 +215,43 @@ func (f FieldConstraints) GetFieldConstraintForPath(path document.Path) *FieldCo
 	return nil
 }
 
+// ExtraFields returns the paths of the fields of d that are not declared
+// in the constraints, including those of nested documents with a declared type.
+// It always returns nil if extra fields are allowed.
+func (f FieldConstraints) ExtraFields(d types.Document) ([]document.Path, error) {
+	return f.extraFields(nil, d)
+}
+
+func (f FieldConstraints) extraFields(parent document.Path, d types.Document) ([]document.Path, error) {
+	if f.AllowExtraFields {
+		return nil, nil
+	}
+
+	var paths []document.Path
+	err := d.Iterate(func(field string, v types.Value) error {
+		path := append(parent[:len(parent):len(parent)], document.PathFragment{FieldName: field})
+
+		fc, ok := f.ByField[field]
+		if !ok {
+			paths = append(paths, path)
+			return nil
+		}
+
+		if fc.AnonymousType == nil || v.Type() != types.DocumentValue {
+			return nil
+		}
+
+		nested, err := fc.AnonymousType.FieldConstraints.extraFields(path, types.As[types.Document](v))
+		if err != nil {
+			return err
+		}
+		paths = append(paths, nested...)
+		return nil
+	})
+
+	return paths, err
+}
+
 func (f FieldConstraints) convertDocumentAtPath(path document.Path, d types.Document, conversionFn ConversionFunc) (*document.FieldBuffer, error) {
 	fb, ok := d.(*document.FieldBuffer)
 	if !ok {
@@ -239,21 +297,29 @@ type TableConstraint struct {
 	Unique     bool
 	PrimaryKey bool
 	SortOrder  tree.SortOrder
+	// AutoIncrement is only set on primary keys parsed from a CREATE TABLE statement.
+	// The table is then created with a sequence owned by the primary key, which is used
+	// as the DEFAULT value of the primary key field.
+	AutoIncrement bool
 }
 
 func (t *TableConstraint) String() string {
 	var sb strings.Builder
 
-	sb.WriteString("CONSTRAINT ")
-	sb.WriteString(stringutil.NormalizeIdentifier(t.Name, '"'))
+	// the name is optional when the constraint is parsed
+	if t.Name != "" {
+		sb.WriteString("CONSTRAINT ")
+		sb.WriteString(stringutil.NormalizeIdentifier(t.Name, '"'))
+		sb.WriteString(" ")
+	}
 
 	switch {
 	case t.Check != nil:
-		sb.WriteString(" CHECK (")
+		sb.WriteString("CHECK (")
 		sb.WriteString(t.Check.String())
 		sb.WriteString(")")
 	case t.PrimaryKey:
-		sb.WriteString(" PRIMARY KEY (")
+		sb.WriteString("PRIMARY KEY (")
 		for i, pt := range t.Paths {
 			if i > 0 {
 				sb.WriteString(", ")
@@ -266,7 +332,7 @@ func (t *TableConstraint) String() string {
 		}
 		sb.WriteString(")")
 	case t.Unique:
-		sb.WriteString(" UNIQUE (")
+		sb.WriteString("UNIQUE (")
 		for i, pt := range t.Paths {
 			if i > 0 {
 				sb.WriteString(", ")
@@ -310,7 +376,11 @@ func (t *TableConstraints) ValidateDocument(tx *Transaction, d types.Document) e
 		}
 
 		if !ok {
-			return fmt.Errorf("document violates check constraint %q", tc.Name)
+			return &ConstraintViolationError{
+				Constraint: "CHECK",
+				Name:       tc.Name,
+				Paths:      tc.Paths,
+			}
 		}
 	}
 
@@ -356,16 +426,28 @@ func (an *AnonymousType) String() string {
 	return sb.String()
 }
 
+// A ConstraintViolationError is returned when a document doesn't satisfy
+// one of the constraints of a table.
 type ConstraintViolationError struct {
+	// Type of the constraint, e.g. "UNIQUE" or "CHECK".
 	Constraint string
-	Paths      []document.Path
-	Key        *tree.Key
+	// Name of the table constraint, if any.
+	Name  string
+	Paths []document.Path
+	// Key of the conflicting document, if any.
+	Key *tree.Key
 }
 
 func (c ConstraintViolationError) Error() string {
+	if c.Constraint == "CHECK" {
+		return fmt.Sprintf("document violates check constraint %q", c.Name)
+	}
+
 	return fmt.Sprintf("%s constraint error: %s", c.Constraint, c.Paths)
 }
 
+// IsConstraintViolationError returns true if err is, or wraps, a *ConstraintViolationError.
 func IsConstraintViolationError(err error) bool {
-	return errors.Is(err, (*ConstraintViolationError)(nil))
+	var cerr *ConstraintViolationError
+	return errors.As(err, &cerr)
 }
@@ -4,6 +4,7 @@ package database
 import (
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble"
@@ -15,6 +16,10 @@ import (
 
 const (
 	InternalPrefix = "__genji_"
+
+	// DefaultSortBufferSize is the default size in bytes
+	// of the documents sorted in memory.
+	DefaultSortBufferSize = 1 << 20 // 1MB
 )
 
 type Database struct {
@@ -45,6 +50,18 @@ type Database struct {
 
 	closeOnce sync.Once
 
+	// Interval between two deletions of the expired documents.
+	ttlInterval time.Duration
+	// Used to start and stop the goroutine deleting expired documents.
+	reaperMu     sync.Mutex
+	reaperStop   chan struct{}
+	reaperDone   chan struct{}
+	reaperClosed bool
+
+	// Size in bytes of the documents sorted in memory
+	// before being written to disk.
+	SortBufferSize int
+
 	// Underlying kv store.
 	Store *kv.Store
 }
@@ -53,6 +70,21 @@ type Database struct {
 // how the database is loaded.
 type Options struct {
 	CatalogLoader func(tx *Transaction) error
+	// Size in bytes above which temporary trees, used for sorting
+	// or deduplicating documents, are spilled to disk.
+	// Defaults to 512KB.
+	MaxTransientBatchSize int
+	// Interval between two deletions of the expired documents
+	// of the tables with a TTL field. Expired documents are only
+	// deleted in the background once such a table exists.
+	// Defaults to one minute. If negative, expired documents
+	// are only deleted by calling DeleteExpired.
+	TTLInterval time.Duration
+	// Size in bytes of the documents sorted in memory by ORDER BY.
+	// Larger results are sorted in runs which are written to disk
+	// and merged when the documents are read.
+	// Defaults to 1MB.
+	SortBufferSize int
 }
 
 // CatalogLoader loads the catalog from the disk.
@@ -70,6 +102,9 @@ type TxOptions struct {
 	// Any queries run by the database will use that transaction until it is
 	// rolled back or commited.
 	Attached bool
+	// Reject documents containing fields that are not declared
+	// in the schema of the table they are written to.
+	StrictFields bool
 }
 
 func Open(path string, opts *Options) (*Database, error) {
@@ -124,6 +159,7 @@ func New(pdb *pebble.DB, opts *Options) (*Database, error) {
 		DB: pdb,
 		Store: kv.NewStore(pdb, kv.Options{
 			RollbackSegmentNamespace: int64(RollbackSegmentNamespace),
+			MaxTransientBatchSize:    opts.MaxTransientBatchSize,
 		}),
 	}
 
@@ -165,6 +201,19 @@ func New(pdb *pebble.DB, opts *Options) (*Database, error) {
 		return nil, err
 	}
 
+	db.ttlInterval = opts.TTLInterval
+	if db.ttlInterval == 0 {
+		db.ttlInterval = DefaultTTLInterval
+	}
+	db.SortBufferSize = opts.SortBufferSize
+	if db.SortBufferSize <= 0 {
+		db.SortBufferSize = DefaultSortBufferSize
+	}
+
+	if db.Catalog().hasTTLTables() {
+		db.startReaper()
+	}
+
 	return &db, nil
 }
 
@@ -185,6 +234,7 @@ func (db *Database) closeDatabase() error {
 	if tx := db.GetAttachedTx(); tx != nil {
 		_ = tx.Rollback()
 	}
+	db.stopReaper()
 	db.writetxmu.Lock()
 	defer db.writetxmu.Unlock()
 
@@ -275,12 +325,13 @@ func (db *Database) beginTx(opts *TxOptions) (*Transaction, error) {
 	}
 
 	tx := Transaction{
-		db:       db,
-		Store:    db.Store,
-		Session:  sess,
-		Writable: !opts.ReadOnly,
-		ID:       atomic.AddUint64(&db.TransactionIDs, 1),
-		Catalog:  db.Catalog(),
+		db:           db,
+		Store:        db.Store,
+		Session:      sess,
+		Writable:     !opts.ReadOnly,
+		StrictFields: opts.StrictFields,
+		ID:           atomic.AddUint64(&db.TransactionIDs, 1),
+		Catalog:      db.Catalog(),
 	}
 
 	if !opts.ReadOnly {
@@ -5,7 +5,12 @@ import (
 	"time"
 
 	"github.com/genjidb/genji"
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/database/catalogstore"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/testutil"
 	"github.com/genjidb/genji/internal/testutil/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // See issue https://github.com/genjidb/genji/issues/298
@@ -67,3 +72,68 @@ func TestConcurrentTransactionManagement(t *testing.T) {
 		t.Fatal("deadlock")
 	}
 }
+
+func TestDeleteExpired(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec(`CREATE TABLE test(a INT PRIMARY KEY, b INT, expires_at TIMESTAMP) WITH (ttl_field = expires_at)`)
+	assert.NoError(t, err)
+	err = db.Exec(`CREATE INDEX test_b_idx ON test(b)`)
+	assert.NoError(t, err)
+
+	// more expired documents than can be deleted by a single transaction,
+	// mixed with documents that are not expired yet
+	for i := 0; i < 250; i++ {
+		expiresAt := time.Now().Add(-time.Hour)
+		if i%5 == 0 {
+			expiresAt = time.Now().Add(time.Hour)
+		}
+		err = db.Exec(`INSERT INTO test (a, b, expires_at) VALUES (?, ?, ?)`, i, i, expiresAt)
+		assert.NoError(t, err)
+	}
+	err = db.Exec(`INSERT INTO test (a, b, expires_at) VALUES (1000, 1000, ?), (1001, 1001, NULL)`, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	err = db.DB.DeleteExpired()
+	assert.NoError(t, err)
+
+	tx, err := db.DB.Begin(false)
+	assert.NoError(t, err)
+	defer tx.Rollback()
+
+	tb, err := tx.Catalog.GetTable(tx, "test")
+	assert.NoError(t, err)
+
+	stats, err := tb.Stats()
+	assert.NoError(t, err)
+	require.Equal(t, int64(52), stats.DocumentCount)
+	require.Equal(t, int64(52), stats.Indexes["test_b_idx"].EntryCount)
+}
+
+func TestDeleteExpiredInBackground(t *testing.T) {
+	db, err := database.New(testutil.NewMemPebble(t), &database.Options{
+		CatalogLoader: catalogstore.LoadCatalog,
+		TTLInterval:   10 * time.Millisecond,
+	})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// the expired documents are deleted once a table with a TTL field is created
+	testutil.MustExec(t, db, nil, `CREATE TABLE test(a INT PRIMARY KEY, expires_at TIMESTAMP) WITH (ttl_field = expires_at)`)
+	testutil.MustExec(t, db, nil, `INSERT INTO test (a, expires_at) VALUES (1, ?)`, environment.Param{Value: time.Now().Add(-time.Hour)})
+
+	require.Eventually(t, func() bool {
+		tx, err := db.Begin(false)
+		assert.NoError(t, err)
+		defer tx.Rollback()
+
+		tb, err := tx.Catalog.GetTable(tx, "test")
+		assert.NoError(t, err)
+
+		stats, err := tb.Stats()
+		assert.NoError(t, err)
+		return stats.DocumentCount == 0
+	}, time.Second, 10*time.Millisecond)
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"encoding/binary"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/document"
@@ -13,14 +14,18 @@ import (
 // EncodeDocument validates a document against all the constraints of the table
 // and encodes it.
 func (t *TableInfo) EncodeDocument(tx *Transaction, dst []byte, d types.Document) ([]byte, error) {
-	if ed, ok := d.(*encoding.EncodedDocument); ok {
-		return ed.Encoded, nil
-	}
+	return encodeDocument(tx, dst, &t.FieldConstraints, nil, d)
+}
 
-	return encodeDocument(tx, dst, &t.FieldConstraints, d)
+// encodeStoredDocument behaves like EncodeDocument but also applies the codecs
+// of the table to the values, once they have been validated.
+func (t *TableInfo) encodeStoredDocument(tx *Transaction, dst []byte, d types.Document) ([]byte, error) {
+	return encodeDocument(tx, dst, &t.FieldConstraints, t.FieldCodecs, d)
 }
 
-func encodeDocument(tx *Transaction, dst []byte, fcs *FieldConstraints, d types.Document) ([]byte, error) {
+// encodeDocument validates and encodes d. Codecs, if any, are applied to the
+// top-level fields after validation, constraints only apply to the values of d.
+func encodeDocument(tx *Transaction, dst []byte, fcs *FieldConstraints, codecs map[string]*FieldCodec, d types.Document) ([]byte, error) {
 	var err error
 
 	// loop over all the defined field contraints in order.
@@ -67,6 +72,18 @@ func encodeDocument(tx *Transaction, dst []byte, fcs *FieldConstraints, d types.
 			}
 		}
 
+		// ensure text values don't exceed the maximum length
+		if fc.MaxLength > 0 && v.Type() == types.TextValue && utf8.RuneCountInString(types.As[string](v)) > fc.MaxLength {
+			return nil, &ConstraintViolationError{Constraint: fc.lengthTypeString(), Paths: []document.Path{document.NewPath(fc.Field)}}
+		}
+
+		if c, ok := codecs[fc.Field]; ok {
+			v, err = applyCodec(v, c.Encode)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		// Encode the value only.
 		if v.Type() == types.DocumentValue {
 			// encode map length
@@ -75,7 +92,7 @@ func encodeDocument(tx *Transaction, dst []byte, fcs *FieldConstraints, d types.
 				mlen += 1
 			}
 			dst = encoding.EncodeArrayLength(dst, mlen)
-			dst, err = encodeDocument(tx, dst, &fc.AnonymousType.FieldConstraints, types.As[types.Document](v))
+			dst, err = encodeDocument(tx, dst, &fc.AnonymousType.FieldConstraints, nil, types.As[types.Document](v))
 		} else {
 			dst, err = encoding.EncodeValue(dst, v, false)
 		}
@@ -86,7 +103,7 @@ func encodeDocument(tx *Transaction, dst []byte, fcs *FieldConstraints, d types.
 
 	// encode the extra fields, if any.
 	if fcs.AllowExtraFields {
-		dst, err = encodeExtraFields(dst, fcs, d)
+		dst, err = encodeExtraFields(dst, fcs, codecs, d)
 		if err != nil {
 			return nil, err
 		}
@@ -95,7 +112,7 @@ func encodeDocument(tx *Transaction, dst []byte, fcs *FieldConstraints, d types.
 	return dst, nil
 }
 
-func encodeExtraFields(dst []byte, fcs *FieldConstraints, d types.Document) ([]byte, error) {
+func encodeExtraFields(dst []byte, fcs *FieldConstraints, codecs map[string]*FieldCodec, d types.Document) ([]byte, error) {
 	// count the number of extra fields
 	extraFields := 0
 	err := d.Iterate(func(field string, value types.Value) error {
@@ -143,6 +160,13 @@ func encodeExtraFields(dst []byte, fcs *FieldConstraints, d types.Document) ([]b
 			}
 		}
 
+		if c, ok := codecs[field]; ok {
+			value, err = applyCodec(value, c.Encode)
+			if err != nil {
+				return err
+			}
+		}
+
 		dst, err = encoding.EncodeValue(dst, value, false)
 		return err
 	})
@@ -250,6 +274,9 @@ func (e *EncodedDocument) GetByField(field string) (v types.Value, err error) {
 
 // Iterate decodes each fields one by one and passes them to fn
 // until the end of the document or until fn returns an error.
+// Declared fields are always passed in the order of the table schema,
+// regardless of the order used when the document was inserted,
+// followed by extra fields in insertion order.
 func (e *EncodedDocument) Iterate(fn func(field string, value types.Value) error) error {
 	b := e.encoded
 
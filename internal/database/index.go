@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/encoding"
 	"github.com/genjidb/genji/internal/kv"
 	"github.com/genjidb/genji/internal/tree"
 	"github.com/genjidb/genji/types"
@@ -25,6 +27,10 @@ type Index struct {
 	// For example, an index created with `CREATE INDEX idx_a_b ON foo (a, b)` has an arity of 2.
 	Arity int
 	Tree  *tree.Tree
+
+	// codecs of the indexed fields, by position.
+	// They are set by the catalog from the info of the table.
+	codecs []*FieldCodec
 }
 
 // NewIndex creates an index that associates values with a list of keys.
@@ -35,6 +41,62 @@ func NewIndex(tr *tree.Tree, opts IndexInfo) *Index {
 	}
 }
 
+// encodeValues applies the codecs of the indexed fields to vs.
+// Fields with a codec are indexed using their encoded value,
+// which means only equality lookups are meaningful on them.
+func (idx *Index) encodeValues(vs []types.Value) ([]types.Value, error) {
+	if idx.codecs == nil {
+		return vs, nil
+	}
+
+	encoded := make([]types.Value, len(vs))
+	for i, v := range vs {
+		encoded[i] = v
+		if i >= len(idx.codecs) || idx.codecs[i] == nil {
+			continue
+		}
+
+		var err error
+		encoded[i], err = applyCodec(v, idx.codecs[i].Encode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return encoded, nil
+}
+
+// ToTreeRange converts rng to a tree range,
+// applying the codecs of the indexed fields to its boundaries.
+func (idx *Index) ToTreeRange(rng *Range, constraints *FieldConstraints, paths []document.Path) (*tree.Range, error) {
+	r, err := rng.ToTreeRange(constraints, paths)
+	if err != nil || idx.codecs == nil {
+		return r, err
+	}
+
+	if len(rng.Min) > 0 {
+		vs, err := idx.encodeValues(rng.Min)
+		if err != nil {
+			return nil, err
+		}
+		r.Min = tree.NewKey(vs...)
+	}
+
+	if len(rng.Max) > 0 {
+		vs, err := idx.encodeValues(rng.Max)
+		if err != nil {
+			return nil, err
+		}
+		r.Max = tree.NewKey(vs...)
+	}
+
+	if rng.Exact {
+		r.Max = r.Min
+	}
+
+	return r, nil
+}
+
 var errStop = errors.New("stop")
 
 // Set associates values with a key. If Unique is set to false, it is
@@ -58,6 +120,11 @@ func (idx *Index) Set(vs []types.Value, key []byte) error {
 		return fmt.Errorf("cannot index %d values on an index of arity %d", len(vs), idx.Arity)
 	}
 
+	vs, err := idx.encodeValues(vs)
+	if err != nil {
+		return err
+	}
+
 	// append the key to the values
 	values := append(vs, types.NewBlobValue(key))
 
@@ -73,12 +140,17 @@ func (idx *Index) Exists(vs []types.Value) (bool, *tree.Key, error) {
 		return false, nil, fmt.Errorf("required arity of %d", idx.Arity)
 	}
 
+	vs, err := idx.encodeValues(vs)
+	if err != nil {
+		return false, nil, err
+	}
+
 	seek := tree.NewKey(vs...)
 
 	var found bool
 	var dKey *tree.Key
 
-	err := idx.Tree.IterateOnRange(&tree.Range{Min: seek, Max: seek}, false, func(k *tree.Key, _ []byte) error {
+	err = idx.Tree.IterateOnRange(&tree.Range{Min: seek, Max: seek}, false, func(k *tree.Key, _ []byte) error {
 		values, err := k.Decode()
 		if err != nil {
 			return err
@@ -96,13 +168,18 @@ func (idx *Index) Exists(vs []types.Value) (bool, *tree.Key, error) {
 
 // Delete all the references to the key from the index.
 func (idx *Index) Delete(vs []types.Value, key []byte) error {
+	vs, err := idx.encodeValues(vs)
+	if err != nil {
+		return err
+	}
+
 	vk := tree.NewKey(vs...)
 	rng := tree.Range{
 		Min: vk,
 		Max: vk,
 	}
 
-	err := idx.iterateOnRange(&rng, false, func(itmKey *tree.Key, pk *tree.Key) error {
+	err = idx.iterateOnRange(&rng, false, func(itmKey *tree.Key, pk *tree.Key) error {
 		if bytes.Equal(pk.Encoded, key) {
 			err := idx.Tree.Delete(itmKey)
 			if err == nil {
@@ -130,6 +207,21 @@ func (idx *Index) IterateOnRange(rng *tree.Range, reverse bool, fn func(key *tre
 	})
 }
 
+// IterateValuesOnRange works like IterateOnRange but also passes
+// the indexed values of each entry to fn.
+func (idx *Index) IterateValuesOnRange(rng *tree.Range, reverse bool, fn func(values []types.Value, key *tree.Key) error) error {
+	return idx.Tree.IterateOnRange(rng, reverse, func(k *tree.Key, _ []byte) error {
+		values, err := k.Decode()
+		if err != nil {
+			return err
+		}
+
+		pk := tree.NewEncodedKey(types.As[[]byte](values[len(values)-1]))
+
+		return fn(values[:len(values)-1], pk)
+	})
+}
+
 func (idx *Index) iterateOnRange(rng *tree.Range, reverse bool, fn func(itmKey *tree.Key, key *tree.Key) error) error {
 	return idx.Tree.IterateOnRange(rng, reverse, idx.iterator(fn))
 }
@@ -153,3 +245,43 @@ func (idx *Index) iterator(fn func(itmKey *tree.Key, key *tree.Key) error) func(
 func (idx *Index) Truncate() error {
 	return idx.Tree.Truncate()
 }
+
+// IndexStats holds statistics about the content of an index.
+type IndexStats struct {
+	// Number of entries of the index.
+	EntryCount int64
+	// Number of distinct indexed values.
+	DistinctCount int64
+}
+
+// Stats scans the whole index and returns statistics about its content.
+func (idx *Index) Stats() (*IndexStats, error) {
+	var stats IndexStats
+	var prev []byte
+
+	err := idx.Tree.IterateOnRange(nil, false, func(k *tree.Key, _ []byte) error {
+		stats.EntryCount++
+
+		// skip the namespace and the indexed values,
+		// ignoring the primary key
+		b := k.Encoded
+		n := encoding.Skip(b)
+		for i := 0; i < idx.Arity; i++ {
+			n += encoding.Skip(b[n:])
+		}
+
+		// entries are sorted, equal values are adjacent
+		if prev != nil && bytes.Equal(prev, b[:n]) {
+			return nil
+		}
+
+		stats.DistinctCount++
+		prev = append(prev[:0], b[:n]...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
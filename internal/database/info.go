@@ -5,6 +5,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/document"
@@ -26,6 +27,16 @@ type TableInfo struct {
 
 	FieldConstraints FieldConstraints
 	TableConstraints TableConstraints
+
+	// Name of the TIMESTAMP field holding the expiration time
+	// of each document, if any. Expired documents are skipped by reads
+	// and deleted in the background by the database.
+	TTLField string
+
+	// Codecs of the top-level fields of the table, by field name.
+	// They are registered at runtime and never stored in the catalog.
+	// The map is never modified once assigned, it is replaced instead.
+	FieldCodecs map[string]*FieldCodec
 }
 
 func (ti *TableInfo) AddFieldConstraint(newFc *FieldConstraint) error {
@@ -198,9 +209,36 @@ func (ti *TableInfo) String() string {
 		s.WriteString(")")
 	}
 
+	if ti.TTLField != "" {
+		fmt.Fprintf(&s, " WITH (ttl_field = %s)", stringutil.NormalizeIdentifier(ti.TTLField, '`'))
+	}
+
 	return s.String()
 }
 
+// IsExpired returns whether the document has expired at the given time,
+// according to the TTL field of the table.
+// Documents without a TIMESTAMP value in that field never expire.
+func (ti *TableInfo) IsExpired(d types.Document, now time.Time) (bool, error) {
+	if ti.TTLField == "" {
+		return false, nil
+	}
+
+	v, err := d.GetByField(ti.TTLField)
+	if errors.Is(err, types.ErrFieldNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if v.Type() != types.TimestampValue {
+		return false, nil
+	}
+
+	return !types.As[time.Time](v).After(now), nil
+}
+
 // Clone creates another tableInfo with the same values.
 func (ti *TableInfo) Clone() *TableInfo {
 	cp := *ti
@@ -228,6 +266,12 @@ type IndexInfo struct {
 	IndexName      string
 	Paths          []document.Path
 
+	// Expressions computing the values of the indexed columns
+	// that are not paths, e.g. CREATE INDEX ON foo (LOWER(a)).
+	// If set, it has one entry per indexed column: nil for columns
+	// indexing a path, and the paths of expression columns are empty.
+	Exprs []TableExpression
+
 	// Sort order of each indexed field.
 	KeySortOrder tree.SortOrder
 
@@ -249,15 +293,26 @@ func (idx *IndexInfo) String() string {
 		s.WriteString("UNIQUE ")
 	}
 
-	fmt.Fprintf(&s, "INDEX %s ON %s (", stringutil.NormalizeIdentifier(idx.IndexName, '`'), stringutil.NormalizeIdentifier(idx.Owner.TableName, '`'))
+	s.WriteString("INDEX ")
+	// the name is optional when the index is parsed
+	if idx.IndexName != "" {
+		s.WriteString(stringutil.NormalizeIdentifier(idx.IndexName, '`'))
+		s.WriteString(" ")
+	}
+	fmt.Fprintf(&s, "ON %s (", stringutil.NormalizeIdentifier(idx.Owner.TableName, '`'))
 
 	for i, p := range idx.Paths {
 		if i > 0 {
 			s.WriteString(", ")
 		}
 
-		// Path
-		s.WriteString(p.String())
+		if e := idx.ColumnExpr(i); e != nil {
+			// Expression
+			s.WriteString(e.String())
+		} else {
+			// Path
+			s.WriteString(p.String())
+		}
 
 		if idx.KeySortOrder.IsDesc(i) {
 			s.WriteString(" DESC")
@@ -269,6 +324,55 @@ func (idx *IndexInfo) String() string {
 	return s.String()
 }
 
+// ColumnExpr returns the expression computing the value of the i-th
+// indexed column, or nil if the column indexes a path.
+func (idx *IndexInfo) ColumnExpr(i int) TableExpression {
+	if i >= len(idx.Exprs) {
+		return nil
+	}
+
+	return idx.Exprs[i]
+}
+
+// Values returns the values of d for each indexed column.
+// Missing paths are indexed as NULL.
+func (idx *IndexInfo) Values(tx *Transaction, d types.Document) ([]types.Value, error) {
+	vs := make([]types.Value, 0, len(idx.Paths))
+
+	for i, path := range idx.Paths {
+		e := idx.ColumnExpr(i)
+		if e == nil {
+			v, err := path.GetValueFromDocument(d)
+			if err != nil {
+				v = types.NewNullValue()
+			}
+			vs = append(vs, v)
+			continue
+		}
+
+		v, err := e.Eval(tx, d)
+		if errors.Is(err, types.ErrFieldNotFound) {
+			v, err = types.NewNullValue(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// the result of an expression has no type constraint,
+		// it is converted like the boundaries of the ranges
+		// used to read the index
+		var noConstraints FieldConstraints
+		v, err = noConstraints.ConvertValueAtPath(nil, v, CastConversion)
+		if err != nil {
+			return nil, err
+		}
+
+		vs = append(vs, v)
+	}
+
+	return vs, nil
+}
+
 // Clone returns a copy of the index information.
 func (i IndexInfo) Clone() *IndexInfo {
 	c := i
@@ -278,6 +382,10 @@ func (i IndexInfo) Clone() *IndexInfo {
 		c.Paths[i] = p.Clone()
 	}
 
+	if i.Exprs != nil {
+		c.Exprs = append([]TableExpression(nil), i.Exprs...)
+	}
+
 	return &c
 }
 
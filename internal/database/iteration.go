@@ -3,6 +3,7 @@ package database
 import (
 	"math"
 
+	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/tree"
 	"github.com/genjidb/genji/types"
@@ -16,12 +17,20 @@ type Range struct {
 	Exact     bool
 }
 
+// ToTreeRange converts the range to a tree range, converting the values of its boundaries
+// to the types of the given paths.
+// The boundaries cannot have more values than there are paths. Ranges on the keys of tables
+// without primary key have no paths, their boundaries are used as is.
 func (r *Range) ToTreeRange(constraints *FieldConstraints, paths []document.Path) (*tree.Range, error) {
 	var rng tree.Range
 	var err error
 
+	if len(paths) > 0 && (len(r.Min) > len(paths) || len(r.Max) > len(paths)) {
+		return nil, errors.Errorf("range has more values than the %d indexed paths", len(paths))
+	}
+
 	if len(r.Min) > 0 {
-		for i := range r.Min {
+		for i := 0; i < len(r.Min) && i < len(paths); i++ {
 			r.Min[i], err = r.Convert(constraints, r.Min[i], paths[i], true)
 			if err != nil {
 				return nil, err
@@ -32,7 +41,7 @@ func (r *Range) ToTreeRange(constraints *FieldConstraints, paths []document.Path
 	}
 
 	if len(r.Max) > 0 {
-		for i := range r.Max {
+		for i := 0; i < len(r.Max) && i < len(paths); i++ {
 			r.Max[i], err = r.Convert(constraints, r.Max[i], paths[i], false)
 			if err != nil {
 				return nil, err
@@ -81,27 +90,16 @@ func (r *Range) Convert(constraints *FieldConstraints, v types.Value, p document
 
 			// we want to convert a non rounded double to int in a way that preserves
 			// comparison logic with the index. ex:
-			// a > 1.1  -> a >= 2; exclusive -> false
-			// a >= 1.1 -> a >= 2; exclusive -> false
-			// a < 1.1  -> a < 2;  exclusive -> true
-			// a <= 1.1 -> a < 2;  exclusive -> true
-			// a BETWEEN 1.1 AND 2.2 -> a >= 2 AND a <= 3; exclusive -> false
-
-			// First, we need to ceil the number. Ex: 1.1 -> 2
-			v = types.NewIntegerValue(int64(math.Ceil(f)))
-
-			// Next, we need to convert the boundaries
-			if isMin {
-				// (a > 1.1) or (a >= 1.1) must be transformed to (a >= 2)
-				r.Exclusive = false
-			} else {
-				// (a < 1.1) or (a <= 1.1) must be transformed to (a < 2)
-				// But there is an exception: if we are dealing with both min
-				// and max boundaries, we are operating a BETWEEN operation,
-				// meaning that we need to convert a BETWEEN 1.1 AND 2.2 to a >= 2 AND a <= 3,
-				// and thus have to set exclusive to false.
-				r.Exclusive = r.Min == nil || len(r.Min) == 0
+			// a > 1.1  -> a > 1
+			// a >= 1.1 -> a >= 2
+			// a < 1.1  -> a < 2
+			// a <= 1.1 -> a <= 1
+			// a BETWEEN 1.1 AND 2.2 -> a >= 2 AND a <= 2
+			if isMin == r.Exclusive {
+				return types.NewIntegerValue(int64(math.Floor(f))), nil
 			}
+
+			return types.NewIntegerValue(int64(math.Ceil(f))), nil
 		}
 
 		if v.Type() == types.TimestampValue && targetType == types.TextValue {
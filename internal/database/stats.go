@@ -0,0 +1,527 @@
+package database
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	errs "github.com/genjidb/genji/internal/errors"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+)
+
+var statsTableInfo = &TableInfo{
+	TableName:      StatsTableName,
+	StoreNamespace: StatsTableNamespace,
+	FieldConstraints: MustNewFieldConstraints(
+		&FieldConstraint{
+			Position:  0,
+			Field:     "table_name",
+			Type:      types.TextValue,
+			IsNotNull: true,
+		},
+		&FieldConstraint{
+			Position:  1,
+			Field:     "document_count",
+			Type:      types.IntegerValue,
+			IsNotNull: true,
+		},
+		&FieldConstraint{
+			Position: 2,
+			Field:    "indexes",
+			Type:     types.ArrayValue,
+		},
+		&FieldConstraint{
+			Position: 3,
+			Field:    "fields",
+			Type:     types.ArrayValue,
+		},
+	),
+	TableConstraints: []*TableConstraint{
+		{
+			Name: StatsTableName + "_pk",
+			Paths: []document.Path{
+				document.NewPath("table_name"),
+			},
+			PrimaryKey: true,
+		},
+	},
+}
+
+// GetTableStats returns the statistics collected by the last ANALYZE
+// of the given table, or nil if the table was never analyzed.
+func (c *Catalog) GetTableStats(tableName string) *TableStats {
+	return c.Cache.stats[tableName]
+}
+
+// LoadTableStats loads the content of the __genji_stats table
+// into the catalog.
+func (c *Catalog) LoadTableStats(tx *Transaction) error {
+	tb, err := c.GetTable(tx, StatsTableName)
+	if err != nil {
+		if errs.IsNotFoundError(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	return tb.IterateOnRange(nil, false, func(_ *tree.Key, d types.Document) error {
+		name, stats, err := tableStatsFromDocument(d)
+		if err != nil {
+			return err
+		}
+
+		c.Cache.stats[name] = stats
+		return nil
+	})
+}
+
+// SetTableStats stores the statistics of the given table
+// in the __genji_stats table, replacing existing ones.
+func (c *CatalogWriter) SetTableStats(tx *Transaction, tableName string, stats *TableStats) error {
+	tb, err := c.getOrCreateStatsTable(tx)
+	if err != nil {
+		return err
+	}
+
+	key := tree.NewKey(types.NewTextValue(tableName))
+	err = tb.Delete(key)
+	if err != nil && !errs.IsNotFoundError(err) {
+		return err
+	}
+
+	_, _, err = tb.Insert(tableStatsToDocument(tableName, stats))
+	if err != nil {
+		return err
+	}
+
+	c.setCachedStats(tx, tableName, stats)
+	return nil
+}
+
+// DeleteTableStats removes the statistics of the given table, if any.
+func (c *CatalogWriter) DeleteTableStats(tx *Transaction, tableName string) error {
+	if c.Cache.stats[tableName] == nil {
+		return nil
+	}
+
+	tb, err := c.GetTable(tx, StatsTableName)
+	if err != nil {
+		return err
+	}
+
+	err = tb.Delete(tree.NewKey(types.NewTextValue(tableName)))
+	if err != nil && !errs.IsNotFoundError(err) {
+		return err
+	}
+
+	c.setCachedStats(tx, tableName, nil)
+	return nil
+}
+
+func (c *CatalogWriter) setCachedStats(tx *Transaction, tableName string, stats *TableStats) {
+	old, ok := c.Cache.stats[tableName]
+	if stats == nil {
+		delete(c.Cache.stats, tableName)
+	} else {
+		c.Cache.stats[tableName] = stats
+	}
+
+	tx.OnRollbackHooks = append(tx.OnRollbackHooks, func() {
+		if ok {
+			c.Cache.stats[tableName] = old
+		} else {
+			delete(c.Cache.stats, tableName)
+		}
+	})
+}
+
+func (c *CatalogWriter) getOrCreateStatsTable(tx *Transaction) (*Table, error) {
+	tb, err := c.GetTable(tx, StatsTableName)
+	if err == nil || !errs.IsNotFoundError(err) {
+		return tb, err
+	}
+
+	err = c.CreateTable(tx, StatsTableName, statsTableInfo.Clone())
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetTable(tx, StatsTableName)
+}
+
+func tableStatsToDocument(tableName string, stats *TableStats) types.Document {
+	names := make([]string, 0, len(stats.Indexes))
+	for name := range stats.Indexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	indexes := document.NewValueBuffer()
+	for _, name := range names {
+		is := stats.Indexes[name]
+		indexes.Append(types.NewDocumentValue(document.NewFieldBuffer().
+			Add("name", types.NewTextValue(name)).
+			Add("entry_count", types.NewIntegerValue(is.EntryCount)).
+			Add("distinct_count", types.NewIntegerValue(is.DistinctCount)),
+		))
+	}
+
+	names = names[:0]
+	for name := range stats.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := document.NewValueBuffer()
+	for _, name := range names {
+		fs := stats.Fields[name]
+
+		histogram := document.NewValueBuffer()
+		for _, b := range fs.Histogram {
+			histogram.Append(types.NewDocumentValue(document.NewFieldBuffer().
+				Add("max", b.Max).
+				Add("count", types.NewIntegerValue(b.Count)).
+				Add("distinct_count", types.NewIntegerValue(b.DistinctCount)),
+			))
+		}
+
+		min := fs.Min
+		if min == nil {
+			min = types.NewNullValue()
+		}
+
+		fields.Append(types.NewDocumentValue(document.NewFieldBuffer().
+			Add("name", types.NewTextValue(name)).
+			Add("count", types.NewIntegerValue(fs.Count)).
+			Add("distinct_count", types.NewIntegerValue(fs.DistinctCount)).
+			Add("min", min).
+			Add("histogram", types.NewArrayValue(histogram)),
+		))
+	}
+
+	return document.NewFieldBuffer().
+		Add("table_name", types.NewTextValue(tableName)).
+		Add("document_count", types.NewIntegerValue(stats.DocumentCount)).
+		Add("indexes", types.NewArrayValue(indexes)).
+		Add("fields", types.NewArrayValue(fields))
+}
+
+func tableStatsFromDocument(d types.Document) (string, *TableStats, error) {
+	v, err := d.GetByField("table_name")
+	if err != nil {
+		return "", nil, err
+	}
+	name := types.As[string](v)
+
+	v, err = d.GetByField("document_count")
+	if err != nil {
+		return "", nil, err
+	}
+
+	stats := TableStats{
+		DocumentCount: types.As[int64](v),
+		Indexes:       make(map[string]*IndexStats),
+		Fields:        make(map[string]*FieldStats),
+	}
+
+	// integers nested in arrays and documents are decoded as doubles
+	err = iterateStatsArray(d, "indexes", func(d types.Document) error {
+		var is IndexStats
+		var idxName string
+		err := d.Iterate(func(field string, v types.Value) (err error) {
+			switch field {
+			case "name":
+				idxName = types.As[string](v)
+			case "entry_count":
+				is.EntryCount, err = statsInteger(v)
+			case "distinct_count":
+				is.DistinctCount, err = statsInteger(v)
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		stats.Indexes[idxName] = &is
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	err = iterateStatsArray(d, "fields", func(d types.Document) error {
+		var fs FieldStats
+		var fieldName string
+		err := d.Iterate(func(field string, v types.Value) (err error) {
+			switch field {
+			case "name":
+				fieldName = types.As[string](v)
+			case "count":
+				fs.Count, err = statsInteger(v)
+			case "distinct_count":
+				fs.DistinctCount, err = statsInteger(v)
+			case "min":
+				if v.Type() != types.NullValue {
+					fs.Min, err = document.CloneValue(v)
+				}
+			case "histogram":
+				err = iterateStatsArray(d, "histogram", func(d types.Document) error {
+					var b HistogramBucket
+					err := d.Iterate(func(field string, v types.Value) (err error) {
+						switch field {
+						case "max":
+							b.Max, err = document.CloneValue(v)
+						case "count":
+							b.Count, err = statsInteger(v)
+						case "distinct_count":
+							b.DistinctCount, err = statsInteger(v)
+						}
+						return err
+					})
+					if err != nil {
+						return err
+					}
+
+					fs.Histogram = append(fs.Histogram, b)
+					return nil
+				})
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		stats.Fields[fieldName] = &fs
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return name, &stats, nil
+}
+
+// iterateStatsArray calls fn for every document of the given array field, if any.
+func iterateStatsArray(d types.Document, field string, fn func(d types.Document) error) error {
+	v, err := d.GetByField(field)
+	if err != nil {
+		if errors.Is(err, types.ErrFieldNotFound) {
+			return nil
+		}
+		return err
+	}
+	if v.Type() != types.ArrayValue {
+		return nil
+	}
+
+	return types.As[types.Array](v).Iterate(func(_ int, v types.Value) error {
+		if v.Type() != types.DocumentValue {
+			return nil
+		}
+
+		return fn(types.As[types.Document](v))
+	})
+}
+
+func statsInteger(v types.Value) (int64, error) {
+	v, err := document.CastAsInteger(v)
+	if err != nil {
+		return 0, err
+	}
+
+	return types.As[int64](v), nil
+}
+
+// histogramSize is the maximum number of buckets
+// of the histogram of a field.
+const histogramSize = 16
+
+// FieldStats holds statistics about the non-NULL scalar
+// values of a top-level field.
+type FieldStats struct {
+	// Number of documents where the field is set to a scalar value.
+	Count int64
+	// Number of distinct values.
+	DistinctCount int64
+	// Smallest value of the field.
+	Min types.Value
+	// Equi-depth histogram of the values: buckets contain roughly
+	// the same number of values and are sorted by their upper bound.
+	// Equal values always belong to the same bucket.
+	Histogram []HistogramBucket
+}
+
+// HistogramBucket holds the number of values lesser than or equal to Max
+// and greater than the upper bound of the previous bucket.
+type HistogramBucket struct {
+	Max           types.Value
+	Count         int64
+	DistinctCount int64
+}
+
+// EqualFraction estimates the fraction of the values of the field
+// that are equal to a given value.
+func (fs *FieldStats) EqualFraction() float64 {
+	if fs.DistinctCount == 0 {
+		return 0
+	}
+
+	return 1 / float64(fs.DistinctCount)
+}
+
+// RangeFraction estimates the fraction of the values of the field
+// between min and max. A nil boundary is unbounded.
+// Values are assumed to be uniformly distributed within a bucket.
+func (fs *FieldStats) RangeFraction(min, max types.Value, exclusive bool) float64 {
+	if fs.Count == 0 {
+		return 0
+	}
+
+	from, to := 0.0, 1.0
+	if min != nil {
+		from = fs.fractionBelow(min, exclusive)
+	}
+	if max != nil {
+		to = fs.fractionBelow(max, !exclusive)
+	}
+
+	return math.Max(to-from, 0)
+}
+
+// fractionBelow estimates the fraction of the values lesser than v,
+// or lesser than or equal to v if inclusive is true.
+func (fs *FieldStats) fractionBelow(v types.Value, inclusive bool) float64 {
+	var n float64
+	lower := fs.Min
+
+	for _, b := range fs.Histogram {
+		lt, err := types.IsLesserThan(b.Max, v)
+		if err != nil {
+			return 0.5
+		}
+		eq, err := types.IsEqual(b.Max, v)
+		if err != nil {
+			return 0.5
+		}
+
+		if lt || (eq && inclusive) {
+			n += float64(b.Count)
+			lower = b.Max
+			continue
+		}
+
+		if eq {
+			// exclude the values equal to the upper bound
+			n += float64(b.Count) * (1 - 1/float64(b.DistinctCount))
+		} else {
+			n += float64(b.Count) * interpolate(lower, b.Max, v)
+		}
+		break
+	}
+
+	return n / float64(fs.Count)
+}
+
+// interpolate returns the position of v between lower and upper,
+// from 0 to 1. Non-numeric values are assumed to be in the middle.
+func interpolate(lower, upper, v types.Value) float64 {
+	if ok, err := types.IsLesserThanOrEqual(v, lower); err == nil && ok {
+		return 0
+	}
+
+	l, lok := numericValue(lower)
+	u, uok := numericValue(upper)
+	x, xok := numericValue(v)
+	if !lok || !uok || !xok || u <= l {
+		return 0.5
+	}
+
+	return math.Min((x-l)/(u-l), 1)
+}
+
+func numericValue(v types.Value) (float64, bool) {
+	switch v.Type() {
+	case types.IntegerValue:
+		return float64(types.As[int64](v)), true
+	case types.DoubleValue:
+		return types.As[float64](v), true
+	case types.TimestampValue:
+		return float64(types.As[time.Time](v).UnixMicro()), true
+	}
+
+	return 0, false
+}
+
+// histogramBuilder computes the distinct count and the histogram
+// of a field from its sorted values.
+type histogramBuilder struct {
+	field      string
+	stats      *FieldStats
+	bucketSize int64
+	prev       types.Value
+	count      int64
+	distinct   int64
+}
+
+func (b *histogramBuilder) reset(field string, fs *FieldStats) {
+	b.flush()
+
+	b.field = strings.Clone(field)
+	b.stats = fs
+	b.bucketSize = (fs.Count + histogramSize - 1) / histogramSize
+	b.prev = nil
+	b.count = 0
+	b.distinct = 0
+}
+
+func (b *histogramBuilder) add(v types.Value) error {
+	if b.prev != nil {
+		ok, err := types.IsEqual(b.prev, v)
+		if err != nil {
+			return err
+		}
+		if ok {
+			b.count++
+			return nil
+		}
+
+		// only close a bucket between distinct values
+		if b.count >= b.bucketSize {
+			b.flush()
+		}
+	}
+
+	// values are decoded from the keys of the tree
+	// and must outlive the iteration
+	v, err := document.CloneValue(v)
+	if err != nil {
+		return err
+	}
+	if b.prev == nil {
+		b.stats.Min = v
+	}
+
+	b.stats.DistinctCount++
+	b.prev = v
+	b.count++
+	b.distinct++
+	return nil
+}
+
+// flush closes the current bucket.
+func (b *histogramBuilder) flush() {
+	if b.stats == nil || b.count == 0 {
+		return
+	}
+
+	b.stats.Histogram = append(b.stats.Histogram, HistogramBucket{Max: b.prev, Count: b.count, DistinctCount: b.distinct})
+	b.count = 0
+	b.distinct = 0
+}
@@ -1,7 +1,9 @@
 package database
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/document"
@@ -41,6 +43,17 @@ func (t *Table) Insert(d types.Document) (*tree.Key, types.Document, error) {
 		return nil, nil, err
 	}
 
+	return t.InsertWithKey(key, d)
+}
+
+// InsertWithKey inserts the document into the table under the given key,
+// instead of generating one.
+// It returns the inserted document alongside its key.
+func (t *Table) InsertWithKey(key *tree.Key, d types.Document) (*tree.Key, types.Document, error) {
+	if t.Info.ReadOnly {
+		return nil, nil, errors.New("cannot write to read-only table")
+	}
+
 	d, enc, err := t.encodeDocument(d)
 	if err != nil {
 		return nil, nil, err
@@ -50,9 +63,14 @@ func (t *Table) Insert(d types.Document) (*tree.Key, types.Document, error) {
 	err = t.Tree.Insert(key, enc)
 	if err != nil {
 		if errors.Is(err, kv.ErrKeyAlreadyExists) {
+			var paths []document.Path
+			if pk := t.Info.GetPrimaryKey(); pk != nil {
+				paths = pk.Paths
+			}
+
 			return nil, nil, &ConstraintViolationError{
 				Constraint: "PRIMARY KEY",
-				Paths:      t.Info.GetPrimaryKey().Paths,
+				Paths:      paths,
 				Key:        key,
 			}
 		}
@@ -63,18 +81,29 @@ func (t *Table) Insert(d types.Document) (*tree.Key, types.Document, error) {
 	return key, d, nil
 }
 
+// encodeDocument encodes d and applies the codecs of the table, if any.
+// The returned document is decoded using these codecs.
 func (t *Table) encodeDocument(d types.Document) (types.Document, []byte, error) {
+	if len(t.Info.FieldCodecs) > 0 {
+		dst, err := t.Info.encodeStoredDocument(t.Tx, nil, d)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return DecodeFields(t.Info, NewEncodedDocument(&t.Info.FieldConstraints, dst)), dst, nil
+	}
+
 	ed, ok := d.(*EncodedDocument)
 	// pointer comparison is enough here
-	if ok && ed.fieldConstraints == &t.Info.FieldConstraints {
-		return d, ed.encoded, nil
+	if !ok || ed.fieldConstraints != &t.Info.FieldConstraints {
+		dst, err := t.Info.EncodeDocument(t.Tx, nil, d)
+		if err != nil {
+			return nil, nil, err
+		}
+		ed = NewEncodedDocument(&t.Info.FieldConstraints, dst)
 	}
 
-	dst, err := t.Info.EncodeDocument(t.Tx, nil, d)
-	if err != nil {
-		return nil, nil, err
-	}
-	return NewEncodedDocument(&t.Info.FieldConstraints, dst), dst, nil
+	return ed, ed.encoded, nil
 }
 
 // Delete a document by key.
@@ -117,6 +146,11 @@ func (t *Table) Replace(key *tree.Key, d types.Document) (types.Document, error)
 	return d, err
 }
 
+// IterateOnRange iterates over the documents of the table whose keys are within the given range,
+// or over all the documents if rng is nil.
+// Documents are always returned in key order, i.e. by primary key or by docid,
+// or in reverse key order if reverse is true.
+// Features relying on a stable ordering, such as pagination, can depend on it.
 func (t *Table) IterateOnRange(rng *Range, reverse bool, fn func(key *tree.Key, d types.Document) error) error {
 	var paths []document.Path
 
@@ -139,10 +173,99 @@ func (t *Table) IterateOnRange(rng *Range, reverse bool, fn func(key *tree.Key,
 		fieldConstraints: &t.Info.FieldConstraints,
 	}
 
+	d := DecodeFields(t.Info, &e)
+
 	return t.Tree.IterateOnRange(r, reverse, func(k *tree.Key, enc []byte) error {
 		e.encoded = enc
-		return fn(k, &e)
+		return fn(k, d)
+	})
+}
+
+// TableStats holds statistics about the content of a table.
+type TableStats struct {
+	// Number of documents stored in the table.
+	DocumentCount int64
+	// Statistics of each index of the table, by index name.
+	Indexes map[string]*IndexStats
+	// Statistics of the values of each top-level field, by field name.
+	Fields map[string]*FieldStats
+}
+
+// Stats scans the whole table and its indexes and returns
+// statistics about their content.
+func (t *Table) Stats() (*TableStats, error) {
+	stats := TableStats{
+		Indexes: make(map[string]*IndexStats),
+		Fields:  make(map[string]*FieldStats),
+	}
+
+	// scalar values are sorted by field in a transient tree,
+	// to compute the distinct count and the histogram of each field
+	// without loading them in memory
+	tr, cleanup, err := tree.NewTransient(t.Tx.Store.NewTransientSession(), t.Tx.Catalog.GetFreeTransientNamespace(), 0)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	var seq int64
+	err = t.IterateOnRange(nil, false, func(_ *tree.Key, d types.Document) error {
+		stats.DocumentCount++
+
+		return d.Iterate(func(field string, v types.Value) error {
+			switch v.Type() {
+			case types.NullValue, types.ArrayValue, types.DocumentValue:
+				return nil
+			}
+
+			fs, ok := stats.Fields[field]
+			if !ok {
+				fs = new(FieldStats)
+				stats.Fields[strings.Clone(field)] = fs
+			}
+			fs.Count++
+
+			// the sequence keeps duplicate values
+			seq++
+			return tr.Put(tree.NewKey(types.NewTextValue(field), v, types.NewIntegerValue(seq)), nil)
+		})
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	var b histogramBuilder
+	err = tr.IterateOnRange(nil, false, func(k *tree.Key, _ []byte) error {
+		values, err := k.Decode()
+		if err != nil {
+			return err
+		}
+
+		field := types.As[string](values[0])
+		if b.stats == nil || b.field != field {
+			b.reset(field, stats.Fields[field])
+		}
+
+		return b.add(values[1])
+	})
+	if err != nil {
+		return nil, err
+	}
+	b.flush()
+
+	for _, name := range t.Tx.Catalog.ListIndexes(t.Info.TableName) {
+		idx, err := t.Tx.Catalog.GetIndex(t.Tx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		stats.Indexes[name], err = idx.Stats()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &stats, nil
 }
 
 // GetDocument returns one document by key.
@@ -155,7 +278,32 @@ func (t *Table) GetDocument(key *tree.Key) (types.Document, error) {
 		return nil, fmt.Errorf("failed to fetch document %q: %w", key, err)
 	}
 
-	return NewEncodedDocument(&t.Info.FieldConstraints, enc), nil
+	return DecodeFields(t.Info, NewEncodedDocument(&t.Info.FieldConstraints, enc)), nil
+}
+
+// ContentKey returns a key derived from a hash of the encoded values
+// stored at the given paths of d.
+// Documents with the same values at these paths get the same key.
+func ContentKey(paths []document.Path, d types.Document) (*tree.Key, error) {
+	vs := make([]types.Value, 0, len(paths))
+	for _, p := range paths {
+		v, err := p.GetValueFromDocument(d)
+		if errors.Is(err, types.ErrFieldNotFound) {
+			v = types.NewNullValue()
+		} else if err != nil {
+			return nil, err
+		}
+
+		vs = append(vs, v)
+	}
+
+	enc, err := tree.NewKey(vs...).Encode(0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(enc)
+	return tree.NewKey(types.NewBlobValue(sum[:])), nil
 }
 
 // generate a key for d based on the table configuration.
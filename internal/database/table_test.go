@@ -9,6 +9,7 @@ import (
 	"github.com/cockroachdb/pebble/vfs"
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/environment"
 	errs "github.com/genjidb/genji/internal/errors"
 	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/testutil"
@@ -302,6 +303,201 @@ func TestTableTruncate(t *testing.T) {
 	})
 }
 
+// TestTableIterateOnRangeOrder verifies documents are always returned in key order.
+func TestTableIterateOnRangeOrder(t *testing.T) {
+	db, tx, cleanup := testutil.NewTestTx(t)
+	defer cleanup()
+
+	testutil.MustExec(t, db, tx, "CREATE TABLE test(a INT PRIMARY KEY)")
+
+	// insert documents in an order unrelated to their keys
+	keys := []int64{42, -7, 3, 1000, 0, 15, -300, 8, 1}
+	for _, k := range keys {
+		testutil.MustExec(t, db, tx, "INSERT INTO test (a) VALUES (?)", environment.Param{Value: k})
+	}
+
+	tb, err := tx.Catalog.GetTable(tx, "test")
+	assert.NoError(t, err)
+
+	collect := func(rng *database.Range, reverse bool) []int64 {
+		t.Helper()
+
+		var got []int64
+		err := tb.IterateOnRange(rng, reverse, func(key *tree.Key, _ types.Document) error {
+			vs, err := key.Decode()
+			if err != nil {
+				return err
+			}
+			got = append(got, types.As[int64](vs[0]))
+			return nil
+		})
+		assert.NoError(t, err)
+		return got
+	}
+
+	t.Run("Ascending", func(t *testing.T) {
+		require.Equal(t, []int64{-300, -7, 0, 1, 3, 8, 15, 42, 1000}, collect(nil, false))
+	})
+
+	t.Run("Descending", func(t *testing.T) {
+		require.Equal(t, []int64{1000, 42, 15, 8, 3, 1, 0, -7, -300}, collect(nil, true))
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		rng := database.Range{
+			Min: database.Pivot{types.NewIntegerValue(0)},
+			Max: database.Pivot{types.NewIntegerValue(15)},
+		}
+		require.Equal(t, []int64{0, 1, 3, 8, 15}, collect(&rng, false))
+	})
+
+	t.Run("Stable", func(t *testing.T) {
+		require.Equal(t, collect(nil, false), collect(nil, false))
+	})
+}
+
+// TestTableIterateOnRangeWithoutPK verifies ranges can be used on tables
+// without primary key, whose keys are not associated with any path.
+func TestTableIterateOnRangeWithoutPK(t *testing.T) {
+	tb, cleanup := newTestTable(t)
+	defer cleanup()
+
+	for _, k := range []int64{5, 1, 3, 10} {
+		_, _, err := tb.InsertWithKey(tree.NewKey(types.NewIntegerValue(k)), newDocument())
+		assert.NoError(t, err)
+	}
+
+	rng := database.Range{
+		Min: database.Pivot{types.NewIntegerValue(2)},
+		Max: database.Pivot{types.NewIntegerValue(5)},
+	}
+
+	var got []int64
+	err := tb.IterateOnRange(&rng, false, func(key *tree.Key, _ types.Document) error {
+		vs, err := key.Decode()
+		if err != nil {
+			return err
+		}
+		got = append(got, types.As[int64](vs[0]))
+		return nil
+	})
+	assert.NoError(t, err)
+	require.Equal(t, []int64{3, 5}, got)
+}
+
+func TestTableStats(t *testing.T) {
+	db, tx, cleanup := testutil.NewTestTx(t)
+	defer cleanup()
+
+	testutil.MustExec(t, db, tx, "CREATE TABLE test(a INT, b INT, c TEXT)")
+	testutil.MustExec(t, db, tx, "CREATE INDEX idx_a ON test(a)")
+	testutil.MustExec(t, db, tx, "CREATE INDEX idx_a_b ON test(a, b)")
+	testutil.MustExec(t, db, tx, "CREATE UNIQUE INDEX idx_c ON test(c)")
+
+	t.Run("Empty", func(t *testing.T) {
+		tb, err := tx.Catalog.GetTable(tx, "test")
+		assert.NoError(t, err)
+
+		stats, err := tb.Stats()
+		assert.NoError(t, err)
+		require.Equal(t, &database.TableStats{
+			Indexes: map[string]*database.IndexStats{
+				"idx_a":   {},
+				"idx_a_b": {},
+				"idx_c":   {},
+			},
+			Fields: map[string]*database.FieldStats{},
+		}, stats)
+	})
+
+	for i := 0; i < 100; i++ {
+		testutil.MustExec(t, db, tx, "INSERT INTO test (a, b, c) VALUES (?, ?, ?)",
+			environment.Param{Value: i % 10}, environment.Param{Value: i % 4}, environment.Param{Value: fmt.Sprintf("c-%d", i)})
+	}
+
+	t.Run("Count", func(t *testing.T) {
+		tb, err := tx.Catalog.GetTable(tx, "test")
+		assert.NoError(t, err)
+
+		stats, err := tb.Stats()
+		assert.NoError(t, err)
+		require.Equal(t, int64(100), stats.DocumentCount)
+		require.Equal(t, map[string]*database.IndexStats{
+			"idx_a":   {EntryCount: 100, DistinctCount: 10},
+			"idx_a_b": {EntryCount: 100, DistinctCount: 20},
+			"idx_c":   {EntryCount: 100, DistinctCount: 100},
+		}, stats.Indexes)
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		tb, err := tx.Catalog.GetTable(tx, "test")
+		assert.NoError(t, err)
+
+		stats, err := tb.Stats()
+		assert.NoError(t, err)
+		require.Len(t, stats.Fields, 3)
+
+		a := stats.Fields["a"]
+		require.Equal(t, int64(100), a.Count)
+		require.Equal(t, int64(10), a.DistinctCount)
+		require.Equal(t, types.NewIntegerValue(0), a.Min)
+		// buckets hold at least 7 values and equal values are never split
+		require.Len(t, a.Histogram, 10)
+		for i, b := range a.Histogram {
+			require.Equal(t, database.HistogramBucket{Max: types.NewIntegerValue(int64(i)), Count: 10, DistinctCount: 1}, b)
+		}
+
+		c := stats.Fields["c"]
+		require.Equal(t, int64(100), c.DistinctCount)
+		require.Len(t, c.Histogram, 15)
+
+		require.Equal(t, 0.1, a.EqualFraction())
+		require.Equal(t, 1.0, a.RangeFraction(nil, nil, false))
+		require.InDelta(t, 0.5, a.RangeFraction(types.NewIntegerValue(5), nil, false), 0.01)
+		require.InDelta(t, 0.4, a.RangeFraction(types.NewIntegerValue(5), nil, true), 0.01)
+		require.InDelta(t, 0.2, a.RangeFraction(types.NewIntegerValue(2), types.NewIntegerValue(3), false), 0.01)
+		require.InDelta(t, 0.2, a.RangeFraction(types.NewDoubleValue(2.5), types.NewDoubleValue(5), true), 0.1)
+		require.Equal(t, 0.0, a.RangeFraction(types.NewIntegerValue(10), nil, false))
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		tb, err := tx.Catalog.GetTable(tx, "test")
+		assert.NoError(t, err)
+
+		stats, err := tb.Stats()
+		assert.NoError(t, err)
+
+		err = tx.CatalogWriter().SetTableStats(tx, "test", stats)
+		assert.NoError(t, err)
+		err = tx.Catalog.LoadTableStats(tx)
+		assert.NoError(t, err)
+
+		loaded := tx.Catalog.GetTableStats("test")
+		require.Equal(t, stats.DocumentCount, loaded.DocumentCount)
+		require.Equal(t, stats.Indexes, loaded.Indexes)
+		require.Len(t, loaded.Fields, len(stats.Fields))
+		for name, fs := range stats.Fields {
+			require.Equal(t, fs.Count, loaded.Fields[name].Count)
+			require.Equal(t, fs.DistinctCount, loaded.Fields[name].DistinctCount)
+			require.Len(t, loaded.Fields[name].Histogram, len(fs.Histogram))
+			require.Equal(t, fs.RangeFraction(types.NewIntegerValue(5), nil, false), loaded.Fields[name].RangeFraction(types.NewIntegerValue(5), nil, false))
+		}
+	})
+
+	t.Run("After delete", func(t *testing.T) {
+		testutil.MustExec(t, db, tx, "DELETE FROM test WHERE a = 0")
+
+		tb, err := tx.Catalog.GetTable(tx, "test")
+		assert.NoError(t, err)
+
+		stats, err := tb.Stats()
+		assert.NoError(t, err)
+		require.Equal(t, int64(90), stats.DocumentCount)
+		require.Equal(t, &database.IndexStats{EntryCount: 90, DistinctCount: 9}, stats.Indexes["idx_a"])
+		require.Equal(t, &database.IndexStats{EntryCount: 90, DistinctCount: 18}, stats.Indexes["idx_a_b"])
+	})
+}
+
 // BenchmarkTableInsert benchmarks the Insert method with 1, 10, 1000 and 10000 successive insertions.
 func BenchmarkTableInsert(b *testing.B) {
 	for size := 1; size <= 10000; size *= 10 {
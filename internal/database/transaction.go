@@ -19,6 +19,10 @@ type Transaction struct {
 	ID        uint64
 	Writable  bool
 	WriteTxMu *sync.Mutex
+	// If set, writing a document containing fields that are not declared
+	// in the schema of the table returns an error instead of
+	// silently dropping them.
+	StrictFields bool
 	// these functions are run after a successful rollback.
 	OnRollbackHooks []func()
 	// these functions are run after a successful commit.
@@ -26,6 +30,8 @@ type Transaction struct {
 
 	Catalog       *Catalog
 	catalogWriter *CatalogWriter
+
+	savepoints []txSavepoint
 }
 
 // Rollback the transaction. Can be used safely after commit.
@@ -101,3 +107,115 @@ func (tx *Transaction) CatalogWriter() *CatalogWriter {
 
 	return tx.catalogWriter
 }
+
+// Savepoint creates a savepoint with the given name within the transaction.
+// Every change made after the savepoint, including changes to the catalog,
+// can be undone by calling RollbackTo.
+func (tx *Transaction) Savepoint(name string) error {
+	s, err := tx.batchSession()
+	if err != nil {
+		return err
+	}
+
+	err = s.Savepoint(name)
+	if err != nil {
+		return err
+	}
+
+	// keep a copy of the catalog if it has been modified
+	// by the transaction, so that it can be restored.
+	var c *Catalog
+	if tx.catalogWriter != nil {
+		c = tx.Catalog.Clone()
+	}
+
+	tx.savepoints = append(tx.savepoints, txSavepoint{
+		name:    name,
+		catalog: c,
+	})
+
+	return nil
+}
+
+// RollbackTo undoes every change made after the most recent savepoint with the given name.
+// The savepoint remains active, the ones created after it are released.
+func (tx *Transaction) RollbackTo(name string) error {
+	s, err := tx.batchSession()
+	if err != nil {
+		return err
+	}
+
+	i := tx.lookupSavepoint(name)
+	if i < 0 {
+		return errors.Errorf("savepoint %q not found", name)
+	}
+
+	err = s.RollbackTo(name)
+	if err != nil {
+		return err
+	}
+
+	sp := tx.savepoints[i]
+	if sp.catalog != nil {
+		tx.Catalog = sp.catalog.Clone()
+		tx.catalogWriter = NewCatalogWriter(tx.Catalog)
+	} else {
+		tx.Catalog = tx.db.Catalog()
+		tx.catalogWriter = nil
+	}
+
+	tx.savepoints = tx.savepoints[:i+1]
+	return nil
+}
+
+// ReleaseSavepoint releases the most recent savepoint with the given name
+// and every savepoint created after it, keeping the changes made since then.
+func (tx *Transaction) ReleaseSavepoint(name string) error {
+	s, err := tx.batchSession()
+	if err != nil {
+		return err
+	}
+
+	i := tx.lookupSavepoint(name)
+	if i < 0 {
+		return errors.Errorf("savepoint %q not found", name)
+	}
+
+	err = s.ReleaseSavepoint(name)
+	if err != nil {
+		return err
+	}
+
+	tx.savepoints = tx.savepoints[:i]
+	return nil
+}
+
+func (tx *Transaction) lookupSavepoint(name string) int {
+	for i := len(tx.savepoints) - 1; i >= 0; i-- {
+		if tx.savepoints[i].name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (tx *Transaction) batchSession() (*kv.BatchSession, error) {
+	if !tx.Writable {
+		return nil, errors.New("cannot use savepoints in a read-only transaction")
+	}
+
+	s, ok := tx.Session.(*kv.BatchSession)
+	if !ok {
+		return nil, errors.New("savepoints are not supported by this transaction")
+	}
+
+	return s, nil
+}
+
+type txSavepoint struct {
+	name string
+	// copy of the catalog at the time the savepoint was created,
+	// nil if the transaction didn't modify it.
+	catalog *Catalog
+}
@@ -0,0 +1,179 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/stringutil"
+	"github.com/genjidb/genji/types"
+)
+
+// TriggerTiming defines when a trigger is fired,
+// relative to the modification of the document.
+type TriggerTiming int
+
+const (
+	// TriggerBefore fires the trigger before the document is written.
+	TriggerBefore TriggerTiming = iota + 1
+	// TriggerAfter fires the trigger after the document is written.
+	TriggerAfter
+)
+
+func (t TriggerTiming) String() string {
+	switch t {
+	case TriggerBefore:
+		return "BEFORE"
+	case TriggerAfter:
+		return "AFTER"
+	}
+
+	return ""
+}
+
+// TriggerEvent is the kind of modification that fires a trigger.
+type TriggerEvent int
+
+const (
+	TriggerInsert TriggerEvent = iota + 1
+	TriggerUpdate
+	TriggerDelete
+)
+
+func (e TriggerEvent) String() string {
+	switch e {
+	case TriggerInsert:
+		return "INSERT"
+	case TriggerUpdate:
+		return "UPDATE"
+	case TriggerDelete:
+		return "DELETE"
+	}
+
+	return ""
+}
+
+// TriggerInfo contains information about a trigger.
+type TriggerInfo struct {
+	TriggerName string
+	TableName   string
+	Timing      TriggerTiming
+	Event       TriggerEvent
+	// Action run for every document modified by the event.
+	// It is parsed again every time a statement fires the trigger.
+	Action fmt.Stringer
+}
+
+// String returns a SQL representation.
+func (t *TriggerInfo) String() string {
+	return fmt.Sprintf("CREATE TRIGGER %s %s %s ON %s %s",
+		stringutil.NormalizeIdentifier(t.TriggerName, '`'),
+		t.Timing,
+		t.Event,
+		stringutil.NormalizeIdentifier(t.TableName, '`'),
+		t.Action,
+	)
+}
+
+// Clone returns a copy of the trigger information.
+func (t TriggerInfo) Clone() *TriggerInfo {
+	return &t
+}
+
+type TriggerInfoRelation struct {
+	Info *TriggerInfo
+}
+
+func (r *TriggerInfoRelation) Type() string {
+	return RelationTriggerType
+}
+
+func (r *TriggerInfoRelation) Name() string {
+	return r.Info.TriggerName
+}
+
+func (r *TriggerInfoRelation) SetName(name string) {
+	r.Info.TriggerName = name
+}
+
+func (r *TriggerInfoRelation) GenerateBaseName() string {
+	return r.Info.TriggerName
+}
+
+func (r *TriggerInfoRelation) Clone() Relation {
+	clone := *r
+	clone.Info = r.Info.Clone()
+	return &clone
+}
+
+// GetTriggerInfo returns the trigger info for the given trigger name.
+func (c *Catalog) GetTriggerInfo(triggerName string) (*TriggerInfo, error) {
+	r, err := c.Cache.Get(RelationTriggerType, triggerName)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.(*TriggerInfoRelation).Info, nil
+}
+
+// ListTriggers returns the names of the triggers of the given table,
+// sorted lexicographically. If tableName is empty, it returns all the triggers.
+func (c *Catalog) ListTriggers(tableName string) []string {
+	if tableName == "" {
+		return c.Cache.ListObjects(RelationTriggerType)
+	}
+
+	var names []string
+	for _, t := range c.Cache.GetTableTriggers(tableName) {
+		names = append(names, t.TriggerName)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// CreateTrigger creates a trigger with the given name.
+// If a relation with the same name already exists, returns errs.AlreadyExistsError.
+func (c *CatalogWriter) CreateTrigger(tx *Transaction, info *TriggerInfo) error {
+	if info.TriggerName == "" {
+		return errors.New("trigger name required")
+	}
+
+	ti, err := c.Catalog.GetTableInfo(info.TableName)
+	if err != nil {
+		return err
+	}
+
+	if ti.ReadOnly {
+		return errors.New("cannot create a trigger on a read-only table")
+	}
+
+	rel := TriggerInfoRelation{Info: info}
+	err = c.Cache.Add(tx, &rel)
+	if err != nil {
+		return err
+	}
+
+	return c.CatalogTable.Insert(tx, &rel)
+}
+
+// DropTrigger deletes a trigger from the catalog.
+func (c *CatalogWriter) DropTrigger(tx *Transaction, triggerName string) error {
+	_, err := c.Cache.Delete(tx, RelationTriggerType, triggerName)
+	if err != nil {
+		return err
+	}
+
+	return c.CatalogTable.Delete(tx, triggerName)
+}
+
+func triggerInfoToDocument(t *TriggerInfo) types.Document {
+	buf := document.NewFieldBuffer()
+	buf.Add("name", types.NewTextValue(t.TriggerName))
+	buf.Add("type", types.NewTextValue(RelationTriggerType))
+	buf.Add("sql", types.NewTextValue(t.String()))
+	buf.Add("owner", types.NewDocumentValue(ownerToDocument(&Owner{TableName: t.TableName})))
+
+	return buf
+}
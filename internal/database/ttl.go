@@ -0,0 +1,225 @@
+package database
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	errs "github.com/genjidb/genji/internal/errors"
+	"github.com/genjidb/genji/internal/tree"
+)
+
+const (
+	// DefaultTTLInterval is the default interval between
+	// two deletions of the expired documents.
+	DefaultTTLInterval = time.Minute
+
+	// maximum number of expired documents deleted by a single transaction,
+	// to avoid blocking writers for too long.
+	ttlBatchSize = 100
+)
+
+var errTTLBatchFull = errors.New("batch full")
+
+// startReaper runs a goroutine that deletes the expired documents
+// of every table with a TTL field at the interval of the options,
+// until stopReaper is called.
+// It does nothing if the goroutine is already running, if the database
+// is closed or if the expired documents are only deleted by calling DeleteExpired.
+// It is called when the database is opened with a table with a TTL field,
+// and when such a table is created.
+func (db *Database) startReaper() {
+	db.reaperMu.Lock()
+	defer db.reaperMu.Unlock()
+
+	if db.ttlInterval <= 0 || db.reaperStop != nil || db.reaperClosed {
+		return
+	}
+
+	stop, done := make(chan struct{}), make(chan struct{})
+	db.reaperStop, db.reaperDone = stop, done
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(db.ttlInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				// errors are ignored, documents that couldn't be deleted,
+				// e.g. because of an attached transaction, are deleted during the next run
+				_ = db.DeleteExpired()
+			}
+		}
+	}()
+}
+
+// stopReaper stops the goroutine started by startReaper, if any,
+// waits for it to return and prevents it from being started again.
+func (db *Database) stopReaper() {
+	db.reaperMu.Lock()
+	db.reaperClosed = true
+	stop, done := db.reaperStop, db.reaperDone
+	db.reaperMu.Unlock()
+
+	if stop == nil {
+		return
+	}
+
+	close(stop)
+	<-done
+}
+
+// hasTTLTables returns whether the catalog contains a table with a TTL field.
+func (c *Catalog) hasTTLTables() bool {
+	for _, name := range c.Cache.ListObjects(RelationTableType) {
+		info, err := c.GetTableInfo(name)
+		if err == nil && info.TTLField != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DeleteExpired deletes the expired documents of every table with a TTL field,
+// along with their index entries.
+// Documents are deleted in small transactions, to avoid blocking writers for too long.
+func (db *Database) DeleteExpired() error {
+	now := time.Now()
+
+	for _, name := range db.Catalog().Cache.ListObjects(RelationTableType) {
+		info, err := db.Catalog().GetTableInfo(name)
+		if err != nil {
+			if errs.IsNotFoundError(err) {
+				continue
+			}
+			return err
+		}
+
+		if info.TTLField == "" || info.ReadOnly {
+			continue
+		}
+
+		// every batch resumes from the last key of the previous one
+		var last *tree.Key
+		for {
+			last, err = db.deleteExpiredBatch(name, now, last)
+			if err != nil {
+				return err
+			}
+
+			if last == nil {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// deleteExpiredBatch deletes up to ttlBatchSize expired documents of the given table
+// with a key greater than after, within a single transaction.
+// If the batch is full, it returns the key of the last deleted document,
+// otherwise the end of the table was reached and it returns nil.
+func (db *Database) deleteExpiredBatch(tableName string, now time.Time, after *tree.Key) (*tree.Key, error) {
+	tx, err := db.Begin(true)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	table, err := tx.Catalog.GetTable(tx, tableName)
+	if err != nil {
+		// the table was dropped in the meantime
+		if errs.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	e := EncodedDocument{
+		fieldConstraints: &table.Info.FieldConstraints,
+	}
+	d := DecodeFields(table.Info, &e)
+
+	var keys []*tree.Key
+	err = table.Tree.IterateAfter(after, func(key *tree.Key, enc []byte) error {
+		e.encoded = enc
+
+		expired, err := table.Info.IsExpired(d, now)
+		if err != nil || !expired {
+			return err
+		}
+
+		keys = append(keys, tree.NewEncodedKey(append([]byte(nil), key.Encoded...)))
+		if len(keys) == ttlBatchSize {
+			return errTTLBatchFull
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errTTLBatchFull) {
+		return nil, err
+	}
+
+	var last *tree.Key
+	if errors.Is(err, errTTLBatchFull) {
+		last = keys[len(keys)-1]
+	}
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	var indexes []*Index
+	var infos []*IndexInfo
+	for _, name := range tx.Catalog.ListIndexes(tableName) {
+		info, err := tx.Catalog.GetIndexInfo(name)
+		if err != nil {
+			return nil, err
+		}
+
+		idx, err := tx.Catalog.GetIndex(tx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		infos = append(infos, info)
+		indexes = append(indexes, idx)
+	}
+
+	for _, key := range keys {
+		d, err := table.GetDocument(key)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, idx := range indexes {
+			vs, err := infos[i].Values(tx, d)
+			if err != nil {
+				return nil, err
+			}
+
+			err = idx.Delete(vs, key.Encoded)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err = table.Delete(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return last, nil
+}
@@ -0,0 +1,104 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/stringutil"
+	"github.com/genjidb/genji/types"
+)
+
+// ViewInfo contains information about a view.
+type ViewInfo struct {
+	ViewName string
+	// SELECT statement read by the view.
+	// It is expanded every time the view is referenced by another statement.
+	Query fmt.Stringer
+}
+
+// String returns a SQL representation.
+func (v *ViewInfo) String() string {
+	return fmt.Sprintf("CREATE VIEW %s AS %s", stringutil.NormalizeIdentifier(v.ViewName, '`'), v.Query)
+}
+
+// Clone returns a copy of the view information.
+func (v ViewInfo) Clone() *ViewInfo {
+	return &v
+}
+
+type ViewInfoRelation struct {
+	Info *ViewInfo
+}
+
+func (r *ViewInfoRelation) Type() string {
+	return RelationViewType
+}
+
+func (r *ViewInfoRelation) Name() string {
+	return r.Info.ViewName
+}
+
+func (r *ViewInfoRelation) SetName(name string) {
+	r.Info.ViewName = name
+}
+
+func (r *ViewInfoRelation) GenerateBaseName() string {
+	return r.Info.ViewName
+}
+
+func (r *ViewInfoRelation) Clone() Relation {
+	clone := *r
+	clone.Info = r.Info.Clone()
+	return &clone
+}
+
+// GetViewInfo returns the view info for the given view name.
+func (c *Catalog) GetViewInfo(viewName string) (*ViewInfo, error) {
+	r, err := c.Cache.Get(RelationViewType, viewName)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.(*ViewInfoRelation).Info, nil
+}
+
+// ListViews returns all view names sorted lexicographically.
+func (c *Catalog) ListViews() []string {
+	return c.Cache.ListObjects(RelationViewType)
+}
+
+// CreateView creates a view with the given name.
+// If a relation with the same name already exists, returns errs.AlreadyExistsError.
+func (c *CatalogWriter) CreateView(tx *Transaction, info *ViewInfo) error {
+	if info.ViewName == "" {
+		return errors.New("view name required")
+	}
+
+	rel := ViewInfoRelation{Info: info}
+	err := c.Cache.Add(tx, &rel)
+	if err != nil {
+		return err
+	}
+
+	return c.CatalogTable.Insert(tx, &rel)
+}
+
+// DropView deletes a view from the catalog.
+func (c *CatalogWriter) DropView(tx *Transaction, viewName string) error {
+	_, err := c.Cache.Delete(tx, RelationViewType, viewName)
+	if err != nil {
+		return err
+	}
+
+	return c.CatalogTable.Delete(tx, viewName)
+}
+
+func viewInfoToDocument(v *ViewInfo) types.Document {
+	buf := document.NewFieldBuffer()
+	buf.Add("name", types.NewTextValue(v.ViewName))
+	buf.Add("type", types.NewTextValue(RelationViewType))
+	buf.Add("sql", types.NewTextValue(v.String()))
+
+	return buf
+}
@@ -1,6 +1,7 @@
 package environment
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/genjidb/genji/document"
@@ -33,6 +34,18 @@ type Environment struct {
 	Tx     *database.Transaction
 
 	Outer *Environment
+
+	// cache holds the values of memoized expressions
+	// evaluated against the current document.
+	cache map[string]types.Value
+
+	// state holds values shared by the operators of a stream
+	// for the duration of its execution.
+	state map[string]interface{}
+
+	// ctx is checked by the operators reading the documents
+	// to stop the stream once it is canceled or past its deadline.
+	ctx context.Context
 }
 
 func New(d types.Document, params ...Param) *Environment {
@@ -73,6 +86,7 @@ func (e *Environment) Set(path document.Path, v types.Value) {
 	}
 
 	e.Vars.Set(path, v)
+	e.ResetCache()
 }
 
 func (e *Environment) GetDocument() (types.Document, bool) {
@@ -89,6 +103,7 @@ func (e *Environment) GetDocument() (types.Document, bool) {
 
 func (e *Environment) SetDocument(d types.Document) {
 	e.Doc = d
+	e.ResetCache()
 }
 
 func (e *Environment) GetKey() (*tree.Key, bool) {
@@ -105,6 +120,7 @@ func (e *Environment) GetKey() (*tree.Key, bool) {
 
 func (e *Environment) SetKey(k *tree.Key) {
 	e.Key = k
+	e.ResetCache()
 }
 
 func (e *Environment) SetParams(params []Param) {
@@ -165,3 +181,70 @@ func (e *Environment) GetDB() *database.Database {
 
 	return nil
 }
+
+// GetContext returns the context of the closest environment
+// that has one, or context.Background if none is set.
+func (e *Environment) GetContext() context.Context {
+	if e.ctx != nil {
+		return e.ctx
+	}
+
+	if outer := e.GetOuter(); outer != nil {
+		return outer.GetContext()
+	}
+
+	return context.Background()
+}
+
+// SetContext sets the context of the environment.
+func (e *Environment) SetContext(ctx context.Context) {
+	e.ctx = ctx
+}
+
+// GetCachedValue returns the value stored under key by SetCachedValue
+// since the last time the document or the key of the environment changed.
+func (e *Environment) GetCachedValue(key string) (types.Value, bool) {
+	v, ok := e.cache[key]
+	return v, ok
+}
+
+// SetCachedValue stores v under key until the document or the key
+// of the environment changes.
+func (e *Environment) SetCachedValue(key string, v types.Value) {
+	if e.cache == nil {
+		e.cache = make(map[string]types.Value)
+	}
+
+	e.cache[key] = v
+}
+
+// ResetCache removes all the cached values.
+func (e *Environment) ResetCache() {
+	for k := range e.cache {
+		delete(e.cache, k)
+	}
+}
+
+// GetState returns the value stored under key by SetState,
+// in this environment or in one of its outer environments.
+func (e *Environment) GetState(key string) (interface{}, bool) {
+	if v, ok := e.state[key]; ok {
+		return v, true
+	}
+
+	if e.Outer != nil {
+		return e.Outer.GetState(key)
+	}
+
+	return nil, false
+}
+
+// SetState stores v under key. The value is visible to the environments
+// nested in this one.
+func (e *Environment) SetState(key string, v interface{}) {
+	if e.state == nil {
+		e.state = make(map[string]interface{})
+	}
+
+	e.state[key] = v
+}
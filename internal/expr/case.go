@@ -0,0 +1,149 @@
+package expr
+
+import (
+	"strings"
+
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/types"
+)
+
+// CaseWhen is a WHEN ... THEN ... branch of a CASE expression.
+type CaseWhen struct {
+	When Expr
+	Then Expr
+}
+
+// CaseExpr represents the CASE [operand] WHEN ... THEN ... [ELSE ...] END expression.
+type CaseExpr struct {
+	// If set, the WHEN expressions are values compared
+	// to the operand instead of conditions.
+	Operand Expr
+	Whens   []CaseWhen
+	Else    Expr
+}
+
+// Eval evaluates the WHEN conditions in order and returns the result of the THEN
+// expression associated with the first truthy condition.
+// If the expression has an operand, it returns the result of the THEN expression
+// associated with the first WHEN value equal to the operand.
+// If none of the conditions is truthy, it returns the result of the ELSE expression,
+// or NULL if there is none.
+func (c *CaseExpr) Eval(env *environment.Environment) (types.Value, error) {
+	var operand types.Value
+	if c.Operand != nil {
+		var err error
+		operand, err = c.Operand.Eval(env)
+		if err != nil {
+			return NullLiteral, err
+		}
+	}
+
+	for _, w := range c.Whens {
+		v, err := w.When.Eval(env)
+		if err != nil {
+			return NullLiteral, err
+		}
+
+		var ok bool
+		if c.Operand != nil {
+			// comparing with NULL never matches
+			if operand.Type() != types.NullValue && v.Type() != types.NullValue {
+				ok, err = types.IsEqual(operand, v)
+			}
+		} else {
+			ok, err = types.IsTruthy(v)
+		}
+		if err != nil {
+			return NullLiteral, err
+		}
+		if ok {
+			return w.Then.Eval(env)
+		}
+	}
+
+	if c.Else == nil {
+		return NullLiteral, nil
+	}
+
+	return c.Else.Eval(env)
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (c *CaseExpr) IsEqual(other Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*CaseExpr)
+	if !ok {
+		return false
+	}
+
+	if len(c.Whens) != len(o.Whens) {
+		return false
+	}
+
+	if c.Operand == nil || o.Operand == nil {
+		if c.Operand != nil || o.Operand != nil {
+			return false
+		}
+	} else if !Equal(c.Operand, o.Operand) {
+		return false
+	}
+
+	for i := range c.Whens {
+		if !Equal(c.Whens[i].When, o.Whens[i].When) || !Equal(c.Whens[i].Then, o.Whens[i].Then) {
+			return false
+		}
+	}
+
+	if c.Else == nil || o.Else == nil {
+		return c.Else == nil && o.Else == nil
+	}
+
+	return Equal(c.Else, o.Else)
+}
+
+// Params returns the operand, the conditions and the results
+// of every branch of the expression.
+func (c *CaseExpr) Params() []Expr {
+	params := make([]Expr, 0, len(c.Whens)*2+2)
+	if c.Operand != nil {
+		params = append(params, c.Operand)
+	}
+	for _, w := range c.Whens {
+		params = append(params, w.When, w.Then)
+	}
+	if c.Else != nil {
+		params = append(params, c.Else)
+	}
+
+	return params
+}
+
+func (c *CaseExpr) IsDeterministic() bool { return true }
+
+func (c *CaseExpr) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("CASE")
+	if c.Operand != nil {
+		sb.WriteString(" ")
+		sb.WriteString(c.Operand.String())
+	}
+	for _, w := range c.Whens {
+		sb.WriteString(" WHEN ")
+		sb.WriteString(w.When.String())
+		sb.WriteString(" THEN ")
+		sb.WriteString(w.Then.String())
+	}
+
+	if c.Else != nil {
+		sb.WriteString(" ELSE ")
+		sb.WriteString(c.Else.String())
+	}
+
+	sb.WriteString(" END")
+	return sb.String()
+}
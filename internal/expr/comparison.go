@@ -86,6 +86,8 @@ func Lte(a, b Expr) Expr {
 	return newCmpOp(a, b, scanner.LTE)
 }
 
+// A BetweenOperator is a ternary operator that returns true
+// if X is between a and b, inclusive.
 type BetweenOperator struct {
 	*simpleOperator
 	X Expr
@@ -99,40 +101,93 @@ func Between(a Expr) func(x, b Expr) Expr {
 	}
 }
 
+// Eval evaluates x BETWEEN a AND b as x >= a AND x <= b.
+// As with other comparisons, values of different types are never between each other,
+// and comparing with NULL evaluates to NULL, unless the other comparison is false.
 func (op *BetweenOperator) Eval(env *environment.Environment) (types.Value, error) {
 	x, err := op.X.Eval(env)
 	if err != nil {
-		return FalseLiteral, err
+		return NullLiteral, err
 	}
 
 	return op.simpleOperator.eval(env, func(a, b types.Value) (types.Value, error) {
-		if a.Type() == types.NullValue || b.Type() == types.NullValue {
+		if x.Type() == types.NullValue {
 			return NullLiteral, nil
 		}
 
-		ok, err := types.IsGreaterThanOrEqual(x, a)
-		if !ok || err != nil {
-			return FalseLiteral, err
+		var isNull bool
+
+		if a.Type() == types.NullValue {
+			isNull = true
+		} else {
+			ok, err := types.IsGreaterThanOrEqual(x, a)
+			if !ok || err != nil {
+				return FalseLiteral, err
+			}
+		}
+
+		if b.Type() == types.NullValue {
+			isNull = true
+		} else {
+			ok, err := types.IsLesserThanOrEqual(x, b)
+			if !ok || err != nil {
+				return FalseLiteral, err
+			}
 		}
 
-		ok, err = types.IsLesserThanOrEqual(x, b)
-		if !ok || err != nil {
-			return FalseLiteral, err
+		if isNull {
+			return NullLiteral, nil
 		}
 
 		return TrueLiteral, nil
 	})
 }
 
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (op *BetweenOperator) IsEqual(other Expr) bool {
+	var x Expr
+	switch o := other.(type) {
+	case *BetweenOperator:
+		x = o.X
+	case *NotBetweenOperator:
+		x = o.X
+	default:
+		return false
+	}
+
+	return op.simpleOperator.IsEqual(other) && Equal(op.X, x)
+}
+
 func (op *BetweenOperator) String() string {
 	return fmt.Sprintf("%v BETWEEN %v AND %v", op.X, op.a, op.b)
 }
 
+type NotBetweenOperator struct {
+	BetweenOperator
+}
+
+// NotBetween returns a function that creates a NOT BETWEEN operator that
+// returns true if x is not between a and b.
+func NotBetween(a Expr) func(x, b Expr) Expr {
+	return func(x, b Expr) Expr {
+		return &NotBetweenOperator{BetweenOperator{&simpleOperator{a, b, scanner.NBETWEEN}, x}}
+	}
+}
+
+func (op *NotBetweenOperator) Eval(env *environment.Environment) (types.Value, error) {
+	return invertBoolResult(op.BetweenOperator.Eval)(env)
+}
+
+func (op *NotBetweenOperator) String() string {
+	return fmt.Sprintf("%v NOT BETWEEN %v AND %v", op.X, op.a, op.b)
+}
+
 // IsComparisonOperator returns true if e is one of
-// =, !=, >, >=, <, <=, IS, IS NOT, IN, or NOT IN operators.
+// =, !=, >, >=, <, <=, IS, IS NOT, IN, NOT IN, LIKE, NOT LIKE, BETWEEN or NOT BETWEEN operators.
 func IsComparisonOperator(op Operator) bool {
 	switch op.(type) {
-	case *cmpOp, *IsOperator, *IsNotOperator, *InOperator, *NotInOperator, *LikeOperator, *NotLikeOperator, *BetweenOperator:
+	case *cmpOp, *IsOperator, *IsNotOperator, *InOperator, *NotInOperator, *LikeOperator, *NotLikeOperator, *BetweenOperator, *NotBetweenOperator:
 		return true
 	}
 
@@ -158,7 +213,8 @@ func (op *InOperator) Eval(env *environment.Environment) (types.Value, error) {
 			return FalseLiteral, nil
 		}
 
-		ok, err := document.ArrayContains(types.As[types.Array](b), a)
+		arr := types.As[types.Array](b)
+		ok, err := document.ArrayContains(arr, a)
 		if err != nil {
 			return NullLiteral, err
 		}
@@ -166,6 +222,17 @@ func (op *InOperator) Eval(env *environment.Environment) (types.Value, error) {
 		if ok {
 			return TrueLiteral, nil
 		}
+
+		// if no value matches, a NULL value in the list
+		// makes the result unknown
+		ok, err = document.ArrayContains(arr, NullLiteral)
+		if err != nil {
+			return NullLiteral, err
+		}
+
+		if ok {
+			return NullLiteral, nil
+		}
 		return FalseLiteral, nil
 	})
 }
@@ -193,7 +260,7 @@ type IsOperator struct {
 
 // Is creates an expression that evaluates to the result of a IS b.
 func Is(a, b Expr) Expr {
-	return &IsOperator{&simpleOperator{a, b, scanner.IN}}
+	return &IsOperator{&simpleOperator{a, b, scanner.IS}}
 }
 
 func (op *IsOperator) Eval(env *environment.Environment) (types.Value, error) {
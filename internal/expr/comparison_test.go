@@ -57,6 +57,11 @@ func TestComparisonINExpr(t *testing.T) {
 		{"[1, 2] IN 1", types.NewBoolValue(false), false},
 		{"1 IN NULL", nullLiteral, false},
 		{"NULL IN [1, 2, NULL]", nullLiteral, false},
+		{"1 IN [1, NULL]", types.NewBoolValue(true), false},
+		{"1 IN [2, NULL]", nullLiteral, false},
+		{"1 IN (1)", types.NewBoolValue(true), false},
+		{"1 IN (2)", types.NewBoolValue(false), false},
+		{"2 IN (1, 1 + 1)", types.NewBoolValue(true), false},
 	}
 
 	for _, test := range tests {
@@ -81,6 +86,10 @@ func TestComparisonNOTINExpr(t *testing.T) {
 		{"[1, 2] NOT IN 1", types.NewBoolValue(true), false},
 		{"1 NOT IN NULL", nullLiteral, false},
 		{"NULL NOT IN [1, 2, NULL]", nullLiteral, false},
+		{"1 NOT IN [1, NULL]", types.NewBoolValue(false), false},
+		{"1 NOT IN [2, NULL]", nullLiteral, false},
+		{"1 NOT IN (1)", types.NewBoolValue(false), false},
+		{"1 NOT IN (2)", types.NewBoolValue(true), false},
 	}
 
 	for _, test := range tests {
@@ -175,6 +184,44 @@ func TestComparisonBetweenExpr(t *testing.T) {
 		{"1 BETWEEN '1' AND 2", types.NewBoolValue(false), false},
 		{"1 BETWEEN CAST('1' AS int) AND 2", types.NewBoolValue(true), false},
 		{"1 BETWEEN CAST('1' AS double) AND 2", types.NewBoolValue(true), false},
+		{"1 BETWEEN 0.5 AND 1.5", types.NewBoolValue(true), false},
+		{"1.5 BETWEEN 1 AND 2", types.NewBoolValue(true), false},
+		{"3 BETWEEN 1 AND 2", types.NewBoolValue(false), false},
+		{"2 BETWEEN 2 AND 1", types.NewBoolValue(false), false},
+		{"a BETWEEN 0 AND 2", types.NewBoolValue(true), false},
+		{"NULL BETWEEN 0 AND 2", nullLiteral, false},
+		{"notFound BETWEEN 0 AND 2", nullLiteral, false},
+		{"1 BETWEEN 0 AND NULL", nullLiteral, false},
+		{"3 BETWEEN NULL AND 2", types.NewBoolValue(false), false},
+		{"0 BETWEEN 1 AND NULL", types.NewBoolValue(false), false},
+		{"'b' BETWEEN 'a' AND 'c'", types.NewBoolValue(true), false},
+		{"'d' BETWEEN 'a' AND 'c'", types.NewBoolValue(false), false},
+		{"'\\xbb' BETWEEN '\\xaa' AND '\\xcc'", types.NewBoolValue(true), false},
+		{"true BETWEEN false AND true", types.NewBoolValue(true), false},
+		{"[1, 2] BETWEEN [1] AND [2]", types.NewBoolValue(true), false},
+		{"{a: 2} BETWEEN {a: 1} AND {a: 3}", types.NewBoolValue(true), false},
+		{"'2021-06-01' BETWEEN CAST('2021-01-01' AS TIMESTAMP) AND CAST('2021-12-31' AS TIMESTAMP)", types.NewBoolValue(true), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			testutil.TestExpr(t, test.expr, envWithDoc, test.res, test.fails)
+		})
+	}
+}
+
+func TestComparisonNotBetweenExpr(t *testing.T) {
+	tests := []struct {
+		expr  string
+		res   types.Value
+		fails bool
+	}{
+		{"1 NOT BETWEEN 0 AND 2", types.NewBoolValue(false), false},
+		{"3 NOT BETWEEN 0 AND 2", types.NewBoolValue(true), false},
+		{"1 NOT BETWEEN 0 AND 'foo'", types.NewBoolValue(true), false},
+		{"NULL NOT BETWEEN 0 AND 2", nullLiteral, false},
+		{"1 NOT BETWEEN NULL AND 2", nullLiteral, false},
+		{"3 NOT BETWEEN NULL AND 2", types.NewBoolValue(true), false},
 	}
 
 	for _, test := range tests {
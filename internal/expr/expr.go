@@ -114,6 +114,16 @@ type Function interface {
 	Params() []Expr
 }
 
+// A DeterministicFunction is a function that reports whether it always returns
+// the same result for the same parameters and the same document, without side effects.
+// Functions that don't implement this interface, like user-defined functions,
+// are considered non-deterministic.
+type DeterministicFunction interface {
+	Function
+
+	IsDeterministic() bool
+}
+
 // An Aggregator is an expression that aggregates documents into one result.
 type Aggregator interface {
 	Expr
@@ -137,6 +147,10 @@ func Walk(e Expr, fn func(Expr) bool) bool {
 	}
 
 	switch t := e.(type) {
+	case *BetweenOperator:
+		return Walk(t.X, fn) && Walk(t.LeftHand(), fn) && Walk(t.RightHand(), fn)
+	case *NotBetweenOperator:
+		return Walk(t.X, fn) && Walk(t.LeftHand(), fn) && Walk(t.RightHand(), fn)
 	case Operator:
 		if !Walk(t.LeftHand(), fn) {
 			return false
@@ -144,8 +158,12 @@ func Walk(e Expr, fn func(Expr) bool) bool {
 		if !Walk(t.RightHand(), fn) {
 			return false
 		}
+	case Parentheses:
+		return Walk(t.E, fn)
 	case *NamedExpr:
 		return Walk(t.Expr, fn)
+	case *MemoizedExpr:
+		return Walk(t.Expr, fn)
 	case Function:
 		for _, p := range t.Params() {
 			if !Walk(p, fn) {
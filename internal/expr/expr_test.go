@@ -40,7 +40,7 @@ func TestString(t *testing.T) {
 
 	var operators = []string{
 		"=", ">", ">=", "<", "<=",
-		"+", "-", "*", "/", "%", "&", "|", "^",
+		"+", "-", "*", "/", "%", "&", "|", "^", "||",
 		"AND", "OR",
 	}
 
@@ -59,4 +59,7 @@ func TestString(t *testing.T) {
 		want := fmt.Sprintf("10.4 %s foo.bar[1]", op)
 		testFn(want, want)
 	}
+
+	testFn("a BETWEEN 1 AND 2", "a BETWEEN 1 AND 2")
+	testFn("a NOT BETWEEN 1 AND 2", "a NOT BETWEEN 1 AND 2")
 }
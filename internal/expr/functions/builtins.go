@@ -1,6 +1,7 @@
 package functions
 
 import (
+	"crypto/rand"
 	"fmt"
 	"time"
 
@@ -19,6 +20,18 @@ var builtinFunctions = Definitions{
 			return &TypeOf{Expr: args[0]}, nil
 		},
 	},
+	"exists": &definition{
+		name:  "exists",
+		arity: 1,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			p, ok := args[0].(expr.Path)
+			if !ok {
+				return nil, errors.Errorf("exists() expects a path, got %q", args[0])
+			}
+
+			return &Exists{Path: p}, nil
+		},
+	},
 	"pk": &definition{
 		name:  "pk",
 		arity: 0,
@@ -68,6 +81,13 @@ var builtinFunctions = Definitions{
 			return &Len{Expr: args[0]}, nil
 		},
 	},
+	"array_length": &definition{
+		name:  "array_length",
+		arity: 1,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &ArrayLength{Expr: args[0]}, nil
+		},
+	},
 	"coalesce": &definition{
 		name:  "coalesce",
 		arity: variadicArity,
@@ -75,6 +95,13 @@ var builtinFunctions = Definitions{
 			return &Coalesce{Exprs: args}, nil
 		},
 	},
+	"nullif": &definition{
+		name:  "nullif",
+		arity: 2,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &NullIf{A: args[0], B: args[1]}, nil
+		},
+	},
 	"now": &definition{
 		name:  "now",
 		arity: 0,
@@ -82,16 +109,28 @@ var builtinFunctions = Definitions{
 			return &Now{}, nil
 		},
 	},
+	"uuid": &definition{
+		name:  "uuid",
+		arity: 0,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &UUID{}, nil
+		},
+	},
 
 	// strings alias
-	"lower": stringsFunctions["lower"],
-	"upper": stringsFunctions["upper"],
-	"trim":  stringsFunctions["trim"],
-	"ltrim": stringsFunctions["ltrim"],
-	"rtrim": stringsFunctions["rtrim"],
+	"lower":  stringsFunctions["lower"],
+	"upper":  stringsFunctions["upper"],
+	"trim":   stringsFunctions["trim"],
+	"ltrim":  stringsFunctions["ltrim"],
+	"rtrim":  stringsFunctions["rtrim"],
+	"substr": stringsFunctions["substr"],
+	"length": stringsFunctions["length"],
+	"concat": stringsFunctions["concat"],
 
 	// math alias
 	"floor":  mathFunctions["floor"],
+	"ceil":   mathFunctions["ceil"],
+	"round":  mathFunctions["round"],
 	"abs":    mathFunctions["abs"],
 	"acos":   mathFunctions["acos"],
 	"acosh":  mathFunctions["acosh"],
@@ -101,6 +140,14 @@ var builtinFunctions = Definitions{
 	"atan2":  mathFunctions["atan2"],
 	"random": mathFunctions["random"],
 	"sqrt":   mathFunctions["sqrt"],
+	"pow":    mathFunctions["pow"],
+
+	// conversion functions
+	"to_bool":      conversionFunctions["to_bool"],
+	"to_integer":   conversionFunctions["to_integer"],
+	"to_double":    conversionFunctions["to_double"],
+	"to_text":      conversionFunctions["to_text"],
+	"to_timestamp": conversionFunctions["to_timestamp"],
 }
 
 // BuiltinDefinitions returns a map of builtin functions.
@@ -138,10 +185,60 @@ func (t *TypeOf) IsEqual(other expr.Expr) bool {
 
 func (t *TypeOf) Params() []expr.Expr { return []expr.Expr{t.Expr} }
 
+func (t *TypeOf) IsDeterministic() bool { return true }
+
 func (t *TypeOf) String() string {
 	return fmt.Sprintf("typeof(%v)", t.Expr)
 }
 
+// Exists represents the exists() function.
+// It returns true if the current document contains the given path,
+// even if its value is null.
+type Exists struct {
+	Path expr.Path
+}
+
+// Eval returns true if the path exists in the current document.
+func (e *Exists) Eval(env *environment.Environment) (types.Value, error) {
+	d, ok := env.GetDocument()
+	if !ok {
+		return expr.FalseLiteral, nil
+	}
+
+	_, err := document.Path(e.Path).GetValueFromDocument(d)
+	if errors.Is(err, types.ErrFieldNotFound) {
+		return expr.FalseLiteral, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return expr.TrueLiteral, nil
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (e *Exists) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*Exists)
+	if !ok {
+		return false
+	}
+
+	return e.Path.IsEqual(o.Path)
+}
+
+func (e *Exists) Params() []expr.Expr { return []expr.Expr{e.Path} }
+
+func (e *Exists) IsDeterministic() bool { return true }
+
+func (e *Exists) String() string {
+	return fmt.Sprintf("exists(%v)", e.Path)
+}
+
 // PK represents the pk() function.
 // It returns the primary key of the current document.
 type PK struct{}
@@ -191,6 +288,8 @@ func (k *PK) Eval(env *environment.Environment) (types.Value, error) {
 
 func (*PK) Params() []expr.Expr { return nil }
 
+func (*PK) IsDeterministic() bool { return true }
+
 // IsEqual compares this expression with the other expression and returns
 // true if they are equal.
 func (k *PK) IsEqual(other expr.Expr) bool {
@@ -747,11 +846,66 @@ func (s *Len) IsEqual(other expr.Expr) bool {
 
 func (s *Len) Params() []expr.Expr { return []expr.Expr{s.Expr} }
 
+func (s *Len) IsDeterministic() bool { return true }
+
 // String returns the literal representation of len.
 func (s *Len) String() string {
 	return fmt.Sprintf("LEN(%v)", s.Expr)
 }
 
+// ArrayLength returns the number of elements of an array.
+// It returns NULL if the value is NULL and an error for any other type.
+type ArrayLength struct {
+	Expr expr.Expr
+}
+
+// Eval returns the length of the array.
+func (s *ArrayLength) Eval(env *environment.Environment) (types.Value, error) {
+	val, err := s.Expr.Eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch val.Type() {
+	case types.NullValue:
+		return types.NewNullValue(), nil
+	case types.ArrayValue:
+	default:
+		return nil, fmt.Errorf("ARRAY_LENGTH() expects an array, got %s", val.Type())
+	}
+
+	length, err := document.ArrayLength(types.As[types.Array](val))
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewIntegerValue(int64(length)), nil
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (s *ArrayLength) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*ArrayLength)
+	if !ok {
+		return false
+	}
+
+	return expr.Equal(s.Expr, o.Expr)
+}
+
+func (s *ArrayLength) Params() []expr.Expr { return []expr.Expr{s.Expr} }
+
+func (s *ArrayLength) IsDeterministic() bool { return true }
+
+// String returns the literal representation of array_length.
+func (s *ArrayLength) String() string {
+	return fmt.Sprintf("ARRAY_LENGTH(%v)", s.Expr)
+}
+
 type Coalesce struct {
 	Exprs []expr.Expr
 }
@@ -766,7 +920,7 @@ func (c *Coalesce) Eval(e *environment.Environment) (types.Value, error) {
 			return v, nil
 		}
 	}
-	return nil, nil
+	return types.NewNullValue(), nil
 }
 
 func (c *Coalesce) String() string {
@@ -777,6 +931,63 @@ func (c *Coalesce) Params() []expr.Expr {
 	return c.Exprs
 }
 
+func (c *Coalesce) IsDeterministic() bool { return true }
+
+// NullIf returns NULL if both of its arguments are equal,
+// otherwise it returns its first argument.
+type NullIf struct {
+	A, B expr.Expr
+}
+
+func (n *NullIf) Eval(env *environment.Environment) (types.Value, error) {
+	a, err := n.A.Eval(env)
+	if err != nil {
+		return nil, err
+	}
+	if a.Type() == types.NullValue {
+		return a, nil
+	}
+
+	b, err := n.B.Eval(env)
+	if err != nil {
+		return nil, err
+	}
+	if b.Type() == types.NullValue {
+		return a, nil
+	}
+
+	ok, err := types.IsEqual(a, b)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return types.NewNullValue(), nil
+	}
+
+	return a, nil
+}
+
+func (n *NullIf) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*NullIf)
+	if !ok {
+		return false
+	}
+
+	return expr.Equal(n.A, o.A) && expr.Equal(n.B, o.B)
+}
+
+func (n *NullIf) Params() []expr.Expr { return []expr.Expr{n.A, n.B} }
+
+func (n *NullIf) IsDeterministic() bool { return true }
+
+func (n *NullIf) String() string {
+	return fmt.Sprintf("NULLIF(%v, %v)", n.A, n.B)
+}
+
 type Now struct{}
 
 func (n *Now) Eval(env *environment.Environment) (types.Value, error) {
@@ -797,3 +1008,33 @@ func (n *Now) Params() []expr.Expr { return nil }
 func (n *Now) String() string {
 	return "NOW()"
 }
+
+// UUID returns a random version 4 UUID, as defined by RFC 4122,
+// in its canonical text representation.
+type UUID struct{}
+
+func (u *UUID) Eval(env *environment.Environment) (types.Value, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	return types.NewTextValue(fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])), nil
+}
+
+func (u *UUID) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	_, ok := other.(*UUID)
+	return ok
+}
+
+func (u *UUID) Params() []expr.Expr { return nil }
+
+func (u *UUID) String() string {
+	return "UUID()"
+}
@@ -0,0 +1,31 @@
+package functions
+
+import (
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/types"
+)
+
+var conversionFunctions = Definitions{
+	"to_bool":      conversionDefinition("to_bool", types.BooleanValue),
+	"to_integer":   conversionDefinition("to_integer", types.IntegerValue),
+	"to_double":    conversionDefinition("to_double", types.DoubleValue),
+	"to_text":      conversionDefinition("to_text", types.TextValue),
+	"to_timestamp": conversionDefinition("to_timestamp", types.TimestampValue),
+}
+
+// conversionDefinition returns the definition of a function converting its argument
+// to the given type, using the same rules as CAST.
+// Unlike CAST, it returns NULL instead of an error if the value cannot be converted.
+func conversionDefinition(name string, t types.ValueType) *ScalarDefinition {
+	return &ScalarDefinition{
+		name:  name,
+		arity: 1,
+		callFn: func(args ...types.Value) (types.Value, error) {
+			v, err := document.CastAs(args[0], t)
+			if err != nil {
+				return types.NewNullValue(), nil
+			}
+			return v, nil
+		},
+	}
+}
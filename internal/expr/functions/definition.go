@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/expr"
 )
 
@@ -24,23 +25,68 @@ type Definitions map[string]Definition
 // Packages represent a table of SQL functions grouped by their packages
 type Packages map[string]Definitions
 
+// DefaultPackages returns a new table containing the builtin functions
+// and the functions of the math and strings packages.
+// The returned table can be extended with Register without affecting
+// other tables.
 func DefaultPackages() Packages {
 	return Packages{
-		"":        BuiltinDefinitions(),
-		"math":    MathFunctions(),
-		"strings": StringsDefinitions(),
+		"":        BuiltinDefinitions().clone(),
+		"math":    MathFunctions().clone(),
+		"strings": StringsDefinitions().clone(),
 	}
 }
 
+// Register adds a function definition to the given package of the table,
+// creating the package if it doesn't exist.
+// Functions registered in the "" package can be called without a package name.
+// It returns an error if the package already contains a function with the same name.
+func (t Packages) Register(pkg string, def Definition) error {
+	name := strings.ToLower(def.Name())
+	if name == "" {
+		return errors.New("missing function name")
+	}
+
+	fs, ok := t[pkg]
+	if !ok {
+		fs = make(Definitions)
+		t[pkg] = fs
+	}
+
+	if _, ok := fs[name]; ok {
+		if pkg == "" {
+			return errors.Errorf("function %q already exists", name)
+		}
+		return errors.Errorf("function %q.%q already exists", pkg, name)
+	}
+
+	fs[name] = def
+	return nil
+}
+
+func (d Definitions) clone() Definitions {
+	c := make(Definitions, len(d))
+	for name, def := range d {
+		c[name] = def
+	}
+	return c
+}
+
 // GetFunc return a function definition by its package and name.
 func (t Packages) GetFunc(pkg string, fname string) (Definition, error) {
 	fs, ok := t[pkg]
 	if !ok {
-		return nil, fmt.Errorf("no such package: %q", fname)
+		return nil, fmt.Errorf("no such package: %q", pkg)
 	}
 	def, ok := fs[strings.ToLower(fname)]
 	if !ok {
 		if pkg == "" {
+			if _, ok := lookupUserFunc(fname); ok {
+				return &userDefinition{name: strings.ToLower(fname)}, nil
+			}
+			if _, ok := lookupUserAggregate(fname); ok {
+				return &userDefinition{name: strings.ToLower(fname)}, nil
+			}
 			return nil, fmt.Errorf("no such function: %q", fname)
 		}
 		return nil, fmt.Errorf("no such function: %q.%q", pkg, fname)
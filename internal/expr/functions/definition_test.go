@@ -7,6 +7,7 @@ import (
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/expr/functions"
 	"github.com/genjidb/genji/internal/testutil/assert"
+	"github.com/genjidb/genji/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -57,3 +58,41 @@ func TestPackages(t *testing.T) {
 		require.Nil(t, def)
 	})
 }
+
+func TestPackagesRegister(t *testing.T) {
+	def := functions.NewScalarDefinition("Double", 1, func(args ...types.Value) (types.Value, error) {
+		return types.NewIntegerValue(types.As[int64](args[0]) * 2), nil
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		table := functions.DefaultPackages()
+
+		err := table.Register("", def)
+		assert.NoError(t, err)
+		got, err := table.GetFunc("", "double")
+		assert.NoError(t, err)
+		require.Equal(t, def, got)
+
+		err = table.Register("foo", def)
+		assert.NoError(t, err)
+		got, err = table.GetFunc("foo", "DOUBLE")
+		assert.NoError(t, err)
+		require.Equal(t, def, got)
+
+		// other tables are not affected
+		_, err = functions.DefaultPackages().GetFunc("", "double")
+		assert.Error(t, err)
+	})
+
+	t.Run("NOK duplicate", func(t *testing.T) {
+		table := functions.DefaultPackages()
+
+		err := table.Register("", def)
+		assert.NoError(t, err)
+		err = table.Register("", def)
+		assert.Error(t, err)
+
+		err = table.Register("math", functions.NewScalarDefinition("floor", 1, nil))
+		assert.Error(t, err)
+	})
+}
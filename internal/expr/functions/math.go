@@ -6,6 +6,8 @@ import (
 	"math/rand"
 
 	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/types"
 )
 
@@ -16,6 +18,8 @@ func MathFunctions() Definitions {
 
 var mathFunctions = Definitions{
 	"floor":  floor,
+	"ceil":   ceil,
+	"round":  round,
 	"abs":    abs,
 	"acos":   acos,
 	"acosh":  acosh,
@@ -25,6 +29,7 @@ var mathFunctions = Definitions{
 	"atan2":  atan2,
 	"random": random,
 	"sqrt":   sqrt,
+	"pow":    pow,
 }
 
 var floor = &ScalarDefinition{
@@ -34,7 +39,7 @@ var floor = &ScalarDefinition{
 		switch args[0].Type() {
 		case types.DoubleValue:
 			return types.NewDoubleValue(math.Floor(types.As[float64](args[0]))), nil
-		case types.IntegerValue:
+		case types.IntegerValue, types.NullValue:
 			return args[0], nil
 		default:
 			return nil, fmt.Errorf("floor(arg1) expects arg1 to be a number")
@@ -42,6 +47,36 @@ var floor = &ScalarDefinition{
 	},
 }
 
+var ceil = &ScalarDefinition{
+	name:  "ceil",
+	arity: 1,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		switch args[0].Type() {
+		case types.DoubleValue:
+			return types.NewDoubleValue(math.Ceil(types.As[float64](args[0]))), nil
+		case types.IntegerValue, types.NullValue:
+			return args[0], nil
+		default:
+			return nil, fmt.Errorf("ceil(arg1) expects arg1 to be a number")
+		}
+	},
+}
+
+var round = &definition{
+	name:  "round",
+	arity: variadicArity,
+	constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+		switch len(args) {
+		case 1:
+			return &Round{Expr: args[0]}, nil
+		case 2:
+			return &Round{Expr: args[0], Digits: args[1]}, nil
+		}
+
+		return nil, fmt.Errorf("round(arg1[, arg2]) takes 1 or 2 arguments, not %d", len(args))
+	},
+}
+
 var abs = &ScalarDefinition{
 	name:  "abs",
 	arity: 1,
@@ -175,6 +210,7 @@ var random = &ScalarDefinition{
 		randomNum := rand.Int63()
 		return types.NewIntegerValue(randomNum), nil
 	},
+	nonDeterministic: true,
 }
 
 var sqrt = &ScalarDefinition{
@@ -192,3 +228,115 @@ var sqrt = &ScalarDefinition{
 		return types.NewDoubleValue(res), nil
 	},
 }
+
+var pow = &ScalarDefinition{
+	name:  "pow",
+	arity: 2,
+	callFn: func(args ...types.Value) (types.Value, error) {
+		if args[0].Type() == types.NullValue || args[1].Type() == types.NullValue {
+			return types.NewNullValue(), nil
+		}
+		vA, err := document.CastAs(args[0], types.DoubleValue)
+		if err != nil {
+			return nil, fmt.Errorf("pow(arg1, arg2) expects arg1 to be a number")
+		}
+		vB, err := document.CastAs(args[1], types.DoubleValue)
+		if err != nil {
+			return nil, fmt.Errorf("pow(arg1, arg2) expects arg2 to be a number")
+		}
+		res := math.Pow(types.As[float64](vA), types.As[float64](vB))
+		if math.IsNaN(res) || math.IsInf(res, 0) {
+			return types.NewNullValue(), nil
+		}
+		return types.NewDoubleValue(res), nil
+	},
+}
+
+// Round rounds a number to the given number of decimal digits,
+// or to the nearest integer if no digits are specified.
+// Halfway values are rounded away from zero.
+type Round struct {
+	Expr   expr.Expr
+	Digits expr.Expr
+}
+
+func (r *Round) Eval(env *environment.Environment) (types.Value, error) {
+	v, err := r.Expr.Eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	var digits int64
+	if r.Digits != nil {
+		d, err := r.Digits.Eval(env)
+		if err != nil {
+			return nil, err
+		}
+		if d.Type() == types.NullValue {
+			return d, nil
+		}
+		if d.Type() != types.IntegerValue {
+			return nil, fmt.Errorf("round(arg1, arg2) expects arg2 to be an integer")
+		}
+		digits = types.As[int64](d)
+	}
+
+	switch v.Type() {
+	case types.NullValue:
+		return v, nil
+	case types.IntegerValue:
+		if digits >= 0 {
+			return v, nil
+		}
+		p := math.Pow10(int(-digits))
+		return document.CastAs(types.NewDoubleValue(math.Round(float64(types.As[int64](v))/p)*p), types.IntegerValue)
+	case types.DoubleValue:
+		if digits < 0 {
+			p := math.Pow10(int(-digits))
+			return types.NewDoubleValue(math.Round(types.As[float64](v)/p) * p), nil
+		}
+		p := math.Pow10(int(digits))
+		return types.NewDoubleValue(math.Round(types.As[float64](v)*p) / p), nil
+	}
+
+	return nil, fmt.Errorf("round(arg1) expects arg1 to be a number")
+}
+
+func (r *Round) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*Round)
+	if !ok {
+		return false
+	}
+
+	if !expr.Equal(r.Expr, o.Expr) {
+		return false
+	}
+
+	if r.Digits == nil || o.Digits == nil {
+		return r.Digits == nil && o.Digits == nil
+	}
+
+	return expr.Equal(r.Digits, o.Digits)
+}
+
+func (r *Round) Params() []expr.Expr {
+	if r.Digits == nil {
+		return []expr.Expr{r.Expr}
+	}
+
+	return []expr.Expr{r.Expr, r.Digits}
+}
+
+func (r *Round) IsDeterministic() bool { return true }
+
+func (r *Round) String() string {
+	if r.Digits == nil {
+		return fmt.Sprintf("ROUND(%v)", r.Expr)
+	}
+
+	return fmt.Sprintf("ROUND(%v, %v)", r.Expr, r.Digits)
+}
@@ -18,6 +18,8 @@ type ScalarDefinition struct {
 	name   string
 	arity  int
 	callFn func(...types.Value) (types.Value, error)
+	// If true, callFn may return different results for the same arguments.
+	nonDeterministic bool
 }
 
 func NewScalarDefinition(name string, arity int, callFn func(...types.Value) (types.Value, error)) *ScalarDefinition {
@@ -86,10 +88,41 @@ func (sf *ScalarFunction) evalParams(env *environment.Environment) ([]types.Valu
 
 // String returns a string represention of the function expression and its arguments.
 func (sf *ScalarFunction) String() string {
-	return fmt.Sprintf("%s(%v)", sf.def.name, sf.params)
+	args := make([]string, len(sf.params))
+	for i := range sf.params {
+		args[i] = fmt.Sprint(sf.params[i])
+	}
+	return fmt.Sprintf("%s(%s)", sf.def.name, strings.Join(args, ", "))
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (sf *ScalarFunction) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*ScalarFunction)
+	if !ok || sf.def != o.def || len(sf.params) != len(o.params) {
+		return false
+	}
+
+	for i := range sf.params {
+		if !expr.Equal(sf.params[i], o.params[i]) {
+			return false
+		}
+	}
+
+	return true
 }
 
 // Params return the function arguments.
 func (sf *ScalarFunction) Params() []expr.Expr {
 	return sf.params
 }
+
+// IsDeterministic returns whether the function always returns the same result
+// for the same arguments.
+func (sf *ScalarFunction) IsDeterministic() bool {
+	return !sf.def.nonDeterministic
+}
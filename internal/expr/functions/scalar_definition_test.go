@@ -57,5 +57,23 @@ func TestScalarFunctionDef(t *testing.T) {
 			_, err := def.Function(expr1, expr2)
 			assert.Error(t, err)
 		})
+
+		t.Run("String()", func(t *testing.T) {
+			fexpr, err := def.Function(expr1, expr2, expr3)
+			assert.NoError(t, err)
+			require.Equal(t, "foo(1 + 0, a, 6 / 2)", fexpr.String())
+		})
+
+		t.Run("IsEqual()", func(t *testing.T) {
+			fexpr1, err := def.Function(expr1, expr2, expr3)
+			assert.NoError(t, err)
+			fexpr2, err := def.Function(expr1, expr2, expr3)
+			assert.NoError(t, err)
+			fexpr3, err := def.Function(expr1, expr3, expr2)
+			assert.NoError(t, err)
+
+			require.True(t, expr.Equal(fexpr1, fexpr2))
+			require.False(t, expr.Equal(fexpr1, fexpr3))
+		})
 	})
 }
@@ -3,7 +3,9 @@ package functions
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/types"
@@ -45,6 +47,34 @@ var stringsFunctions = Definitions{
 			return &Trim{Expr: args, TrimFunc: strings.TrimRight, Name: "RTRIM"}, nil
 		},
 	},
+	"substr": &definition{
+		name:  "substr",
+		arity: variadicArity,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			switch len(args) {
+			case 2:
+				return &Substr{Expr: args[0], Start: args[1]}, nil
+			case 3:
+				return &Substr{Expr: args[0], Start: args[1], Length: args[2]}, nil
+			}
+
+			return nil, fmt.Errorf("substr(arg1, arg2[, arg3]) takes 2 or 3 arguments, not %d", len(args))
+		},
+	},
+	"length": &definition{
+		name:  "length",
+		arity: 1,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &Length{Expr: args[0]}, nil
+		},
+	},
+	"concat": &definition{
+		name:  "concat",
+		arity: variadicArity,
+		constructorFn: func(args ...expr.Expr) (expr.Function, error) {
+			return &Concat{Exprs: args}, nil
+		},
+	},
 }
 
 func StringsDefinitions() Definitions {
@@ -87,6 +117,8 @@ func (s *Lower) IsEqual(other expr.Expr) bool {
 
 func (s *Lower) Params() []expr.Expr { return []expr.Expr{s.Expr} }
 
+func (s *Lower) IsDeterministic() bool { return true }
+
 func (s *Lower) String() string {
 	return fmt.Sprintf("LOWER(%v)", s.Expr)
 }
@@ -127,6 +159,8 @@ func (s *Upper) IsEqual(other expr.Expr) bool {
 
 func (s *Upper) Params() []expr.Expr { return []expr.Expr{s.Expr} }
 
+func (s *Upper) IsDeterministic() bool { return true }
+
 func (s *Upper) String() string {
 	return fmt.Sprintf("UPPER(%v)", s.Expr)
 }
@@ -144,7 +178,7 @@ type Trim struct {
 type TrimFunc func(string, string) string
 
 func (s *Trim) Eval(env *environment.Environment) (types.Value, error) {
-	if len(s.Expr) > 2 {
+	if len(s.Expr) == 0 || len(s.Expr) > 2 {
 		return nil, fmt.Errorf("misuse of string function %v()", s.Name)
 	}
 
@@ -200,9 +234,246 @@ func (s *Trim) Params() []expr.Expr {
 	return s.Expr
 }
 
+func (s *Trim) IsDeterministic() bool { return true }
+
 func (s *Trim) String() string {
 	if len(s.Expr) == 1 {
 		return fmt.Sprintf("%v(%v)", s.Name, s.Expr[0])
 	}
 	return fmt.Sprintf("%v(%v, %v)", s.Name, s.Expr[0], s.Expr[1])
 }
+
+// Substr is the SUBSTR function.
+// It returns the portion of a string starting at the given position,
+// counted in characters from 1. A negative position is counted from the end
+// of the string. If a length is given, at most length characters are returned.
+// It returns NULL if any argument is NULL or if the first argument is not a string.
+type Substr struct {
+	Expr   expr.Expr
+	Start  expr.Expr
+	Length expr.Expr
+}
+
+func (s *Substr) Eval(env *environment.Environment) (types.Value, error) {
+	val, err := s.Expr.Eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	start, err := s.Start.Eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	if val.Type() != types.TextValue || start.Type() == types.NullValue {
+		return types.NewNullValue(), nil
+	}
+	if start.Type() != types.IntegerValue {
+		return nil, fmt.Errorf("substr(arg1, arg2) expects arg2 to be an integer")
+	}
+
+	runes := []rune(types.As[string](val))
+	n := int64(len(runes))
+	if n == 0 {
+		return types.NewTextValue(""), nil
+	}
+
+	// from is the 0-based position of the first character,
+	// it can be negative if the position is before the start of the string
+	from := types.As[int64](start)
+	if from >= 0 {
+		from--
+	} else {
+		from += n
+	}
+
+	to := n
+	if s.Length != nil {
+		l, err := s.Length.Eval(env)
+		if err != nil {
+			return nil, err
+		}
+		if l.Type() == types.NullValue {
+			return l, nil
+		}
+		if l.Type() != types.IntegerValue {
+			return nil, fmt.Errorf("substr(arg1, arg2, arg3) expects arg3 to be an integer")
+		}
+		length := types.As[int64](l)
+		if length < 0 {
+			return nil, fmt.Errorf("substr(arg1, arg2, arg3) expects arg3 to be positive")
+		}
+
+		// characters before the start of the string are counted in the length
+		if from < 0 {
+			if length <= -from {
+				return types.NewTextValue(""), nil
+			}
+			length += from
+			from = 0
+		}
+		if length < n-from {
+			to = from + length
+		}
+	}
+
+	if from < 0 {
+		from = 0
+	}
+	if from >= to {
+		return types.NewTextValue(""), nil
+	}
+
+	return types.NewTextValue(string(runes[from:to])), nil
+}
+
+func (s *Substr) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*Substr)
+	if !ok {
+		return false
+	}
+
+	if !expr.Equal(s.Expr, o.Expr) || !expr.Equal(s.Start, o.Start) {
+		return false
+	}
+
+	if s.Length == nil || o.Length == nil {
+		return s.Length == nil && o.Length == nil
+	}
+
+	return expr.Equal(s.Length, o.Length)
+}
+
+func (s *Substr) Params() []expr.Expr {
+	if s.Length == nil {
+		return []expr.Expr{s.Expr, s.Start}
+	}
+
+	return []expr.Expr{s.Expr, s.Start, s.Length}
+}
+
+func (s *Substr) IsDeterministic() bool { return true }
+
+func (s *Substr) String() string {
+	if s.Length == nil {
+		return fmt.Sprintf("SUBSTR(%v, %v)", s.Expr, s.Start)
+	}
+
+	return fmt.Sprintf("SUBSTR(%v, %v, %v)", s.Expr, s.Start, s.Length)
+}
+
+// Length is the LENGTH function.
+// It returns the number of characters of a string
+// or the number of bytes of a blob.
+// For other types it returns NULL.
+type Length struct {
+	Expr expr.Expr
+}
+
+func (s *Length) Eval(env *environment.Environment) (types.Value, error) {
+	val, err := s.Expr.Eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch val.Type() {
+	case types.TextValue:
+		return types.NewIntegerValue(int64(utf8.RuneCountInString(types.As[string](val)))), nil
+	case types.BlobValue:
+		return types.NewIntegerValue(int64(len(types.As[[]byte](val)))), nil
+	}
+
+	return types.NewNullValue(), nil
+}
+
+func (s *Length) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*Length)
+	if !ok {
+		return false
+	}
+
+	return expr.Equal(s.Expr, o.Expr)
+}
+
+func (s *Length) Params() []expr.Expr { return []expr.Expr{s.Expr} }
+
+func (s *Length) IsDeterministic() bool { return true }
+
+func (s *Length) String() string {
+	return fmt.Sprintf("LENGTH(%v)", s.Expr)
+}
+
+// Concat is the CONCAT function.
+// It returns the concatenation of the text representation of its arguments.
+// NULL arguments are ignored.
+type Concat struct {
+	Exprs []expr.Expr
+}
+
+func (s *Concat) Eval(env *environment.Environment) (types.Value, error) {
+	var sb strings.Builder
+
+	for _, e := range s.Exprs {
+		val, err := e.Eval(env)
+		if err != nil {
+			return nil, err
+		}
+
+		if val.Type() == types.NullValue {
+			continue
+		}
+
+		val, err = document.CastAsText(val)
+		if err != nil {
+			return nil, err
+		}
+
+		sb.WriteString(types.As[string](val))
+	}
+
+	return types.NewTextValue(sb.String()), nil
+}
+
+func (s *Concat) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*Concat)
+	if !ok {
+		return false
+	}
+
+	if len(s.Exprs) != len(o.Exprs) {
+		return false
+	}
+
+	for i := range s.Exprs {
+		if !expr.Equal(s.Exprs[i], o.Exprs[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *Concat) Params() []expr.Expr { return s.Exprs }
+
+func (s *Concat) IsDeterministic() bool { return true }
+
+func (s *Concat) String() string {
+	args := make([]string, len(s.Exprs))
+	for i := range s.Exprs {
+		args[i] = fmt.Sprint(s.Exprs[i])
+	}
+
+	return fmt.Sprintf("CONCAT(%s)", strings.Join(args, ", "))
+}
@@ -34,6 +34,115 @@
 > typeof(NULL)
 'null'
 
+-- test: exists
+! exists()
+
+! exists(1)
+
+> exists(a)
+false
+
 -- test: now
 > typeof(now())
 'timestamp'
+
+-- test: uuid
+! uuid(1)
+
+> typeof(uuid())
+'text'
+
+> uuid() = uuid()
+false
+
+-- test: array_length
+! array_length()
+
+> array_length([])
+0
+
+> array_length([1, 2, 3])
+3
+
+> array_length([1, [2, 3], {a: 4}])
+3
+
+> array_length(NULL)
+NULL
+
+! array_length('hello')
+'ARRAY_LENGTH() expects an array, got text'
+
+! array_length(1)
+'ARRAY_LENGTH() expects an array, got integer'
+
+! array_length({a: 1})
+'ARRAY_LENGTH() expects an array, got document'
+
+-- test: to_bool
+> to_bool(1)
+true
+> to_bool(0)
+false
+> to_bool('true')
+true
+> to_bool('foo')
+NULL
+> to_bool(NULL)
+NULL
+
+-- test: to_integer
+> to_integer('10')
+10
+> to_integer(10.7)
+10
+> to_integer(true)
+1
+> to_integer('foo')
+NULL
+> to_integer([1])
+NULL
+> to_integer(NULL)
+NULL
+
+-- test: to_double
+> to_double(10)
+10.0
+> to_double('1.5')
+1.5
+> to_double('foo')
+NULL
+> to_double(NULL)
+NULL
+
+-- test: to_text
+> to_text(10)
+'10'
+> to_text(1.5)
+'1.5'
+> to_text(true)
+'true'
+> to_text([1, 2])
+'[1, 2]'
+> to_text(NULL)
+NULL
+
+-- test: to_timestamp
+> typeof(to_timestamp('2021-01-01'))
+'timestamp'
+> to_timestamp('foo')
+NULL
+> to_timestamp(10)
+NULL
+> to_timestamp(NULL)
+NULL
+
+-- test: builtin aliases
+> substr('hello', 2, 3)
+'ell'
+> length('hello')
+5
+> concat('foo', 'bar')
+'foobar'
+> pow(2, 10)
+1024.0
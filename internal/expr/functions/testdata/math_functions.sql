@@ -3,6 +3,10 @@
 2.0
 > math.floor(2)
 2
+> math.floor(-2.3)
+-3.0
+> math.floor(NULL)
+NULL
 ! math.floor('a')
 'floor(arg1) expects arg1 to be a number'
 
@@ -134,4 +138,76 @@ NULL
 > math.sqrt(1.1)
 1.0488088481701516
 > math.sqrt('foo')
-NULL
\ No newline at end of file
+NULL
+
+-- test: math.ceil
+> math.ceil(NULL)
+NULL
+> math.ceil(2.3)
+3.0
+> math.ceil(-2.3)
+-2.0
+> math.ceil(2)
+2
+> math.ceil(-2)
+-2
+! math.ceil('a')
+'ceil(arg1) expects arg1 to be a number'
+
+-- test: math.round
+> math.round(NULL)
+NULL
+> math.round(2.4)
+2.0
+> math.round(2.5)
+3.0
+> math.round(-2.5)
+-3.0
+> math.round(-2.4)
+-2.0
+> math.round(2)
+2
+> math.round(-2)
+-2
+> math.round(2.346, 2)
+2.35
+> math.round(-2.345, 1)
+-2.3
+> math.round(2.5, 0)
+3.0
+> math.round(1234.5, -2)
+1200.0
+> math.round(1234, -2)
+1200
+> math.round(1250, -2)
+1300
+> math.round(2, 2)
+2
+> math.round(2.5, NULL)
+NULL
+! math.round('a')
+'round(arg1) expects arg1 to be a number'
+! math.round(2.5, 1.5)
+'round(arg1, arg2) expects arg2 to be an integer'
+
+-- test: math.pow
+> math.pow(2, 3)
+8.0
+> math.pow(2.5, 2)
+6.25
+> math.pow(4, 0.5)
+2.0
+> math.pow(2, -1)
+0.5
+> math.pow('2', 2)
+4.0
+> math.pow(NULL, 2)
+NULL
+> math.pow(2, NULL)
+NULL
+> math.pow(-8, 0.5)
+NULL
+! math.pow('a', 2)
+'pow(arg1, arg2) expects arg1 to be a number'
+! math.pow(2, true)
+'pow(arg1, arg2) expects arg2 to be a number'
@@ -0,0 +1,63 @@
+-- test: strings.substr
+> strings.substr('hello', 2)
+'ello'
+> strings.substr('hello', 2, 3)
+'ell'
+> strings.substr('hello', 1, 0)
+''
+> strings.substr('hello', -3)
+'llo'
+> strings.substr('hello', -3, 2)
+'ll'
+> strings.substr('hello', 0, 2)
+'h'
+> strings.substr('hello', -10, 7)
+'he'
+> strings.substr('hello', 10)
+''
+> strings.substr('héllo', 2, 2)
+'él'
+> strings.substr(NULL, 1)
+NULL
+> strings.substr('hello', NULL)
+NULL
+> strings.substr('hello', 1, NULL)
+NULL
+> strings.substr(1, 1)
+NULL
+! strings.substr('hello')
+'substr(arg1, arg2[, arg3]) takes 2 or 3 arguments, not 1'
+! strings.substr('hello', 'a')
+'substr(arg1, arg2) expects arg2 to be an integer'
+! strings.substr('hello', 1, -1)
+'substr(arg1, arg2, arg3) expects arg3 to be positive'
+
+-- test: strings.length
+> strings.length('hello')
+5
+> strings.length('héllo')
+5
+> strings.length('')
+0
+> strings.length('\xAABB')
+2
+> strings.length(NULL)
+NULL
+> strings.length(10)
+NULL
+> strings.length([1, 2])
+NULL
+
+-- test: strings.concat
+> strings.concat('foo')
+'foo'
+> strings.concat('foo', 'bar', 'baz')
+'foobarbaz'
+> strings.concat('a', 1, 2.5, true)
+'a12.5true'
+> strings.concat('a', NULL, 'b')
+'ab'
+> strings.concat(NULL)
+''
+! strings.concat()
+'concat() requires at least one argument'
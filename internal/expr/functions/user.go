@@ -0,0 +1,326 @@
+package functions
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/sql/scanner"
+	"github.com/genjidb/genji/types"
+)
+
+// UserFunc is the signature of functions registered with RegisterFunc.
+type UserFunc func(args []types.Value) (types.Value, error)
+
+// UserAggregate defines an aggregate function registered with RegisterAggregate.
+// Every group of documents is aggregated into its own state:
+// Init returns the initial state of a group, Step is called with
+// the evaluated arguments of the call for every document of the group
+// and returns the new state, and Finalize returns the result of the group
+// from its last state.
+// Init may be nil, in which case the initial state is nil.
+type UserAggregate struct {
+	Init     func() any
+	Step     func(state any, args []types.Value) (any, error)
+	Finalize func(state any) (types.Value, error)
+}
+
+var userFunctions = struct {
+	sync.RWMutex
+	m    map[string]UserFunc
+	aggs map[string]*UserAggregate
+}{m: make(map[string]UserFunc), aggs: make(map[string]*UserAggregate)}
+
+// RegisterFunc registers a user-defined scalar function that can be called
+// from SQL without a package name.
+// It returns an error if name is already used by a builtin function
+// or is a reserved keyword.
+// Registering a function with the name of another user-defined function
+// or aggregate replaces it.
+func RegisterFunc(name string, fn UserFunc) error {
+	if fn == nil {
+		return errors.Errorf("missing implementation of function %q", name)
+	}
+
+	name, err := userFuncName(name)
+	if err != nil {
+		return err
+	}
+
+	userFunctions.Lock()
+	userFunctions.m[name] = fn
+	delete(userFunctions.aggs, name)
+	userFunctions.Unlock()
+
+	return nil
+}
+
+// RegisterAggregate registers a user-defined aggregate function that can be called
+// from SQL without a package name, e.g. in queries using GROUP BY.
+// It returns an error if name is already used by a builtin function
+// or is a reserved keyword.
+// Registering an aggregate with the name of another user-defined function
+// or aggregate replaces it.
+func RegisterAggregate(name string, agg UserAggregate) error {
+	if agg.Step == nil || agg.Finalize == nil {
+		return errors.Errorf("missing implementation of aggregate %q", name)
+	}
+
+	name, err := userFuncName(name)
+	if err != nil {
+		return err
+	}
+
+	userFunctions.Lock()
+	userFunctions.aggs[name] = &agg
+	delete(userFunctions.m, name)
+	userFunctions.Unlock()
+
+	return nil
+}
+
+// userFuncName validates the name of a user-defined function and returns
+// its lowercase version. Names used by builtin functions and reserved keywords
+// are not allowed, the latter wouldn't be parsed as function calls.
+func userFuncName(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("missing function name")
+	}
+
+	name = strings.ToLower(name)
+	if _, ok := builtinFunctions[name]; ok {
+		return "", errors.Errorf("cannot redefine builtin function %q", name)
+	}
+	if scanner.IsKeyword(name) {
+		return "", errors.Errorf("cannot use reserved keyword %q as function name", name)
+	}
+
+	return name, nil
+}
+
+func lookupUserFunc(name string) (UserFunc, bool) {
+	userFunctions.RLock()
+	fn, ok := userFunctions.m[strings.ToLower(name)]
+	userFunctions.RUnlock()
+	return fn, ok
+}
+
+func lookupUserAggregate(name string) (*UserAggregate, bool) {
+	userFunctions.RLock()
+	agg, ok := userFunctions.aggs[strings.ToLower(name)]
+	userFunctions.RUnlock()
+	return agg, ok
+}
+
+// userDefinition is the definition of a function registered with RegisterFunc
+// or RegisterAggregate.
+// The function accepts any number of arguments.
+type userDefinition struct {
+	name string
+}
+
+func (d *userDefinition) Name() string {
+	return d.name
+}
+
+func (d *userDefinition) String() string {
+	return fmt.Sprintf("%s(...)", d.name)
+}
+
+func (d *userDefinition) Function(args ...expr.Expr) (expr.Function, error) {
+	if agg, ok := lookupUserAggregate(d.name); ok {
+		return &UserAggregateFunction{Name: d.name, Args: args, Agg: agg}, nil
+	}
+
+	return &UserFunction{Name: d.name, Args: args}, nil
+}
+
+func (d *userDefinition) Arity() int {
+	return variadicArity
+}
+
+// UserFunction calls a function registered with RegisterFunc.
+// The function is looked up by name every time the expression is evaluated.
+type UserFunction struct {
+	Name string
+	Args []expr.Expr
+}
+
+func (f *UserFunction) Eval(env *environment.Environment) (types.Value, error) {
+	fn, ok := lookupUserFunc(f.Name)
+	if !ok {
+		return nil, fmt.Errorf("no such function: %q", f.Name)
+	}
+
+	args := make([]types.Value, 0, len(f.Args))
+	for _, a := range f.Args {
+		v, err := a.Eval(env)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+
+	v, err := fn(args)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return types.NewNullValue(), nil
+	}
+
+	return v, nil
+}
+
+func (f *UserFunction) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*UserFunction)
+	if !ok {
+		return false
+	}
+
+	if f.Name != o.Name || len(f.Args) != len(o.Args) {
+		return false
+	}
+
+	for i := range f.Args {
+		if !expr.Equal(f.Args[i], o.Args[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f *UserFunction) Params() []expr.Expr { return f.Args }
+
+func (f *UserFunction) String() string {
+	args := make([]string, len(f.Args))
+	for i, a := range f.Args {
+		args[i] = a.String()
+	}
+
+	return fmt.Sprintf("%s(%s)", f.Name, strings.Join(args, ", "))
+}
+
+var _ expr.AggregatorBuilder = (*UserAggregateFunction)(nil)
+
+// UserAggregateFunction calls an aggregate registered with RegisterAggregate.
+// The aggregate is looked up when the expression is parsed.
+type UserAggregateFunction struct {
+	Name string
+	Args []expr.Expr
+	Agg  *UserAggregate
+}
+
+// Eval returns the result of the aggregation, stored in the document
+// created by the aggregation.
+func (f *UserAggregateFunction) Eval(env *environment.Environment) (types.Value, error) {
+	d, ok := env.GetDocument()
+	if !ok {
+		return nil, fmt.Errorf("misuse of aggregation function %s()", f.Name)
+	}
+
+	return d.GetByField(f.String())
+}
+
+func (f *UserAggregateFunction) IsEqual(other expr.Expr) bool {
+	if other == nil {
+		return false
+	}
+
+	o, ok := other.(*UserAggregateFunction)
+	if !ok {
+		return false
+	}
+
+	if f.Name != o.Name || len(f.Args) != len(o.Args) {
+		return false
+	}
+
+	for i := range f.Args {
+		if !expr.Equal(f.Args[i], o.Args[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f *UserAggregateFunction) Params() []expr.Expr { return f.Args }
+
+func (f *UserAggregateFunction) String() string {
+	args := make([]string, len(f.Args))
+	for i, a := range f.Args {
+		args[i] = a.String()
+	}
+
+	return fmt.Sprintf("%s(%s)", f.Name, strings.Join(args, ", "))
+}
+
+// Aggregator returns a UserAggregator. It implements the AggregatorBuilder interface.
+func (f *UserAggregateFunction) Aggregator() expr.Aggregator {
+	var state any
+	if f.Agg.Init != nil {
+		state = f.Agg.Init()
+	}
+
+	return &UserAggregator{
+		Fn:    f,
+		State: state,
+	}
+}
+
+// UserAggregator aggregates a group of documents using the callbacks
+// of a registered aggregate.
+type UserAggregator struct {
+	Fn    *UserAggregateFunction
+	State any
+}
+
+// Aggregate evaluates the arguments of the call and passes them to the Step callback.
+// Missing fields are passed as NULL.
+func (a *UserAggregator) Aggregate(env *environment.Environment) error {
+	args := make([]types.Value, 0, len(a.Fn.Args))
+	for _, e := range a.Fn.Args {
+		v, err := e.Eval(env)
+		if errors.Is(err, types.ErrFieldNotFound) {
+			v, err = types.NewNullValue(), nil
+		}
+		if err != nil {
+			return err
+		}
+		args = append(args, v)
+	}
+
+	state, err := a.Fn.Agg.Step(a.State, args)
+	if err != nil {
+		return err
+	}
+	a.State = state
+
+	return nil
+}
+
+// Eval returns the result of the Finalize callback.
+func (a *UserAggregator) Eval(_ *environment.Environment) (types.Value, error) {
+	v, err := a.Fn.Agg.Finalize(a.State)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return types.NewNullValue(), nil
+	}
+
+	return v, nil
+}
+
+func (a *UserAggregator) String() string {
+	return a.Fn.String()
+}
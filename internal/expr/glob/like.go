@@ -4,6 +4,7 @@
 package glob
 
 import (
+	"strings"
 	"unicode"
 	"unicode/utf8"
 )
@@ -60,7 +61,59 @@ func equalFold(sr, tr rune) bool {
 // or more characters). They can be escaped by '\' (escape character).
 //
 // MatchLike requires pattern to match whole string, not just a substring.
+// Characters are compared case-insensitively.
 func MatchLike(pattern, s string) bool {
+	return matchLike(pattern, s, equalFold)
+}
+
+// MatchLikeCaseSensitive is like MatchLike but compares characters case-sensitively.
+func MatchLikeCaseSensitive(pattern, s string) bool {
+	return matchLike(pattern, s, func(sr, tr rune) bool { return sr == tr })
+}
+
+// LikePrefix returns the literal prefix of the SQL LIKE-style glob pattern,
+// i.e. the characters preceding its first wildcard, without escape characters.
+// Every string matching the pattern starts with that prefix.
+//
+// If caseSensitive is false, the prefix stops before the first character
+// that has other case variants, since matching strings can start with any of them.
+func LikePrefix(pattern string, caseSensitive bool) string {
+	var prefix strings.Builder
+	var prevEscape bool
+
+	for len(pattern) != 0 {
+		r, size := utf8.DecodeRuneInString(pattern)
+		// invalid characters are compared byte by byte
+		if r == utf8.RuneError && size == 1 {
+			break
+		}
+
+		c := pattern[:size]
+		pattern = pattern[size:]
+
+		if !prevEscape {
+			if r == matchAll || r == matchOne {
+				break
+			}
+
+			if r == matchEsc {
+				prevEscape = true
+				continue
+			}
+		}
+		prevEscape = false
+
+		if !caseSensitive && unicode.SimpleFold(r) != r {
+			break
+		}
+
+		prefix.WriteString(c)
+	}
+
+	return prefix.String()
+}
+
+func matchLike(pattern, s string, equal func(sr, tr rune) bool) bool {
 	var prevEscape bool
 
 	var w, t string // backtracking state
@@ -144,7 +197,7 @@ func MatchLike(pattern, s string) bool {
 
 			var r rune
 			r, s = readRune(s)
-			if !equalFold(p, r) {
+			if !equal(p, r) {
 				goto backtrack
 			}
 		}
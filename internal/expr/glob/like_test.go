@@ -200,3 +200,62 @@ func TestMatchLike(t *testing.T) {
 		}
 	}
 }
+
+func TestMatchLikeCaseSensitive(t *testing.T) {
+	tests := []struct {
+		s, pattern string
+		want       bool
+	}{
+		{"bLah", "bL_h", true},
+		{"bLaH", "_Lah", false},
+		{"bLaH", "_LaH", true},
+		{"Hello", "hello", false},
+		{"Hello", "H%", true},
+		{"Hello", "h%", false},
+		{"ÄÖ", "äö", false},
+		{"ÄÖ", "Ä%", true},
+		{"a_c", "a\\_c", true},
+		{"A_c", "a\\_c", false},
+	}
+
+	for _, test := range tests {
+		if got := MatchLikeCaseSensitive(test.pattern, test.s); got != test.want {
+			t.Errorf(
+				"MatchLikeCaseSensitive(%#v, %#v): expected %#v, got %#v",
+				test.pattern, test.s, test.want, got,
+			)
+		}
+	}
+}
+
+func TestLikePrefix(t *testing.T) {
+	tests := []struct {
+		pattern       string
+		caseSensitive bool
+		want          string
+	}{
+		{"", true, ""},
+		{"%", true, ""},
+		{"abc", true, "abc"},
+		{"abc%", true, "abc"},
+		{"abc_d%", true, "abc"},
+		{"aBc%def", true, "aBc"},
+		{"a\\%c%", true, "a%c"},
+		{"a\\\\%", true, "a\\"},
+		{"ÄÖ%", true, "ÄÖ"},
+		{"abc%", false, ""},
+		{"123abc%", false, "123"},
+		{"12-3\\_4%", false, "12-3_4"},
+		{"1Ä%", false, "1"},
+		{"12\xff%", true, "12"},
+	}
+
+	for _, test := range tests {
+		if got := LikePrefix(test.pattern, test.caseSensitive); got != test.want {
+			t.Errorf(
+				"LikePrefix(%#v, %#v): expected %#v, got %#v",
+				test.pattern, test.caseSensitive, test.want, got,
+			)
+		}
+	}
+}
@@ -9,17 +9,31 @@ import (
 	"github.com/genjidb/genji/types"
 )
 
-func like(pattern, text string) bool {
+func like(pattern, text string, caseSensitive bool) bool {
+	if caseSensitive {
+		return glob.MatchLikeCaseSensitive(pattern, text)
+	}
+
 	return glob.MatchLike(pattern, text)
 }
 
 type LikeOperator struct {
 	*simpleOperator
+
+	// CaseSensitive disables the default
+	// case-insensitive matching.
+	CaseSensitive bool
 }
 
 // Like creates an expression that evaluates to the result of a LIKE b.
 func Like(a, b Expr) Expr {
-	return &LikeOperator{&simpleOperator{a, b, scanner.LIKE}}
+	return &LikeOperator{simpleOperator: &simpleOperator{a, b, scanner.LIKE}}
+}
+
+// CaseSensitiveLike creates an expression that evaluates to the result of a LIKE b,
+// comparing characters case-sensitively.
+func CaseSensitiveLike(a, b Expr) Expr {
+	return &LikeOperator{simpleOperator: &simpleOperator{a, b, scanner.LIKE}, CaseSensitive: true}
 }
 
 func (op *LikeOperator) Eval(env *environment.Environment) (types.Value, error) {
@@ -28,7 +42,7 @@ func (op *LikeOperator) Eval(env *environment.Environment) (types.Value, error)
 			return NullLiteral, nil
 		}
 
-		if like(types.As[string](b), types.As[string](a)) {
+		if like(types.As[string](b), types.As[string](a), op.CaseSensitive) {
 			return TrueLiteral, nil
 		}
 
@@ -36,13 +50,34 @@ func (op *LikeOperator) Eval(env *environment.Environment) (types.Value, error)
 	})
 }
 
+func (op *LikeOperator) IsEqual(other Expr) bool {
+	if !op.simpleOperator.IsEqual(other) {
+		return false
+	}
+
+	switch t := other.(type) {
+	case *LikeOperator:
+		return op.CaseSensitive == t.CaseSensitive
+	case *NotLikeOperator:
+		return op.CaseSensitive == t.CaseSensitive
+	}
+
+	return false
+}
+
 type NotLikeOperator struct {
 	*LikeOperator
 }
 
 // NotLike creates an expression that evaluates to the result of a NOT LIKE b.
 func NotLike(a, b Expr) Expr {
-	return &NotLikeOperator{&LikeOperator{&simpleOperator{a, b, scanner.NLIKE}}}
+	return &NotLikeOperator{&LikeOperator{simpleOperator: &simpleOperator{a, b, scanner.NLIKE}}}
+}
+
+// CaseSensitiveNotLike creates an expression that evaluates to the result of a NOT LIKE b,
+// comparing characters case-sensitively.
+func CaseSensitiveNotLike(a, b Expr) Expr {
+	return &NotLikeOperator{&LikeOperator{simpleOperator: &simpleOperator{a, b, scanner.NLIKE}, CaseSensitive: true}}
 }
 
 func (op *NotLikeOperator) Eval(env *environment.Environment) (types.Value, error) {
@@ -19,7 +19,7 @@ func And(a, b Expr) Expr {
 }
 
 // Eval implements the Expr interface. It evaluates a and b and returns true if both evaluate
-// to true.
+// to true. If a is falsy, b is not evaluated.
 func (op *AndOp) Eval(env *environment.Environment) (types.Value, error) {
 	s, err := op.a.Eval(env)
 	if err != nil {
@@ -53,7 +53,7 @@ func Or(a, b Expr) Expr {
 }
 
 // Eval implements the Expr interface. It evaluates a and b and returns true if a or b evalutate
-// to true.
+// to true. If a is truthy, b is not evaluated.
 func (op *OrOp) Eval(env *environment.Environment) (types.Value, error) {
 	s, err := op.a.Eval(env)
 	if err != nil {
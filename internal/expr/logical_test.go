@@ -0,0 +1,65 @@
+package expr_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/types"
+	"github.com/stretchr/testify/require"
+)
+
+// failingExpr is an expression that fails the test if it gets evaluated.
+type failingExpr struct {
+	t *testing.T
+}
+
+func (e failingExpr) Eval(*environment.Environment) (types.Value, error) {
+	e.t.Fatal("right operand must not be evaluated")
+	return nil, errors.New("unreachable")
+}
+
+func (e failingExpr) String() string { return "failing()" }
+
+// erroringExpr is an expression that always returns an error.
+type erroringExpr struct{}
+
+func (e erroringExpr) Eval(*environment.Environment) (types.Value, error) {
+	return nil, errors.New("boom")
+}
+
+func (e erroringExpr) String() string { return "erroring()" }
+
+func TestLogicalShortCircuit(t *testing.T) {
+	trueExpr := expr.LiteralValue{Value: types.NewBoolValue(true)}
+	falseExpr := expr.LiteralValue{Value: types.NewBoolValue(false)}
+	nullExpr := expr.LiteralValue{Value: types.NewNullValue()}
+
+	tests := []struct {
+		name string
+		e    expr.Expr
+		res  types.Value
+	}{
+		{"false AND x", expr.And(falseExpr, failingExpr{t}), expr.FalseLiteral},
+		{"NULL AND x", expr.And(nullExpr, failingExpr{t}), expr.FalseLiteral},
+		{"true OR x", expr.Or(trueExpr, failingExpr{t}), expr.TrueLiteral},
+		{"(false AND x) OR true", expr.Or(expr.And(falseExpr, failingExpr{t}), trueExpr), expr.TrueLiteral},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v, err := test.e.Eval(envWithDoc)
+			require.NoError(t, err)
+			require.Equal(t, test.res, v)
+		})
+	}
+
+	t.Run("right operand errors are returned when evaluated", func(t *testing.T) {
+		_, err := expr.And(trueExpr, erroringExpr{}).Eval(envWithDoc)
+		require.Error(t, err)
+
+		_, err = expr.Or(falseExpr, erroringExpr{}).Eval(envWithDoc)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,132 @@
+package expr
+
+import (
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/types"
+)
+
+// MemoizedExpr is an expression whose result is cached in the environment
+// the first time it is evaluated against a document.
+// Any other evaluation of an identical expression against the same document
+// returns the cached value instead of evaluating the expression again.
+type MemoizedExpr struct {
+	Expr
+
+	key string
+}
+
+// Memoize returns an expression that caches the result of e
+// for the current document of the environment.
+// Two memoized expressions share the same cached value
+// if they have the same string representation.
+func Memoize(e Expr) *MemoizedExpr {
+	if m, ok := e.(*MemoizedExpr); ok {
+		return m
+	}
+
+	return &MemoizedExpr{Expr: e, key: e.String()}
+}
+
+// Eval returns the cached value of the expression if any,
+// otherwise it evaluates the underlying expression and caches the result.
+func (m *MemoizedExpr) Eval(env *environment.Environment) (types.Value, error) {
+	if v, ok := env.GetCachedValue(m.key); ok {
+		return v, nil
+	}
+
+	v, err := m.Expr.Eval(env)
+	if err != nil {
+		return v, err
+	}
+
+	env.SetCachedValue(m.key, v)
+	return v, nil
+}
+
+// IsEqual compares the underlying expression with the other expression and returns
+// true if they are equal.
+func (m *MemoizedExpr) IsEqual(other Expr) bool {
+	if o, ok := other.(*MemoizedExpr); ok {
+		other = o.Expr
+	}
+
+	return Equal(m.Expr, other)
+}
+
+func (m *MemoizedExpr) String() string {
+	return m.Expr.String()
+}
+
+// MemoizeDuplicates memoizes every sub-expression that appears more than once
+// across the given expressions, so that it is evaluated only once per document.
+// Operators are modified in place, other expressions are replaced in the returned list.
+// Cheap expressions, like literals, paths and params, are never memoized, and neither are
+// expressions whose result might change between two evaluations, like NEXT VALUE FOR
+// or calls to functions that are not deterministic, see DeterministicFunction.
+// Expressions containing params are not memoized either: all positional params
+// have the same string representation.
+func MemoizeDuplicates(exprs ...Expr) []Expr {
+	counts := make(map[string]int)
+	for _, e := range exprs {
+		Walk(e, func(e Expr) bool {
+			if isMemoizable(e) {
+				counts[e.String()]++
+			}
+			return true
+		})
+	}
+
+	for i, e := range exprs {
+		exprs[i] = memoizeDuplicates(e, counts)
+	}
+
+	return exprs
+}
+
+func memoizeDuplicates(e Expr, counts map[string]int) Expr {
+	switch t := e.(type) {
+	case *MemoizedExpr:
+		return t
+	case Operator:
+		if lh := t.LeftHand(); lh != nil {
+			t.SetLeftHandExpr(memoizeDuplicates(lh, counts))
+		}
+		if rh := t.RightHand(); rh != nil {
+			t.SetRightHandExpr(memoizeDuplicates(rh, counts))
+		}
+	case Parentheses:
+		t.E = memoizeDuplicates(t.E, counts)
+		e = t
+	case *NamedExpr:
+		t.Expr = memoizeDuplicates(t.Expr, counts)
+	}
+
+	if counts[e.String()] > 1 && isMemoizable(e) {
+		return Memoize(e)
+	}
+
+	return e
+}
+
+func isMemoizable(e Expr) bool {
+	switch e.(type) {
+	case LiteralValue, Path, Wildcard, NamedParam, PositionalParam, Parentheses, *NamedExpr, *MemoizedExpr:
+		return false
+	case Aggregator, AggregatorBuilder:
+		return false
+	}
+
+	ok := true
+	Walk(e, func(e Expr) bool {
+		switch t := e.(type) {
+		case NextValueFor, NamedParam, PositionalParam:
+			ok = false
+		case Function:
+			df, isDeterministic := t.(DeterministicFunction)
+			ok = isDeterministic && df.IsDeterministic()
+		}
+		return ok
+	})
+
+	return ok
+}
@@ -0,0 +1,140 @@
+package expr_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/expr/functions"
+	"github.com/genjidb/genji/internal/sql/parser"
+	"github.com/genjidb/genji/internal/testutil"
+	"github.com/genjidb/genji/internal/testutil/assert"
+	"github.com/genjidb/genji/types"
+	"github.com/stretchr/testify/require"
+)
+
+// countingExpr returns the value of the "a" field of the current document
+// and counts how many times it was evaluated.
+type countingExpr struct {
+	count *int
+}
+
+func (c countingExpr) Eval(env *environment.Environment) (types.Value, error) {
+	*c.count++
+	return expr.Path(document.NewPath("a")).Eval(env)
+}
+
+func (c countingExpr) String() string {
+	return "count(a)"
+}
+
+func TestMemoizeDuplicates(t *testing.T) {
+	var count int
+	c := countingExpr{count: &count}
+
+	exprs := expr.MemoizeDuplicates(
+		expr.And(
+			expr.Gt(c, testutil.IntegerValue(0)),
+			expr.Lt(c, testutil.IntegerValue(10)),
+		),
+		expr.Neq(c, testutil.IntegerValue(5)),
+	)
+
+	var env environment.Environment
+	for i := 1; i <= 3; i++ {
+		env.SetDocument(document.NewFieldBuffer().Add("a", types.NewIntegerValue(int64(i))))
+
+		for _, e := range exprs {
+			v, err := e.Eval(&env)
+			assert.NoError(t, err)
+			require.Equal(t, types.NewBoolValue(true), v)
+		}
+
+		require.Equal(t, i, count)
+	}
+
+	t.Run("String", func(t *testing.T) {
+		require.Equal(t, "count(a) > 0 AND count(a) < 10", exprs[0].String())
+		require.Equal(t, "count(a) != 5", exprs[1].String())
+	})
+
+	t.Run("No duplicates", func(t *testing.T) {
+		e := expr.Gt(c, testutil.IntegerValue(0))
+		exprs := expr.MemoizeDuplicates(e)
+		require.Equal(t, e, exprs[0])
+		_, ok := e.(expr.Operator).LeftHand().(countingExpr)
+		require.True(t, ok)
+	})
+
+	t.Run("NEXT VALUE FOR", func(t *testing.T) {
+		e := expr.Eq(expr.NextValueFor{SeqName: "seq"}, expr.NextValueFor{SeqName: "seq"})
+		expr.MemoizeDuplicates(e)
+		_, ok := e.(expr.Operator).LeftHand().(*expr.MemoizedExpr)
+		require.False(t, ok)
+	})
+
+	t.Run("Params", func(t *testing.T) {
+		tests := []struct {
+			expr   string
+			params []environment.Param
+			want   bool
+		}{
+			{"a || ? = 'hellox' OR a || ? = 'helloy'", []environment.Param{{Value: "z"}, {Value: "y"}}, true},
+			{"a || ? = 'hellox' AND a || ? = 'helloy'", []environment.Param{{Value: "x"}, {Value: "y"}}, true},
+			{"a || $p = 'hellox' OR a || $p = 'helloy'", []environment.Param{{Name: "p", Value: "y"}}, true},
+		}
+
+		for _, test := range tests {
+			t.Run(test.expr, func(t *testing.T) {
+				e, err := parser.NewParser(strings.NewReader(test.expr)).ParseExpr()
+				assert.NoError(t, err)
+
+				expr.MemoizeDuplicates(e)
+				_, ok := e.(expr.Operator).LeftHand().(expr.Operator).LeftHand().(*expr.MemoizedExpr)
+				require.False(t, ok)
+
+				var env environment.Environment
+				env.SetParams(test.params)
+				env.SetDocument(document.NewFieldBuffer().Add("a", types.NewTextValue("hello")))
+
+				v, err := e.Eval(&env)
+				assert.NoError(t, err)
+				require.Equal(t, types.NewBoolValue(test.want), v)
+			})
+		}
+	})
+
+	t.Run("Functions", func(t *testing.T) {
+		err := functions.RegisterFunc("memo_udf", func(args []types.Value) (types.Value, error) {
+			return args[0], nil
+		})
+		assert.NoError(t, err)
+
+		tests := []struct {
+			expr     string
+			memoized bool
+		}{
+			{"LOWER(a) = LOWER(a)", true},
+			{"typeof(a) = typeof(a)", true},
+			{"math.floor(a) = math.floor(a)", true},
+			{"math.random() = math.random()", false},
+			{"UUID() = UUID()", false},
+			{"NOW() = NOW()", false},
+			{"memo_udf(a) = memo_udf(a)", false},
+			{"LOWER(memo_udf(a)) = LOWER(memo_udf(a))", false},
+		}
+
+		for _, test := range tests {
+			t.Run(test.expr, func(t *testing.T) {
+				e, err := parser.NewParser(strings.NewReader(test.expr)).ParseExpr()
+				assert.NoError(t, err)
+
+				expr.MemoizeDuplicates(e)
+				_, ok := e.(expr.Operator).LeftHand().(*expr.MemoizedExpr)
+				require.Equal(t, test.memoized, ok)
+			})
+		}
+	})
+}
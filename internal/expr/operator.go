@@ -152,6 +152,8 @@ func (c Cast) IsEqual(other Expr) bool {
 
 func (c Cast) Params() []Expr { return []Expr{c.Expr} }
 
+func (c Cast) IsDeterministic() bool { return true }
+
 func (c Cast) String() string {
 	return fmt.Sprintf("CAST(%v AS %v)", c.Expr, c.CastAs)
 }
@@ -38,6 +38,9 @@ func TestPathExpr(t *testing.T) {
 		{"c[1].foo", types.NewTextValue("bar"), false},
 		{"c.foo", nullLiteral, false},
 		{"d", nullLiteral, false},
+		{"d.e.f", nullLiteral, false},
+		{"a.b", nullLiteral, false},
+		{"b.`foo bar`.c", nullLiteral, false},
 	}
 
 	d := document.NewFromJSON([]byte(`{
@@ -18,6 +18,8 @@ type BatchSession struct {
 	closed          bool
 	rollbackSegment *RollbackSegment
 	maxBatchSize    int
+	savepoints      []savepoint
+	undoLog         []undoEntry
 }
 
 func (s *BatchSession) Commit() error {
@@ -103,6 +105,11 @@ func (s *BatchSession) Insert(k, v []byte) error {
 		return ErrKeyAlreadyExists
 	}
 
+	err = s.recordUndo(k)
+	if err != nil {
+		return err
+	}
+
 	s.rollbackSegment.EnqueueOp(k, kvOpInsert)
 
 	err = s.Batch.Set(k, v, nil)
@@ -123,9 +130,14 @@ func (s *BatchSession) Put(k, v []byte) error {
 		return errors.New("cannot store empty value")
 	}
 
+	err := s.recordUndo(k)
+	if err != nil {
+		return err
+	}
+
 	s.rollbackSegment.EnqueueOp(k, kvOpSet)
 
-	err := s.Batch.Set(k, v, nil)
+	err = s.Batch.Set(k, v, nil)
 	if err != nil {
 		return err
 	}
@@ -135,9 +147,14 @@ func (s *BatchSession) Put(k, v []byte) error {
 
 // Delete a record by key. If the key doesn't exist, it doesn't do anything.
 func (s *BatchSession) Delete(k []byte) error {
+	err := s.recordUndo(k)
+	if err != nil {
+		return err
+	}
+
 	s.rollbackSegment.EnqueueOp(k, kvOpDel)
 
-	err := s.Batch.Delete(k, nil)
+	err = s.Batch.Delete(k, nil)
 	if err != nil {
 		return err
 	}
@@ -170,3 +187,129 @@ func (s *BatchSession) DeleteRange(start []byte, end []byte) error {
 func (s *BatchSession) Iterator(opts *pebble.IterOptions) (*pebble.Iterator, error) {
 	return s.Batch.NewIter(opts)
 }
+
+// Savepoint marks the current state of the batch under the given name.
+// Any write performed after the savepoint can be undone by calling RollbackTo
+// without discarding the writes that were made before it.
+// If a savepoint with the same name already exists, the new one hides it
+// until it is released.
+func (s *BatchSession) Savepoint(name string) error {
+	if s.closed {
+		return errors.New("already closed")
+	}
+
+	s.savepoints = append(s.savepoints, savepoint{
+		name: name,
+		undo: len(s.undoLog),
+	})
+
+	return nil
+}
+
+// RollbackTo undoes all the writes performed after the most recent savepoint
+// with the given name. The savepoint itself is kept and can be rolled back to again,
+// while the savepoints created after it are discarded.
+func (s *BatchSession) RollbackTo(name string) error {
+	if s.closed {
+		return errors.New("already closed")
+	}
+
+	i := s.lookupSavepoint(name)
+	if i < 0 {
+		return errors.Errorf("savepoint %q not found", name)
+	}
+
+	sp := s.savepoints[i]
+
+	// replay the undo log in reverse order to restore
+	// the values as they were when the savepoint was created.
+	for j := len(s.undoLog) - 1; j >= sp.undo; j-- {
+		entry := s.undoLog[j]
+
+		var err error
+		if entry.value == nil {
+			s.rollbackSegment.EnqueueOp(entry.key, kvOpDel)
+			err = s.Batch.Delete(entry.key, nil)
+		} else {
+			s.rollbackSegment.EnqueueOp(entry.key, kvOpSet)
+			err = s.Batch.Set(entry.key, entry.value, nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	s.undoLog = s.undoLog[:sp.undo]
+	s.savepoints = s.savepoints[:i+1]
+
+	return s.ensureBatchSize()
+}
+
+// ReleaseSavepoint removes the most recent savepoint with the given name,
+// as well as every savepoint created after it. The writes performed
+// since then are kept.
+func (s *BatchSession) ReleaseSavepoint(name string) error {
+	if s.closed {
+		return errors.New("already closed")
+	}
+
+	i := s.lookupSavepoint(name)
+	if i < 0 {
+		return errors.Errorf("savepoint %q not found", name)
+	}
+
+	s.savepoints = s.savepoints[:i]
+	if len(s.savepoints) == 0 {
+		s.undoLog = s.undoLog[:0]
+	}
+
+	return nil
+}
+
+func (s *BatchSession) lookupSavepoint(name string) int {
+	for i := len(s.savepoints) - 1; i >= 0; i-- {
+		if s.savepoints[i].name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// recordUndo stores the current value of k in the undo log,
+// if there is at least one active savepoint.
+func (s *BatchSession) recordUndo(k []byte) error {
+	if len(s.savepoints) == 0 {
+		return nil
+	}
+
+	v, err := s.Get(k)
+	if err != nil {
+		if !errors.Is(err, ErrKeyNotFound) {
+			return err
+		}
+		v = nil
+	}
+
+	key := make([]byte, len(k))
+	copy(key, k)
+
+	s.undoLog = append(s.undoLog, undoEntry{
+		key:   key,
+		value: v,
+	})
+
+	return nil
+}
+
+type savepoint struct {
+	name string
+	// position of the savepoint in the undo log
+	undo int
+}
+
+type undoEntry struct {
+	key []byte
+	// nil if the key didn't exist
+	value []byte
+}
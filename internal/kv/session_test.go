@@ -141,6 +141,60 @@ func TestRollback(t *testing.T) {
 	}
 }
 
+func TestSavepoint(t *testing.T) {
+	pdb := testutil.NewPebble(t)
+
+	store := kv.NewStore(pdb, kv.Options{
+		RollbackSegmentNamespace: int64(database.RollbackSegmentNamespace),
+		MaxBatchSize:             1 << 7,
+	})
+	s := store.NewBatchSession()
+	defer s.Close()
+
+	err := s.Put([]byte("a"), []byte("A"))
+	require.NoError(t, err)
+	err = s.Put([]byte("b"), []byte("B"))
+	require.NoError(t, err)
+
+	err = s.Savepoint("sp")
+	require.NoError(t, err)
+
+	err = s.Put([]byte("a"), []byte("AA"))
+	require.NoError(t, err)
+	err = s.Delete([]byte("b"))
+	require.NoError(t, err)
+	err = s.Insert([]byte("c"), []byte("C"))
+	require.NoError(t, err)
+
+	err = s.RollbackTo("sp")
+	require.NoError(t, err)
+
+	require.Equal(t, []byte("A"), getValue(t, s, []byte("a")))
+	require.Equal(t, []byte("B"), getValue(t, s, []byte("b")))
+	_, err = s.Get([]byte("c"))
+	assert.ErrorIs(t, err, kv.ErrKeyNotFound)
+
+	// the savepoint is still active
+	err = s.Put([]byte("a"), []byte("AAA"))
+	require.NoError(t, err)
+	err = s.RollbackTo("sp")
+	require.NoError(t, err)
+	require.Equal(t, []byte("A"), getValue(t, s, []byte("a")))
+
+	err = s.ReleaseSavepoint("sp")
+	require.NoError(t, err)
+	err = s.RollbackTo("sp")
+	require.Error(t, err)
+
+	err = s.Commit()
+	require.NoError(t, err)
+
+	v, closer, err := pdb.Get([]byte("a"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("A"), v)
+	closer.Close()
+}
+
 func TestStorePut(t *testing.T) {
 	t.Run("Should insert data", func(t *testing.T) {
 		st := kvBuilder(t)
@@ -7,10 +7,15 @@ import (
 
 var _ Session = (*TransientSession)(nil)
 
+// A TransientSession stores temporary data that is never committed.
+// Data is kept in memory until it exceeds maxBatchSize, after which it is
+// spilled to disk. Deletions made before closing the session, if any, are applied
+// to the spilled data when the session is closed.
 type TransientSession struct {
 	db           *pebble.DB
 	batch        *pebble.Batch
 	maxBatchSize int
+	spilled      bool
 	closed       bool
 }
 
@@ -24,6 +29,19 @@ func (s *TransientSession) Close() error {
 	}
 	s.closed = true
 
+	if s.batch == nil {
+		return nil
+	}
+
+	if s.spilled {
+		// some of the data was written to disk, persist pending
+		// deletions so that it doesn't outlive the session.
+		err := s.batch.Commit(pebble.NoSync)
+		if err != nil {
+			return err
+		}
+	}
+
 	return s.batch.Close()
 }
 
@@ -45,18 +63,32 @@ func (s *TransientSession) Put(k, v []byte) error {
 		s.batch = s.db.NewIndexedBatch()
 	}
 
-	if len(s.batch.Repr()) > s.maxBatchSize && s.batch.Count() > 0 {
-		err := s.batch.Commit(pebble.NoSync)
+	if len(s.batch.Repr()) > s.maxBatchSize {
+		err := s.Spill()
 		if err != nil {
 			return err
 		}
-
-		s.batch.Reset()
 	}
 
 	return s.batch.Set(k, v, nil)
 }
 
+// Spill writes the data kept in memory to disk.
+func (s *TransientSession) Spill() error {
+	if s.batch == nil || s.batch.Count() == 0 {
+		return nil
+	}
+
+	err := s.batch.Commit(pebble.NoSync)
+	if err != nil {
+		return err
+	}
+
+	s.batch.Reset()
+	s.spilled = true
+	return nil
+}
+
 // Get returns a value associated with the given key. If not found, returns ErrKeyNotFound.
 func (s *TransientSession) Get(k []byte) ([]byte, error) {
 	if s.batch == nil {
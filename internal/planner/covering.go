@@ -0,0 +1,130 @@
+package planner
+
+import (
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/stream/docs"
+	"github.com/genjidb/genji/internal/stream/index"
+)
+
+// SelectCoveringIndex makes index scans build documents from the entries
+// of the index instead of fetching them from the table, if the stream
+// only uses fields that are stored in the index until the documents are projected.
+// Example, with an index on foo(a, b):
+//
+//	this:
+//	  index.Scan("foo_a_b_idx", [{"min": [1], "exact": true}]) | docs.Filter(b > 2) | docs.Project(a, b)
+//	becomes this:
+//	  index.CoveringScan("foo_a_b_idx", [{"min": [1], "exact": true}]) | docs.Filter(b > 2) | docs.Project(a, b)
+func SelectCoveringIndex(sctx *StreamContext) error {
+	scan, ok := sctx.Stream.First().(*index.ScanOperator)
+	if !ok {
+		return nil
+	}
+
+	info, err := sctx.Catalog.GetIndexInfo(scan.IndexName)
+	if err != nil {
+		return err
+	}
+
+	tb, err := sctx.Catalog.GetTableInfo(info.Owner.TableName)
+	if err != nil {
+		return err
+	}
+
+	// the expiration of the documents is checked on the whole document
+	if tb.TTLField != "" {
+		return nil
+	}
+
+	fields := make(map[string]bool)
+	for _, f := range index.CoveredFields(tb, info) {
+		fields[f] = true
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	var projected bool
+	for op := scan.GetNext(); op != nil; op = op.GetNext() {
+		switch t := op.(type) {
+		case *docs.FilterOperator:
+			if !isCoveredExpr(t.Expr, fields) {
+				return nil
+			}
+		case *docs.TempTreeSortOperator:
+			// sort keys that are not projected are evaluated
+			// against the documents read by the scan
+			if !isCoveredExpr(t.Expr, fields) {
+				return nil
+			}
+			for _, k := range t.ThenBy {
+				if !isCoveredExpr(k.Expr, fields) {
+					return nil
+				}
+			}
+		case *docs.TakeOperator, *docs.SkipOperator:
+		case *docs.ProjectOperator:
+			// strict projections need to know if a field is missing
+			if t.Strict {
+				return nil
+			}
+			for _, e := range t.Exprs {
+				if !isCoveredExpr(e, fields) {
+					return nil
+				}
+			}
+
+			projected = true
+		default:
+			return nil
+		}
+	}
+
+	// without projection, the whole documents are returned
+	scan.Covering = projected
+	return nil
+}
+
+// isCoveredExpr returns whether e can be evaluated using only the given fields.
+// Expressions it doesn't know about, like subqueries or wildcards, are never covered.
+func isCoveredExpr(e expr.Expr, fields map[string]bool) bool {
+	switch t := e.(type) {
+	case nil:
+		// unary operators have no right hand side
+		return true
+	case expr.LiteralValue, expr.NamedParam, expr.PositionalParam:
+		return true
+	case expr.Path:
+		return len(t) > 0 && fields[t[0].FieldName]
+	case *expr.NamedExpr:
+		return isCoveredExpr(t.Expr, fields)
+	case expr.Parentheses:
+		return isCoveredExpr(t.E, fields)
+	case expr.Cast:
+		return isCoveredExpr(t.Expr, fields)
+	case expr.LiteralExprList:
+		for _, e := range t {
+			if !isCoveredExpr(e, fields) {
+				return false
+			}
+		}
+		return true
+	case *expr.BetweenOperator:
+		return isCoveredExpr(t.X, fields) && isCoveredExpr(t.LeftHand(), fields) && isCoveredExpr(t.RightHand(), fields)
+	case *expr.NotBetweenOperator:
+		return isCoveredExpr(t.X, fields) && isCoveredExpr(t.LeftHand(), fields) && isCoveredExpr(t.RightHand(), fields)
+	case expr.Operator:
+		return isCoveredExpr(t.LeftHand(), fields) && isCoveredExpr(t.RightHand(), fields)
+	case expr.AggregatorBuilder:
+		return false
+	case expr.Function:
+		for _, p := range t.Params() {
+			if !isCoveredExpr(p, fields) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
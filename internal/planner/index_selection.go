@@ -1,14 +1,19 @@
 package planner
 
 import (
+	"math"
+
 	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/expr/glob"
 	"github.com/genjidb/genji/internal/sql/scanner"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/docs"
 	"github.com/genjidb/genji/internal/stream/index"
 	"github.com/genjidb/genji/internal/stream/table"
 	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
 )
 
 // SelectIndex attempts to replace a sequential scan by an index scan or a pk scan by
@@ -29,6 +34,13 @@ import (
 // compatible operator: one of =, >, >=, <, <=, IN
 // expression: any expression
 //
+// LIKE operators whose pattern starts with a literal prefix, such as a LIKE 'abc%',
+// read the range of values starting with that prefix. Their filter node is kept
+// to match the rest of the pattern.
+//
+// Filter nodes comparing an expression that isn't a path, such as LOWER(a) = 'foo',
+// can also be selected by indexes created on that same expression.
+//
 // Index compatibility.
 //
 // Once we have a list of all compatible filter nodes, we try to associate
@@ -91,6 +103,12 @@ func SelectIndex(sctx *StreamContext) error {
 		return nil
 	}
 
+	// the nodes following a join operate on the joined documents,
+	// their paths don't refer to the documents of the scanned table
+	if _, ok := seq.GetNext().(*table.JoinOperator); ok {
+		return nil
+	}
+
 	// ensure the table exists
 	_, err := sctx.Catalog.GetTableInfo(seq.TableName)
 	if err != nil {
@@ -158,7 +176,7 @@ func (i *indexSelector) selectIndex() error {
 	}
 	pk := tb.GetPrimaryKey()
 	if pk != nil {
-		selected = i.associateIndexWithNodes(tb.TableName, false, false, pk.Paths, pk.SortOrder, nodes)
+		selected = i.associateIndexWithNodes(tb.TableName, false, false, pk.Paths, nil, pk.SortOrder, nodes)
 		if selected != nil {
 			cost = selected.Cost()
 		}
@@ -172,9 +190,9 @@ func (i *indexSelector) selectIndex() error {
 			return err
 		}
 
-		candidate := i.associateIndexWithNodes(idxInfo.IndexName, true, idxInfo.Unique, idxInfo.Paths, idxInfo.KeySortOrder, nodes)
+		candidate := i.associateIndexWithNodes(idxInfo.IndexName, true, idxInfo.Unique, idxInfo.Paths, indexExprs(idxInfo), idxInfo.KeySortOrder, nodes)
 
-		if candidate == nil {
+		if candidate == nil || i.isSeqScanCheaper(candidate) {
 			continue
 		}
 
@@ -186,12 +204,31 @@ func (i *indexSelector) selectIndex() error {
 
 		c := candidate.Cost()
 
+		// if statistics are available for both candidates,
+		// prefer the one reading the fewest entries
+		if selected.rows >= 0 && candidate.rows >= 0 && selected.rows != candidate.rows {
+			if candidate.rows < selected.rows {
+				cost = c
+				selected = candidate
+			}
+			continue
+		}
+
 		if len(selected.nodes) < len(candidate.nodes) || (len(selected.nodes) == len(candidate.nodes) && c < cost) {
 			cost = c
 			selected = candidate
 		}
 	}
 
+	// if no filter node could be associated with an index,
+	// try to read the results of OR conditions from multiple indexes
+	if selected == nil || !selected.hasFilterNodes() {
+		ok, err := i.selectIndexUnion()
+		if ok || err != nil {
+			return err
+		}
+	}
+
 	if selected == nil {
 		return nil
 	}
@@ -200,14 +237,18 @@ func (i *indexSelector) selectIndex() error {
 	for _, f := range selected.nodes {
 		switch tp := f.node.(type) {
 		case *docs.FilterOperator:
-			i.sctx.removeFilterNode(tp)
-			if f.orderBy != nil {
-				i.sctx.removeTempTreeNodeNode(f.orderBy.node.(*docs.TempTreeSortOperator))
+			// the range only matches the prefix of the pattern
+			if f.operator == scanner.LIKE {
+				continue
 			}
+			i.sctx.removeFilterNode(tp)
 		case *docs.TempTreeSortOperator:
 			i.sctx.removeTempTreeNodeNode(tp)
 		}
 	}
+	if selected.orderBy != nil {
+		i.sctx.removeTempTreeNodeNode(selected.orderBy.node.(*docs.TempTreeSortOperator))
+	}
 
 	// we replace the seq scan node by the selected root
 	s := i.sctx.Stream
@@ -224,6 +265,90 @@ func (i *indexSelector) selectIndex() error {
 	return nil
 }
 
+// selectIndexUnion looks for a filter node whose condition is a list of OR operators
+// and for which every operand can be read from an index or the primary key.
+// If found, the table.Scan is replaced by a table.Union of these index scans
+// and the filter node is removed.
+// Example:
+//
+//	SELECT * FROM foo WHERE a = 1 OR b = 2
+//	table.Scan('foo') | docs.Filter(a = 1 OR b = 2)
+//
+// becomes:
+//
+//	table.Union('foo', index.Scan('idx_foo_a', [1]), index.Scan('idx_foo_b', [2]))
+//
+// If any of the operands requires a full table scan, the stream is left untouched.
+func (i *indexSelector) selectIndexUnion() (bool, error) {
+	for _, f := range i.sctx.Filters {
+		conds := splitORExpr(f.Expr)
+		if len(conds) < 2 {
+			continue
+		}
+
+		streams := make([]*stream.Stream, 0, len(conds))
+		for _, cond := range conds {
+			sctx := NewStreamContext(stream.New(table.Scan(i.tableScan.TableName)).Pipe(docs.Filter(cond)))
+			sctx.Catalog = i.sctx.Catalog
+
+			err := SplitANDConditionRule(sctx)
+			if err != nil {
+				return false, err
+			}
+
+			err = SelectIndex(sctx)
+			if err != nil {
+				return false, err
+			}
+
+			// the condition cannot use an index
+			s := sctx.Stream
+			if sc, ok := s.First().(*table.ScanOperator); ok && len(sc.Ranges) == 0 {
+				streams = nil
+				break
+			}
+
+			streams = append(streams, s)
+		}
+
+		if streams == nil {
+			continue
+		}
+
+		i.sctx.removeFilterNode(f)
+
+		s := i.sctx.Stream
+		s.Remove(s.First())
+		u := table.Union(i.tableScan.TableName, streams...)
+		if s.Op == nil {
+			s.Op = u
+		} else {
+			stream.InsertBefore(s.First(), u)
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// splitORExpr takes an expression and splits it by OR operator.
+func splitORExpr(cond expr.Expr) (exprs []expr.Expr) {
+	if p, ok := cond.(expr.Parentheses); ok {
+		cond = p.E
+	}
+
+	op, ok := cond.(expr.Operator)
+	if ok && op.Token() == scanner.OR {
+		exprs = append(exprs, splitORExpr(op.LeftHand())...)
+		exprs = append(exprs, splitORExpr(op.RightHand())...)
+		return
+	}
+
+	exprs = append(exprs, cond)
+	return
+}
+
 func (i *indexSelector) isFilterIndexable(f *docs.FilterOperator) *indexableNode {
 	// only operators can associate this node to an index
 	op, ok := f.Expr.(expr.Operator)
@@ -231,28 +356,109 @@ func (i *indexSelector) isFilterIndexable(f *docs.FilterOperator) *indexableNode
 		return nil
 	}
 
+	if like, ok := op.(*expr.LikeOperator); ok {
+		return i.isLikeIndexable(f, like)
+	}
+
 	// ensure the operator is compatible
 	if !operatorIsIndexCompatible(op) {
 		return nil
 	}
 
 	// determine if the operator could benefit from an index
-	ok, path, e := operatorCanUseIndex(op)
+	ok, path, tok, e := operatorCanUseIndex(op)
+	if ok {
+		return &indexableNode{
+			node:     f,
+			path:     path,
+			operator: tok,
+			operand:  e,
+		}
+	}
+
+	// otherwise, it could benefit from an index on an expression
+	ok, x, tok, e := operatorCanUseExprIndex(op)
 	if !ok {
 		return nil
 	}
 
-	node := indexableNode{
+	return &indexableNode{
 		node:     f,
-		path:     path,
-		operator: op.Token(),
+		expr:     x,
+		operator: tok,
 		operand:  e,
 	}
+}
+
+// isLikeIndexable returns an indexable node reading the range of text values
+// starting with the literal prefix of the pattern of the LIKE operator, if any.
+// The range is expressed as a BETWEEN, from the prefix to the smallest text
+// greater than every text starting with the prefix.
+func (i *indexSelector) isLikeIndexable(f *docs.FilterOperator, op *expr.LikeOperator) *indexableNode {
+	lv, ok := op.RightHand().(expr.LiteralValue)
+	if !ok || lv.Value.Type() != types.TextValue {
+		return nil
+	}
+
+	prefix := glob.LikePrefix(types.As[string](lv.Value), op.CaseSensitive)
+	upper, ok := prefixUpperBound(prefix)
+	if !ok {
+		return nil
+	}
 
-	return &node
+	n := indexableNode{
+		node:     f,
+		operator: scanner.LIKE,
+		operand: expr.LiteralExprList{
+			expr.LiteralValue{Value: types.NewTextValue(prefix)},
+			expr.LiteralValue{Value: types.NewTextValue(upper)},
+		},
+	}
+
+	switch lh := op.LeftHand().(type) {
+	case expr.Path:
+		// the boundaries can't be converted to other types
+		tb, err := i.sctx.Catalog.GetTableInfo(i.tableScan.TableName)
+		if err != nil {
+			return nil
+		}
+		if fc := tb.GetFieldConstraintForPath(document.Path(lh)); fc != nil && fc.Type != types.AnyValue && fc.Type != types.TextValue {
+			return nil
+		}
+
+		n.path = document.Path(lh)
+	default:
+		if !exprContainsPath(lh) {
+			return nil
+		}
+
+		n.expr = lh
+	}
+
+	return &n
+}
+
+// prefixUpperBound returns the smallest text greater than
+// every text starting with the given prefix.
+// It returns false if the prefix is empty.
+func prefixUpperBound(prefix string) (string, bool) {
+	b := []byte(prefix)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] < 0xff {
+			b[i]++
+			return string(b[:i+1]), true
+		}
+	}
+
+	return "", false
 }
 
 func (i *indexSelector) isTempTreeSortIndexable(n *docs.TempTreeSortOperator) *indexableNode {
+	// only nodes sorting by a single key can be replaced by an index
+	if len(n.ThenBy) > 0 {
+		return nil
+	}
+
 	// only paths can be associated with an index
 	path, ok := n.Expr.(expr.Path)
 	if !ok {
@@ -282,14 +488,29 @@ func (i *indexSelector) isTempTreeSortIndexable(n *docs.TempTreeSortOperator) *i
 //	 -> range = {min: [3], exact: true}
 //	docs.Filter(a IN (1, 2))
 //	 -> ranges = [1], [2]
-func (i *indexSelector) associateIndexWithNodes(treeName string, isIndex bool, isUnique bool, paths []document.Path, sortOrder tree.SortOrder, nodes indexableNodes) *candidate {
+//	fitler(a = 3) | docs.Filter(b > 10) | docs.Filter(b < 20)
+//	 -> range = {min: [3, 10], max: [3, 20], exclusive: true}
+//
+// If exprs is not nil, the columns with a non-nil expression
+// are associated with nodes on that expression instead of a path.
+func (i *indexSelector) associateIndexWithNodes(treeName string, isIndex bool, isUnique bool, paths []document.Path, exprs []expr.Expr, sortOrder tree.SortOrder, nodes indexableNodes) *candidate {
 	found := make([]*indexableNode, 0, len(paths))
 	var desc bool
 
 	var hasIn bool
 	var sorter *indexableNode
-	for _, p := range paths {
-		ns := nodes.getByPath(p)
+	// node providing the upper bound of the range, if the last
+	// associated node provides the lower bound on the same column
+	var upper *indexableNode
+	// column of the index matching the TempSort node, if any
+	sorterCol := -1
+	for col, p := range paths {
+		var ns []*indexableNode
+		if col < len(exprs) && exprs[col] != nil {
+			ns = nodes.getByExpr(exprs[col])
+		} else {
+			ns = nodes.getByPath(p)
+		}
 		if len(ns) == 0 {
 			break
 		}
@@ -297,9 +518,14 @@ func (i *indexSelector) associateIndexWithNodes(treeName string, isIndex bool, i
 		// get the filter node and the TempSort node if any
 		var filter *indexableNode
 		for i, n := range ns {
-			if n.operator == scanner.ORDER && sorter == nil {
-				sorter = ns[i]
-				desc = sorter.desc
+			if n.operator == scanner.ORDER {
+				// IN operators generate one range per value, which are not
+				// read in order: the results must still be sorted downstream
+				if sorter == nil && !hasIn {
+					sorter = ns[i]
+					sorterCol = col
+					desc = sorter.desc
+				}
 				continue
 			}
 			if filter == nil {
@@ -315,14 +541,14 @@ func (i *indexSelector) associateIndexWithNodes(treeName string, isIndex bool, i
 			break
 		}
 
-		// if we have both a filter and a TempSort node, we can merge them
-		if filter != nil && sorter != nil {
-			filter.orderBy = sorter
-			sorter = nil
-		}
-
 		if filter.operator == scanner.IN {
 			hasIn = true
+
+			if sorterCol == col {
+				sorter = nil
+				sorterCol = -1
+				desc = false
+			}
 		}
 
 		// in the case there is an IN operator somewhere
@@ -334,6 +560,12 @@ func (i *indexSelector) associateIndexWithNodes(treeName string, isIndex bool, i
 
 		// we must stop at the first operator that is not a IN or a =
 		if filter.operator != scanner.EQ && filter.operator != scanner.IN {
+			if !hasIn {
+				if lower, up := rangeBounds(filter, ns); up != nil {
+					found[len(found)-1] = lower
+					upper = up
+				}
+			}
 			break
 		}
 	}
@@ -347,12 +579,13 @@ func (i *indexSelector) associateIndexWithNodes(treeName string, isIndex bool, i
 		c := candidate{
 			nodes:      []*indexableNode{sorter},
 			rangesCost: 10_000,
+			rows:       i.estimateRows(treeName, isIndex, len(paths), nil),
 			isIndex:    isIndex,
 			isUnique:   isUnique,
 		}
 
 		// in case the primary key or index is descending, we need to use a reverse the order
-		if sortOrder.IsDesc(0) {
+		if sortOrder.IsDesc(sorterCol) {
 			desc = !desc
 		}
 
@@ -381,34 +614,49 @@ func (i *indexSelector) associateIndexWithNodes(treeName string, isIndex bool, i
 		return &c
 	}
 
-	// in case we found an orphan sorter node and we need to assign it to the first filter node
-	// for deletion
-	if sorter != nil {
-		found[0].orderBy = sorter
-	}
-
 	// in case there is an IN operator in the list, we need to generate multiple ranges.
 	// If not, we only need one range.
 	var ranges stream.Ranges
 
 	if !hasIn {
-		ranges = stream.Ranges{i.buildRangeFromFilterNodes(found...)}
+		rng := i.buildRangeFromFilterNodes(found...)
+		if upper != nil {
+			lower := found[len(found)-1]
+			rng.Max = make(expr.LiteralExprList, len(rng.Min))
+			copy(rng.Max, rng.Min)
+			rng.Max[len(rng.Max)-1] = upper.operand
+
+			// both boundaries of a range are either exclusive or inclusive.
+			// if they differ, the range is inclusive and the exclusive
+			// filter node is kept to exclude its boundary
+			switch {
+			case (lower.operator == scanner.GT) == (upper.operator == scanner.LT):
+				found = append(found, upper)
+			case lower.operator == scanner.GT:
+				rng.Exclusive = false
+				found[len(found)-1] = upper
+			default:
+				rng.Exclusive = false
+			}
+		}
+
+		ranges = stream.Ranges{rng}
 	} else {
 		ranges = i.buildRangesFromFilterNodes(paths, found)
 	}
 
 	c := candidate{
 		nodes:      found,
+		orderBy:    sorter,
 		rangesCost: ranges.Cost(),
+		rows:       i.estimateRows(treeName, isIndex, len(paths), ranges),
 		isIndex:    isIndex,
 		isUnique:   isUnique,
 	}
 
 	// in case the indexed path is descending, we need to reverse the order
-	if found[len(found)-1].orderBy != nil {
-		if sortOrder.IsDesc(len(found) - 1) {
-			desc = !desc
-		}
+	if sorterCol != -1 && sortOrder.IsDesc(sorterCol) {
+		desc = !desc
 	}
 
 	if !isIndex {
@@ -531,7 +779,7 @@ func (i *indexSelector) buildRangeFromOperator(lastOp scanner.Token, paths []doc
 		rng.Max = el
 	case scanner.LTE:
 		rng.Max = el
-	case scanner.BETWEEN:
+	case scanner.BETWEEN, scanner.LIKE:
 		/* example:
 		CREATE TABLE test(a int, b int, c int, d int, e int);
 		CREATE INDEX on test(a, b, c, d);
@@ -588,9 +836,9 @@ type indexableNode struct {
 	operand  expr.Expr
 	desc     bool
 
-	// merged TempTreeSort node to remove
-	// from the stream
-	orderBy *indexableNode
+	// For filter nodes on an expression that is not a path,
+	// e.g. LOWER(a) = 'foo', the expression, and path is nil.
+	expr expr.Expr
 }
 
 type indexableNodes []*indexableNode
@@ -601,7 +849,7 @@ type indexableNodes []*indexableNode
 func (n indexableNodes) getByPath(p document.Path) []*indexableNode {
 	var nodes []*indexableNode
 	for _, fn := range n {
-		if fn.path.IsEqual(p) {
+		if fn.expr == nil && fn.path.IsEqual(p) {
 			nodes = append(nodes, fn)
 		}
 	}
@@ -609,17 +857,55 @@ func (n indexableNodes) getByPath(p document.Path) []*indexableNode {
 	return nodes
 }
 
+// getByExpr returns all indexable nodes for the given expression.
+func (n indexableNodes) getByExpr(e expr.Expr) []*indexableNode {
+	var nodes []*indexableNode
+	for _, fn := range n {
+		if fn.expr != nil && expr.Equal(fn.expr, e) {
+			nodes = append(nodes, fn)
+		}
+	}
+
+	return nodes
+}
+
+// indexExprs returns the expressions of the columns of the index,
+// or nil if it only indexes paths.
+func indexExprs(info *database.IndexInfo) []expr.Expr {
+	if info.Exprs == nil {
+		return nil
+	}
+
+	exprs := make([]expr.Expr, len(info.Exprs))
+	for i, e := range info.Exprs {
+		if ce, ok := e.(*expr.ConstraintExpr); ok {
+			exprs[i] = ce.Expr
+		}
+	}
+
+	return exprs
+}
+
 type candidate struct {
 	// filter operators to remove and replace by either an index.Scan
 	// or pkScan operators.
 	nodes indexableNodes
 
+	// TempSort node made unnecessary by the order
+	// in which the ranges are read, if any.
+	orderBy *indexableNode
+
 	// replace the table.Scan by these nodes
 	replaceRootBy []stream.Operator
 
 	// cost of the associated ranges
 	rangesCost int
 
+	// number of entries the ranges are estimated to read,
+	// based on the statistics collected by ANALYZE.
+	// -1 if the table or the index was never analyzed.
+	rows float64
+
 	// is this candidate reading from an index.
 	// if false, we are reading from the table
 	// primary key.
@@ -628,6 +914,28 @@ type candidate struct {
 	isUnique bool
 }
 
+// hasFilterNodes returns whether the candidate replaces at least one filter node.
+func (c *candidate) hasFilterNodes() bool {
+	for _, n := range c.nodes {
+		if _, ok := n.node.(*docs.FilterOperator); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasTempTreeSortNodes returns whether the candidate replaces a TempTreeSort node.
+func (c *candidate) hasTempTreeSortNodes() bool {
+	for _, n := range c.nodes {
+		if _, ok := n.node.(*docs.TempTreeSortOperator); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (c *candidate) Cost() int {
 	// we start with the cost of ranges
 	cost := c.rangesCost
@@ -644,6 +952,118 @@ func (c *candidate) Cost() int {
 	return cost
 }
 
+// isSeqScanCheaper returns whether reading the table sequentially is cheaper than
+// using the given index candidate. Reading an index requires fetching every matching
+// document from the table: if the statistics show that a large part of the table
+// matches, a sequential scan is preferred, unless the index is also used to sort the results.
+func (i *indexSelector) isSeqScanCheaper(c *candidate) bool {
+	if c.rows < 0 || c.orderBy != nil || c.hasTempTreeSortNodes() {
+		return false
+	}
+
+	stats := i.sctx.Catalog.GetTableStats(i.tableScan.TableName)
+	return stats != nil && c.rows*2 > float64(stats.DocumentCount)
+}
+
+// estimateRows estimates the number of entries read by the given ranges
+// from the primary key or the index with the given name, using the
+// statistics collected by ANALYZE. If ranges is empty, the whole tree is read.
+// It returns -1 if no statistics are available.
+// Values are assumed to be uniformly distributed: each column matched by an
+// equality divides the number of entries by the number of distinct values
+// per column. Open ranges use the histogram of the field, see rangeFraction.
+func (i *indexSelector) estimateRows(treeName string, isIndex bool, arity int, ranges stream.Ranges) float64 {
+	stats := i.sctx.Catalog.GetTableStats(i.tableScan.TableName)
+	if stats == nil {
+		return -1
+	}
+
+	// every document has a distinct primary key
+	entries, distinct := float64(stats.DocumentCount), float64(stats.DocumentCount)
+	if isIndex {
+		is, ok := stats.Indexes[treeName]
+		if !ok {
+			return -1
+		}
+		entries, distinct = float64(is.EntryCount), float64(is.DistinctCount)
+	}
+
+	if len(ranges) == 0 {
+		return entries
+	}
+	if distinct == 0 {
+		return 0
+	}
+
+	// number of distinct values of each column, assuming
+	// they contribute equally to the distinct count of the tree
+	perCol := math.Pow(distinct, 1/float64(arity))
+
+	var rows float64
+	for _, rng := range ranges {
+		n := entries
+
+		if rng.Exact {
+			n /= math.Pow(perCol, float64(len(rng.Min)))
+		} else {
+			// all the columns but the last one are equal to
+			// the boundaries, the last one is an open range
+			eqCols := len(rng.Min)
+			if len(rng.Max) > eqCols {
+				eqCols = len(rng.Max)
+			}
+			if eqCols > 0 {
+				n /= math.Pow(perCol, float64(eqCols-1))
+			}
+
+			n *= rangeFraction(stats, rng, eqCols)
+		}
+
+		rows += n
+	}
+
+	return math.Min(rows, entries)
+}
+
+// rangeFraction estimates the fraction of the entries matched by the
+// open range on the last of the eqCols columns of rng.
+// If the column is a top-level field with a histogram and the boundaries
+// are literal values, the histogram is used. Otherwise, open ranges are assumed
+// to match a third of the entries, or a quarter if they have two boundaries.
+func rangeFraction(stats *database.TableStats, rng stream.Range, eqCols int) float64 {
+	var min, max types.Value
+	if len(rng.Min) == eqCols {
+		min = literalValue(rng.Min[eqCols-1])
+	}
+	if len(rng.Max) == eqCols {
+		max = literalValue(rng.Max[eqCols-1])
+	}
+
+	if stats.DocumentCount > 0 && eqCols <= len(rng.Paths) && (min != nil || max != nil) {
+		p := rng.Paths[eqCols-1]
+		if len(p) == 1 && p[0].FieldName != "" {
+			fs, ok := stats.Fields[p[0].FieldName]
+			if ok && (min != nil || len(rng.Min) < eqCols) && (max != nil || len(rng.Max) < eqCols) {
+				return fs.RangeFraction(min, max, rng.Exclusive) * float64(fs.Count) / float64(stats.DocumentCount)
+			}
+		}
+	}
+
+	if len(rng.Min) > 0 && len(rng.Max) > 0 {
+		return 1.0 / 4
+	}
+	return 1.0 / 3
+}
+
+// literalValue returns the value of e if it is a literal, or nil.
+func literalValue(e expr.Expr) types.Value {
+	if l, ok := e.(expr.LiteralValue); ok {
+		return l.Value
+	}
+
+	return nil
+}
+
 // operatorIsIndexCompatible returns whether the operator can be used to read from an index.
 func operatorIsIndexCompatible(op expr.Operator) bool {
 	switch op.Token() {
@@ -654,7 +1074,11 @@ func operatorIsIndexCompatible(op expr.Operator) bool {
 	return false
 }
 
-func operatorCanUseIndex(op expr.Operator) (bool, document.Path, expr.Expr) {
+// operatorCanUseIndex returns whether the operator compares a path
+// to operands that don't contain any path.
+// The returned operator is the one to apply to the path, e.g. for 5 < a,
+// the returned operator is >.
+func operatorCanUseIndex(op expr.Operator) (bool, document.Path, scanner.Token, expr.Expr) {
 	lf, leftIsPath := op.LeftHand().(expr.Path)
 	rf, rightIsPath := op.RightHand().(expr.Path)
 
@@ -664,15 +1088,15 @@ func operatorCanUseIndex(op expr.Operator) (bool, document.Path, expr.Expr) {
 	// invalid: a IN (b + 1, 2)
 	if op.Token() == scanner.IN {
 		if leftIsPath && !rightIsPath && !exprContainsPath(op.RightHand()) {
-			rh := op.RightHand()
-			// The IN operator can use indexes only if the right hand side is an expression list.
-			if _, ok := rh.(expr.LiteralExprList); !ok {
-				return false, nil, nil
+			// The IN operator can use indexes only if the right hand side is a list of values.
+			rh, ok := inOperandList(op.RightHand())
+			if !ok {
+				return false, nil, 0, nil
 			}
-			return true, document.Path(lf), rh
+			return true, document.Path(lf), op.Token(), rh
 		}
 
-		return false, nil, nil
+		return false, nil, 0, nil
 	}
 
 	// Special case for BETWEEN operator: Given this expression (x BETWEEN a AND b),
@@ -680,24 +1104,178 @@ func operatorCanUseIndex(op expr.Operator) (bool, document.Path, expr.Expr) {
 	if op.Token() == scanner.BETWEEN {
 		bt := op.(*expr.BetweenOperator)
 		x, xIsPath := bt.X.(expr.Path)
-		if !xIsPath || exprContainsPath(bt.LeftHand()) || exprContainsPath(bt.RightHand()) {
-			return false, nil, nil
+		if !xIsPath || !isBetweenIndexable(bt) {
+			return false, nil, 0, nil
 		}
 
-		return true, document.Path(x), expr.LiteralExprList{bt.LeftHand(), bt.RightHand()}
+		return true, document.Path(x), op.Token(), expr.LiteralExprList{bt.LeftHand(), bt.RightHand()}
 	}
 
 	// path OP expr
 	if leftIsPath && !rightIsPath && !exprContainsPath(op.RightHand()) {
-		return true, document.Path(lf), op.RightHand()
+		return true, document.Path(lf), op.Token(), op.RightHand()
 	}
 
 	// expr OP path
 	if rightIsPath && !leftIsPath && !exprContainsPath(op.LeftHand()) {
-		return true, document.Path(rf), op.LeftHand()
+		return true, document.Path(rf), swapOperator(op.Token()), op.LeftHand()
+	}
+
+	return false, nil, 0, nil
+}
+
+// isBetweenIndexable returns whether the boundaries of the BETWEEN operator
+// can be used as the boundaries of a range: they must not contain any path,
+// and must not be NULL, since no value is between NULL and another value.
+func isBetweenIndexable(bt *expr.BetweenOperator) bool {
+	for _, e := range []expr.Expr{bt.LeftHand(), bt.RightHand()} {
+		if exprContainsPath(e) {
+			return false
+		}
+
+		if lv, ok := e.(expr.LiteralValue); ok && lv.Value.Type() == types.NullValue {
+			return false
+		}
+	}
+
+	return true
+}
+
+// inOperandList returns the list of values of the right operand of an IN operator,
+// which is either a list of expressions or, once precalculated, an array.
+// Each value is read from the index with its own range: NULL values, which can't match,
+// and duplicate values, which would return the same documents twice, are removed.
+// It returns false if the operand isn't a list, or if no value is left.
+func inOperandList(e expr.Expr) (expr.LiteralExprList, bool) {
+	var l expr.LiteralExprList
+	switch t := e.(type) {
+	case expr.LiteralExprList:
+		l = t
+	case expr.LiteralValue:
+		if t.Value.Type() != types.ArrayValue {
+			return nil, false
+		}
+
+		err := types.As[types.Array](t.Value).Iterate(func(i int, v types.Value) error {
+			l = append(l, expr.LiteralValue{Value: v})
+			return nil
+		})
+		if err != nil {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+
+	values := make(expr.LiteralExprList, 0, len(l))
+	for _, e := range l {
+		lv, ok := e.(expr.LiteralValue)
+		if !ok {
+			values = append(values, e)
+			continue
+		}
+
+		if lv.Value.Type() == types.NullValue || containsLiteral(values, lv) {
+			continue
+		}
+
+		values = append(values, e)
+	}
+
+	if len(values) == 0 {
+		return nil, false
+	}
+
+	return values, true
+}
+
+// containsLiteral returns whether l contains a literal of the same type
+// and equal to lv. Values of different types are converted to the type
+// of the indexed column, if any, and may not be read from the same range.
+func containsLiteral(l expr.LiteralExprList, lv expr.LiteralValue) bool {
+	for _, e := range l {
+		o, ok := e.(expr.LiteralValue)
+		if ok && o.Value.Type() == lv.Value.Type() && o.IsEqual(lv) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// operatorCanUseExprIndex returns whether the operator compares an expression
+// that depends on the document, and isn't a path, to operands that don't.
+// Such operators can read from an index created on that expression.
+// As with operatorCanUseIndex, the returned operator is the one to apply
+// to the expression.
+func operatorCanUseExprIndex(op expr.Operator) (bool, expr.Expr, scanner.Token, expr.Expr) {
+	switch op.Token() {
+	case scanner.IN:
+		rh, ok := inOperandList(op.RightHand())
+		if !ok || !exprContainsPath(op.LeftHand()) || exprContainsPath(rh) {
+			return false, nil, 0, nil
+		}
+
+		return true, op.LeftHand(), op.Token(), rh
+	case scanner.BETWEEN:
+		bt := op.(*expr.BetweenOperator)
+		if !exprContainsPath(bt.X) || !isBetweenIndexable(bt) {
+			return false, nil, 0, nil
+		}
+
+		return true, bt.X, op.Token(), expr.LiteralExprList{bt.LeftHand(), bt.RightHand()}
+	}
+
+	if exprContainsPath(op.LeftHand()) && !exprContainsPath(op.RightHand()) {
+		return true, op.LeftHand(), op.Token(), op.RightHand()
+	}
+
+	if exprContainsPath(op.RightHand()) && !exprContainsPath(op.LeftHand()) {
+		return true, op.RightHand(), swapOperator(op.Token()), op.LeftHand()
+	}
+
+	return false, nil, 0, nil
+}
+
+// rangeBounds returns the lower and upper bounds of a range
+// if the given filter node can be combined with one of the nodes
+// of the same column, e.g. a > 1 and a < 10.
+// Otherwise, it returns the filter node and a nil upper bound.
+func rangeBounds(filter *indexableNode, ns []*indexableNode) (lower, upper *indexableNode) {
+	isLower := func(n *indexableNode) bool {
+		return n.operator == scanner.GT || n.operator == scanner.GTE
+	}
+	isUpper := func(n *indexableNode) bool {
+		return n.operator == scanner.LT || n.operator == scanner.LTE
+	}
+
+	for _, n := range ns {
+		switch {
+		case isLower(filter) && isUpper(n):
+			return filter, n
+		case isUpper(filter) && isLower(n):
+			return n, filter
+		}
+	}
+
+	return filter, nil
+}
+
+// swapOperator returns the comparison operator to use
+// once its operands are swapped, e.g. > for <.
+func swapOperator(tok scanner.Token) scanner.Token {
+	switch tok {
+	case scanner.GT:
+		return scanner.LT
+	case scanner.GTE:
+		return scanner.LTE
+	case scanner.LT:
+		return scanner.GT
+	case scanner.LTE:
+		return scanner.GTE
 	}
 
-	return false, nil, nil
+	return tok
 }
 
 func exprContainsPath(e expr.Expr) bool {
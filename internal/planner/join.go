@@ -0,0 +1,233 @@
+package planner
+
+import (
+	"math"
+
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/sql/scanner"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/stream/index"
+	"github.com/genjidb/genji/internal/stream/table"
+)
+
+// SelectJoinIndex makes join nodes read the joined table using its primary key
+// or one of its indexes, if the join condition requires a path of the joined table
+// to be equal to an expression that only depends on the incoming documents.
+// The expression is evaluated for every incoming document.
+// Example:
+//
+//	this:
+//	  table.Scan("foo") | table.Join("foo", "bar", table.Scan("bar"), foo.a = bar.b)
+//	becomes this, if bar.b is indexed:
+//	  table.Scan("foo") | table.Join("foo", "bar", index.Scan("bar_b_idx", [{"min": [foo.a], "exact": true}]), foo.a = bar.b)
+func SelectJoinIndex(sctx *StreamContext) error {
+	for op := sctx.Stream.First(); op != nil; op = op.GetNext() {
+		j, ok := op.(*table.JoinOperator)
+		if !ok || j.Right == nil {
+			continue
+		}
+
+		scan, ok := j.Right.First().(*table.ScanOperator)
+		if !ok || len(scan.Ranges) > 0 {
+			continue
+		}
+
+		tb, err := sctx.Catalog.GetTableInfo(j.TableName)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range splitANDExpr(j.On) {
+			p, operand := joinEqualityOperands(j.TableName, e)
+			if p == nil {
+				continue
+			}
+
+			rng := stream.Range{
+				Min:   expr.LiteralExprList{operand},
+				Paths: []document.Path{p},
+				Exact: true,
+			}
+
+			if pk := tb.GetPrimaryKey(); pk != nil && pk.Paths[0].IsEqual(p) {
+				j.Right = stream.New(table.Scan(j.TableName, rng))
+				break
+			}
+
+			var found bool
+			for _, idxName := range sctx.Catalog.ListIndexes(j.TableName) {
+				idxInfo, err := sctx.Catalog.GetIndexInfo(idxName)
+				if err != nil {
+					return err
+				}
+
+				if idxInfo.Paths[0].IsEqual(p) {
+					j.Right = stream.New(index.Scan(idxName, rng))
+					found = true
+					break
+				}
+			}
+			if found {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// ReorderJoins swaps the table of the FROM clause with the table of the first join
+// if the statistics collected by ANALYZE show that reading the joined table first
+// is cheaper. Both tables must have been analyzed and the join must be an inner join.
+// The cost of an order is the number of documents of the first table times the cost
+// of looking up the matching documents of the second one: if the join condition
+// requires an indexed path of the second table to be equal to an expression of the
+// first one, this is the average number of documents per value, otherwise the second
+// table is read entirely for every document.
+// Example:
+//
+//	this:
+//	  table.Scan("foo") | table.Join("foo", "bar", table.Scan("bar"), foo.a = bar.b)
+//	becomes this, if foo.a is indexed and bar has less documents than foo:
+//	  table.Scan("bar") | table.Join("bar", "foo", table.Scan("foo"), foo.a = bar.b)
+//
+// The joined documents keep the order of the FROM clause.
+// This rule must run before SelectJoinIndex.
+func ReorderJoins(sctx *StreamContext) error {
+	scan, ok := sctx.Stream.First().(*table.ScanOperator)
+	if !ok || len(scan.Ranges) > 0 || scan.Reverse {
+		return nil
+	}
+
+	j, ok := scan.GetNext().(*table.JoinOperator)
+	if !ok || j.Outer || j.Swapped || j.LeftName == "" || j.Right == nil {
+		return nil
+	}
+
+	right, ok := j.Right.First().(*table.ScanOperator)
+	if !ok || right != j.Right.Op || len(right.Ranges) > 0 {
+		return nil
+	}
+
+	leftStats := sctx.Catalog.GetTableStats(scan.TableName)
+	rightStats := sctx.Catalog.GetTableStats(j.TableName)
+	if leftStats == nil || rightStats == nil {
+		return nil
+	}
+
+	rightCost, err := joinLookupCost(sctx, j.TableName, rightStats, j.On)
+	if err != nil {
+		return err
+	}
+	leftCost, err := joinLookupCost(sctx, scan.TableName, leftStats, j.On)
+	if err != nil {
+		return err
+	}
+
+	if float64(rightStats.DocumentCount)*leftCost >= float64(leftStats.DocumentCount)*rightCost {
+		return nil
+	}
+
+	leftName := scan.TableName
+	scan.TableName = j.TableName
+	j.LeftName = j.TableName
+	j.TableName = leftName
+	j.Right = stream.New(table.Scan(leftName))
+	j.Swapped = true
+
+	return nil
+}
+
+// joinLookupCost estimates the number of documents of the given table
+// read for every incoming document of a join using the given condition.
+func joinLookupCost(sctx *StreamContext, tableName string, stats *database.TableStats, on expr.Expr) (float64, error) {
+	cost := float64(stats.DocumentCount)
+
+	tb, err := sctx.Catalog.GetTableInfo(tableName)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, e := range splitANDExpr(on) {
+		p, _ := joinEqualityOperands(tableName, e)
+		if p == nil {
+			continue
+		}
+
+		indexed := false
+		if pk := tb.GetPrimaryKey(); pk != nil && pk.Paths[0].IsEqual(p) {
+			indexed = true
+		}
+		for _, idxName := range sctx.Catalog.ListIndexes(tableName) {
+			idxInfo, err := sctx.Catalog.GetIndexInfo(idxName)
+			if err != nil {
+				return 0, err
+			}
+
+			indexed = indexed || idxInfo.Paths[0].IsEqual(p)
+		}
+		if !indexed {
+			continue
+		}
+
+		// average number of documents per value,
+		// plus the cost of the lookup itself
+		matches := 1.0
+		if fs, ok := stats.Fields[p[0].FieldName]; ok && len(p) == 1 && fs.DistinctCount > 0 {
+			matches = float64(fs.Count) / float64(fs.DistinctCount)
+		}
+		cost = math.Min(cost, matches+1)
+	}
+
+	return cost, nil
+}
+
+// joinEqualityOperands returns the path of the joined table and the other operand
+// of e, if e is an equality between a path of the joined table and an expression
+// that doesn't reference it.
+// The returned path is relative to the documents of the joined table.
+func joinEqualityOperands(tableName string, e expr.Expr) (document.Path, expr.Expr) {
+	op, ok := e.(expr.Operator)
+	if !ok || op.Token() != scanner.EQ {
+		return nil, nil
+	}
+
+	if p := joinedTablePath(tableName, op.LeftHand()); p != nil && !referencesTable(tableName, op.RightHand()) {
+		return p, op.RightHand()
+	}
+
+	if p := joinedTablePath(tableName, op.RightHand()); p != nil && !referencesTable(tableName, op.LeftHand()) {
+		return p, op.LeftHand()
+	}
+
+	return nil, nil
+}
+
+// joinedTablePath returns the path of e relative to the documents
+// of the joined table, if e is a path starting with its name.
+func joinedTablePath(tableName string, e expr.Expr) document.Path {
+	p, ok := e.(expr.Path)
+	if !ok || len(p) < 2 || p[0].FieldName != tableName {
+		return nil
+	}
+
+	return document.Path(p[1:])
+}
+
+// referencesTable returns whether e contains a path starting with
+// the name of the joined table.
+func referencesTable(tableName string, e expr.Expr) bool {
+	var found bool
+
+	expr.Walk(e, func(e expr.Expr) bool {
+		if p, ok := e.(expr.Path); ok && len(p) > 0 && p[0].FieldName == tableName {
+			found = true
+			return false
+		}
+		return true
+	})
+
+	return found
+}
@@ -19,6 +19,10 @@ var optimizerRules = []func(sctx *StreamContext) error{
 	RemoveUnnecessaryFilterNodesRule,
 	RemoveUnnecessaryTempSortNodesRule,
 	SelectIndex,
+	ReorderJoins,
+	SelectJoinIndex,
+	SelectCoveringIndex,
+	MemoizeFilterExprRule,
 }
 
 // Optimize takes a tree, applies a list of optimization rules
@@ -52,6 +56,52 @@ func Optimize(s *stream.Stream, catalog *database.Catalog) (*stream.Stream, erro
 		return s, nil
 	}
 
+	if firstNode, ok := s.First().(*stream.IntersectOperator); ok {
+		// If the first operation is an intersection, optimize all streams individually.
+		for i, st := range firstNode.Streams {
+			ss, err := Optimize(st, catalog)
+			if err != nil {
+				return nil, err
+			}
+			firstNode.Streams[i] = ss
+		}
+
+		return s, nil
+	}
+
+	if firstNode, ok := s.First().(*stream.ExceptOperator); ok {
+		// If the first operation is an except, optimize all streams individually.
+		for i, st := range firstNode.Streams {
+			ss, err := Optimize(st, catalog)
+			if err != nil {
+				return nil, err
+			}
+			firstNode.Streams[i] = ss
+		}
+
+		return s, nil
+	}
+
+	if firstNode, ok := s.First().(*stream.WithOperator); ok {
+		// If the first operation is a with, optimize the common table expressions
+		// and the main stream individually.
+		for _, cte := range firstNode.CTEs {
+			ss, err := Optimize(cte.Stream, catalog)
+			if err != nil {
+				return nil, err
+			}
+			cte.Stream = ss
+		}
+
+		ss, err := Optimize(firstNode.Stream, catalog)
+		if err != nil {
+			return nil, err
+		}
+		firstNode.Stream = ss
+
+		return s, nil
+	}
+
 	return optimize(s, catalog)
 }
 
@@ -229,6 +279,9 @@ func PrecalculateExprRule(sctx *StreamContext) error {
 			}
 		case *docs.TempTreeSortOperator:
 			t.Expr, err = precalculateExpr(t.Expr)
+			for i := 0; err == nil && i < len(t.ThenBy); i++ {
+				t.ThenBy[i].Expr, err = precalculateExpr(t.ThenBy[i].Expr)
+			}
 		case *path.SetOperator:
 			t.Expr, err = precalculateExpr(t.Expr)
 		case *docs.EmitOperator:
@@ -333,7 +386,15 @@ func precalculateExpr(e expr.Expr) (expr.Expr, error) {
 		t.SetLeftHandExpr(lh)
 		t.SetRightHandExpr(rh)
 
-		if b, ok := t.(*expr.BetweenOperator); ok {
+		var b *expr.BetweenOperator
+		switch bt := t.(type) {
+		case *expr.BetweenOperator:
+			b = bt
+		case *expr.NotBetweenOperator:
+			b = &bt.BetweenOperator
+		}
+
+		if b != nil {
 			b.X, err = precalculateExpr(b.X)
 			if err != nil {
 				return nil, err
@@ -434,6 +495,11 @@ func RemoveUnnecessaryTempSortNodesRule(sctx *StreamContext) error {
 		return nil
 	}
 
+	// nodes sorting by multiple keys are kept as is
+	if len(sctx.TempTreeSorts[0].ThenBy) > 0 || len(sctx.TempTreeSorts[1].ThenBy) > 0 {
+		return nil
+	}
+
 	lpath, ok := sctx.TempTreeSorts[0].Expr.(expr.Path)
 	if !ok {
 		return nil
@@ -455,3 +521,24 @@ func RemoveUnnecessaryTempSortNodesRule(sctx *StreamContext) error {
 
 	return nil
 }
+
+// MemoizeFilterExprRule memoizes any sub-expression that appears more than once
+// in the filter nodes, so that it is only evaluated once per document.
+// Example:
+//   this:
+//     docs.Filter(LEN(a) > 2)
+//     docs.Filter(LEN(a) < 10)
+//   evaluates LEN(a) once per document instead of twice.
+func MemoizeFilterExprRule(sctx *StreamContext) error {
+	exprs := make([]expr.Expr, len(sctx.Filters))
+	for i, f := range sctx.Filters {
+		exprs[i] = f.Expr
+	}
+
+	exprs = expr.MemoizeDuplicates(exprs...)
+	for i, f := range sctx.Filters {
+		f.Expr = exprs[i]
+	}
+
+	return nil
+}
@@ -253,6 +253,16 @@ func TestSelectIndex_Simple(t *testing.T) {
 			stream.New(index.Scan("idx_foo_c", stream.Range{Min: exprList(testutil.IntegerValue(3)), Exact: true})).
 				Pipe(docs.Filter(parser.MustParseExpr("b = 2"))),
 		},
+		{
+			"FROM foo WHERE a = 1 AND b = 2 AND c = 3",
+			stream.New(table.Scan("foo")).
+				Pipe(docs.Filter(parser.MustParseExpr("a = 1"))).
+				Pipe(docs.Filter(parser.MustParseExpr("b = 2"))).
+				Pipe(docs.Filter(parser.MustParseExpr("c = 3"))),
+			stream.New(index.Scan("idx_foo_c", stream.Range{Min: exprList(testutil.IntegerValue(3)), Exact: true})).
+				Pipe(docs.Filter(parser.MustParseExpr("a = 1"))).
+				Pipe(docs.Filter(parser.MustParseExpr("b = 2"))),
+		},
 		{
 			"FROM foo WHERE c > 3 AND b = 2",
 			stream.New(table.Scan("foo")).
@@ -301,6 +311,21 @@ func TestSelectIndex_Simple(t *testing.T) {
 			)),
 			stream.New(index.Scan("idx_foo_a", stream.Range{Min: exprList(testutil.IntegerValue(1)), Exact: true}, stream.Range{Min: exprList(testutil.IntegerValue(2)), Exact: true})),
 		},
+		{
+			"FROM foo WHERE a IN [1, NULL, 1, 2], precalculated",
+			stream.New(table.Scan("foo")).Pipe(docs.Filter(
+				expr.In(
+					parser.MustParseExpr("a"),
+					testutil.ArrayValue(document.NewValueBuffer(
+						types.NewIntegerValue(1),
+						types.NewNullValue(),
+						types.NewIntegerValue(1),
+						types.NewIntegerValue(2),
+					)),
+				),
+			)),
+			stream.New(index.Scan("idx_foo_a", stream.Range{Min: exprList(testutil.IntegerValue(1)), Exact: true}, stream.Range{Min: exprList(testutil.IntegerValue(2)), Exact: true})),
+		},
 		{
 			"FROM foo WHERE 1 IN a",
 			stream.New(table.Scan("foo")).Pipe(docs.Filter(parser.MustParseExpr("1 IN a"))),
@@ -353,35 +378,60 @@ func TestSelectIndex_Simple(t *testing.T) {
 			stream.New(table.Scan("foo")).Pipe(docs.Filter(parser.MustParseExpr("c < 1.1"))),
 			stream.New(index.Scan("idx_foo_c", stream.Range{Max: exprList(testutil.DoubleValue(1.1)), Exclusive: true})),
 		},
-		// {
-		// 	"FROM foo WHERE a = 1 OR b = 2",
-		// 	stream.New(table.TableScan("foo")).
-		// 		Pipe(stream.Filter(parser.MustParseExpr("a = 1 OR b = 2"))),
-		// 	stream.New(
-		// 		stream.Union(
-		// 			index.IndexScan("idx_foo_a", stream.IndexRange{Min: exprList(testutil.IntegerValue(1)), Exact: true}),
-		// 			index.IndexScan("idx_foo_b", stream.IndexRange{Min: exprList(testutil.IntegerValue(2)), Exact: true}),
-		// 		),
-		// 	),
-		// },
-		// {
-		// 	"FROM foo WHERE a = 1 OR b > 2",
-		// 	stream.New(table.TableScan("foo")).
-		// 		Pipe(stream.Filter(parser.MustParseExpr("a = 1 OR b = 2"))),
-		// 	stream.New(
-		// 		stream.Union(
-		// 			index.IndexScan("idx_foo_a", stream.IndexRange{Min: exprList(testutil.IntegerValue(1)), Exact: true}),
-		// 			index.IndexScan("idx_foo_b", stream.IndexRange{Min: exprList(testutil.IntegerValue(2)), Exclusive: true}),
-		// 		),
-		// 	),
-		// },
-		// {
-		// 	"FROM foo WHERE a > 1 OR b > 2",
-		// 	stream.New(table.TableScan("foo")).
-		// 		Pipe(stream.Filter(parser.MustParseExpr("a = 1 OR b = 2"))),
-		// 	stream.New(table.TableScan("foo")).
-		// 		Pipe(stream.Filter(parser.MustParseExpr("a = 1 OR b = 2"))),
-		// },
+		{
+			"FROM foo WHERE a = 1 OR b = 2",
+			stream.New(table.Scan("foo")).
+				Pipe(docs.Filter(parser.MustParseExpr("a = 1 OR b = 2"))),
+			stream.New(
+				table.Union("foo",
+					stream.New(index.Scan("idx_foo_a", stream.Range{Min: exprList(testutil.IntegerValue(1)), Exact: true})),
+					stream.New(index.Scan("idx_foo_b", stream.Range{Min: exprList(testutil.IntegerValue(2)), Exact: true})),
+				),
+			),
+		},
+		{
+			"FROM foo WHERE a = 1 OR b > 2",
+			stream.New(table.Scan("foo")).
+				Pipe(docs.Filter(parser.MustParseExpr("a = 1 OR b > 2"))),
+			stream.New(
+				table.Union("foo",
+					stream.New(index.Scan("idx_foo_a", stream.Range{Min: exprList(testutil.IntegerValue(1)), Exact: true})),
+					stream.New(index.Scan("idx_foo_b", stream.Range{Min: exprList(testutil.IntegerValue(2)), Exclusive: true})),
+				),
+			),
+		},
+		{
+			"FROM foo WHERE a = 1 OR (b = 2 AND d = 3) OR k = 4",
+			stream.New(table.Scan("foo")).
+				Pipe(docs.Filter(parser.MustParseExpr("a = 1 OR (b = 2 AND d = 3) OR k = 4"))),
+			stream.New(
+				table.Union("foo",
+					stream.New(index.Scan("idx_foo_a", stream.Range{Min: exprList(testutil.IntegerValue(1)), Exact: true})),
+					stream.New(index.Scan("idx_foo_b", stream.Range{Min: exprList(testutil.IntegerValue(2)), Exact: true})).
+						Pipe(docs.Filter(parser.MustParseExpr("d = 3"))),
+					stream.New(table.Scan("foo", stream.Range{Min: exprList(testutil.IntegerValue(4)), Exact: true})),
+				),
+			),
+		},
+		{
+			"FROM foo WHERE a = 1 OR d = 2",
+			stream.New(table.Scan("foo")).
+				Pipe(docs.Filter(parser.MustParseExpr("a = 1 OR d = 2"))),
+			stream.New(table.Scan("foo")).
+				Pipe(docs.Filter(parser.MustParseExpr("a = 1 OR d = 2"))),
+		},
+		{
+			"FROM foo WHERE (a = 1 OR b = 2) AND d = 3",
+			stream.New(table.Scan("foo")).
+				Pipe(docs.Filter(parser.MustParseExpr("(a = 1 OR b = 2)"))).
+				Pipe(docs.Filter(parser.MustParseExpr("d = 3"))),
+			stream.New(
+				table.Union("foo",
+					stream.New(index.Scan("idx_foo_a", stream.Range{Min: exprList(testutil.IntegerValue(1)), Exact: true})),
+					stream.New(index.Scan("idx_foo_b", stream.Range{Min: exprList(testutil.IntegerValue(2)), Exact: true})),
+				),
+			).Pipe(docs.Filter(parser.MustParseExpr("d = 3"))),
+		},
 	}
 
 	for _, test := range tests {
@@ -464,6 +514,81 @@ func TestSelectIndex_Simple(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("LIKE", func(t *testing.T) {
+		caseSensitiveLike := func(path, pattern string) *docs.FilterOperator {
+			return docs.Filter(expr.CaseSensitiveLike(parser.MustParseExpr(path), testutil.TextValue(pattern)))
+		}
+
+		tests := []struct {
+			name           string
+			root, expected *stream.Stream
+		}{
+			{
+				"FROM foo WHERE a LIKE 'abc%'",
+				stream.New(table.Scan("foo")).Pipe(docs.Filter(parser.MustParseExpr("a LIKE 'abc%'"))),
+				stream.New(table.Scan("foo")).Pipe(docs.Filter(parser.MustParseExpr("a LIKE 'abc%'"))),
+			},
+			{
+				"FROM foo WHERE a LIKE '12%'",
+				stream.New(table.Scan("foo")).Pipe(docs.Filter(parser.MustParseExpr("a LIKE '12%'"))),
+				stream.New(index.Scan("idx_foo_a", stream.Range{Min: exprList(testutil.TextValue("12")), Max: exprList(testutil.TextValue("13"))})).
+					Pipe(docs.Filter(parser.MustParseExpr("a LIKE '12%'"))),
+			},
+			{
+				"FROM foo WHERE a LIKE '%12'",
+				stream.New(table.Scan("foo")).Pipe(docs.Filter(parser.MustParseExpr("a LIKE '%12'"))),
+				stream.New(table.Scan("foo")).Pipe(docs.Filter(parser.MustParseExpr("a LIKE '%12'"))),
+			},
+			{
+				"FROM foo WHERE a NOT LIKE '12%'",
+				stream.New(table.Scan("foo")).Pipe(docs.Filter(parser.MustParseExpr("a NOT LIKE '12%'"))),
+				stream.New(table.Scan("foo")).Pipe(docs.Filter(parser.MustParseExpr("a NOT LIKE '12%'"))),
+			},
+			{
+				"FROM foo WHERE b LIKE '12%'",
+				stream.New(table.Scan("foo")).Pipe(docs.Filter(parser.MustParseExpr("b LIKE '12%'"))),
+				stream.New(table.Scan("foo")).Pipe(docs.Filter(parser.MustParseExpr("b LIKE '12%'"))),
+			},
+			{
+				"FROM foo WHERE k LIKE '12%'",
+				stream.New(table.Scan("foo")).Pipe(docs.Filter(parser.MustParseExpr("k LIKE '12%'"))),
+				stream.New(table.Scan("foo", stream.Range{Min: exprList(testutil.TextValue("12")), Max: exprList(testutil.TextValue("13"))})).
+					Pipe(docs.Filter(parser.MustParseExpr("k LIKE '12%'"))),
+			},
+			{
+				"FROM foo WHERE a LIKE 'abc%' case-sensitive",
+				stream.New(table.Scan("foo")).Pipe(caseSensitiveLike("a", "abc%")),
+				stream.New(index.Scan("idx_foo_a", stream.Range{Min: exprList(testutil.TextValue("abc")), Max: exprList(testutil.TextValue("abd"))})).
+					Pipe(caseSensitiveLike("a", "abc%")),
+			},
+			{
+				"FROM foo WHERE a LIKE 'ab_d%' case-sensitive",
+				stream.New(table.Scan("foo")).Pipe(caseSensitiveLike("a", "ab_d%")),
+				stream.New(index.Scan("idx_foo_a", stream.Range{Min: exprList(testutil.TextValue("ab")), Max: exprList(testutil.TextValue("ac"))})).
+					Pipe(caseSensitiveLike("a", "ab_d%")),
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				db, tx, cleanup := testutil.NewTestTx(t)
+				defer cleanup()
+
+				testutil.MustExec(t, db, tx, `
+					CREATE TABLE foo (k TEXT PRIMARY KEY, a TEXT, b INT);
+					CREATE INDEX idx_foo_a ON foo(a);
+					CREATE INDEX idx_foo_b ON foo(b);
+				`)
+
+				sctx := planner.NewStreamContext(test.root)
+				sctx.Catalog = tx.Catalog
+				err := planner.SelectIndex(sctx)
+				assert.NoError(t, err)
+				require.Equal(t, test.expected.String(), sctx.Stream.String())
+			})
+		}
+	})
 }
 
 func TestSelectIndex_Composite(t *testing.T) {
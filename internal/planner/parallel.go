@@ -0,0 +1,55 @@
+package planner
+
+import (
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/stream/docs"
+	"github.com/genjidb/genji/internal/stream/table"
+)
+
+// Parallelize replaces a sequential scan of a whole table, and the filter nodes that
+// directly follow it, by a parallel scan using the given number of workers.
+// Since the parallel scan doesn't preserve the order of the table,
+// it must only be called on streams that don't rely on it.
+// Streams that don't start with a sequential scan of a whole table are returned unchanged.
+func Parallelize(s *stream.Stream, workers int) *stream.Stream {
+	if workers <= 1 {
+		return s
+	}
+
+	scan, ok := s.First().(*table.ScanOperator)
+	if !ok || len(scan.Ranges) > 0 || scan.Reverse {
+		return s
+	}
+
+	var filter expr.Expr
+	var filters []stream.Operator
+	for op := scan.GetNext(); op != nil; op = op.GetNext() {
+		f, ok := op.(*docs.FilterOperator)
+		if !ok {
+			break
+		}
+
+		if filter == nil {
+			filter = f.Expr
+		} else {
+			filter = expr.And(filter, f.Expr)
+		}
+		filters = append(filters, f)
+	}
+
+	for _, f := range filters {
+		s.Remove(f)
+	}
+
+	// we replace the seq scan node by the parallel scan
+	ps := table.ParallelScan(scan.TableName, workers, filter)
+	s.Remove(scan)
+	if s.Op == nil {
+		s.Op = ps
+	} else {
+		stream.InsertBefore(s.First(), ps)
+	}
+
+	return s
+}
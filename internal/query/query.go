@@ -74,8 +74,9 @@ func (q *Query) Prepare(context *Context) error {
 		}
 
 		stmt, err := p.Prepare(&statement.Context{
-			DB: context.DB,
-			Tx: tx,
+			Ctx: context.Ctx,
+			DB:  context.DB,
+			Tx:  tx,
 		})
 		if err != nil {
 			return err
@@ -132,6 +133,7 @@ func (q Query) Run(context *Context) (*statement.Result, error) {
 		}
 
 		res, err = stmt.Run(&statement.Context{
+			Ctx:    context.Ctx,
 			DB:     context.DB,
 			Tx:     q.tx,
 			Params: context.Params,
@@ -1,18 +1,26 @@
 package statement
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
 	errs "github.com/genjidb/genji/internal/errors"
+	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/index"
+	"github.com/genjidb/genji/internal/stream/path"
 	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/internal/stringutil"
 )
 
 // AlterTableRenameStmt is a DSL that allows creating a full ALTER TABLE query.
 type AlterTableRenameStmt struct {
 	TableName    string
 	NewTableName string
+	IfExists     bool
 }
 
 // IsReadOnly always returns false. It implements the Statement interface.
@@ -20,6 +28,19 @@ func (stmt AlterTableRenameStmt) IsReadOnly() bool {
 	return false
 }
 
+// String returns a SQL representation.
+func (stmt AlterTableRenameStmt) String() string {
+	return fmt.Sprintf("%s RENAME TO %s", alterTablePrefix(stmt.TableName, stmt.IfExists), stringutil.NormalizeIdentifier(stmt.NewTableName, '`'))
+}
+
+func alterTablePrefix(tableName string, ifExists bool) string {
+	if ifExists {
+		return "ALTER TABLE IF EXISTS " + stringutil.NormalizeIdentifier(tableName, '`')
+	}
+
+	return "ALTER TABLE " + stringutil.NormalizeIdentifier(tableName, '`')
+}
+
 // Run runs the ALTER TABLE statement in the given transaction.
 // It implements the Statement interface.
 func (stmt AlterTableRenameStmt) Run(ctx *Context) (Result, error) {
@@ -33,6 +54,13 @@ func (stmt AlterTableRenameStmt) Run(ctx *Context) (Result, error) {
 		return res, errors.New("missing new table name")
 	}
 
+	if stmt.IfExists {
+		_, err := ctx.Tx.Catalog.GetTableInfo(stmt.TableName)
+		if errs.IsNotFoundError(err) {
+			return res, nil
+		}
+	}
+
 	if stmt.TableName == stmt.NewTableName {
 		return res, errs.AlreadyExistsError{Name: stmt.NewTableName}
 	}
@@ -45,6 +73,7 @@ type AlterTableAddFieldStmt struct {
 	TableName        string
 	FieldConstraint  *database.FieldConstraint
 	TableConstraints database.TableConstraints
+	IfExists         bool
 }
 
 // IsReadOnly always returns false. It implements the Statement interface.
@@ -52,6 +81,35 @@ func (stmt *AlterTableAddFieldStmt) IsReadOnly() bool {
 	return false
 }
 
+// String returns a SQL representation.
+// Constraints on paths nested in the new field are not represented.
+func (stmt *AlterTableAddFieldStmt) String() string {
+	var b strings.Builder
+
+	b.WriteString(alterTablePrefix(stmt.TableName, stmt.IfExists))
+	b.WriteString(" ADD FIELD ")
+	b.WriteString(stmt.FieldConstraint.String())
+
+	path := document.NewPath(stmt.FieldConstraint.Field)
+	for _, tc := range stmt.TableConstraints {
+		switch {
+		case tc.Check != nil:
+			fmt.Fprintf(&b, " CHECK (%s)", tc.Check)
+		case len(tc.Paths) != 1 || !tc.Paths[0].IsEqual(path):
+			continue
+		case tc.PrimaryKey:
+			b.WriteString(" PRIMARY KEY")
+			if tc.SortOrder.IsDesc(0) {
+				b.WriteString(" DESC")
+			}
+		case tc.Unique:
+			b.WriteString(" UNIQUE")
+		}
+	}
+
+	return b.String()
+}
+
 // Run runs the ALTER TABLE ADD FIELD statement in the given transaction.
 // It implements the Statement interface.
 // The statement rebuilds the table.
@@ -63,6 +121,9 @@ func (stmt *AlterTableAddFieldStmt) Run(ctx *Context) (Result, error) {
 	// so that it can decode the records properly
 	scan := table.Scan(stmt.TableName)
 	scan.Table, err = ctx.Tx.Catalog.GetTable(ctx.Tx, stmt.TableName)
+	if errs.IsNotFoundError(err) && stmt.IfExists {
+		return Result{}, nil
+	}
 	if err != nil {
 		return Result{}, errors.Wrap(err, "failed to get table")
 	}
@@ -169,3 +230,164 @@ func (stmt *AlterTableAddFieldStmt) Run(ctx *Context) (Result, error) {
 		},
 	}, nil
 }
+
+// AlterTableDropFieldStmt is a DSL that allows creating an ALTER TABLE DROP FIELD query.
+type AlterTableDropFieldStmt struct {
+	TableName string
+	Field     string
+	IfExists  bool
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt *AlterTableDropFieldStmt) IsReadOnly() bool {
+	return false
+}
+
+// String returns a SQL representation.
+func (stmt *AlterTableDropFieldStmt) String() string {
+	return fmt.Sprintf("%s DROP FIELD %s", alterTablePrefix(stmt.TableName, stmt.IfExists), stringutil.NormalizeIdentifier(stmt.Field, '`'))
+}
+
+// Run runs the ALTER TABLE DROP FIELD statement in the given transaction.
+// It implements the Statement interface.
+// The statement removes the field from every document of the table,
+// and drops the indexes and unique constraints on it.
+func (stmt *AlterTableDropFieldStmt) Run(ctx *Context) (Result, error) {
+	scan, err := alterTableFieldScan(ctx, stmt.TableName, stmt.Field, "drop")
+	if errs.IsNotFoundError(err) && stmt.IfExists {
+		return Result{}, nil
+	}
+	if err != nil {
+		return Result{}, err
+	}
+
+	err = ctx.Tx.CatalogWriter().DropFieldConstraint(ctx.Tx, stmt.TableName, stmt.Field)
+	if err != nil {
+		return Result{}, err
+	}
+
+	s := stream.New(scan).
+		Pipe(path.Unset(stmt.Field)).
+		Pipe(table.Validate(stmt.TableName)).
+		Pipe(table.Replace(stmt.TableName)).
+		Pipe(stream.Discard())
+
+	// do NOT optimize the stream
+	return Result{
+		Iterator: &StreamStmtIterator{
+			Stream:  s,
+			Context: ctx,
+		},
+	}, nil
+}
+
+// AlterTableRenameFieldStmt is a DSL that allows creating an ALTER TABLE RENAME FIELD query.
+type AlterTableRenameFieldStmt struct {
+	TableName string
+	Field     string
+	NewName   string
+	IfExists  bool
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt *AlterTableRenameFieldStmt) IsReadOnly() bool {
+	return false
+}
+
+// String returns a SQL representation.
+func (stmt *AlterTableRenameFieldStmt) String() string {
+	return fmt.Sprintf("%s RENAME FIELD %s TO %s",
+		alterTablePrefix(stmt.TableName, stmt.IfExists),
+		stringutil.NormalizeIdentifier(stmt.Field, '`'),
+		stringutil.NormalizeIdentifier(stmt.NewName, '`'))
+}
+
+// Run runs the ALTER TABLE RENAME FIELD statement in the given transaction.
+// It implements the Statement interface.
+// The statement renames the field in every document of the table,
+// and in the constraints and indexes referencing it.
+func (stmt *AlterTableRenameFieldStmt) Run(ctx *Context) (Result, error) {
+	if stmt.Field == stmt.NewName {
+		return Result{}, errors.Errorf("field %q already exists for table %q", stmt.NewName, stmt.TableName)
+	}
+
+	scan, err := alterTableFieldScan(ctx, stmt.TableName, stmt.Field, "rename")
+	if errs.IsNotFoundError(err) && stmt.IfExists {
+		return Result{}, nil
+	}
+	if err != nil {
+		return Result{}, err
+	}
+
+	err = ctx.Tx.CatalogWriter().RenameFieldConstraint(ctx.Tx, stmt.TableName, stmt.Field, stmt.NewName)
+	if err != nil {
+		return Result{}, err
+	}
+
+	s := stream.New(scan).
+		Pipe(path.RenameField(stmt.Field, stmt.NewName)).
+		Pipe(table.Validate(stmt.TableName)).
+		Pipe(table.Replace(stmt.TableName)).
+		Pipe(stream.Discard())
+
+	// do NOT optimize the stream
+	return Result{
+		Iterator: &StreamStmtIterator{
+			Stream:  s,
+			Context: ctx,
+		},
+	}, nil
+}
+
+// alterTableFieldScan returns a scan of the table using its current schema,
+// so that the documents can be decoded once the schema is modified.
+// It returns an error if a CHECK constraint or an index on an expression
+// references the field.
+func alterTableFieldScan(ctx *Context, tableName, field, action string) (*table.ScanOperator, error) {
+	var err error
+
+	scan := table.Scan(tableName)
+	scan.Table, err = ctx.Tx.Catalog.GetTable(ctx.Tx, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tc := range scan.Table.Info.TableConstraints {
+		if tableExprReferencesField(tc.Check, field) {
+			return nil, errors.Errorf("cannot %s field %q because check constraint %q requires it", action, field, tc.Name)
+		}
+	}
+
+	for _, indexName := range ctx.Tx.Catalog.ListIndexes(tableName) {
+		info, err := ctx.Tx.Catalog.GetIndexInfo(indexName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range info.Exprs {
+			if tableExprReferencesField(e, field) {
+				return nil, errors.Errorf("cannot %s field %q because index %q requires it", action, field, indexName)
+			}
+		}
+	}
+
+	return scan, nil
+}
+
+// tableExprReferencesField returns whether te contains a path starting with field.
+func tableExprReferencesField(te database.TableExpression, field string) bool {
+	ce, ok := te.(*expr.ConstraintExpr)
+	if !ok {
+		return false
+	}
+
+	var found bool
+	expr.Walk(ce.Expr, func(e expr.Expr) bool {
+		if p, ok := e.(expr.Path); ok && len(p) > 0 && p[0].FieldName == field {
+			found = true
+		}
+		return !found
+	})
+
+	return found
+}
@@ -0,0 +1,65 @@
+package statement
+
+import (
+	"strings"
+
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/stringutil"
+)
+
+// AnalyzeStmt is a DSL that allows creating an ANALYZE statement.
+// It collects statistics about the content of a table and of its indexes
+// and stores them in the __genji_stats table, to be used by the planner.
+// If no table name is provided, all the tables are analyzed.
+type AnalyzeStmt struct {
+	TableName string
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt AnalyzeStmt) IsReadOnly() bool {
+	return false
+}
+
+// String returns a SQL representation.
+func (stmt AnalyzeStmt) String() string {
+	if stmt.TableName == "" {
+		return "ANALYZE"
+	}
+
+	return "ANALYZE " + stringutil.NormalizeIdentifier(stmt.TableName, '`')
+}
+
+// Run runs the Analyze statement in the given transaction.
+// It implements the Statement interface.
+func (stmt AnalyzeStmt) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	tableNames := []string{stmt.TableName}
+	if stmt.TableName == "" {
+		tableNames = tableNames[:0]
+		for _, name := range ctx.Tx.Catalog.Cache.ListObjects(database.RelationTableType) {
+			if !strings.HasPrefix(name, database.InternalPrefix) {
+				tableNames = append(tableNames, name)
+			}
+		}
+	}
+
+	for _, name := range tableNames {
+		tb, err := ctx.Tx.Catalog.GetTable(ctx.Tx, name)
+		if err != nil {
+			return res, err
+		}
+
+		stats, err := tb.Stats()
+		if err != nil {
+			return res, err
+		}
+
+		err = ctx.Tx.CatalogWriter().SetTableStats(ctx.Tx, name, stats)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
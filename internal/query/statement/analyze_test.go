@@ -0,0 +1,66 @@
+package statement_test
+
+import (
+	"testing"
+
+	"github.com/genjidb/genji/internal/testutil"
+	"github.com/genjidb/genji/internal/testutil/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		expectTables  []string
+		expectIndexes map[string][2]int64
+		fails         bool
+	}{
+		{"Analyze all", `ANALYZE`, []string{"test1", "test2"}, map[string][2]int64{"idx_test1_a": {3, 2}, "idx_test2_a": {2, 2}}, false},
+		{"Analyze table", `ANALYZE test2`, []string{"test2"}, map[string][2]int64{"idx_test2_a": {2, 2}}, false},
+		{"Analyze unknown", `ANALYZE doesntexist`, nil, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, tx, cleanup := testutil.NewTestTx(t)
+			defer cleanup()
+
+			testutil.MustExec(t, db, tx, `
+				CREATE TABLE test1(a any, b any);
+				CREATE TABLE test2(a any, b any);
+
+				CREATE INDEX idx_test1_a ON test1(a);
+				CREATE INDEX idx_test2_a ON test2(a);
+
+				INSERT INTO test1(a, b) VALUES (1, 'a'), (1, 'b'), (2, 'c');
+				INSERT INTO test2(a, b) VALUES (3, 'c'), (4, 'd');
+			`)
+
+			err := testutil.Exec(db, tx, test.query)
+			if test.fails {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			for _, name := range []string{"test1", "test2"} {
+				stats := tx.Catalog.GetTableStats(name)
+
+				var expected bool
+				for _, n := range test.expectTables {
+					expected = expected || n == name
+				}
+				if !expected {
+					require.Nil(t, stats)
+					continue
+				}
+
+				require.NotNil(t, stats)
+				for idxName, is := range stats.Indexes {
+					require.Equal(t, test.expectIndexes[idxName], [2]int64{is.EntryCount, is.DistinctCount})
+				}
+			}
+		})
+	}
+}
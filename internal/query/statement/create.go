@@ -1,10 +1,13 @@
 package statement
 
 import (
+	"fmt"
 	"math"
+	"strings"
 
 	"github.com/genjidb/genji/internal/database"
 	errs "github.com/genjidb/genji/internal/errors"
+	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/index"
 	"github.com/genjidb/genji/internal/stream/table"
@@ -21,11 +24,33 @@ func (stmt *CreateTableStmt) IsReadOnly() bool {
 	return false
 }
 
+// String returns a SQL representation.
+func (stmt *CreateTableStmt) String() string {
+	s := stmt.Info.String()
+	if stmt.IfNotExists {
+		s = strings.Replace(s, "TABLE ", "TABLE IF NOT EXISTS ", 1)
+	}
+
+	return s
+}
+
 // Run runs the Create table statement in the given transaction.
 // It implements the Statement interface.
 func (stmt *CreateTableStmt) Run(ctx *Context) (Result, error) {
 	var res Result
 
+	// if the table already exists, return early to avoid creating
+	// a docid sequence that wouldn't be used by any table
+	if stmt.IfNotExists {
+		_, err := ctx.Tx.Catalog.GetTableInfo(stmt.Info.TableName)
+		if err == nil {
+			return res, nil
+		}
+		if !errs.IsNotFoundError(err) {
+			return res, err
+		}
+	}
+
 	// if there is no primary key, create a docid sequence
 	if stmt.Info.GetPrimaryKey() == nil {
 		seq := database.SequenceInfo{
@@ -45,11 +70,35 @@ func (stmt *CreateTableStmt) Run(ctx *Context) (Result, error) {
 		stmt.Info.DocidSequenceName = seq.Name
 	}
 
-	err := ctx.Tx.CatalogWriter().CreateTable(ctx.Tx, stmt.Info.TableName, &stmt.Info)
-	if stmt.IfNotExists {
-		if errs.IsAlreadyExistsError(err) {
-			return res, nil
+	// if the primary key is AUTOINCREMENT, create a sequence owned by the primary key
+	// and use it as the default value of the primary key field
+	for _, tc := range stmt.Info.TableConstraints {
+		if !tc.PrimaryKey || !tc.AutoIncrement {
+			continue
 		}
+
+		seq := database.SequenceInfo{
+			IncrementBy: 1,
+			Min:         1, Max: math.MaxInt64,
+			Start: 1,
+			Cache: 64,
+			Owner: database.Owner{
+				TableName: stmt.Info.TableName,
+				Paths:     tc.Paths,
+			},
+		}
+		err := ctx.Tx.CatalogWriter().CreateSequence(ctx.Tx, &seq)
+		if err != nil {
+			return res, err
+		}
+
+		fc := stmt.Info.GetFieldConstraintForPath(tc.Paths[0])
+		fc.DefaultValue = expr.Constraint(expr.NextValueFor{SeqName: seq.Name})
+	}
+
+	err := ctx.Tx.CatalogWriter().CreateTable(ctx.Tx, stmt.Info.TableName, &stmt.Info)
+	if err != nil {
+		return res, err
 	}
 
 	// create a unique index for every unique constraint
@@ -84,6 +133,16 @@ func (stmt *CreateIndexStmt) IsReadOnly() bool {
 	return false
 }
 
+// String returns a SQL representation.
+func (stmt *CreateIndexStmt) String() string {
+	s := stmt.Info.String()
+	if stmt.IfNotExists {
+		s = strings.Replace(s, "INDEX ", "INDEX IF NOT EXISTS ", 1)
+	}
+
+	return s
+}
+
 // Run runs the Create index statement in the given transaction.
 // It implements the Statement interface.
 func (stmt *CreateIndexStmt) Run(ctx *Context) (Result, error) {
@@ -99,8 +158,17 @@ func (stmt *CreateIndexStmt) Run(ctx *Context) (Result, error) {
 		return res, err
 	}
 
-	s := stream.New(table.Scan(stmt.Info.Owner.TableName)).
-		Pipe(index.Insert(stmt.Info.IndexName)).
+	// backfill the index with the existing documents of the table.
+	// documents are streamed and the underlying batch is flushed regularly,
+	// so large tables don't require holding every write in memory.
+	s := stream.New(table.Scan(stmt.Info.Owner.TableName))
+
+	// ensure the existing documents don't violate the unique constraint.
+	if stmt.Info.Unique {
+		s = s.Pipe(index.Validate(stmt.Info.IndexName))
+	}
+
+	s = s.Pipe(index.Insert(stmt.Info.IndexName)).
 		Pipe(stream.Discard())
 
 	ss := PreparedStreamStmt{
@@ -122,6 +190,16 @@ func (stmt *CreateSequenceStmt) IsReadOnly() bool {
 	return false
 }
 
+// String returns a SQL representation.
+func (stmt *CreateSequenceStmt) String() string {
+	s := stmt.Info.String()
+	if stmt.IfNotExists {
+		s = strings.Replace(s, "SEQUENCE ", "SEQUENCE IF NOT EXISTS ", 1)
+	}
+
+	return s
+}
+
 // Run the statement in the given transaction.
 // It implements the Statement interface.
 func (stmt *CreateSequenceStmt) Run(ctx *Context) (Result, error) {
@@ -135,3 +213,114 @@ func (stmt *CreateSequenceStmt) Run(ctx *Context) (Result, error) {
 	}
 	return res, err
 }
+
+// CreateViewStmt represents a parsed CREATE VIEW statement.
+type CreateViewStmt struct {
+	IfNotExists bool
+	Info        database.ViewInfo
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt *CreateViewStmt) IsReadOnly() bool {
+	return false
+}
+
+// String returns a SQL representation.
+func (stmt *CreateViewStmt) String() string {
+	s := stmt.Info.String()
+	if stmt.IfNotExists {
+		s = strings.Replace(s, "VIEW ", "VIEW IF NOT EXISTS ", 1)
+	}
+
+	return s
+}
+
+// Run runs the Create view statement in the given transaction.
+// It implements the Statement interface.
+func (stmt *CreateViewStmt) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	if stmt.IfNotExists {
+		_, err := ctx.Tx.Catalog.GetViewInfo(stmt.Info.ViewName)
+		if err == nil {
+			return res, nil
+		}
+		if !errs.IsNotFoundError(err) {
+			return res, err
+		}
+	}
+
+	q, ok := stmt.Info.Query.(ViewQuery)
+	if !ok {
+		return res, fmt.Errorf("invalid query for view %q", stmt.Info.ViewName)
+	}
+
+	// ensure the query can be prepared, i.e. that the tables
+	// and views it reads exist
+	sel, err := q.SelectStmt()
+	if err != nil {
+		return res, err
+	}
+	_, err = sel.Prepare(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	err = ctx.Tx.CatalogWriter().CreateView(ctx.Tx, &stmt.Info)
+	if stmt.IfNotExists && errs.IsAlreadyExistsError(err) {
+		return res, nil
+	}
+
+	return res, err
+}
+
+// CreateTriggerStmt represents a parsed CREATE TRIGGER statement.
+type CreateTriggerStmt struct {
+	IfNotExists bool
+	Info        database.TriggerInfo
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt *CreateTriggerStmt) IsReadOnly() bool {
+	return false
+}
+
+// String returns a SQL representation.
+func (stmt *CreateTriggerStmt) String() string {
+	s := stmt.Info.String()
+	if stmt.IfNotExists {
+		s = strings.Replace(s, "TRIGGER ", "TRIGGER IF NOT EXISTS ", 1)
+	}
+
+	return s
+}
+
+// Run runs the Create trigger statement in the given transaction.
+// It implements the Statement interface.
+func (stmt *CreateTriggerStmt) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	if stmt.IfNotExists {
+		_, err := ctx.Tx.Catalog.GetTriggerInfo(stmt.Info.TriggerName)
+		if err == nil {
+			return res, nil
+		}
+		if !errs.IsNotFoundError(err) {
+			return res, err
+		}
+	}
+
+	// ensure the action can be prepared, i.e. that the tables
+	// it modifies exist
+	_, err := prepareTriggerAction(ctx, &stmt.Info)
+	if err != nil {
+		return res, err
+	}
+
+	err = ctx.Tx.CatalogWriter().CreateTrigger(ctx.Tx, &stmt.Info)
+	if stmt.IfNotExists && errs.IsAlreadyExistsError(err) {
+		return res, nil
+	}
+
+	return res, err
+}
@@ -1,12 +1,19 @@
 package statement
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/expr"
-	"github.com/genjidb/genji/internal/sql/scanner"
+	"github.com/genjidb/genji/internal/expr/functions"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/docs"
 	"github.com/genjidb/genji/internal/stream/index"
 	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/internal/stringutil"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
 )
 
 // DeleteConfig holds DELETE configuration.
@@ -16,9 +23,12 @@ type DeleteStmt struct {
 	TableName        string
 	WhereExpr        expr.Expr
 	OffsetExpr       expr.Expr
-	OrderBy          expr.Path
+	OrderBy          []expr.Path
 	LimitExpr        expr.Expr
-	OrderByDirection scanner.Token
+	OrderByDirection tree.SortOrder
+	// If true, the statement returns the documents
+	// that would be deleted, without deleting them.
+	dryRun bool
 }
 
 func NewDeleteStatement() *DeleteStmt {
@@ -32,6 +42,25 @@ func NewDeleteStatement() *DeleteStmt {
 	return &p
 }
 
+// Limit deletes at most n documents.
+func (stmt *DeleteStmt) Limit(n int64) *DeleteStmt {
+	stmt.LimitExpr = expr.LiteralValue{Value: types.NewIntegerValue(n)}
+	return stmt
+}
+
+// Offset skips the first n documents matching the statement.
+func (stmt *DeleteStmt) Offset(n int64) *DeleteStmt {
+	stmt.OffsetExpr = expr.LiteralValue{Value: types.NewIntegerValue(n)}
+	return stmt
+}
+
+// DryRun makes the statement return the documents it would modify
+// instead of modifying the table.
+func (stmt *DeleteStmt) DryRun() *DeleteStmt {
+	stmt.dryRun = true
+	return stmt
+}
+
 func (stmt *DeleteStmt) Prepare(c *Context) (Statement, error) {
 	s := stream.New(table.Scan(stmt.TableName))
 
@@ -39,12 +68,9 @@ func (stmt *DeleteStmt) Prepare(c *Context) (Statement, error) {
 		s = s.Pipe(docs.Filter(stmt.WhereExpr))
 	}
 
-	if stmt.OrderBy != nil {
-		if stmt.OrderByDirection == scanner.DESC {
-			s = s.Pipe(docs.TempTreeSortReverse(stmt.OrderBy))
-		} else {
-			s = s.Pipe(docs.TempTreeSort(stmt.OrderBy))
-		}
+	s, err := pipeOrderBy(s, stmt.OrderBy, stmt.OrderByDirection)
+	if err != nil {
+		return nil, err
 	}
 
 	if stmt.OffsetExpr != nil {
@@ -55,6 +81,20 @@ func (stmt *DeleteStmt) Prepare(c *Context) (Statement, error) {
 		s = s.Pipe(docs.Take(stmt.LimitExpr))
 	}
 
+	if stmt.dryRun {
+		st := StreamStmt{
+			Stream:   pipeDryRun(s),
+			ReadOnly: true,
+		}
+
+		return st.Prepare(c)
+	}
+
+	s, err = pipeTriggers(c, s, stmt.TableName, database.TriggerBefore, database.TriggerDelete)
+	if err != nil {
+		return nil, err
+	}
+
 	indexNames := c.Tx.Catalog.ListIndexes(stmt.TableName)
 	for _, indexName := range indexNames {
 		s = s.Pipe(index.Delete(indexName))
@@ -62,6 +102,11 @@ func (stmt *DeleteStmt) Prepare(c *Context) (Statement, error) {
 
 	s = s.Pipe(table.Delete(stmt.TableName))
 
+	s, err = pipeTriggers(c, s, stmt.TableName, database.TriggerAfter, database.TriggerDelete)
+	if err != nil {
+		return nil, err
+	}
+
 	s = s.Pipe(stream.Discard())
 
 	st := StreamStmt{
@@ -71,3 +116,32 @@ func (stmt *DeleteStmt) Prepare(c *Context) (Statement, error) {
 
 	return st.Prepare(c)
 }
+
+// String returns a SQL representation.
+func (stmt *DeleteStmt) String() string {
+	var b strings.Builder
+
+	b.WriteString("DELETE FROM ")
+	b.WriteString(stringutil.NormalizeIdentifier(stmt.TableName, '`'))
+
+	if stmt.WhereExpr != nil {
+		fmt.Fprintf(&b, " WHERE %s", stmt.WhereExpr)
+	}
+
+	writeOrderByLimitOffset(&b, stmt.OrderBy, stmt.OrderByDirection, stmt.LimitExpr, stmt.OffsetExpr)
+
+	if stmt.dryRun {
+		b.WriteString(" DRY RUN")
+	}
+
+	return b.String()
+}
+
+// pipeDryRun pipes a projection returning the key and the content
+// of each document of the stream, instead of modifying the table.
+func pipeDryRun(s *stream.Stream) *stream.Stream {
+	return s.Pipe(docs.Project(
+		&expr.NamedExpr{ExprName: "pk()", Expr: new(functions.PK)},
+		expr.Wildcard{},
+	))
+}
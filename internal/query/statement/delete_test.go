@@ -2,9 +2,14 @@ package statement_test
 
 import (
 	"bytes"
+	"context"
 	"testing"
 
 	"github.com/genjidb/genji"
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/query"
+	"github.com/genjidb/genji/internal/query/statement"
+	"github.com/genjidb/genji/internal/sql/parser"
 	"github.com/genjidb/genji/internal/testutil"
 	"github.com/genjidb/genji/internal/testutil/assert"
 	"github.com/stretchr/testify/require"
@@ -62,3 +67,74 @@ func TestDeleteStmt(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteStmtDryRun(t *testing.T) {
+	db, tx, cleanup := testutil.NewTestTx(t)
+	defer cleanup()
+
+	testutil.MustExec(t, db, tx, `
+		CREATE TABLE test(a INT PRIMARY KEY, b TEXT);
+		INSERT INTO test (a, b) VALUES (1, 'foo'), (2, 'bar'), (3, 'foo');
+	`)
+
+	pq, err := parser.ParseQuery("DELETE FROM test WHERE b = 'foo'")
+	assert.NoError(t, err)
+	pq.Statements[0].(*statement.DeleteStmt).DryRun()
+
+	require.JSONEq(t, `[
+		{"pk()": [1], "a": 1, "b": "foo"},
+		{"pk()": [3], "a": 3, "b": "foo"}
+	]`, runQueryToJSON(t, db, tx, pq))
+
+	res := testutil.MustQuery(t, db, tx, "SELECT COUNT(*) FROM test")
+	defer res.Close()
+
+	var buf bytes.Buffer
+	err = testutil.IteratorToJSONArray(&buf, res)
+	assert.NoError(t, err)
+	require.JSONEq(t, `[{"COUNT(*)": 3}]`, buf.String())
+}
+
+func TestDeleteStmtLimitOffset(t *testing.T) {
+	db, tx, cleanup := testutil.NewTestTx(t)
+	defer cleanup()
+
+	testutil.MustExec(t, db, tx, `
+		CREATE TABLE test(a INT PRIMARY KEY, b TEXT);
+		INSERT INTO test (a, b) VALUES (1, 'foo'), (2, 'bar'), (3, 'foo'), (4, 'foo'), (5, 'foo');
+	`)
+
+	pq, err := parser.ParseQuery("DELETE FROM test WHERE b = 'foo' ORDER BY a DESC")
+	assert.NoError(t, err)
+	pq.Statements[0].(*statement.DeleteStmt).Limit(2).Offset(1)
+
+	runQueryToJSON(t, db, tx, pq)
+
+	res := testutil.MustQuery(t, db, tx, "SELECT a FROM test")
+	defer res.Close()
+
+	var buf bytes.Buffer
+	err = testutil.IteratorToJSONArray(&buf, res)
+	assert.NoError(t, err)
+	require.JSONEq(t, `[{"a": 1}, {"a": 2}, {"a": 5}]`, buf.String())
+}
+
+// runQueryToJSON prepares and runs pq, and returns its result
+// as a JSON array.
+func runQueryToJSON(t *testing.T, db *database.Database, tx *database.Transaction, pq query.Query) string {
+	t.Helper()
+
+	ctx := &query.Context{Ctx: context.Background(), DB: db, Tx: tx}
+	err := pq.Prepare(ctx)
+	assert.NoError(t, err)
+
+	res, err := pq.Run(ctx)
+	assert.NoError(t, err)
+	defer res.Close()
+
+	var buf bytes.Buffer
+	err = testutil.IteratorToJSONArray(&buf, res)
+	assert.NoError(t, err)
+
+	return buf.String()
+}
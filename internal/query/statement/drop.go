@@ -5,6 +5,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 	errs "github.com/genjidb/genji/internal/errors"
+	"github.com/genjidb/genji/internal/stringutil"
 )
 
 // DropTableStmt is a DSL that allows creating a DROP TABLE query.
@@ -18,6 +19,15 @@ func (stmt DropTableStmt) IsReadOnly() bool {
 	return false
 }
 
+// String returns a SQL representation.
+func (stmt DropTableStmt) String() string {
+	if stmt.IfExists {
+		return fmt.Sprintf("DROP TABLE IF EXISTS %s", stringutil.NormalizeIdentifier(stmt.TableName, '`'))
+	}
+
+	return fmt.Sprintf("DROP TABLE %s", stringutil.NormalizeIdentifier(stmt.TableName, '`'))
+}
+
 // Run runs the DropTable statement in the given transaction.
 // It implements the Statement interface.
 func (stmt DropTableStmt) Run(ctx *Context) (Result, error) {
@@ -41,9 +51,18 @@ func (stmt DropTableStmt) Run(ctx *Context) (Result, error) {
 		return res, err
 	}
 
-	// if there is no primary key, drop the docid sequence
-	if tb.Info.GetPrimaryKey() == nil {
-		err = ctx.Tx.CatalogWriter().DropSequence(ctx.Tx, tb.Info.DocidSequenceName)
+	// drop the sequences owned by the table, i.e. the docid sequence
+	// or the sequence of an AUTOINCREMENT primary key
+	for _, seqName := range ctx.Tx.Catalog.ListSequences() {
+		seq, err := ctx.Tx.Catalog.GetSequence(seqName)
+		if err != nil {
+			return res, err
+		}
+		if seq.Info.Owner.TableName != tb.Info.TableName {
+			continue
+		}
+
+		err = ctx.Tx.CatalogWriter().DropSequence(ctx.Tx, seqName)
 		if err != nil {
 			return res, err
 		}
@@ -63,6 +82,15 @@ func (stmt DropIndexStmt) IsReadOnly() bool {
 	return false
 }
 
+// String returns a SQL representation.
+func (stmt DropIndexStmt) String() string {
+	if stmt.IfExists {
+		return fmt.Sprintf("DROP INDEX IF EXISTS %s", stringutil.NormalizeIdentifier(stmt.IndexName, '`'))
+	}
+
+	return fmt.Sprintf("DROP INDEX %s", stringutil.NormalizeIdentifier(stmt.IndexName, '`'))
+}
+
 // Run runs the DropIndex statement in the given transaction.
 // It implements the Statement interface.
 func (stmt DropIndexStmt) Run(ctx *Context) (Result, error) {
@@ -91,6 +119,15 @@ func (stmt DropSequenceStmt) IsReadOnly() bool {
 	return false
 }
 
+// String returns a SQL representation.
+func (stmt DropSequenceStmt) String() string {
+	if stmt.IfExists {
+		return fmt.Sprintf("DROP SEQUENCE IF EXISTS %s", stringutil.NormalizeIdentifier(stmt.SequenceName, '`'))
+	}
+
+	return fmt.Sprintf("DROP SEQUENCE %s", stringutil.NormalizeIdentifier(stmt.SequenceName, '`'))
+}
+
 // Run runs the DropSequence statement in the given transaction.
 // It implements the Statement interface.
 func (stmt DropSequenceStmt) Run(ctx *Context) (Result, error) {
@@ -119,3 +156,77 @@ func (stmt DropSequenceStmt) Run(ctx *Context) (Result, error) {
 
 	return res, err
 }
+
+// DropViewStmt is a DSL that allows creating a DROP VIEW query.
+type DropViewStmt struct {
+	ViewName string
+	IfExists bool
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt DropViewStmt) IsReadOnly() bool {
+	return false
+}
+
+// String returns a SQL representation.
+func (stmt DropViewStmt) String() string {
+	if stmt.IfExists {
+		return fmt.Sprintf("DROP VIEW IF EXISTS %s", stringutil.NormalizeIdentifier(stmt.ViewName, '`'))
+	}
+
+	return fmt.Sprintf("DROP VIEW %s", stringutil.NormalizeIdentifier(stmt.ViewName, '`'))
+}
+
+// Run runs the DropView statement in the given transaction.
+// It implements the Statement interface.
+func (stmt DropViewStmt) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	if stmt.ViewName == "" {
+		return res, errors.New("missing view name")
+	}
+
+	err := ctx.Tx.CatalogWriter().DropView(ctx.Tx, stmt.ViewName)
+	if errs.IsNotFoundError(err) && stmt.IfExists {
+		err = nil
+	}
+
+	return res, err
+}
+
+// DropTriggerStmt is a DSL that allows creating a DROP TRIGGER query.
+type DropTriggerStmt struct {
+	TriggerName string
+	IfExists    bool
+}
+
+// IsReadOnly always returns false. It implements the Statement interface.
+func (stmt DropTriggerStmt) IsReadOnly() bool {
+	return false
+}
+
+// String returns a SQL representation.
+func (stmt DropTriggerStmt) String() string {
+	if stmt.IfExists {
+		return fmt.Sprintf("DROP TRIGGER IF EXISTS %s", stringutil.NormalizeIdentifier(stmt.TriggerName, '`'))
+	}
+
+	return fmt.Sprintf("DROP TRIGGER %s", stringutil.NormalizeIdentifier(stmt.TriggerName, '`'))
+}
+
+// Run runs the DropTrigger statement in the given transaction.
+// It implements the Statement interface.
+func (stmt DropTriggerStmt) Run(ctx *Context) (Result, error) {
+	var res Result
+
+	if stmt.TriggerName == "" {
+		return res, errors.New("missing trigger name")
+	}
+
+	err := ctx.Tx.CatalogWriter().DropTrigger(ctx.Tx, stmt.TriggerName)
+	if errs.IsNotFoundError(err) && stmt.IfExists {
+		err = nil
+	}
+
+	return res, err
+}
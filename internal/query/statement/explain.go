@@ -1,7 +1,11 @@
 package statement
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/docs"
@@ -11,8 +15,12 @@ import (
 // ExplainStmt is a Statement that
 // displays information about how a statement
 // is going to be executed, without executing it.
+// If Analyze is true, the statement is executed
+// and the number of documents and the time spent
+// in each operator is reported along with the plan.
 type ExplainStmt struct {
 	Statement Preparer
+	Analyze   bool
 }
 
 // Run analyses the inner statement and displays its execution plan.
@@ -37,21 +45,104 @@ func (stmt *ExplainStmt) Run(ctx *Context) (Result, error) {
 		plan = "<no exec>"
 	}
 
+	exprs := []expr.Expr{
+		&expr.NamedExpr{
+			ExprName: "plan",
+			Expr:     expr.LiteralValue{Value: types.NewTextValue(plan)},
+		},
+	}
+
+	if stmt.Analyze && s.Stream != nil && s.Stream.Op != nil {
+		analyzed, err := analyze(ctx, s)
+		if err != nil {
+			return Result{}, err
+		}
+		exprs = append(exprs, analyzed...)
+	}
+
 	newStatement := PreparedStreamStmt{
 		Stream: &stream.Stream{
-			Op: docs.Project(
-				&expr.NamedExpr{
-					ExprName: "plan",
-					Expr:     expr.LiteralValue{Value: types.NewTextValue(plan)},
-				}),
+			Op: docs.Project(exprs...),
 		},
 		ReadOnly: true,
 	}
 	return newStatement.Run(ctx)
 }
 
+// analyze runs the statement with an AnalyzeOperator inserted after each operator
+// and returns the collected metrics as a list of named expressions.
+func analyze(ctx *Context, s *PreparedStreamStmt) ([]expr.Expr, error) {
+	var ops []stream.Operator
+	for op := s.Stream.First(); op != nil; op = op.GetNext() {
+		ops = append(ops, op)
+	}
+
+	analyzers := make([]*stream.AnalyzeOperator, len(ops))
+	for i, op := range ops {
+		analyzers[i] = stream.Analyze()
+		stream.InsertAfter(op, analyzers[i])
+	}
+	s.Stream.Op = analyzers[len(analyzers)-1]
+
+	res, err := s.Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var returned int64
+	err = res.Iterate(func(d types.Document) error {
+		returned++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// each analyzer measures the time spent in its operator
+	// and in all the operators before it.
+	stages := document.NewValueBuffer()
+	var prev time.Duration
+	for i, op := range ops {
+		elapsed := analyzers[i].Elapsed - prev
+		if elapsed < 0 {
+			elapsed = 0
+		}
+		prev = analyzers[i].Elapsed
+
+		stages.Append(types.NewDocumentValue(document.NewFieldBuffer().
+			Add("operator", types.NewTextValue(op.String())).
+			Add("rows", types.NewIntegerValue(analyzers[i].Rows)).
+			Add("time", types.NewTextValue(elapsed.String())),
+		))
+	}
+
+	return []expr.Expr{
+		&expr.NamedExpr{
+			ExprName: "rows_examined",
+			Expr:     expr.LiteralValue{Value: types.NewIntegerValue(analyzers[0].Rows)},
+		},
+		&expr.NamedExpr{
+			ExprName: "rows_returned",
+			Expr:     expr.LiteralValue{Value: types.NewIntegerValue(returned)},
+		},
+		&expr.NamedExpr{
+			ExprName: "stages",
+			Expr:     expr.LiteralValue{Value: types.NewArrayValue(stages)},
+		},
+	}, nil
+}
+
 // IsReadOnly indicates that this statement doesn't write anything into
 // the database.
 func (s *ExplainStmt) IsReadOnly() bool {
 	return true
 }
+
+// String returns a SQL representation.
+func (s *ExplainStmt) String() string {
+	if s.Analyze {
+		return fmt.Sprintf("EXPLAIN ANALYZE %s", s.Statement)
+	}
+
+	return fmt.Sprintf("EXPLAIN %s", s.Statement)
+}
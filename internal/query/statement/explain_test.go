@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/genjidb/genji"
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/testutil/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -31,13 +32,17 @@ func TestExplainStmt(t *testing.T) {
 		{"EXPLAIN SELECT a + 1 FROM test WHERE c > 30 ORDER BY d DESC LIMIT 10 OFFSET 20", false, `"table.Scan(\"test\") | docs.Filter(c > 30) | docs.Project(a + 1) | docs.TempTreeSortReverse(d) | docs.Skip(20) | docs.Take(10)"`},
 		{"EXPLAIN SELECT a + 1 FROM test WHERE c > 30 ORDER BY a DESC LIMIT 10 OFFSET 20", false, `"index.ScanReverse(\"idx_a\") | docs.Filter(c > 30) | docs.Project(a + 1) | docs.Skip(20) | docs.Take(10)"`},
 		{"EXPLAIN SELECT a FROM test WHERE c > 30 GROUP BY a ORDER BY a DESC LIMIT 10 OFFSET 20", false, `"index.ScanReverse(\"idx_a\") | docs.Filter(c > 30) | docs.GroupAggregate(a) | docs.Project(a) | docs.Skip(20) | docs.Take(10)"`},
-		{"EXPLAIN SELECT a + 1 FROM test WHERE c > 30 GROUP BY a + 1 ORDER BY a DESC LIMIT 10 OFFSET 20", false, `"table.Scan(\"test\") | docs.Filter(c > 30) | docs.TempTreeSort(a + 1) | docs.GroupAggregate(a + 1) | docs.Project(a + 1) | docs.TempTreeSortReverse(a) | docs.Skip(20) | docs.Take(10)"`},
+		{"EXPLAIN SELECT a + 1 FROM test WHERE c > 30 GROUP BY a + 1 ORDER BY a DESC LIMIT 10 OFFSET 20", false, `"table.Scan(\"test\") | docs.Filter(c > 30) | docs.TempTreeSort(a + 1) | docs.GroupAggregate(a + 1) | docs.Project(` + "`a + 1`" + `) | docs.TempTreeSortReverse(a) | docs.Skip(20) | docs.Take(10)"`},
 		{"EXPLAIN UPDATE test SET a = 10", false, `"table.Scan(\"test\") | paths.Set(a, 10) | table.Validate(\"test\") | index.Delete(\"idx_a\") | index.Delete(\"idx_b\") | index.Delete(\"idx_x_y\") | table.Replace(\"test\") | index.Insert(\"idx_a\") | index.Validate(\"idx_b\") | index.Insert(\"idx_b\") | index.Insert(\"idx_x_y\") | discard()"`},
 		{"EXPLAIN UPDATE test SET a = 10 WHERE c > 10", false, `"table.Scan(\"test\") | docs.Filter(c > 10) | paths.Set(a, 10) | table.Validate(\"test\") | index.Delete(\"idx_a\") | index.Delete(\"idx_b\") | index.Delete(\"idx_x_y\") | table.Replace(\"test\") | index.Insert(\"idx_a\") | index.Validate(\"idx_b\") | index.Insert(\"idx_b\") | index.Insert(\"idx_x_y\") | discard()"`},
 		{"EXPLAIN UPDATE test SET a = 10 WHERE a > 10", false, `"index.Scan(\"idx_a\", [{\"min\": [10], \"exclusive\": true}]) | paths.Set(a, 10) | table.Validate(\"test\") | index.Delete(\"idx_a\") | index.Delete(\"idx_b\") | index.Delete(\"idx_x_y\") | table.Replace(\"test\") | index.Insert(\"idx_a\") | index.Validate(\"idx_b\") | index.Insert(\"idx_b\") | index.Insert(\"idx_x_y\") | discard()"`},
 		{"EXPLAIN DELETE FROM test", false, `"table.Scan(\"test\") | index.Delete(\"idx_a\") | index.Delete(\"idx_b\") | index.Delete(\"idx_x_y\") | table.Delete('test') | discard()"`},
 		{"EXPLAIN DELETE FROM test WHERE c > 10", false, `"table.Scan(\"test\") | docs.Filter(c > 10) | index.Delete(\"idx_a\") | index.Delete(\"idx_b\") | index.Delete(\"idx_x_y\") | table.Delete('test') | discard()"`},
 		{"EXPLAIN DELETE FROM test WHERE a > 10", false, `"index.Scan(\"idx_a\", [{\"min\": [10], \"exclusive\": true}]) | index.Delete(\"idx_a\") | index.Delete(\"idx_b\") | index.Delete(\"idx_x_y\") | table.Delete('test') | discard()"`},
+		{"EXPLAIN SELECT a FROM test WHERE k = 10", false, `"table.Scan(\"test\", [{\"min\": [10], \"exact\": true}]) | docs.Project(a)"`},
+		{"EXPLAIN SELECT a FROM test WHERE k BETWEEN 1 AND 5", false, `"table.Scan(\"test\", [{\"min\": [1], \"max\": [5]}]) | docs.Project(a)"`},
+		{"EXPLAIN UPDATE test SET a = 10 WHERE k = 10", false, `"table.Scan(\"test\", [{\"min\": [10], \"exact\": true}]) | paths.Set(a, 10) | table.Validate(\"test\") | index.Delete(\"idx_a\") | index.Delete(\"idx_b\") | index.Delete(\"idx_x_y\") | table.Replace(\"test\") | index.Insert(\"idx_a\") | index.Validate(\"idx_b\") | index.Insert(\"idx_b\") | index.Insert(\"idx_x_y\") | discard()"`},
+		{"EXPLAIN DELETE FROM test WHERE k = 10", false, `"table.Scan(\"test\", [{\"min\": [10], \"exact\": true}]) | index.Delete(\"idx_a\") | index.Delete(\"idx_b\") | index.Delete(\"idx_x_y\") | table.Delete('test') | discard()"`},
 	}
 
 	for _, test := range tests {
@@ -72,3 +77,68 @@ func TestExplainStmt(t *testing.T) {
 		})
 	}
 }
+
+func TestExplainAnalyzeStmt(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec("CREATE TABLE test (k INTEGER PRIMARY KEY, a INTEGER)")
+	assert.NoError(t, err)
+	for i := 1; i <= 10; i++ {
+		err = db.Exec("INSERT INTO test (k, a) VALUES (?, ?)", i, i%3)
+		assert.NoError(t, err)
+	}
+
+	d, err := db.QueryDocument("EXPLAIN ANALYZE SELECT k FROM test WHERE a = 1")
+	assert.NoError(t, err)
+
+	var res struct {
+		Plan         string
+		RowsExamined int64 `genji:"rows_examined"`
+		RowsReturned int64 `genji:"rows_returned"`
+		Stages       []struct {
+			Operator string
+			Rows     int64
+			Time     string
+		}
+	}
+	err = document.StructScan(d, &res)
+	assert.NoError(t, err)
+
+	require.Equal(t, `table.Scan("test") | docs.Filter(a = 1) | docs.Project(k)`, res.Plan)
+	require.EqualValues(t, 10, res.RowsExamined)
+	require.EqualValues(t, 4, res.RowsReturned)
+	require.GreaterOrEqual(t, res.RowsExamined, res.RowsReturned)
+
+	require.Len(t, res.Stages, 3)
+	require.Equal(t, `table.Scan("test")`, res.Stages[0].Operator)
+	require.EqualValues(t, 10, res.Stages[0].Rows)
+	require.Equal(t, "docs.Filter(a = 1)", res.Stages[1].Operator)
+	require.EqualValues(t, 4, res.Stages[1].Rows)
+	require.Equal(t, "docs.Project(k)", res.Stages[2].Operator)
+	require.EqualValues(t, 4, res.Stages[2].Rows)
+	for _, s := range res.Stages {
+		require.NotEmpty(t, s.Time)
+	}
+
+	t.Run("With limit", func(t *testing.T) {
+		d, err := db.QueryDocument("EXPLAIN ANALYZE SELECT k FROM test LIMIT 2")
+		assert.NoError(t, err)
+
+		err = document.StructScan(d, &res)
+		assert.NoError(t, err)
+
+		// the scan stops as soon as enough documents have been returned
+		require.EqualValues(t, 2, res.RowsExamined)
+		require.EqualValues(t, 2, res.RowsReturned)
+	})
+
+	t.Run("Not analyzed", func(t *testing.T) {
+		d, err := db.QueryDocument("EXPLAIN SELECT k FROM test WHERE a = 1")
+		assert.NoError(t, err)
+
+		_, err = d.GetByField("rows_examined")
+		assert.Error(t, err)
+	})
+}
@@ -1,14 +1,22 @@
 package statement
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/docs"
 	"github.com/genjidb/genji/internal/stream/index"
 	"github.com/genjidb/genji/internal/stream/path"
 	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/internal/stringutil"
+	"github.com/genjidb/genji/types"
 )
 
 // InsertStmt holds INSERT configuration.
@@ -21,6 +29,21 @@ type InsertStmt struct {
 	SelectStmt Preparer
 	Returning  []expr.Expr
 	OnConflict database.OnConflictAction
+	// Paths of the primary key or unique constraint whose
+	// violation triggers the OnConflict action, if any.
+	// If empty, any constraint violation triggers it.
+	OnConflictTarget []document.Path
+	// Assignments and condition of the DO UPDATE action.
+	// The conflicting document can be referenced using the "excluded" variable.
+	OnConflictSetPairs []UpdateSetPair
+	OnConflictWhere    expr.Expr
+	// If set, the key of each inserted document is derived
+	// from a hash of the values stored at these paths,
+	// so that inserting the same content twice results in a conflict.
+	ContentKeyPaths []document.Path
+	// If set, the number of seconds after which the inserted
+	// documents expire. It is stored in the TTL field of the table.
+	TTLExpr expr.Expr
 }
 
 func NewInsertStatement() *InsertStmt {
@@ -37,6 +60,17 @@ func NewInsertStatement() *InsertStmt {
 func (stmt *InsertStmt) Prepare(c *Context) (Statement, error) {
 	var s *stream.Stream
 
+	if len(stmt.ContentKeyPaths) > 0 {
+		ti, err := c.Tx.Catalog.GetTableInfo(stmt.TableName)
+		if err != nil {
+			return nil, err
+		}
+
+		if ti.GetPrimaryKey() != nil {
+			return nil, errors.Errorf("cannot use content-addressed keys on table %s: it has a primary key", stmt.TableName)
+		}
+	}
+
 	if stmt.Values != nil {
 		ti, err := c.Tx.Catalog.GetTableInfo(stmt.TableName)
 		if err != nil {
@@ -91,18 +125,53 @@ func (stmt *InsertStmt) Prepare(c *Context) (Statement, error) {
 		}
 	}
 
+	if stmt.TTLExpr != nil {
+		ti, err := c.Tx.Catalog.GetTableInfo(stmt.TableName)
+		if err != nil {
+			return nil, err
+		}
+
+		if ti.TTLField == "" {
+			return nil, errors.Errorf("cannot use TTL on table %s: it has no TTL field", stmt.TableName)
+		}
+
+		s = s.Pipe(path.Set(document.NewPath(ti.TTLField), &expiryExpr{TTL: stmt.TTLExpr}))
+	}
+
 	// validate document
 	s = s.Pipe(table.Validate(stmt.TableName))
 
+	s, err := pipeTriggers(c, s, stmt.TableName, database.TriggerBefore, database.TriggerInsert)
+	if err != nil {
+		return nil, err
+	}
+
 	if stmt.OnConflict != 0 {
+		var op *stream.OnConflictOperator
 		switch stmt.OnConflict {
 		case database.OnConflictDoNothing:
-			s = s.Pipe(stream.OnConflict(nil))
+			op = stream.OnConflict(nil)
 		case database.OnConflictDoReplace:
-			s = s.Pipe(stream.OnConflict(stream.New(table.Replace(stmt.TableName))))
+			op = stream.OnConflict(stream.New(table.Replace(stmt.TableName)))
+		case database.OnConflictDoUpdate:
+			us, err := stmt.prepareOnConflictUpdate(c)
+			if err != nil {
+				return nil, err
+			}
+			op = stream.OnConflict(us)
 		default:
 			panic("unreachable")
 		}
+
+		if len(stmt.OnConflictTarget) > 0 {
+			err := stmt.checkOnConflictTarget(c)
+			if err != nil {
+				return nil, err
+			}
+			op.Paths = stmt.OnConflictTarget
+		}
+
+		s = s.Pipe(op)
 	}
 
 	// check unique constraints
@@ -118,12 +187,21 @@ func (stmt *InsertStmt) Prepare(c *Context) (Statement, error) {
 		}
 	}
 
-	s = s.Pipe(table.Insert(stmt.TableName))
+	if len(stmt.ContentKeyPaths) > 0 {
+		s = s.Pipe(table.InsertWithContentKey(stmt.TableName, stmt.ContentKeyPaths...))
+	} else {
+		s = s.Pipe(table.Insert(stmt.TableName))
+	}
 
 	for _, indexName := range indexNames {
 		s = s.Pipe(index.Insert(indexName))
 	}
 
+	s, err = pipeTriggers(c, s, stmt.TableName, database.TriggerAfter, database.TriggerInsert)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(stmt.Returning) > 0 {
 		s = s.Pipe(docs.Project(stmt.Returning...))
 	} else {
@@ -137,3 +215,192 @@ func (stmt *InsertStmt) Prepare(c *Context) (Statement, error) {
 
 	return st.Prepare(c)
 }
+
+// checkOnConflictTarget ensures the paths of the ON CONFLICT clause
+// match the primary key or a unique index of the table.
+func (stmt *InsertStmt) checkOnConflictTarget(c *Context) error {
+	ti, err := c.Tx.Catalog.GetTableInfo(stmt.TableName)
+	if err != nil {
+		return err
+	}
+
+	target := document.Paths(stmt.OnConflictTarget)
+	if pk := ti.GetPrimaryKey(); pk != nil && target.IsEqual(pk.Paths) {
+		return nil
+	}
+
+	for _, indexName := range c.Tx.Catalog.ListIndexes(stmt.TableName) {
+		info, err := c.Tx.Catalog.GetIndexInfo(indexName)
+		if err != nil {
+			return err
+		}
+
+		if info.Unique && target.IsEqual(info.Paths) {
+			return nil
+		}
+	}
+
+	return errors.Errorf("no primary key or unique constraint on %s(%s) matches the ON CONFLICT clause", stmt.TableName, target)
+}
+
+// prepareOnConflictUpdate returns the stream updating the existing
+// document when a conflict occurs.
+func (stmt *InsertStmt) prepareOnConflictUpdate(c *Context) (*stream.Stream, error) {
+	ti, err := c.Tx.Catalog.GetTableInfo(stmt.TableName)
+	if err != nil {
+		return nil, err
+	}
+
+	s := stream.New(table.Fetch(stmt.TableName))
+
+	if hasTriggers(c, stmt.TableName, database.TriggerUpdate) {
+		s = s.Pipe(stream.TriggerOld())
+	}
+
+	if stmt.OnConflictWhere != nil {
+		s = s.Pipe(docs.Filter(stmt.OnConflictWhere))
+	}
+
+	for _, pair := range stmt.OnConflictSetPairs {
+		s = s.Pipe(path.Set(pair.Path, pair.E))
+	}
+
+	s = s.Pipe(table.Validate(stmt.TableName))
+
+	return pipeUpdate(c, s, stmt.TableName, isPrimaryKeyModified(ti.GetPrimaryKey(), stmt.OnConflictSetPairs))
+}
+
+// String returns a SQL representation.
+func (stmt *InsertStmt) String() string {
+	var b strings.Builder
+
+	b.WriteString("INSERT INTO ")
+	b.WriteString(stringutil.NormalizeIdentifier(stmt.TableName, '`'))
+
+	if len(stmt.Fields) > 0 {
+		b.WriteString(" (")
+		for i, f := range stmt.Fields {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(stringutil.NormalizeIdentifier(f, '`'))
+		}
+		b.WriteString(")")
+	}
+
+	if stmt.Values != nil {
+		b.WriteString(" VALUES ")
+		for i, v := range stmt.Values {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+
+			kvs, ok := v.(*expr.KVPairs)
+			if !ok || !isValueList(kvs, stmt.Fields) {
+				b.WriteString(v.String())
+				continue
+			}
+
+			b.WriteString("(")
+			for j, pair := range kvs.Pairs {
+				if j > 0 {
+					b.WriteString(", ")
+				}
+				b.WriteString(pair.V.String())
+			}
+			b.WriteString(")")
+		}
+	} else if stmt.SelectStmt != nil {
+		fmt.Fprintf(&b, " %s", stmt.SelectStmt)
+	}
+
+	if len(stmt.ContentKeyPaths) > 0 {
+		fmt.Fprintf(&b, " WITH KEY (%s)", document.Paths(stmt.ContentKeyPaths))
+	}
+
+	if stmt.TTLExpr != nil {
+		fmt.Fprintf(&b, " TTL %s", stmt.TTLExpr)
+	}
+
+	if stmt.OnConflict != 0 {
+		b.WriteString(" ON CONFLICT")
+		if len(stmt.OnConflictTarget) > 0 {
+			fmt.Fprintf(&b, " (%s)", document.Paths(stmt.OnConflictTarget))
+		}
+		fmt.Fprintf(&b, " %s", stmt.OnConflict)
+
+		if stmt.OnConflict == database.OnConflictDoUpdate {
+			b.WriteString(" SET ")
+			for i, pair := range stmt.OnConflictSetPairs {
+				if i > 0 {
+					b.WriteString(", ")
+				}
+				fmt.Fprintf(&b, "%s = %s", pair.Path, pair.E)
+			}
+
+			if stmt.OnConflictWhere != nil {
+				fmt.Fprintf(&b, " WHERE %s", stmt.OnConflictWhere)
+			}
+		}
+	}
+
+	if len(stmt.Returning) > 0 {
+		b.WriteString(" RETURNING ")
+		writeProjectedExprs(&b, stmt.Returning)
+	}
+
+	return b.String()
+}
+
+// isValueList reports whether kvs was parsed from a list of values,
+// i.e. VALUES (1, 2), rather than from a document, i.e. VALUES {a: 1, b: 2}.
+func isValueList(kvs *expr.KVPairs, fields []string) bool {
+	if len(fields) > 0 {
+		return true
+	}
+
+	if len(kvs.Pairs) == 0 {
+		return false
+	}
+
+	for _, pair := range kvs.Pairs {
+		if pair.K != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// expiryExpr evaluates to the time at which a document inserted
+// now expires, given its time to live in seconds.
+type expiryExpr struct {
+	TTL expr.Expr
+}
+
+func (e *expiryExpr) Eval(env *environment.Environment) (types.Value, error) {
+	v, err := e.TTL.Eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.Type() == types.NullValue {
+		return v, nil
+	}
+
+	if !v.Type().IsNumber() {
+		return nil, errors.Errorf("invalid TTL %s: expected a number of seconds", v)
+	}
+
+	v, err = document.CastAsDouble(v)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := time.Duration(types.As[float64](v) * float64(time.Second))
+	return types.NewTimestampValue(time.Now().Add(ttl)), nil
+}
+
+func (e *expiryExpr) String() string {
+	return fmt.Sprintf("TTL %s", e.TTL)
+}
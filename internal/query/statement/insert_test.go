@@ -3,9 +3,12 @@ package statement_test
 import (
 	"bytes"
 	"database/sql"
+	"errors"
 	"testing"
 
 	"github.com/genjidb/genji"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/testutil"
 	"github.com/genjidb/genji/internal/testutil/assert"
 	"github.com/stretchr/testify/require"
@@ -213,3 +216,66 @@ func TestInsertSelect(t *testing.T) {
 		})
 	}
 }
+
+func TestInsertContentKey(t *testing.T) {
+	db, tx, cleanup := testutil.NewTestTx(t)
+	defer cleanup()
+
+	testutil.MustExec(t, db, tx, "CREATE TABLE test(a INT, b TEXT, c INT)")
+
+	// same content
+	testutil.MustExec(t, db, tx, "INSERT INTO test (a, b, c) VALUES (1, 'foo', 1) WITH KEY (a, b)")
+	err := testutil.Exec(db, tx, "INSERT INTO test (a, b, c) VALUES (1, 'foo', 2) WITH KEY (a, b)")
+	require.True(t, database.IsConstraintViolationError(err))
+	require.EqualError(t, err, "PRIMARY KEY constraint error: [a b]")
+
+	var cerr *database.ConstraintViolationError
+	require.True(t, errors.As(err, &cerr))
+	require.Equal(t, []document.Path{document.NewPath("a"), document.NewPath("b")}, cerr.Paths)
+
+	// same content, do nothing
+	testutil.MustExec(t, db, tx, "INSERT INTO test (a, b, c) VALUES (1, 'foo', 2) WITH KEY (a, b) ON CONFLICT DO NOTHING")
+
+	requireRows := func(expected string) {
+		t.Helper()
+
+		res := testutil.MustQuery(t, db, tx, "SELECT a, b, c FROM test ORDER BY a")
+		defer res.Close()
+
+		var buf bytes.Buffer
+		err := testutil.IteratorToJSONArray(&buf, res)
+		assert.NoError(t, err)
+		require.JSONEq(t, expected, buf.String())
+	}
+
+	requireRows(`[{"a": 1, "b": "foo", "c": 1}]`)
+
+	// same content, replace
+	testutil.MustExec(t, db, tx, "INSERT INTO test (a, b, c) VALUES (1, 'foo', 3) WITH KEY (a, b) ON CONFLICT DO REPLACE")
+
+	// different content
+	testutil.MustExec(t, db, tx, "INSERT INTO test (a, b, c) VALUES (2, 'foo', 4) WITH KEY (a, b)")
+
+	requireRows(`[{"a": 1, "b": "foo", "c": 3}, {"a": 2, "b": "foo", "c": 4}]`)
+
+	t.Run("INSERT ... SELECT", func(t *testing.T) {
+		testutil.MustExec(t, db, tx, "CREATE TABLE events(a INT, b TEXT, c INT)")
+		testutil.MustExec(t, db, tx, "INSERT INTO events SELECT * FROM test WITH KEY (a)")
+		testutil.MustExec(t, db, tx, "INSERT INTO events SELECT * FROM test WITH KEY (a) ON CONFLICT DO NOTHING")
+
+		res := testutil.MustQuery(t, db, tx, "SELECT COUNT(*) AS n FROM events")
+		defer res.Close()
+
+		var buf bytes.Buffer
+		err := testutil.IteratorToJSONArray(&buf, res)
+		assert.NoError(t, err)
+		require.JSONEq(t, `[{"n": 2}]`, buf.String())
+	})
+
+	t.Run("Primary key", func(t *testing.T) {
+		testutil.MustExec(t, db, tx, "CREATE TABLE withpk(a INT PRIMARY KEY, b TEXT)")
+
+		err := testutil.Exec(db, tx, "INSERT INTO withpk (a, b) VALUES (1, 'foo') WITH KEY (b)")
+		assert.Error(t, err)
+	})
+}
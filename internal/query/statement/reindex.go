@@ -6,6 +6,7 @@ import (
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/index"
 	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/internal/stringutil"
 )
 
 // ReIndexStmt is a DSL that allows creating a full REINDEX statement.
@@ -13,6 +14,7 @@ type ReIndexStmt struct {
 	basePreparedStatement
 
 	TableOrIndexName string
+	IfExists         bool
 }
 
 func NewReIndexStatement() *ReIndexStmt {
@@ -26,6 +28,18 @@ func NewReIndexStatement() *ReIndexStmt {
 	return &p
 }
 
+// String returns a SQL representation.
+func (stmt ReIndexStmt) String() string {
+	switch {
+	case stmt.TableOrIndexName == "":
+		return "REINDEX"
+	case stmt.IfExists:
+		return "REINDEX IF EXISTS " + stringutil.NormalizeIdentifier(stmt.TableOrIndexName, '`')
+	}
+
+	return "REINDEX " + stringutil.NormalizeIdentifier(stmt.TableOrIndexName, '`')
+}
+
 // Prepare implements the Preparer interface.
 func (stmt ReIndexStmt) Prepare(ctx *Context) (Statement, error) {
 	var indexNames []string
@@ -36,6 +50,9 @@ func (stmt ReIndexStmt) Prepare(ctx *Context) (Statement, error) {
 		indexNames = ctx.Tx.Catalog.ListIndexes(stmt.TableOrIndexName)
 	} else if !errs.IsNotFoundError(err) {
 		return nil, err
+	} else if _, err := ctx.Tx.Catalog.GetIndexInfo(stmt.TableOrIndexName); errs.IsNotFoundError(err) && stmt.IfExists {
+		// neither a table nor an index, nothing to reindex
+		indexNames = nil
 	} else {
 		indexNames = []string{stmt.TableOrIndexName}
 	}
@@ -43,21 +60,16 @@ func (stmt ReIndexStmt) Prepare(ctx *Context) (Statement, error) {
 	var streams []*stream.Stream
 
 	for _, indexName := range indexNames {
-		idx, err := ctx.Tx.Catalog.GetIndex(ctx.Tx, indexName)
-		if err != nil {
-			return nil, err
-		}
 		info, err := ctx.Tx.Catalog.GetIndexInfo(indexName)
 		if err != nil {
 			return nil, err
 		}
 
-		err = idx.Truncate()
-		if err != nil {
-			return nil, err
-		}
-
-		s := stream.New(table.Scan(info.Owner.TableName)).Pipe(index.Insert(info.IndexName))
+		// the index is truncated when the statement is executed,
+		// preparing the statement must not modify the database
+		s := stream.New(table.Scan(info.Owner.TableName)).
+			Pipe(index.Truncate(info.IndexName)).
+			Pipe(index.Insert(info.IndexName))
 		streams = append(streams, s)
 	}
 
@@ -2,37 +2,97 @@ package statement
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/document"
+	errs "github.com/genjidb/genji/internal/errors"
 	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/planner"
 	"github.com/genjidb/genji/internal/sql/scanner"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/docs"
 	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/internal/stringutil"
+	"github.com/genjidb/genji/internal/tree"
 )
 
 type SelectCoreStmt struct {
 	TableName       string
+	Joins           []*JoinClause
 	Distinct        bool
+	Only            bool
 	WhereExpr       expr.Expr
 	GroupByExpr     expr.Expr
+	HavingExpr      expr.Expr
 	ProjectionExprs []expr.Expr
 }
 
-func (stmt *SelectCoreStmt) Prepare(*Context) (*StreamStmt, error) {
+// JoinClause holds the configuration of a JOIN clause.
+type JoinClause struct {
+	TableName string
+	On        expr.Expr
+	// If true, documents of the left side that don't
+	// match any document of TableName are also returned.
+	Left bool
+}
+
+func (stmt *SelectCoreStmt) Prepare(ctx *Context) (*StreamStmt, error) {
+	return stmt.prepare(ctx, nil)
+}
+
+// prepare builds the stream of the statement. Tables whose name
+// is in ctes are read from the common table expression of the same name.
+func (stmt *SelectCoreStmt) prepare(_ *Context, ctes map[string]struct{}) (*StreamStmt, error) {
 	isReadOnly := true
 
 	var s *stream.Stream
 
 	if stmt.TableName != "" {
-		s = s.Pipe(table.Scan(stmt.TableName))
+		if _, ok := ctes[stmt.TableName]; ok {
+			s = s.Pipe(stream.CTEScan(stmt.TableName))
+		} else {
+			s = s.Pipe(table.Scan(stmt.TableName))
+		}
+	}
+
+	// joined documents hold the document of each table under the name of the table,
+	// the first join wraps the documents of the FROM clause, the next ones extend
+	// the joined documents.
+	tables := map[string]struct{}{stmt.TableName: {}}
+	for i, j := range stmt.Joins {
+		if _, ok := tables[j.TableName]; ok {
+			return nil, fmt.Errorf("table %q is specified more than once", j.TableName)
+		}
+		tables[j.TableName] = struct{}{}
+
+		var leftName string
+		if i == 0 {
+			leftName = stmt.TableName
+		}
+
+		op := table.Join(leftName, j.TableName, j.On)
+		op.Outer = j.Left
+		if _, ok := ctes[j.TableName]; ok {
+			op.Right = stream.New(stream.CTEScan(j.TableName))
+		}
+		s = s.Pipe(op)
 	}
 
 	if stmt.WhereExpr != nil {
 		s = s.Pipe(docs.Filter(stmt.WhereExpr))
 	}
 
+	// aliases of the projected expressions can be referenced in the HAVING clause.
+	// with GROUP BY, they are resolved once the projection has been adapted to the groups.
+	var having expr.Expr
+	if stmt.HavingExpr != nil && stmt.GroupByExpr == nil {
+		having = replaceExprs(stmt.HavingExpr, func(e expr.Expr) expr.Expr {
+			return projectedAlias(e, stmt.ProjectionExprs)
+		})
+	}
+
 	// when using GROUP BY, only aggregation functions or GroupByExpr can be selected
 	if stmt.GroupByExpr != nil {
 		var invalidProjectedField expr.Expr
@@ -62,6 +122,19 @@ func (stmt *SelectCoreStmt) Prepare(*Context) (*StreamStmt, error) {
 				continue
 			}
 
+			// when grouping by multiple expressions, the group is a list
+			// and each of its expressions can be selected by index
+			if idx := groupByListIndex(stmt.GroupByExpr, e); idx != -1 {
+				stmt.ProjectionExprs[i] = &expr.NamedExpr{
+					ExprName: ne.ExprName,
+					Expr: expr.Path(document.Path{
+						document.PathFragment{FieldName: stmt.GroupByExpr.String()},
+						document.PathFragment{ArrayIndex: idx},
+					}),
+				}
+				continue
+			}
+
 			// otherwise it's an error
 			invalidProjectedField = ne
 			break
@@ -70,6 +143,30 @@ func (stmt *SelectCoreStmt) Prepare(*Context) (*StreamStmt, error) {
 		if invalidProjectedField != nil {
 			return nil, fmt.Errorf("field %q must appear in the GROUP BY clause or be used in an aggregate function", invalidProjectedField)
 		}
+
+		if stmt.HavingExpr != nil {
+			having = replaceExprs(stmt.HavingExpr, func(e expr.Expr) expr.Expr {
+				if pe := projectedAlias(e, stmt.ProjectionExprs); pe != nil {
+					return pe
+				}
+
+				// the grouped expressions are stored in the document
+				// returned by the aggregation node, under their string representation
+				if expr.Equal(e, stmt.GroupByExpr) {
+					return expr.Path(document.NewPath(e.String()))
+				}
+				if idx := groupByListIndex(stmt.GroupByExpr, e); idx != -1 {
+					return expr.Path(document.Path{
+						document.PathFragment{FieldName: stmt.GroupByExpr.String()},
+						document.PathFragment{ArrayIndex: idx},
+					})
+				}
+
+				return nil
+			})
+			aggregators = havingAggregators(having, aggregators)
+		}
+
 		// add Aggregation node
 		s = s.Pipe(docs.TempTreeSort(stmt.GroupByExpr))
 		s = s.Pipe(docs.GroupAggregate(stmt.GroupByExpr, aggregators...))
@@ -91,12 +188,21 @@ func (stmt *SelectCoreStmt) Prepare(*Context) (*StreamStmt, error) {
 			}
 		}
 
+		if having != nil {
+			aggregators = havingAggregators(having, aggregators)
+		}
+
 		// add Aggregation node
 		if len(aggregators) > 0 {
 			s = s.Pipe(docs.GroupAggregate(nil, aggregators...))
 		}
 	}
 
+	// HAVING filters the documents after aggregation
+	if having != nil {
+		s = s.Pipe(docs.Filter(having))
+	}
+
 	// If there is no FROM clause ensure there is no wildcard or path
 	if stmt.TableName == "" {
 		var err error
@@ -116,7 +222,11 @@ func (stmt *SelectCoreStmt) Prepare(*Context) (*StreamStmt, error) {
 			}
 		}
 	}
-	s = s.Pipe(docs.Project(stmt.ProjectionExprs...))
+	if stmt.Only {
+		s = s.Pipe(docs.StrictProject(stmt.ProjectionExprs...))
+	} else {
+		s = s.Pipe(docs.Project(stmt.ProjectionExprs...))
+	}
 
 	// SELECT is read-only most of the time, unless it's using some expressions
 	// that require write access and that are allowed to be run, such as NEXT VALUE FOR
@@ -142,16 +252,220 @@ func (stmt *SelectCoreStmt) Prepare(*Context) (*StreamStmt, error) {
 	}, nil
 }
 
+// String returns a SQL representation.
+func (stmt *SelectCoreStmt) String() string {
+	var b strings.Builder
+
+	b.WriteString("SELECT ")
+	if stmt.Distinct {
+		b.WriteString("DISTINCT ")
+	}
+	if stmt.Only {
+		b.WriteString("ONLY ")
+	}
+
+	writeProjectedExprs(&b, stmt.ProjectionExprs)
+
+	if stmt.TableName != "" {
+		b.WriteString(" FROM ")
+		b.WriteString(stringutil.NormalizeIdentifier(stmt.TableName, '`'))
+	}
+
+	for _, j := range stmt.Joins {
+		if j.Left {
+			b.WriteString(" LEFT")
+		}
+		fmt.Fprintf(&b, " JOIN %s ON %s", stringutil.NormalizeIdentifier(j.TableName, '`'), j.On)
+	}
+
+	if stmt.WhereExpr != nil {
+		fmt.Fprintf(&b, " WHERE %s", stmt.WhereExpr)
+	}
+
+	if stmt.GroupByExpr != nil {
+		b.WriteString(" GROUP BY ")
+		// multiple expressions are stored as a list
+		if l, ok := stmt.GroupByExpr.(expr.LiteralExprList); ok {
+			writeExprList(&b, l)
+		} else {
+			b.WriteString(stmt.GroupByExpr.String())
+		}
+	}
+
+	if stmt.HavingExpr != nil {
+		fmt.Fprintf(&b, " HAVING %s", stmt.HavingExpr)
+	}
+
+	return b.String()
+}
+
+// writeProjectedExprs writes a comma separated list of projected expressions,
+// followed by their alias if it differs from the expression itself.
+func writeProjectedExprs(b *strings.Builder, exprs []expr.Expr) {
+	for i, e := range exprs {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		ne, ok := e.(*expr.NamedExpr)
+		if !ok || ne.ExprName == ne.Expr.String() || isFieldNamed(ne.Expr, ne.ExprName) {
+			b.WriteString(e.String())
+			continue
+		}
+
+		fmt.Fprintf(b, "%s AS %s", ne.Expr, stringutil.NormalizeIdentifier(ne.ExprName, '`'))
+	}
+}
+
+// isFieldNamed returns whether e is the path of the top-level field name.
+func isFieldNamed(e expr.Expr, name string) bool {
+	p, ok := e.(expr.Path)
+	return ok && len(p) == 1 && p[0].FieldName == name
+}
+
+// writeExprList writes a comma separated list of expressions.
+func writeExprList(b *strings.Builder, exprs []expr.Expr) {
+	for i, e := range exprs {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		b.WriteString(e.String())
+	}
+}
+
+// writeOrderByLimitOffset writes the ORDER BY, LIMIT and OFFSET clauses, if any.
+func writeOrderByLimitOffset(b *strings.Builder, paths []expr.Path, order tree.SortOrder, limit, offset expr.Expr) {
+	for i, p := range paths {
+		if i == 0 {
+			b.WriteString(" ORDER BY ")
+		} else {
+			b.WriteString(", ")
+		}
+
+		b.WriteString(p.String())
+		if order.IsDesc(i) {
+			b.WriteString(" DESC")
+		}
+	}
+
+	if limit != nil {
+		fmt.Fprintf(b, " LIMIT %s", limit)
+	}
+
+	if offset != nil {
+		fmt.Fprintf(b, " OFFSET %s", offset)
+	}
+}
+
+// replaceExprs replaces e, or its operands if e is an operator,
+// by the expression returned by fn, if not nil.
+func replaceExprs(e expr.Expr, fn func(expr.Expr) expr.Expr) expr.Expr {
+	if r := fn(e); r != nil {
+		return r
+	}
+
+	switch t := e.(type) {
+	case expr.Parentheses:
+		return expr.Parentheses{E: replaceExprs(t.E, fn)}
+	case expr.Operator:
+		t.SetLeftHandExpr(replaceExprs(t.LeftHand(), fn))
+		t.SetRightHandExpr(replaceExprs(t.RightHand(), fn))
+	}
+
+	return e
+}
+
+// projectedAlias returns the projected expression aliased
+// by the name of the path e, or nil.
+func projectedAlias(e expr.Expr, projection []expr.Expr) expr.Expr {
+	p, ok := e.(expr.Path)
+	if !ok || len(p) != 1 || p[0].FieldName == "" {
+		return nil
+	}
+
+	for _, pe := range projection {
+		ne, ok := pe.(*expr.NamedExpr)
+		if ok && ne.ExprName == p[0].FieldName {
+			return ne.Expr
+		}
+	}
+
+	return nil
+}
+
+// havingAggregators adds the aggregation functions used in the HAVING clause
+// that are not projected to the list of aggregators.
+func havingAggregators(having expr.Expr, aggregators []expr.AggregatorBuilder) []expr.AggregatorBuilder {
+	expr.Walk(having, func(e expr.Expr) bool {
+		agg, ok := e.(expr.AggregatorBuilder)
+		if !ok {
+			return true
+		}
+
+		for _, a := range aggregators {
+			if expr.Equal(a, agg) {
+				return false
+			}
+		}
+
+		aggregators = append(aggregators, agg)
+		return false
+	})
+
+	return aggregators
+}
+
+// groupByListIndex returns the position of e in the GROUP BY clause
+// if it contains multiple expressions, or -1.
+func groupByListIndex(groupBy expr.Expr, e expr.Expr) int {
+	l, ok := groupBy.(expr.LiteralExprList)
+	if !ok {
+		return -1
+	}
+
+	for i := range l {
+		if expr.Equal(l[i], e) {
+			return i
+		}
+	}
+
+	return -1
+}
+
 // SelectStmt holds SELECT configuration.
 type SelectStmt struct {
 	basePreparedStatement
 
+	With              []*CommonTableExpr
 	CompoundSelect    []*SelectCoreStmt
 	CompoundOperators []scanner.Token
-	OrderBy           expr.Path
-	OrderByDirection  scanner.Token
+	OrderBy           []expr.Path
+	OrderByDirection  tree.SortOrder
 	OffsetExpr        expr.Expr
 	LimitExpr         expr.Expr
+	// If greater than 1, the number of goroutines
+	// used to scan the table.
+	Parallelism int
+	// If greater than 0, the maximum duration of the
+	// execution of the statement.
+	MaxExecutionTime time.Duration
+}
+
+// A ViewQuery is the SELECT statement of a view, stored in database.ViewInfo.
+// Statements are modified when they are prepared, every statement
+// reading the view prepares its own copy of the query.
+type ViewQuery interface {
+	String() string
+	// SelectStmt returns a new copy of the SELECT statement.
+	SelectStmt() (*SelectStmt, error)
+}
+
+// CommonTableExpr is a named SELECT statement defined in a WITH clause,
+// that can be read like a table by the statement.
+type CommonTableExpr struct {
+	Name string
+	Stmt *SelectStmt
 }
 
 func NewSelectStatement() *SelectStmt {
@@ -165,8 +479,190 @@ func NewSelectStatement() *SelectStmt {
 	return &p
 }
 
+// Parallel makes the statement scan the table using n goroutines,
+// if it requires reading the whole table.
+// The order of the documents is unspecified, unless the statement
+// uses an ORDER BY clause.
+func (stmt *SelectStmt) Parallel(n int) *SelectStmt {
+	stmt.Parallelism = n
+	return stmt
+}
+
+// Timeout aborts the execution of the statement with stream.ErrQueryTimeout
+// if it takes longer than d.
+func (stmt *SelectStmt) Timeout(d time.Duration) *SelectStmt {
+	stmt.MaxExecutionTime = d
+	return stmt
+}
+
 // Prepare implements the Preparer interface.
 func (stmt *SelectStmt) Prepare(ctx *Context) (Statement, error) {
+	st, err := stmt.prepareWith(ctx, make(map[string]struct{}))
+	if err != nil {
+		return nil, err
+	}
+
+	// the optimizer can remove sort nodes if the table is already read
+	// in the right order, which a parallel scan doesn't preserve
+	sorts := countTempTreeSorts(st.Stream)
+
+	prepared, err := st.Prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if stmt.Parallelism > 1 && len(stmt.CompoundSelect) == 1 {
+		ps := prepared.(*PreparedStreamStmt)
+		if countTempTreeSorts(ps.Stream) == sorts {
+			ps.Stream = planner.Parallelize(ps.Stream, stmt.Parallelism)
+		}
+	}
+
+	if stmt.MaxExecutionTime > 0 {
+		// the timeout wraps the whole stream so that every
+		// operator is given the deadline
+		ps := prepared.(*PreparedStreamStmt)
+		ps.Stream = ps.Stream.Pipe(stream.Timeout(stmt.MaxExecutionTime))
+	}
+
+	return prepared, nil
+}
+
+// prepareWith builds the stream of the statement and of its common table expressions,
+// without optimizing it. The views read by the statement are expanded into common
+// table expressions, expanding holds the names of the views being expanded.
+func (stmt *SelectStmt) prepareWith(ctx *Context, expanding map[string]struct{}) (*StreamStmt, error) {
+	defs, readOnly, err := stmt.prepareViews(ctx, expanding)
+	if err != nil {
+		return nil, err
+	}
+
+	ctes := make(map[string]struct{})
+	for _, def := range defs {
+		ctes[def.Name] = struct{}{}
+	}
+
+	// each common table expression can read the ones defined before it
+	with := make(map[string]struct{})
+	for _, cte := range stmt.With {
+		if _, ok := with[cte.Name]; ok {
+			return nil, fmt.Errorf("WITH query name %q specified more than once", cte.Name)
+		}
+
+		st, err := cte.Stmt.prepareStream(ctx, ctes)
+		if err != nil {
+			return nil, err
+		}
+		if !st.ReadOnly {
+			readOnly = false
+		}
+
+		defs = append(defs, &stream.CommonTableExpr{Name: cte.Name, Stream: st.Stream})
+		ctes[cte.Name] = struct{}{}
+		with[cte.Name] = struct{}{}
+	}
+
+	st, err := stmt.prepareStream(ctx, ctes)
+	if err != nil {
+		return nil, err
+	}
+	if !readOnly {
+		st.ReadOnly = false
+	}
+
+	if len(defs) > 0 {
+		st.Stream = stream.New(stream.With(st.Stream, defs...))
+	}
+
+	return st, nil
+}
+
+// prepareViews returns a common table expression for every view read by the statement,
+// unless its name is also the name of a common table expression of the WITH clause.
+func (stmt *SelectStmt) prepareViews(ctx *Context, expanding map[string]struct{}) ([]*stream.CommonTableExpr, bool, error) {
+	var defs []*stream.CommonTableExpr
+	readOnly := true
+
+	with := make(map[string]struct{})
+	for _, cte := range stmt.With {
+		with[cte.Name] = struct{}{}
+	}
+
+	seen := make(map[string]struct{})
+	for _, name := range stmt.tableNames() {
+		if _, ok := with[name]; ok {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+
+		info, err := ctx.Tx.Catalog.GetViewInfo(name)
+		if err != nil {
+			if errs.IsNotFoundError(err) {
+				continue
+			}
+			return nil, false, err
+		}
+
+		if _, ok := expanding[name]; ok {
+			return nil, false, fmt.Errorf("view %q references itself", name)
+		}
+
+		q, ok := info.Query.(ViewQuery)
+		if !ok {
+			return nil, false, fmt.Errorf("invalid query for view %q", name)
+		}
+
+		sel, err := q.SelectStmt()
+		if err != nil {
+			return nil, false, err
+		}
+
+		expanding[name] = struct{}{}
+		st, err := sel.prepareWith(ctx, expanding)
+		delete(expanding, name)
+		if err != nil {
+			return nil, false, err
+		}
+		if !st.ReadOnly {
+			readOnly = false
+		}
+
+		defs = append(defs, &stream.CommonTableExpr{Name: name, Stream: st.Stream})
+	}
+
+	return defs, readOnly, nil
+}
+
+// tableNames returns the names of the tables read by the statement
+// and by its common table expressions.
+func (stmt *SelectStmt) tableNames() []string {
+	var names []string
+
+	stmts := []*SelectStmt{stmt}
+	for _, cte := range stmt.With {
+		stmts = append(stmts, cte.Stmt)
+	}
+
+	for _, st := range stmts {
+		for _, core := range st.CompoundSelect {
+			if core.TableName != "" {
+				names = append(names, core.TableName)
+			}
+			for _, j := range core.Joins {
+				names = append(names, j.TableName)
+			}
+		}
+	}
+
+	return names
+}
+
+// prepareStream builds the stream of the statement, without optimizing it.
+// Tables whose name is in ctes are read from the common table expression of the same name.
+func (stmt *SelectStmt) prepareStream(ctx *Context, ctes map[string]struct{}) (*StreamStmt, error) {
 	var s *stream.Stream
 
 	var prev scanner.Token
@@ -174,8 +670,13 @@ func (stmt *SelectStmt) Prepare(ctx *Context) (Statement, error) {
 	var coreStmts []*stream.Stream
 	var readOnly bool = true
 
+	err := stmt.checkCompoundProjections()
+	if err != nil {
+		return nil, err
+	}
+
 	for i, coreSelect := range stmt.CompoundSelect {
-		coreStmt, err := coreSelect.Prepare(ctx)
+		coreStmt, err := coreSelect.prepare(ctx, ctes)
 		if err != nil {
 			return nil, err
 		}
@@ -203,6 +704,10 @@ func (stmt *SelectStmt) Prepare(ctx *Context) (Statement, error) {
 				s = stream.New(stream.Union(coreStmts...))
 			case scanner.ALL:
 				s = stream.New(stream.Concat(coreStmts...))
+			case scanner.INTERSECT:
+				s = stream.New(stream.Intersect(coreStmts...))
+			case scanner.EXCEPT:
+				s = stream.New(stream.Except(coreStmts...))
 			}
 
 			coreStmts = []*stream.Stream{s}
@@ -211,12 +716,9 @@ func (stmt *SelectStmt) Prepare(ctx *Context) (Statement, error) {
 		prev = tok
 	}
 
-	if stmt.OrderBy != nil {
-		if stmt.OrderByDirection == scanner.DESC {
-			s = s.Pipe(docs.TempTreeSortReverse(stmt.OrderBy))
-		} else {
-			s = s.Pipe(docs.TempTreeSort(stmt.OrderBy))
-		}
+	s, err = pipeOrderBy(s, stmt.OrderBy, stmt.OrderByDirection)
+	if err != nil {
+		return nil, err
 	}
 
 	if stmt.OffsetExpr != nil {
@@ -227,10 +729,112 @@ func (stmt *SelectStmt) Prepare(ctx *Context) (Statement, error) {
 		s = s.Pipe(docs.Take(stmt.LimitExpr))
 	}
 
-	st := StreamStmt{
+	return &StreamStmt{
 		Stream:   s,
 		ReadOnly: readOnly,
+	}, nil
+}
+
+// String returns a SQL representation.
+func (stmt *SelectStmt) String() string {
+	var b strings.Builder
+
+	for i, cte := range stmt.With {
+		if i == 0 {
+			b.WriteString("WITH ")
+		} else {
+			b.WriteString(", ")
+		}
+
+		fmt.Fprintf(&b, "%s AS (%s)", stringutil.NormalizeIdentifier(cte.Name, '`'), cte.Stmt)
+	}
+	if len(stmt.With) > 0 {
+		b.WriteString(" ")
+	}
+
+	for i, coreSelect := range stmt.CompoundSelect {
+		if i > 0 {
+			fmt.Fprintf(&b, " %s ", compoundOperatorString(stmt.CompoundOperators[i-1]))
+		}
+
+		b.WriteString(coreSelect.String())
+	}
+
+	writeOrderByLimitOffset(&b, stmt.OrderBy, stmt.OrderByDirection, stmt.LimitExpr, stmt.OffsetExpr)
+
+	return b.String()
+}
+
+func countTempTreeSorts(s *stream.Stream) int {
+	var n int
+	for op := s.First(); op != nil; op = op.GetNext() {
+		if _, ok := op.(*docs.TempTreeSortOperator); ok {
+			n++
+		}
+	}
+
+	return n
+}
+
+// checkCompoundProjections ensures that compound selects project compatible documents.
+// Selects using a wildcard can project documents of any shape, the others must project
+// the same number of expressions.
+func (stmt *SelectStmt) checkCompoundProjections() error {
+	n := -1
+
+	for i, coreSelect := range stmt.CompoundSelect {
+		if hasWildcard(coreSelect.ProjectionExprs) {
+			continue
+		}
+
+		if n != -1 && n != len(coreSelect.ProjectionExprs) {
+			return fmt.Errorf("SELECTs to the left and right of %s do not have the same number of result fields", compoundOperatorString(stmt.CompoundOperators[i-1]))
+		}
+
+		n = len(coreSelect.ProjectionExprs)
+	}
+
+	return nil
+}
+
+// compoundOperatorString returns the SQL representation of a compound operator.
+// UNION ALL is stored as the ALL token.
+func compoundOperatorString(tok scanner.Token) string {
+	if tok == scanner.ALL {
+		return "UNION ALL"
+	}
+
+	return tok.String()
+}
+
+func hasWildcard(exprs []expr.Expr) bool {
+	for _, e := range exprs {
+		if _, ok := e.(expr.Wildcard); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pipeOrderBy pipes the operators required to sort the stream
+// according to the ORDER BY clause, if any.
+func pipeOrderBy(s *stream.Stream, paths []expr.Path, order tree.SortOrder) (*stream.Stream, error) {
+	switch len(paths) {
+	case 0:
+		return s, nil
+	case 1:
+		if order.IsDesc(0) {
+			return s.Pipe(docs.TempTreeSortReverse(paths[0])), nil
+		}
+
+		return s.Pipe(docs.TempTreeSort(paths[0])), nil
+	}
+
+	keys := make([]docs.SortKey, len(paths))
+	for i, p := range paths {
+		keys[i] = docs.SortKey{Expr: p, Desc: order.IsDesc(i)}
 	}
 
-	return st.Prepare(ctx)
+	return s.Pipe(docs.TempTreeSortBy(keys...)), nil
 }
@@ -2,13 +2,23 @@ package statement_test
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/genjidb/genji"
 	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/query"
+	"github.com/genjidb/genji/internal/query/statement"
+	"github.com/genjidb/genji/internal/sql/parser"
+	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/testutil"
 	"github.com/genjidb/genji/internal/testutil/assert"
 	"github.com/genjidb/genji/types"
@@ -62,6 +72,8 @@ func TestSelectStmt(t *testing.T) {
 		{"With order by", "SELECT * FROM test ORDER BY color", false, `[{"k":3,"height":100,"weight":200},{"k":2,"color":"blue","size":10,"weight":100},{"k":1,"color":"red","size":10,"shape":"square"}]`, nil},
 		{"With invalid group by / wildcard", "SELECT * FROM test WHERE age = 10 GROUP BY a.b.c", true, ``, nil},
 		{"With invalid group by / a.b", "SELECT a.b FROM test WHERE age = 10 GROUP BY a.b.c", true, ``, nil},
+		{"With group by multiple", "SELECT size, color FROM test GROUP BY size, color", false, `[{"size":null,"color":null},{"size":10,"color":"blue"},{"size":10,"color":"red"}]`, nil},
+		{"With invalid group by multiple", "SELECT size, shape FROM test GROUP BY size, color", true, ``, nil},
 		{"With order by", "SELECT * FROM test ORDER BY color", false, `[{"k":3,"height":100,"weight":200},{"k":2,"color":"blue","size":10,"weight":100},{"k":1,"color":"red","size":10,"shape":"square"}]`, nil},
 		{"With order by asc", "SELECT * FROM test ORDER BY color ASC", false, `[{"k":3,"height":100,"weight":200},{"k":2,"color":"blue","size":10,"weight":100},{"k":1,"color":"red","size":10,"shape":"square"}]`, nil},
 		{"With order by asc numeric", "SELECT * FROM test ORDER BY weight ASC", false, `[{"k":1,"color":"red","size":10,"shape":"square"},{"k":2,"color":"blue","size":10,"weight":100},{"k":3,"height":100,"weight":200}]`, nil},
@@ -445,3 +457,175 @@ func TestDistinct(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectStmtParallel(t *testing.T) {
+	db := testutil.NewTestDB(t)
+
+	tx, err := db.Begin(true)
+	assert.NoError(t, err)
+	testutil.MustExec(t, db, tx, "CREATE TABLE test(a INT PRIMARY KEY, b INT, c TEXT)")
+	for i := 0; i < 500; i++ {
+		testutil.MustExec(t, db, tx, "INSERT INTO test (a, b, c) VALUES (?, ?, ?)",
+			environment.Param{Value: i}, environment.Param{Value: i % 10}, environment.Param{Value: strconv.Itoa(i)})
+	}
+	testutil.MustExec(t, db, tx, "CREATE TABLE nopk(a INT)")
+	testutil.MustExec(t, db, tx, "INSERT INTO nopk (a) SELECT a FROM test")
+	assert.NoError(t, tx.Commit())
+
+	tests := []struct {
+		query    string
+		parallel bool
+		ordered  bool
+	}{
+		{"SELECT * FROM test", true, false},
+		{"SELECT a, c FROM test WHERE b = 3", true, false},
+		{"SELECT a FROM test WHERE b > 2 AND c LIKE '1%'", true, false},
+		{"SELECT pk(), a FROM nopk WHERE a % 7 = 0", true, false},
+		{"SELECT COUNT(*), SUM(a) FROM test WHERE b < 5", true, false},
+		{"SELECT b, COUNT(*) FROM test GROUP BY b", true, false},
+		{"SELECT a FROM test WHERE a % 7 + b > 3 AND a % 7 + b < 8", true, false},
+		{"SELECT a FROM test WHERE b IN (SELECT a FROM nopk WHERE a < 3)", true, false},
+		{"SELECT * FROM test WHERE b = 1 ORDER BY c DESC LIMIT 10", true, true},
+		{"SELECT a FROM test ORDER BY a DESC LIMIT 10", false, true},
+		{"SELECT a, COUNT(*) FROM test GROUP BY a", false, false},
+		{"SELECT * FROM test WHERE a > 490", false, false},
+		{"SELECT a FROM test UNION ALL SELECT a FROM nopk", false, false},
+	}
+
+	run := func(t *testing.T, q string, workers int) ([]string, string) {
+		t.Helper()
+
+		pq, err := parser.ParseQuery(q)
+		assert.NoError(t, err)
+		if s, ok := pq.Statements[0].(*statement.SelectStmt); ok {
+			s.Parallel(workers)
+		}
+
+		ctx := &query.Context{Ctx: context.Background(), DB: db}
+		err = pq.Prepare(ctx)
+		assert.NoError(t, err)
+		plan := pq.Statements[0].(*statement.PreparedStreamStmt).String()
+
+		res, err := pq.Run(ctx)
+		assert.NoError(t, err)
+		defer res.Close()
+
+		var docs []string
+		err = res.Iterate(func(d types.Document) error {
+			b, err := document.MarshalJSON(d)
+			assert.NoError(t, err)
+			docs = append(docs, string(b))
+			return nil
+		})
+		assert.NoError(t, err)
+
+		return docs, plan
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			want, _ := run(t, test.query, 1)
+			got, plan := run(t, test.query, 4)
+
+			require.Equal(t, test.parallel, strings.Contains(plan, "table.ParallelScan"), plan)
+			if !test.ordered {
+				sort.Strings(want)
+				sort.Strings(got)
+			}
+			require.Equal(t, want, got)
+		})
+	}
+}
+
+func TestSelectStmtTimeout(t *testing.T) {
+	db := testutil.NewTestDB(t)
+
+	tx, err := db.Begin(true)
+	assert.NoError(t, err)
+	testutil.MustExec(t, db, tx, "CREATE TABLE test(a INT PRIMARY KEY, b INT)")
+	for i := 0; i < 1000; i++ {
+		testutil.MustExec(t, db, tx, "INSERT INTO test (a, b) VALUES (?, ?)", environment.Param{Value: i}, environment.Param{Value: i % 10})
+	}
+	assert.NoError(t, tx.Commit())
+
+	run := func(t *testing.T, q string, d time.Duration, parallelism int) (int, error) {
+		t.Helper()
+
+		pq, err := parser.ParseQuery(q)
+		assert.NoError(t, err)
+		pq.Statements[0].(*statement.SelectStmt).Timeout(d).Parallel(parallelism)
+
+		ctx := &query.Context{Ctx: context.Background(), DB: db}
+		err = pq.Prepare(ctx)
+		assert.NoError(t, err)
+		require.Contains(t, pq.Statements[0].(*statement.PreparedStreamStmt).String(), "timeout("+d.String()+")")
+
+		res, err := pq.Run(ctx)
+		assert.NoError(t, err)
+		defer res.Close()
+
+		var n int
+		err = res.Iterate(func(d types.Document) error {
+			n++
+			return nil
+		})
+		require.Equal(t, err, res.Err())
+
+		return n, err
+	}
+
+	for _, q := range []string{
+		"SELECT * FROM test",
+		"SELECT a FROM test WHERE b = 3 ORDER BY b",
+		"SELECT b, COUNT(*) FROM test GROUP BY b",
+		// every document is filtered out
+		"SELECT * FROM test WHERE b = 100",
+	} {
+		for _, parallelism := range []int{1, 4} {
+			t.Run(fmt.Sprintf("%s/parallel=%d", q, parallelism), func(t *testing.T) {
+				_, err := run(t, q, time.Nanosecond, parallelism)
+				require.ErrorIs(t, err, stream.ErrQueryTimeout)
+
+				_, err = run(t, q, time.Minute, parallelism)
+				assert.NoError(t, err)
+			})
+		}
+	}
+}
+
+func BenchmarkSelectParallel(b *testing.B) {
+	db := testutil.NewTestDB(b)
+
+	tx, err := db.Begin(true)
+	assert.NoError(b, err)
+	err = testutil.Exec(db, tx, "CREATE TABLE test(a INT PRIMARY KEY, b INT, c TEXT, d DOUBLE)")
+	assert.NoError(b, err)
+	for i := 0; i < 100000; i++ {
+		err = testutil.Exec(db, tx, "INSERT INTO test (a, b, c, d) VALUES (?, ?, ?, ?)",
+			environment.Param{Value: i}, environment.Param{Value: i % 100},
+			environment.Param{Value: "value-" + strconv.Itoa(i)}, environment.Param{Value: float64(i) / 3})
+		assert.NoError(b, err)
+	}
+	assert.NoError(b, tx.Commit())
+
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			pq, err := parser.ParseQuery("SELECT a FROM test WHERE b % 7 = 3 AND c LIKE '%9' AND d * 2 > 100")
+			assert.NoError(b, err)
+			pq.Statements[0].(*statement.SelectStmt).Parallel(workers)
+
+			ctx := &query.Context{Ctx: context.Background(), DB: db}
+			err = pq.Prepare(ctx)
+			assert.NoError(b, err)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				res, err := pq.Run(ctx)
+				assert.NoError(b, err)
+				err = res.Iterate(func(d types.Document) error { return nil })
+				assert.NoError(b, err)
+				res.Close()
+			}
+		})
+	}
+}
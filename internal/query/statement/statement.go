@@ -1,6 +1,8 @@
 package statement
 
 import (
+	"context"
+
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
@@ -12,6 +14,10 @@ import (
 type Statement interface {
 	Run(*Context) (Result, error)
 	IsReadOnly() bool
+	// String returns a representation of the statement.
+	// Parsed statements are represented in canonical SQL
+	// that can be parsed again.
+	String() string
 }
 
 type basePreparedStatement struct {
@@ -33,9 +39,15 @@ func (stmt *basePreparedStatement) Run(ctx *Context) (Result, error) {
 }
 
 type Context struct {
+	// Ctx, if set, stops the execution of the statement
+	// once it is canceled or past its deadline.
+	Ctx    context.Context
 	DB     *database.Database
 	Tx     *database.Transaction
 	Params []environment.Param
+
+	// names of the triggers whose action is being prepared
+	firing map[string]struct{}
 }
 
 type Preparer interface {
@@ -59,6 +71,21 @@ func (r *Result) Iterate(fn func(d types.Document) error) error {
 	return r.err
 }
 
+// Err returns the error returned by the last call to Iterate, if any.
+func (r *Result) Err() error {
+	return r.err
+}
+
+// RowsProcessed returns the number of documents processed
+// by the last call to Iterate.
+func (r *Result) RowsProcessed() int {
+	if it, ok := r.Iterator.(interface{ RowsProcessed() int }); ok {
+		return it.RowsProcessed()
+	}
+
+	return 0
+}
+
 // Close the result stream.
 // After closing the result, Stream is not supposed to be used.
 // If the result stream was already closed, it returns an error.
@@ -57,26 +57,49 @@ func (s *PreparedStreamStmt) String() string {
 type StreamStmtIterator struct {
 	Stream  *stream.Stream
 	Context *Context
+
+	rows int
 }
 
 func (s *StreamStmtIterator) Iterate(fn func(d types.Document) error) error {
+	s.rows = 0
+
 	var env environment.Environment
 	env.DB = s.Context.DB
 	env.Tx = s.Context.Tx
 	env.SetParams(s.Context.Params)
+	if s.Context.Ctx != nil {
+		env.SetContext(s.Context.Ctx)
+	}
+	// the results of the subqueries are only reused
+	// during this execution of the statement
+	env.SetState(subqueryResultsKey, new(subqueryResults))
 
 	err := s.Stream.Iterate(&env, func(env *environment.Environment) error {
 		// if there is no doc in this specific environment,
 		// the last operator is not outputting anything
 		// worth returning to the user.
 		if env.Doc == nil {
+			s.rows++
 			return nil
 		}
 
-		return fn(env.Doc)
+		err := fn(env.Doc)
+		if err != nil {
+			return err
+		}
+
+		s.rows++
+		return nil
 	})
 	if errors.Is(err, stream.ErrStreamClosed) {
 		err = nil
 	}
 	return err
 }
+
+// RowsProcessed returns the number of documents that went through
+// the whole stream during the last call to Iterate.
+func (s *StreamStmtIterator) RowsProcessed() int {
+	return s.rows
+}
@@ -0,0 +1,168 @@
+package statement
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/types"
+)
+
+// A SubqueryExpr is a SELECT statement used as the right operand
+// of the IN and NOT IN operators.
+// It evaluates to an array containing the value of the only field
+// of every document returned by the statement.
+// Only uncorrelated subqueries are supported: the statement is run once
+// per execution of the enclosing statement and its result is reused
+// for every document.
+type SubqueryExpr struct {
+	Stmt *SelectStmt
+
+	// Statements are modified when they are prepared,
+	// every execution prepares its own copy of the query.
+	Query ViewQuery
+}
+
+// subqueryResultsKey is the state key under which the results of the
+// subqueries are stored during the execution of a statement.
+const subqueryResultsKey = "subquery_results"
+
+// subqueryResults holds the results of the subqueries run
+// during one execution of a statement.
+type subqueryResults struct {
+	mu      sync.Mutex
+	results map[*SubqueryExpr]*subqueryResult
+}
+
+type subqueryResult struct {
+	// parallel scans evaluate filters concurrently
+	mu    sync.Mutex
+	value types.Value
+}
+
+// get returns the result of the given subquery, creating it if needed.
+func (r *subqueryResults) get(e *SubqueryExpr) *subqueryResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.results == nil {
+		r.results = make(map[*SubqueryExpr]*subqueryResult)
+	}
+
+	res, ok := r.results[e]
+	if !ok {
+		res = new(subqueryResult)
+		r.results[e] = res
+	}
+
+	return res
+}
+
+// Eval runs the statement, unless it was already run during
+// the same execution of the enclosing statement.
+// Evaluating a subquery requires a transaction.
+func (e *SubqueryExpr) Eval(env *environment.Environment) (types.Value, error) {
+	results, ok := env.GetState(subqueryResultsKey)
+	if !ok {
+		// not run by a statement, nothing to reuse the result for
+		return e.run(env)
+	}
+
+	res := results.(*subqueryResults).get(e)
+	res.mu.Lock()
+	defer res.mu.Unlock()
+
+	if res.value != nil {
+		return res.value, nil
+	}
+
+	v, err := e.run(env)
+	if err != nil {
+		return nil, err
+	}
+
+	res.value = v
+	return v, nil
+}
+
+// run prepares and runs the statement.
+func (e *SubqueryExpr) run(env *environment.Environment) (types.Value, error) {
+	tx := env.GetTx()
+	if tx == nil {
+		return nil, errors.New("subquery requires a transaction")
+	}
+
+	stmt, err := e.Query.SelectStmt()
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := stmt.Prepare(&Context{DB: env.GetDB(), Tx: tx})
+	if err != nil {
+		return nil, err
+	}
+
+	// the subquery doesn't depend on the current document
+	root := env
+	for root.Outer != nil {
+		root = root.Outer
+	}
+
+	var vb document.ValueBuffer
+	var newEnv environment.Environment
+	newEnv.SetOuter(root)
+
+	err = st.(*PreparedStreamStmt).Stream.Iterate(&newEnv, func(out *environment.Environment) error {
+		if out.Doc == nil {
+			return nil
+		}
+
+		var v types.Value
+		var n int
+		err := out.Doc.Iterate(func(field string, fv types.Value) error {
+			n++
+			v = fv
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if n != 1 {
+			return errors.New("subquery must return only one field")
+		}
+
+		// the value may be reused by the stream
+		v, err = document.CloneValue(v)
+		if err != nil {
+			return err
+		}
+
+		vb.Append(v)
+		return nil
+	})
+	if err != nil && !errors.Is(err, stream.ErrStreamClosed) {
+		return nil, err
+	}
+
+	return types.NewArrayValue(&vb), nil
+}
+
+// IsEqual compares this expression with the other expression and returns
+// true if they are equal.
+func (e *SubqueryExpr) IsEqual(other expr.Expr) bool {
+	o, ok := other.(*SubqueryExpr)
+	if !ok {
+		return false
+	}
+
+	return e.String() == o.String()
+}
+
+// String returns the statement between parentheses.
+func (e *SubqueryExpr) String() string {
+	return fmt.Sprintf("(%s)", e.Stmt)
+}
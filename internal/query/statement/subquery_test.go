@@ -0,0 +1,64 @@
+package statement_test
+
+import (
+	"testing"
+
+	"github.com/genjidb/genji"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/testutil/assert"
+	"github.com/genjidb/genji/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubqueryExpr(t *testing.T) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	err = db.Exec(`
+		CREATE TABLE test(a INT, b INT);
+		CREATE TABLE other(a INT);
+		CREATE INDEX other_a_idx ON other(a);
+		INSERT INTO test (a, b) VALUES (1, 1), (2, 2), (3, 3);
+		INSERT INTO other (a) VALUES (1), (2);
+	`)
+	assert.NoError(t, err)
+
+	collect := func(t *testing.T, res *genji.Result) []int {
+		t.Helper()
+		defer res.Close()
+
+		var got []int
+		err := res.Iterate(func(d types.Document) error {
+			var a int
+			err := document.Scan(d, &a)
+			got = append(got, a)
+			return err
+		})
+		assert.NoError(t, err)
+		return got
+	}
+
+	t.Run("Params", func(t *testing.T) {
+		res, err := db.Query("SELECT a FROM test WHERE a = ? OR b IN (SELECT a FROM other WHERE a > ?)", 3, 1)
+		assert.NoError(t, err)
+		require.Equal(t, []int{2, 3}, collect(t, res))
+	})
+
+	t.Run("Prepared", func(t *testing.T) {
+		stmt, err := db.Prepare("SELECT a FROM test WHERE b IN (SELECT a FROM other WHERE a >= 2)")
+		assert.NoError(t, err)
+
+		res, err := stmt.Query()
+		assert.NoError(t, err)
+		require.Equal(t, []int{2}, collect(t, res))
+
+		// every execution runs the subquery against the current catalog
+		err = db.Exec("DROP INDEX other_a_idx; INSERT INTO other (a) VALUES (3)")
+		assert.NoError(t, err)
+
+		res, err = stmt.Query()
+		assert.NoError(t, err)
+		require.Equal(t, []int{2, 3}, collect(t, res))
+	})
+}
@@ -0,0 +1,117 @@
+package statement
+
+import (
+	"fmt"
+
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/stream/docs"
+)
+
+// A TriggerAction is the action of a trigger, stored in database.TriggerInfo.
+// Statements are modified when they are prepared, every statement
+// firing the trigger prepares its own copy of the action.
+type TriggerAction interface {
+	String() string
+	// Statement returns a new copy of the statement run by the trigger.
+	Statement() (Preparer, error)
+}
+
+// ExecuteFunctionStmt is the action of a trigger calling a function
+// registered with RegisterFunc. The value returned by the function is ignored,
+// returning an error aborts the statement that fired the trigger.
+type ExecuteFunctionStmt struct {
+	Func expr.Expr
+}
+
+// Prepare implements the Preparer interface.
+func (stmt *ExecuteFunctionStmt) Prepare(c *Context) (Statement, error) {
+	// the function is evaluated by building a document
+	// holding its result, which is then discarded
+	s := stream.New(docs.Emit(&expr.KVPairs{Pairs: []expr.KVPair{{K: "result", V: stmt.Func}}}))
+	s = s.Pipe(stream.Discard())
+
+	st := StreamStmt{
+		Stream:   s,
+		ReadOnly: false,
+	}
+
+	return st.Prepare(c)
+}
+
+// String returns a SQL representation.
+func (stmt *ExecuteFunctionStmt) String() string {
+	return fmt.Sprintf("EXECUTE FUNCTION %s", stmt.Func)
+}
+
+// pipeTriggers pipes an operator running the action of every trigger
+// of the table fired by the given event at the given time.
+// A trigger is not fired by its own action, directly or through other triggers.
+func pipeTriggers(c *Context, s *stream.Stream, tableName string, timing database.TriggerTiming, event database.TriggerEvent) (*stream.Stream, error) {
+	for _, name := range c.Tx.Catalog.ListTriggers(tableName) {
+		info, err := c.Tx.Catalog.GetTriggerInfo(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if info.Timing != timing || info.Event != event {
+			continue
+		}
+
+		if _, ok := c.firing[name]; ok {
+			continue
+		}
+
+		action, err := prepareTriggerAction(c, info)
+		if err != nil {
+			return nil, err
+		}
+
+		s = s.Pipe(stream.Trigger(name, event, action))
+	}
+
+	return s, nil
+}
+
+// hasTriggers returns whether the table has triggers fired by the given event.
+func hasTriggers(c *Context, tableName string, event database.TriggerEvent) bool {
+	for _, name := range c.Tx.Catalog.ListTriggers(tableName) {
+		info, err := c.Tx.Catalog.GetTriggerInfo(name)
+		if err == nil && info.Event == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+func prepareTriggerAction(c *Context, info *database.TriggerInfo) (*stream.Stream, error) {
+	a, ok := info.Action.(TriggerAction)
+	if !ok {
+		return nil, fmt.Errorf("invalid action for trigger %q", info.TriggerName)
+	}
+
+	p, err := a.Statement()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.firing == nil {
+		c.firing = make(map[string]struct{})
+	}
+	c.firing[info.TriggerName] = struct{}{}
+	defer delete(c.firing, info.TriggerName)
+
+	st, err := p.Prepare(c)
+	if err != nil {
+		return nil, err
+	}
+
+	ps, ok := st.(*PreparedStreamStmt)
+	if !ok {
+		return nil, fmt.Errorf("invalid action for trigger %q", info.TriggerName)
+	}
+
+	return ps.Stream, nil
+}
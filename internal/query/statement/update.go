@@ -1,14 +1,21 @@
 package statement
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/docs"
 	"github.com/genjidb/genji/internal/stream/index"
 	"github.com/genjidb/genji/internal/stream/path"
 	"github.com/genjidb/genji/internal/stream/table"
+	"github.com/genjidb/genji/internal/stringutil"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
 )
 
 // UpdateConfig holds UPDATE configuration.
@@ -27,6 +34,15 @@ type UpdateStmt struct {
 	UnsetFields []string
 
 	WhereExpr expr.Expr
+
+	OrderBy          []expr.Path
+	OrderByDirection tree.SortOrder
+	OffsetExpr       expr.Expr
+	LimitExpr        expr.Expr
+
+	// If true, the statement returns the documents as they
+	// would be after the update, without modifying the table.
+	dryRun bool
 }
 
 func NewUpdateStatement() *UpdateStmt {
@@ -40,6 +56,25 @@ func NewUpdateStatement() *UpdateStmt {
 	return &p
 }
 
+// Limit updates at most n documents.
+func (stmt *UpdateStmt) Limit(n int64) *UpdateStmt {
+	stmt.LimitExpr = expr.LiteralValue{Value: types.NewIntegerValue(n)}
+	return stmt
+}
+
+// Offset skips the first n documents matching the statement.
+func (stmt *UpdateStmt) Offset(n int64) *UpdateStmt {
+	stmt.OffsetExpr = expr.LiteralValue{Value: types.NewIntegerValue(n)}
+	return stmt
+}
+
+// DryRun makes the statement return the documents it would modify
+// instead of modifying the table.
+func (stmt *UpdateStmt) DryRun() *UpdateStmt {
+	stmt.dryRun = true
+	return stmt
+}
+
 type UpdateSetPair struct {
 	Path document.Path
 	E    expr.Expr
@@ -59,32 +94,46 @@ func (stmt *UpdateStmt) Prepare(c *Context) (Statement, error) {
 		s = s.Pipe(docs.Filter(stmt.WhereExpr))
 	}
 
+	s, err = pipeOrderBy(s, stmt.OrderBy, stmt.OrderByDirection)
+	if err != nil {
+		return nil, err
+	}
+
+	if stmt.OffsetExpr != nil {
+		s = s.Pipe(docs.Skip(stmt.OffsetExpr))
+	}
+
+	if stmt.LimitExpr != nil {
+		s = s.Pipe(docs.Take(stmt.LimitExpr))
+	}
+
+	if !stmt.dryRun && hasTriggers(c, stmt.TableName, database.TriggerUpdate) {
+		s = s.Pipe(stream.TriggerOld())
+	}
+
 	var pkModified bool
 	if stmt.SetPairs != nil {
+		pkModified = isPrimaryKeyModified(pk, stmt.SetPairs)
 		for _, pair := range stmt.SetPairs {
-			// if we modify the primary key,
-			// we must remove the old document and create an new one
-			if pk != nil && !pkModified {
-				for _, p := range pk.Paths {
-					if p.IsEqual(pair.Path) {
-						pkModified = true
-						break
-					}
-				}
-			}
 			s = s.Pipe(path.Set(pair.Path, pair.E))
 		}
 	} else if stmt.UnsetFields != nil {
 		for _, name := range stmt.UnsetFields {
+			p := document.NewPath(name)
+
 			// ensure we do not unset any path the is used in the primary key
 			if pk != nil {
-				path := document.NewPath(name)
-				for _, p := range pk.Paths {
-					if p.IsEqual(path) {
+				for _, pkp := range pk.Paths {
+					if pkp.IsEqual(p) {
 						return nil, errors.New("cannot unset primary key path")
 					}
 				}
 			}
+
+			// fields declared in the table schema cannot be removed from a single document
+			if ti.GetFieldConstraintForPath(p) != nil {
+				return nil, errors.Errorf("cannot unset declared field %q", name)
+			}
 			s = s.Pipe(path.Unset(name))
 		}
 	}
@@ -92,19 +141,71 @@ func (stmt *UpdateStmt) Prepare(c *Context) (Statement, error) {
 	// validate document
 	s = s.Pipe(table.Validate(stmt.TableName))
 
+	if stmt.dryRun {
+		st := StreamStmt{
+			Stream:   pipeDryRun(s),
+			ReadOnly: true,
+		}
+
+		return st.Prepare(c)
+	}
+
+	s, err = pipeUpdate(c, s, stmt.TableName, pkModified)
+	if err != nil {
+		return nil, err
+	}
+
+	s = s.Pipe(stream.Discard())
+
+	st := StreamStmt{
+		Stream:   s,
+		ReadOnly: false,
+	}
+
+	return st.Prepare(c)
+}
+
+// isPrimaryKeyModified returns whether one of the pairs sets a path of the primary key.
+func isPrimaryKeyModified(pk *database.PrimaryKey, pairs []UpdateSetPair) bool {
+	if pk == nil {
+		return false
+	}
+
+	for _, pair := range pairs {
+		for _, p := range pk.Paths {
+			if p.IsEqual(pair.Path) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// pipeUpdate pipes the operators writing the incoming documents
+// to the table and its indexes, in place of the documents stored under their key.
+// If the primary key was modified, the documents are deleted and inserted again.
+// The UPDATE triggers of the table are fired around the writes, they expect
+// the old documents to be captured by a TriggerOld operator.
+func pipeUpdate(c *Context, s *stream.Stream, tableName string, pkModified bool) (*stream.Stream, error) {
+	s, err := pipeTriggers(c, s, tableName, database.TriggerBefore, database.TriggerUpdate)
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO(asdine): This removes ALL indexed fields for each document
 	// even if the update modified a single field. We should only
 	// update the indexed fields that were modified.
-	indexNames := c.Tx.Catalog.ListIndexes(stmt.TableName)
+	indexNames := c.Tx.Catalog.ListIndexes(tableName)
 	for _, indexName := range indexNames {
 		s = s.Pipe(index.Delete(indexName))
 	}
 
 	if pkModified {
-		s = s.Pipe(table.Delete(stmt.TableName))
-		s = s.Pipe(table.Insert(stmt.TableName))
+		s = s.Pipe(table.Delete(tableName))
+		s = s.Pipe(table.Insert(tableName))
 	} else {
-		s = s.Pipe(table.Replace(stmt.TableName))
+		s = s.Pipe(table.Replace(tableName))
 	}
 
 	for _, indexName := range indexNames {
@@ -119,12 +220,43 @@ func (stmt *UpdateStmt) Prepare(c *Context) (Statement, error) {
 		s = s.Pipe(index.Insert(indexName))
 	}
 
-	s = s.Pipe(stream.Discard())
+	return pipeTriggers(c, s, tableName, database.TriggerAfter, database.TriggerUpdate)
+}
 
-	st := StreamStmt{
-		Stream:   s,
-		ReadOnly: false,
+// String returns a SQL representation.
+func (stmt *UpdateStmt) String() string {
+	var b strings.Builder
+
+	b.WriteString("UPDATE ")
+	b.WriteString(stringutil.NormalizeIdentifier(stmt.TableName, '`'))
+
+	if stmt.SetPairs != nil {
+		b.WriteString(" SET ")
+		for i, pair := range stmt.SetPairs {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "%s = %s", pair.Path, pair.E)
+		}
+	} else if stmt.UnsetFields != nil {
+		b.WriteString(" UNSET ")
+		for i, name := range stmt.UnsetFields {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(stringutil.NormalizeIdentifier(name, '`'))
+		}
 	}
 
-	return st.Prepare(c)
+	if stmt.WhereExpr != nil {
+		fmt.Fprintf(&b, " WHERE %s", stmt.WhereExpr)
+	}
+
+	writeOrderByLimitOffset(&b, stmt.OrderBy, stmt.OrderByDirection, stmt.LimitExpr, stmt.OffsetExpr)
+
+	if stmt.dryRun {
+		b.WriteString(" DRY RUN")
+	}
+
+	return b.String()
 }
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/genjidb/genji"
+	"github.com/genjidb/genji/internal/query/statement"
+	"github.com/genjidb/genji/internal/sql/parser"
 	"github.com/genjidb/genji/internal/testutil"
 	"github.com/genjidb/genji/internal/testutil/assert"
 	"github.com/stretchr/testify/require"
@@ -37,6 +39,9 @@ func TestUpdateStmt(t *testing.T) {
 		{"SET / Nested documents on a / Wrong type", "UPDATE test SET a.b = 2", false, `[{"a":"foo1","b":"bar1","c":"baz1"},{"a":"foo2","b":"bar2"},{"a":"foo3","d":"bar3","e":"baz3"}]`, nil},
 		{"SET / Nested documents on a / missing document", "UPDATE test SET g.h.i = 2", false, `[{"a":"foo1","b":"bar1","c":"baz1"},{"a":"foo2","b":"bar2"},{"a":"foo3","d":"bar3","e":"baz3"}]`, nil},
 
+		{"SET / With limit", "UPDATE test SET a = 'boo' LIMIT 2", false, `[{"a":"boo","b":"bar1","c":"baz1"},{"a":"boo","b":"bar2"},{"a":"foo3","d":"bar3","e":"baz3"}]`, nil},
+		{"SET / With order by then limit then offset", "UPDATE test SET a = 'boo' ORDER BY a DESC LIMIT 1 OFFSET 1", false, `[{"a":"foo1","b":"bar1","c":"baz1"},{"a":"boo","b":"bar2"},{"a":"foo3","d":"bar3","e":"baz3"}]`, nil},
+
 		// UNSET tests.
 		{"UNSET / No cond", `UPDATE test UNSET b`, false, `[{"a":"foo1","c":"baz1"},{"a":"foo2"},{"a":"foo3","d":"bar3","e":"baz3"}]`, nil},
 		{"UNSET / No cond / with ident string", "UPDATE test UNSET `a`", true, "", nil},
@@ -140,3 +145,86 @@ func TestUpdateStmt(t *testing.T) {
 		}
 	})
 }
+
+func BenchmarkUpdate(b *testing.B) {
+	db, err := genji.Open(":memory:")
+	assert.NoError(b, err)
+	defer db.Close()
+
+	err = db.Exec("CREATE TABLE test(a INT PRIMARY KEY, b INT, c TEXT, d TEXT)")
+	assert.NoError(b, err)
+	for i := 0; i < 10; i++ {
+		err = db.Exec("INSERT INTO test (a, b, c, d) VALUES (?, ?, ?, ?)", i, i, "foo", "bar")
+		assert.NoError(b, err)
+	}
+
+	stmt, err := db.Prepare("UPDATE test SET b = b + 1, c = d WHERE a < 5")
+	assert.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err = stmt.Exec()
+		assert.NoError(b, err)
+	}
+}
+
+func TestUpdateStmtDryRun(t *testing.T) {
+	db, tx, cleanup := testutil.NewTestTx(t)
+	defer cleanup()
+
+	testutil.MustExec(t, db, tx, `
+		CREATE TABLE test(a INT PRIMARY KEY, b TEXT);
+		INSERT INTO test (a, b) VALUES (1, 'foo'), (2, 'bar'), (3, 'foo');
+	`)
+
+	pq, err := parser.ParseQuery("UPDATE test SET b = 'baz' WHERE b = 'foo'")
+	assert.NoError(t, err)
+	pq.Statements[0].(*statement.UpdateStmt).DryRun()
+
+	require.JSONEq(t, `[
+		{"pk()": [1], "a": 1, "b": "baz"},
+		{"pk()": [3], "a": 3, "b": "baz"}
+	]`, runQueryToJSON(t, db, tx, pq))
+
+	res := testutil.MustQuery(t, db, tx, "SELECT * FROM test")
+	defer res.Close()
+
+	var buf bytes.Buffer
+	err = testutil.IteratorToJSONArray(&buf, res)
+	assert.NoError(t, err)
+	require.JSONEq(t, `[
+		{"a": 1, "b": "foo"},
+		{"a": 2, "b": "bar"},
+		{"a": 3, "b": "foo"}
+	]`, buf.String())
+}
+
+func TestUpdateStmtLimitOffset(t *testing.T) {
+	db, tx, cleanup := testutil.NewTestTx(t)
+	defer cleanup()
+
+	testutil.MustExec(t, db, tx, `
+		CREATE TABLE test(a INT PRIMARY KEY, b TEXT);
+		INSERT INTO test (a, b) VALUES (1, 'foo'), (2, 'bar'), (3, 'foo'), (4, 'foo');
+	`)
+
+	pq, err := parser.ParseQuery("UPDATE test SET b = 'baz' WHERE b = 'foo'")
+	assert.NoError(t, err)
+	pq.Statements[0].(*statement.UpdateStmt).Limit(1).Offset(1)
+
+	runQueryToJSON(t, db, tx, pq)
+
+	res := testutil.MustQuery(t, db, tx, "SELECT * FROM test")
+	defer res.Close()
+
+	var buf bytes.Buffer
+	err = testutil.IteratorToJSONArray(&buf, res)
+	assert.NoError(t, err)
+	require.JSONEq(t, `[
+		{"a": 1, "b": "foo"},
+		{"a": 2, "b": "bar"},
+		{"a": 3, "b": "baz"},
+		{"a": 4, "b": "foo"}
+	]`, buf.String())
+}
@@ -34,6 +34,15 @@ func (stmt BeginStmt) IsReadOnly() bool {
 	return !stmt.Writable
 }
 
+// String returns a SQL representation.
+func (stmt BeginStmt) String() string {
+	if stmt.Writable {
+		return "BEGIN"
+	}
+
+	return "BEGIN READ ONLY"
+}
+
 func (stmt BeginStmt) Run(ctx *statement.Context) (statement.Result, error) {
 	return statement.Result{}, errors.New("cannot begin a transaction within a transaction")
 }
@@ -64,6 +73,11 @@ func (stmt RollbackStmt) IsReadOnly() bool {
 	return false
 }
 
+// String returns a SQL representation.
+func (stmt RollbackStmt) String() string {
+	return "ROLLBACK"
+}
+
 func (stmt RollbackStmt) Run(ctx *statement.Context) (statement.Result, error) {
 	return statement.Result{}, errors.New("cannot rollback with no active transaction")
 }
@@ -94,6 +108,11 @@ func (stmt CommitStmt) IsReadOnly() bool {
 	return false
 }
 
+// String returns a SQL representation.
+func (stmt CommitStmt) String() string {
+	return "COMMIT"
+}
+
 func (stmt CommitStmt) Run(ctx *statement.Context) (statement.Result, error) {
 	return statement.Result{}, errors.New("cannot commit with no active transaction")
 }
@@ -45,6 +45,56 @@ func (p *Parser) parseAlterTableAddFieldStatement(tableName string) (*statement.
 		return nil, &ParseError{Message: "cannot add a field with no constraint"}
 	}
 
+	for _, tc := range stmt.TableConstraints {
+		if tc.AutoIncrement {
+			return nil, &ParseError{Message: "cannot add an AUTOINCREMENT field"}
+		}
+	}
+
+	return &stmt, nil
+}
+
+func (p *Parser) parseAlterTableDropFieldStatement(tableName string) (*statement.AlterTableDropFieldStmt, error) {
+	var stmt statement.AlterTableDropFieldStmt
+	stmt.TableName = tableName
+
+	// Parse "FIELD".
+	if err := p.parseTokens(scanner.FIELD); err != nil {
+		return nil, err
+	}
+
+	// Parse field name.
+	var err error
+	stmt.Field, err = p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	return &stmt, nil
+}
+
+func (p *Parser) parseAlterTableRenameFieldStatement(tableName string) (*statement.AlterTableRenameFieldStmt, error) {
+	var stmt statement.AlterTableRenameFieldStmt
+	stmt.TableName = tableName
+
+	// Parse field name.
+	var err error
+	stmt.Field, err = p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse "TO".
+	if err := p.parseTokens(scanner.TO); err != nil {
+		return nil, err
+	}
+
+	// Parse new field name.
+	stmt.NewName, err = p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+
 	return &stmt, nil
 }
 
@@ -57,6 +107,11 @@ func (p *Parser) parseAlterStatement() (statement.Statement, error) {
 		return nil, err
 	}
 
+	ifExists, err := p.parseOptional(scanner.IF, scanner.EXISTS)
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse table name.
 	tableName, err := p.parseIdent()
 	if err != nil {
@@ -68,10 +123,38 @@ func (p *Parser) parseAlterStatement() (statement.Statement, error) {
 	tok, pos, lit := p.ScanIgnoreWhitespace()
 	switch tok {
 	case scanner.RENAME:
-		return p.parseAlterTableRenameStatement(tableName)
+		// Parse optional "FIELD".
+		renameField, err := p.parseOptional(scanner.FIELD)
+		if err != nil {
+			return nil, err
+		}
+		if renameField {
+			stmt, err := p.parseAlterTableRenameFieldStatement(tableName)
+			if err != nil {
+				return nil, err
+			}
+			stmt.IfExists = ifExists
+			return stmt, nil
+		}
+
+		stmt, err := p.parseAlterTableRenameStatement(tableName)
+		stmt.IfExists = ifExists
+		return stmt, err
 	case scanner.ADD_KEYWORD:
-		return p.parseAlterTableAddFieldStatement(tableName)
+		stmt, err := p.parseAlterTableAddFieldStatement(tableName)
+		if err != nil {
+			return nil, err
+		}
+		stmt.IfExists = ifExists
+		return stmt, nil
+	case scanner.DROP:
+		stmt, err := p.parseAlterTableDropFieldStatement(tableName)
+		if err != nil {
+			return nil, err
+		}
+		stmt.IfExists = ifExists
+		return stmt, nil
 	}
 
-	return nil, newParseError(scanner.Tokstr(tok, lit), []string{"ADD", "RENAME"}, pos)
+	return nil, newParseError(scanner.Tokstr(tok, lit), []string{"ADD", "DROP", "RENAME"}, pos)
 }
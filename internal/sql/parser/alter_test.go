@@ -21,6 +21,7 @@ func TestParserAlterTable(t *testing.T) {
 		errored  bool
 	}{
 		{"Basic", "ALTER TABLE foo RENAME TO bar", statement.AlterTableRenameStmt{TableName: "foo", NewTableName: "bar"}, false},
+		{"With IF EXISTS", "ALTER TABLE IF EXISTS foo RENAME TO bar", statement.AlterTableRenameStmt{TableName: "foo", NewTableName: "bar", IfExists: true}, false},
 		{"With error / missing TABLE keyword", "ALTER foo RENAME TO bar", statement.AlterTableRenameStmt{}, true},
 		{"With error / two identifiers for table name", "ALTER TABLE foo baz RENAME TO bar", statement.AlterTableRenameStmt{}, true},
 		{"With error / two identifiers for new table name", "ALTER TABLE foo RENAME TO bar baz", statement.AlterTableRenameStmt{}, true},
@@ -90,6 +91,14 @@ func TestParserAlterTableAddField(t *testing.T) {
 				DefaultValue: expr.Constraint(expr.LiteralValue{Value: types.NewIntegerValue(0)}),
 			},
 		}, false},
+		{"With IF EXISTS", "ALTER TABLE IF EXISTS foo ADD FIELD bar integer", &statement.AlterTableAddFieldStmt{
+			TableName: "foo",
+			FieldConstraint: &database.FieldConstraint{
+				Field: "bar",
+				Type:  types.IntegerValue,
+			},
+			IfExists: true,
+		}, false},
 		{"With error / missing FIELD keyword", "ALTER TABLE foo ADD bar", nil, true},
 		{"With error / missing field name", "ALTER TABLE foo ADD FIELD", nil, true},
 	}
@@ -107,3 +116,59 @@ func TestParserAlterTableAddField(t *testing.T) {
 		})
 	}
 }
+
+func TestParserAlterTableDropField(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected statement.Statement
+		errored  bool
+	}{
+		{"Basic", "ALTER TABLE foo DROP FIELD bar", &statement.AlterTableDropFieldStmt{TableName: "foo", Field: "bar"}, false},
+		{"With IF EXISTS", "ALTER TABLE IF EXISTS foo DROP FIELD bar", &statement.AlterTableDropFieldStmt{TableName: "foo", Field: "bar", IfExists: true}, false},
+		{"With error / missing FIELD keyword", "ALTER TABLE foo DROP bar", nil, true},
+		{"With error / missing field name", "ALTER TABLE foo DROP FIELD", nil, true},
+		{"With error / nested path", "ALTER TABLE foo DROP FIELD bar.baz", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parser.ParseQuery(test.s)
+			if test.errored {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, q.Statements, 1)
+			require.EqualValues(t, test.expected, q.Statements[0])
+		})
+	}
+}
+
+func TestParserAlterTableRenameField(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected statement.Statement
+		errored  bool
+	}{
+		{"Basic", "ALTER TABLE foo RENAME FIELD bar TO baz", &statement.AlterTableRenameFieldStmt{TableName: "foo", Field: "bar", NewName: "baz"}, false},
+		{"With IF EXISTS", "ALTER TABLE IF EXISTS foo RENAME FIELD bar TO baz", &statement.AlterTableRenameFieldStmt{TableName: "foo", Field: "bar", NewName: "baz", IfExists: true}, false},
+		{"With error / missing TO", "ALTER TABLE foo RENAME FIELD bar baz", nil, true},
+		{"With error / missing new name", "ALTER TABLE foo RENAME FIELD bar TO", nil, true},
+		{"With error / missing field name", "ALTER TABLE foo RENAME FIELD TO baz", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parser.ParseQuery(test.s)
+			if test.errored {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, q.Statements, 1)
+			require.EqualValues(t, test.expected, q.Statements[0])
+		})
+	}
+}
@@ -3,10 +3,13 @@ package parser
 import (
 	"fmt"
 	"math"
+	"strings"
 
+	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/expr/functions"
 	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/sql/scanner"
 	"github.com/genjidb/genji/internal/tree"
@@ -34,9 +37,16 @@ func (p *Parser) parseCreateStatement() (statement.Statement, error) {
 		return p.parseCreateIndexStatement(false)
 	case scanner.SEQUENCE:
 		return p.parseCreateSequenceStatement()
+	case scanner.VIEW:
+		return p.parseCreateViewStatement()
 	}
 
-	return nil, newParseError(scanner.Tokstr(tok, lit), []string{"TABLE", "INDEX", "SEQUENCE"}, pos)
+	// TRIGGER is not a reserved keyword
+	if isWord(tok, lit, "TRIGGER") {
+		return p.parseCreateTriggerStatement()
+	}
+
+	return nil, newParseError(scanner.Tokstr(tok, lit), []string{"TABLE", "INDEX", "SEQUENCE", "VIEW", "TRIGGER"}, pos)
 }
 
 // parseCreateTableStatement parses a create table string and returns a Statement AST object.
@@ -66,9 +76,62 @@ func (p *Parser) parseCreateTableStatement() (*statement.CreateTableStmt, error)
 	if len(stmt.Info.FieldConstraints.Ordered) == 0 {
 		stmt.Info.FieldConstraints.AllowExtraFields = true
 	}
+
+	// parse table options
+	err = p.parseTableOptions(&stmt)
+	if err != nil {
+		return nil, err
+	}
+
 	return &stmt, err
 }
 
+// parseTableOptions parses the optional list of table options
+// following the definition of a table: WITH (name = value, ...).
+func (p *Parser) parseTableOptions(stmt *statement.CreateTableStmt) error {
+	if ok, err := p.parseOptional(scanner.WITH, scanner.LPAREN); !ok || err != nil {
+		return err
+	}
+
+	for {
+		tok, pos, lit := p.ScanIgnoreWhitespace()
+		if tok != scanner.IDENT {
+			return newParseError(scanner.Tokstr(tok, lit), []string{"table option"}, pos)
+		}
+
+		if err := p.parseTokens(scanner.EQ); err != nil {
+			return err
+		}
+
+		switch strings.ToLower(lit) {
+		case "ttl_field":
+			if stmt.Info.TTLField != "" {
+				return &ParseError{Message: "conflicting or redundant options"}
+			}
+
+			field, err := p.parseIdent()
+			if err != nil {
+				return err
+			}
+
+			fc := stmt.Info.FieldConstraints.ByField[field]
+			if fc == nil || fc.Type != types.TimestampValue {
+				return &ParseError{Message: fmt.Sprintf("ttl field %q must be a TIMESTAMP field", field)}
+			}
+			stmt.Info.TTLField = field
+		default:
+			return &ParseError{Message: fmt.Sprintf("unknown table option %q", lit)}
+		}
+
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.COMMA {
+			p.Unscan()
+			break
+		}
+	}
+
+	return p.parseTokens(scanner.RPAREN)
+}
+
 func (p *Parser) parseConstraints(stmt *statement.CreateTableStmt) error {
 	// Parse ( token.
 	if ok, err := p.parseOptional(scanner.LPAREN); !ok || err != nil {
@@ -87,7 +150,7 @@ func (p *Parser) parseConstraints(stmt *statement.CreateTableStmt) error {
 	for {
 		// start with the ellipsis token.
 		// if found, stop parsing constraints, as it should be the last one.
-		tok, _, _ := p.ScanIgnoreWhitespace()
+		tok, pos, _ := p.ScanIgnoreWhitespace()
 		if tok == scanner.ELLIPSIS {
 			stmt.Info.FieldConstraints.AllowExtraFields = true
 			break
@@ -119,6 +182,11 @@ func (p *Parser) parseConstraints(stmt *statement.CreateTableStmt) error {
 				return err
 			}
 
+			err = checkDuplicateField(&stmt.Info.FieldConstraints, document.Path{}, fc, pos)
+			if err != nil {
+				return err
+			}
+
 			err = stmt.Info.AddFieldConstraint(fc)
 			if err != nil {
 				return err
@@ -159,9 +227,27 @@ func (p *Parser) parseFieldDefinition(parent document.Path) (*database.FieldCons
 		return nil, nil, err
 	}
 
-	fc.Type, err = p.parseType()
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	p.Unscan()
+
+	fc.Type, fc.MaxLength, err = p.parseTypeWithLength()
+	if err == nil && fc.MaxLength > 0 && tok == scanner.TYPECHARACTER {
+		fc.LengthType = "CHARACTER"
+	}
 	if err != nil {
+		// the type was recognized but its arguments are invalid
+		var perr *ParseError
+		if errors.As(err, &perr) && perr.Pos != pos {
+			return nil, nil, err
+		}
+
 		p.Unscan()
+
+		// the type is optional, but an identifier following the field name
+		// can only be an unknown type
+		if tok == scanner.IDENT {
+			return nil, nil, newParseError(scanner.Tokstr(tok, lit), []string{"type"}, pos)
+		}
 	}
 
 	path := parent.ExtendField(fc.Field)
@@ -219,6 +305,11 @@ LOOP:
 				}
 			}
 
+			tc.AutoIncrement, err = p.parseOptional(scanner.AUTOINCREMENT)
+			if err != nil {
+				return nil, nil, err
+			}
+
 			tcs = append(tcs, &tc)
 		case scanner.NOT:
 			// Parse "NULL"
@@ -267,6 +358,7 @@ LOOP:
 				scanner.TRUE,
 				scanner.FALSE,
 				scanner.NULL,
+				scanner.IDENT,    // only function calls are allowed, see validateDefaultExpr
 				scanner.LPAREN,   // only opening parenthesis are necessary
 				scanner.LBRACKET, // only opening brackets are necessary
 				scanner.NEXT,
@@ -275,6 +367,10 @@ LOOP:
 				return nil, nil, err
 			}
 
+			if err := validateDefaultExpr(e); err != nil {
+				return nil, nil, err
+			}
+
 			fc.DefaultValue = expr.Constraint(e)
 
 			if withParentheses {
@@ -304,6 +400,19 @@ LOOP:
 		}
 	}
 
+	for _, tc := range tcs {
+		if !tc.AutoIncrement {
+			continue
+		}
+
+		if fc.Type != types.IntegerValue {
+			return nil, nil, &ParseError{Message: "AUTOINCREMENT is only allowed on an INTEGER PRIMARY KEY"}
+		}
+		if fc.DefaultValue != nil {
+			return nil, nil, &ParseError{Message: "AUTOINCREMENT cannot be used with a DEFAULT value"}
+		}
+	}
+
 	return &fc, tcs, nil
 }
 
@@ -320,7 +429,7 @@ func (p *Parser) parseDocumentDefinition(parent document.Path) (*database.Anonym
 	for {
 		// start with the ellipsis token.
 		// if found, stop parsing constraints, as it should be the last one.
-		tok, _, _ := p.ScanIgnoreWhitespace()
+		tok, pos, _ := p.ScanIgnoreWhitespace()
 		if tok == scanner.ELLIPSIS {
 			anon.FieldConstraints.AllowExtraFields = true
 			break
@@ -332,6 +441,11 @@ func (p *Parser) parseDocumentDefinition(parent document.Path) (*database.Anonym
 			return nil, nil, err
 		}
 
+		err = checkDuplicateField(&anon.FieldConstraints, parent, fc, pos)
+		if err != nil {
+			return nil, nil, err
+		}
+
 		err = anon.AddFieldConstraint(fc)
 		if err != nil {
 			return nil, nil, err
@@ -353,6 +467,20 @@ func (p *Parser) parseDocumentDefinition(parent document.Path) (*database.Anonym
 	return &anon, nestedTcs, nil
 }
 
+// checkDuplicateField returns a parse error if a field with the same name
+// was already defined at the same level.
+// Field names are case-sensitive, like document paths: a and A are different fields.
+func checkDuplicateField(fcs *database.FieldConstraints, parent document.Path, fc *database.FieldConstraint, pos scanner.Pos) error {
+	if _, ok := fcs.ByField[fc.Field]; !ok {
+		return nil
+	}
+
+	return errors.WithStack(&ParseError{
+		Message: fmt.Sprintf("duplicate field name %q", parent.ExtendField(fc.Field)),
+		Pos:     pos,
+	})
+}
+
 func (p *Parser) parseTableConstraint(stmt *statement.CreateTableStmt) (*database.TableConstraint, error) {
 	var err error
 
@@ -363,7 +491,9 @@ func (p *Parser) parseTableConstraint(stmt *statement.CreateTableStmt) (*databas
 	if ok, _ := p.parseOptional(scanner.CONSTRAINT); ok {
 		tok, pos, lit := p.ScanIgnoreWhitespace()
 		switch tok {
-		case scanner.IDENT, scanner.STRING:
+		case scanner.IDENT:
+			tc.Name = p.foldIdent(lit)
+		case scanner.STRING:
 			tc.Name = lit
 		default:
 			return nil, newParseError(scanner.Tokstr(tok, lit), []string{"IDENT", "STRING"}, pos)
@@ -458,17 +588,58 @@ func (p *Parser) parseCreateIndexStatement(unique bool) (*statement.CreateIndexS
 		return nil, err
 	}
 
-	paths, order, err := p.parsePathList()
-	if err != nil {
+	// Parse required ( token.
+	if err := p.parseTokens(scanner.LPAREN); err != nil {
 		return nil, err
 	}
-	if len(paths) == 0 {
-		tok, pos, lit := p.ScanIgnoreWhitespace()
-		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"("}, pos)
+
+	// Parse indexed columns.
+	for i := 0; ; i++ {
+		e, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := e.(type) {
+		case expr.Path:
+			stmt.Info.Paths = append(stmt.Info.Paths, document.Path(t))
+		case expr.AggregatorBuilder:
+			return nil, &ParseError{Message: "aggregate functions are not allowed in index expressions"}
+		default:
+			// expression columns have an empty path
+			stmt.Info.Paths = append(stmt.Info.Paths, nil)
+			for len(stmt.Info.Exprs) < i {
+				stmt.Info.Exprs = append(stmt.Info.Exprs, nil)
+			}
+			stmt.Info.Exprs = append(stmt.Info.Exprs, expr.Constraint(e))
+		}
+
+		// Parse optional ASC/DESC token.
+		desc, err := p.parseOptional(scanner.DESC)
+		if err != nil {
+			return nil, err
+		}
+		if desc {
+			stmt.Info.KeySortOrder = stmt.Info.KeySortOrder.SetDesc(i)
+		} else if _, err := p.parseOptional(scanner.ASC); err != nil {
+			return nil, err
+		}
+
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.COMMA {
+			p.Unscan()
+			break
+		}
+	}
+
+	// Parse required ) token.
+	if err := p.parseTokens(scanner.RPAREN); err != nil {
+		return nil, err
 	}
 
-	stmt.Info.Paths = paths
-	stmt.Info.KeySortOrder = order
+	// every column has an entry if one of them is an expression
+	for stmt.Info.Exprs != nil && len(stmt.Info.Exprs) < len(stmt.Info.Paths) {
+		stmt.Info.Exprs = append(stmt.Info.Exprs, nil)
+	}
 
 	return &stmt, nil
 }
@@ -745,3 +916,250 @@ func (p *Parser) parseCheckConstraint() (expr.Expr, []document.Path, error) {
 
 	return e, paths, nil
 }
+
+// validateDefaultExpr ensures a DEFAULT expression can be evaluated
+// without a document: it must not reference any path, nor use functions
+// that depend on the document being inserted, such as pk() or aggregators.
+func validateDefaultExpr(e expr.Expr) error {
+	var err error
+
+	expr.Walk(e, func(e expr.Expr) bool {
+		switch t := e.(type) {
+		case expr.Path:
+			err = &ParseError{Message: fmt.Sprintf("DEFAULT expression cannot reference path %q", t)}
+		case *functions.PK:
+			err = &ParseError{Message: "DEFAULT expression cannot use pk()"}
+		case expr.AggregatorBuilder:
+			err = &ParseError{Message: fmt.Sprintf("DEFAULT expression cannot use aggregate function %s", t)}
+		}
+		return err == nil
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// parseCreateViewStatement parses a create view string and returns a Statement AST object.
+// This function assumes the CREATE VIEW tokens have already been consumed.
+func (p *Parser) parseCreateViewStatement() (*statement.CreateViewStmt, error) {
+	var stmt statement.CreateViewStmt
+	var err error
+
+	// Parse IF NOT EXISTS
+	stmt.IfNotExists, err = p.parseOptional(scanner.IF, scanner.NOT, scanner.EXISTS)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse view name
+	stmt.Info.ViewName, err = p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.parseTokens(scanner.AS); err != nil {
+		return nil, err
+	}
+
+	params := p.orderedParams + p.namedParams
+
+	var sel *statement.SelectStmt
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	p.Unscan()
+	switch tok {
+	case scanner.SELECT:
+		sel, err = p.parseSelectStatement()
+	case scanner.WITH:
+		sel, err = p.parseWithStatement()
+	default:
+		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"SELECT", "WITH"}, pos)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if p.orderedParams+p.namedParams != params {
+		return nil, &ParseError{Message: "views cannot use parameters"}
+	}
+
+	stmt.Info.Query = &viewQuery{sql: sel.String(), opts: p.opts}
+
+	return &stmt, nil
+}
+
+// viewQuery is the SELECT statement of a view.
+// It implements the statement.ViewQuery interface
+// by parsing its SQL representation.
+type viewQuery struct {
+	sql  string
+	opts *Options
+}
+
+func (q *viewQuery) String() string {
+	return q.sql
+}
+
+// SelectStmt implements the statement.ViewQuery interface.
+func (q *viewQuery) SelectStmt() (*statement.SelectStmt, error) {
+	stmt, err := NewParserWithOptions(strings.NewReader(q.sql), q.opts).ParseStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	sel, ok := stmt.(*statement.SelectStmt)
+	if !ok {
+		return nil, errors.Errorf("invalid view query %q", q.sql)
+	}
+
+	return sel, nil
+}
+
+// parseCreateTriggerStatement parses a create trigger string and returns a Statement AST object.
+// This function assumes the CREATE TRIGGER tokens have already been consumed.
+func (p *Parser) parseCreateTriggerStatement() (*statement.CreateTriggerStmt, error) {
+	var stmt statement.CreateTriggerStmt
+	var err error
+
+	// Parse IF NOT EXISTS
+	stmt.IfNotExists, err = p.parseOptional(scanner.IF, scanner.NOT, scanner.EXISTS)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse trigger name
+	stmt.Info.TriggerName, err = p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse timing
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	switch {
+	case isWord(tok, lit, "BEFORE"):
+		stmt.Info.Timing = database.TriggerBefore
+	case isWord(tok, lit, "AFTER"):
+		stmt.Info.Timing = database.TriggerAfter
+	default:
+		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"BEFORE", "AFTER"}, pos)
+	}
+
+	// Parse event
+	tok, pos, lit = p.ScanIgnoreWhitespace()
+	switch tok {
+	case scanner.INSERT:
+		stmt.Info.Event = database.TriggerInsert
+	case scanner.UPDATE:
+		stmt.Info.Event = database.TriggerUpdate
+	case scanner.DELETE:
+		stmt.Info.Event = database.TriggerDelete
+	default:
+		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"INSERT", "UPDATE", "DELETE"}, pos)
+	}
+
+	// Parse table name
+	if err := p.parseTokens(scanner.ON); err != nil {
+		return nil, err
+	}
+
+	stmt.Info.TableName, err = p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	params := p.orderedParams + p.namedParams
+
+	action, err := p.parseTriggerAction()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.orderedParams+p.namedParams != params {
+		return nil, &ParseError{Message: "triggers cannot use parameters"}
+	}
+
+	stmt.Info.Action = &triggerAction{sql: action.String(), opts: p.opts}
+
+	return &stmt, nil
+}
+
+// parseTriggerAction parses the INSERT, UPDATE or DELETE statement
+// or the EXECUTE FUNCTION clause run by a trigger.
+func (p *Parser) parseTriggerAction() (fmt.Stringer, error) {
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	p.Unscan()
+	switch tok {
+	case scanner.INSERT:
+		return p.parseInsertStatement()
+	case scanner.UPDATE:
+		return p.parseUpdateStatement()
+	case scanner.DELETE:
+		return p.parseDeleteStatement()
+	}
+
+	if isWord(tok, lit, "EXECUTE") {
+		return p.parseExecuteFunction()
+	}
+
+	return nil, newParseError(scanner.Tokstr(tok, lit), []string{"INSERT", "UPDATE", "DELETE", "EXECUTE"}, pos)
+}
+
+// parseExecuteFunction parses an EXECUTE FUNCTION clause.
+// Only functions registered with RegisterFunc can be executed.
+// The function is looked up when the trigger fires: it doesn't
+// need to be registered when the trigger is created or loaded from the catalog.
+func (p *Parser) parseExecuteFunction() (*statement.ExecuteFunctionStmt, error) {
+	if _, err := p.parseOptionalWords("EXECUTE", "FUNCTION"); err != nil {
+		return nil, err
+	}
+
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	if tok != scanner.IDENT {
+		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"function name"}, pos)
+	}
+
+	if _, ok := p.packagesTable[""][strings.ToLower(lit)]; ok {
+		return nil, &ParseError{Message: fmt.Sprintf("cannot execute builtin function %s", lit)}
+	}
+	if tok, _, _ := p.Scan(); tok == scanner.DOT {
+		return nil, &ParseError{Message: fmt.Sprintf("cannot execute builtin function %s", lit)}
+	}
+	p.Unscan()
+
+	args, err := p.parseExprList(scanner.LPAREN, scanner.RPAREN)
+	if err != nil {
+		return nil, err
+	}
+
+	return &statement.ExecuteFunctionStmt{Func: &functions.UserFunction{Name: strings.ToLower(lit), Args: args}}, nil
+}
+
+// triggerAction is the action of a trigger.
+// It implements the statement.TriggerAction interface
+// by parsing its SQL representation.
+type triggerAction struct {
+	sql  string
+	opts *Options
+}
+
+func (a *triggerAction) String() string {
+	return a.sql
+}
+
+// Statement implements the statement.TriggerAction interface.
+func (a *triggerAction) Statement() (statement.Preparer, error) {
+	p := NewParserWithOptions(strings.NewReader(a.sql), a.opts)
+
+	action, err := p.parseTriggerAction()
+	if err != nil {
+		return nil, p.toParseError(err)
+	}
+
+	pr, ok := action.(statement.Preparer)
+	if !ok {
+		return nil, errors.Errorf("invalid trigger action %q", a.sql)
+	}
+
+	return pr, nil
+}
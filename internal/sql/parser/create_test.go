@@ -1,15 +1,20 @@
 package parser_test
 
 import (
+	"fmt"
 	"math"
 	"testing"
 
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/expr/functions"
 	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/sql/parser"
 	"github.com/genjidb/genji/internal/testutil"
 	"github.com/genjidb/genji/internal/testutil/assert"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -47,7 +52,65 @@ func TestParserCreateIndex(t *testing.T) {
 				},
 			},
 			false},
+		{"DESC", "CREATE INDEX idx ON test (foo DESC)",
+			&statement.CreateIndexStmt{
+				Info: database.IndexInfo{
+					IndexName:    "idx",
+					Owner:        database.Owner{TableName: "test"},
+					Paths:        []document.Path{document.Path(testutil.ParseDocumentPath(t, "foo"))},
+					KeySortOrder: tree.SortOrder(0).SetDesc(0),
+				},
+			},
+			false},
+		{"ASC and DESC", "CREATE INDEX idx ON test (foo ASC, bar DESC)",
+			&statement.CreateIndexStmt{
+				Info: database.IndexInfo{
+					IndexName: "idx",
+					Owner:     database.Owner{TableName: "test"},
+					Paths: []document.Path{
+						document.Path(testutil.ParseDocumentPath(t, "foo")),
+						document.Path(testutil.ParseDocumentPath(t, "bar")),
+					},
+					KeySortOrder: tree.SortOrder(0).SetDesc(1),
+				},
+			},
+			false},
+		{"Expression", "CREATE INDEX idx ON test (LOWER(foo), bar DESC)",
+			&statement.CreateIndexStmt{
+				Info: database.IndexInfo{
+					IndexName: "idx",
+					Owner:     database.Owner{TableName: "test"},
+					Paths: []document.Path{
+						nil,
+						document.Path(testutil.ParseDocumentPath(t, "bar")),
+					},
+					Exprs: []database.TableExpression{
+						expr.Constraint(parser.MustParseExpr("LOWER(foo)")),
+						nil,
+					},
+					KeySortOrder: tree.SortOrder(0).SetDesc(1),
+				},
+			},
+			false},
+		{"Expression after path", "CREATE INDEX idx ON test (foo, a + b)",
+			&statement.CreateIndexStmt{
+				Info: database.IndexInfo{
+					IndexName: "idx",
+					Owner:     database.Owner{TableName: "test"},
+					Paths: []document.Path{
+						document.Path(testutil.ParseDocumentPath(t, "foo")),
+						nil,
+					},
+					Exprs: []database.TableExpression{
+						nil,
+						expr.Constraint(parser.MustParseExpr("a + b")),
+					},
+				},
+			},
+			false},
+		{"Aggregate function", "CREATE INDEX idx ON test (COUNT(foo))", nil, true},
 		{"No fields", "CREATE INDEX idx ON test", nil, true},
+		{"Empty list", "CREATE INDEX idx ON test ()", nil, true},
 	}
 
 	for _, test := range tests {
@@ -204,3 +267,113 @@ func TestParserCreateSequence(t *testing.T) {
 		})
 	}
 }
+
+func TestParserCreateView(t *testing.T) {
+	tests := []struct {
+		name        string
+		s           string
+		viewName    string
+		ifNotExists bool
+		query       string
+		errored     bool
+	}{
+		{"Basic", "CREATE VIEW v AS SELECT a FROM test", "v", false, "SELECT a FROM test", false},
+		{"If not exists", "CREATE VIEW IF NOT EXISTS v AS SELECT a FROM test", "v", true, "SELECT a FROM test", false},
+		{"With clauses", "CREATE VIEW v AS SELECT a, COUNT(*) FROM test WHERE a > 1 GROUP BY a ORDER BY a LIMIT 10",
+			"v", false, "SELECT a, COUNT(*) FROM test WHERE a > 1 GROUP BY a ORDER BY a LIMIT 10", false},
+		{"Compound", "CREATE VIEW v AS SELECT a FROM foo UNION ALL SELECT a FROM bar", "v", false, "SELECT a FROM foo UNION ALL SELECT a FROM bar", false},
+		{"WITH", "CREATE VIEW v AS WITH w AS (SELECT a FROM test) SELECT a FROM w", "v", false, "WITH w AS (SELECT a FROM test) SELECT a FROM w", false},
+		{"No query", "CREATE VIEW v", "", false, "", true},
+		{"No AS", "CREATE VIEW v SELECT a FROM test", "", false, "", true},
+		{"Not a SELECT", "CREATE VIEW v AS DELETE FROM test", "", false, "", true},
+		{"Params", "CREATE VIEW v AS SELECT a FROM test WHERE a = ?", "", false, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parser.ParseQuery(test.s)
+			if test.errored {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, q.Statements, 1)
+
+			stmt, ok := q.Statements[0].(*statement.CreateViewStmt)
+			require.True(t, ok)
+			require.Equal(t, test.viewName, stmt.Info.ViewName)
+			require.Equal(t, test.ifNotExists, stmt.IfNotExists)
+			require.Equal(t, test.query, stmt.Info.Query.String())
+
+			// the query can be parsed again
+			sel, err := stmt.Info.Query.(statement.ViewQuery).SelectStmt()
+			assert.NoError(t, err)
+			require.Equal(t, test.query, sel.String())
+		})
+	}
+}
+
+func TestParserCreateTrigger(t *testing.T) {
+	err := functions.RegisterFunc("audit", func(args []types.Value) (types.Value, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		s           string
+		triggerName string
+		ifNotExists bool
+		timing      database.TriggerTiming
+		event       database.TriggerEvent
+		action      string
+		errored     bool
+	}{
+		{"Insert", "CREATE TRIGGER tr AFTER INSERT ON test INSERT INTO log VALUES (new.a)",
+			"tr", false, database.TriggerAfter, database.TriggerInsert, "INSERT INTO log VALUES (new.a)", false},
+		{"If not exists", "CREATE TRIGGER IF NOT EXISTS tr BEFORE INSERT ON test INSERT INTO log VALUES (new.a)",
+			"tr", true, database.TriggerBefore, database.TriggerInsert, "INSERT INTO log VALUES (new.a)", false},
+		{"Update", "CREATE TRIGGER tr BEFORE UPDATE ON test UPDATE log SET b = new.b WHERE a = old.a",
+			"tr", false, database.TriggerBefore, database.TriggerUpdate, "UPDATE log SET b = new.b WHERE a = old.a", false},
+		{"Delete", "CREATE TRIGGER tr AFTER DELETE ON test DELETE FROM log WHERE a = old.a",
+			"tr", false, database.TriggerAfter, database.TriggerDelete, "DELETE FROM log WHERE a = old.a", false},
+		{"Execute function", "CREATE TRIGGER tr AFTER UPDATE ON test EXECUTE FUNCTION audit(old, new)",
+			"tr", false, database.TriggerAfter, database.TriggerUpdate, "EXECUTE FUNCTION audit(old, new)", false},
+		{"No timing", "CREATE TRIGGER tr INSERT ON test DELETE FROM log", "", false, 0, 0, "", true},
+		{"No event", "CREATE TRIGGER tr AFTER ON test DELETE FROM log", "", false, 0, 0, "", true},
+		{"No table", "CREATE TRIGGER tr AFTER INSERT DELETE FROM log", "", false, 0, 0, "", true},
+		{"No action", "CREATE TRIGGER tr AFTER INSERT ON test", "", false, 0, 0, "", true},
+		{"SELECT action", "CREATE TRIGGER tr AFTER INSERT ON test SELECT * FROM log", "", false, 0, 0, "", true},
+		{"Builtin function", "CREATE TRIGGER tr AFTER INSERT ON test EXECUTE FUNCTION typeof(new)", "", false, 0, 0, "", true},
+		{"Package function", "CREATE TRIGGER tr AFTER INSERT ON test EXECUTE FUNCTION math.floor(new.a)", "", false, 0, 0, "", true},
+		{"Unregistered function", "CREATE TRIGGER tr AFTER INSERT ON test EXECUTE FUNCTION Unknown(new)",
+			"tr", false, database.TriggerAfter, database.TriggerInsert, "EXECUTE FUNCTION unknown(new)", false},
+		{"Params", "CREATE TRIGGER tr AFTER INSERT ON test INSERT INTO log VALUES (?)", "", false, 0, 0, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parser.ParseQuery(test.s)
+			if test.errored {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, q.Statements, 1)
+
+			stmt, ok := q.Statements[0].(*statement.CreateTriggerStmt)
+			require.True(t, ok)
+			require.Equal(t, test.triggerName, stmt.Info.TriggerName)
+			require.Equal(t, "test", stmt.Info.TableName)
+			require.Equal(t, test.ifNotExists, stmt.IfNotExists)
+			require.Equal(t, test.timing, stmt.Info.Timing)
+			require.Equal(t, test.event, stmt.Info.Event)
+			require.Equal(t, test.action, stmt.Info.Action.String())
+
+			// the action can be parsed again
+			action, err := stmt.Info.Action.(statement.TriggerAction).Statement()
+			assert.NoError(t, err)
+			require.Equal(t, test.action, action.(fmt.Stringer).String())
+		})
+	}
+}
@@ -31,7 +31,7 @@ func (p *Parser) parseDeleteStatement() (statement.Statement, error) {
 		return nil, err
 	}
 
-	// Parse order by: "ORDER BY path [ASC|DESC]?"
+	// Parse order by: "ORDER BY path [ASC|DESC]?, ..."
 	stmt.OrderBy, stmt.OrderByDirection, err = p.parseOrderBy()
 	if err != nil {
 		return nil, err
@@ -49,5 +49,14 @@ func (p *Parser) parseDeleteStatement() (statement.Statement, error) {
 		return nil, err
 	}
 
+	// Parse dry run: "DRY RUN"
+	dryRun, err := p.parseOptionalWords("DRY", "RUN")
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		stmt.DryRun()
+	}
+
 	return stmt, nil
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/expr/functions"
 	"github.com/genjidb/genji/internal/query"
 	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/sql/parser"
@@ -59,6 +61,11 @@ func TestParserDelete(t *testing.T) {
 				Pipe(table.Delete("test")).
 				Pipe(stream.Discard()),
 		},
+		{"WithDryRun", "DELETE FROM test WHERE age = 10 DRY RUN",
+			stream.New(table.Scan("test")).
+				Pipe(docs.Filter(parser.MustParseExpr("age = 10"))).
+				Pipe(docs.Project(&expr.NamedExpr{ExprName: "pk()", Expr: new(functions.PK)}, expr.Wildcard{})),
+		},
 	}
 
 	for _, test := range tests {
@@ -77,7 +84,7 @@ func TestParserDelete(t *testing.T) {
 			assert.NoError(t, err)
 
 			require.Len(t, q.Statements, 1)
-			require.EqualValues(t, &statement.PreparedStreamStmt{Stream: test.expected}, q.Statements[0].(*statement.PreparedStreamStmt))
+			require.EqualValues(t, test.expected, q.Statements[0].(*statement.PreparedStreamStmt).Stream)
 		})
 	}
 }
@@ -22,6 +22,11 @@ func TestParserDrop(t *testing.T) {
 		{"Drop index if exists", "DROP INDEX IF EXISTS test", statement.DropIndexStmt{IndexName: "test", IfExists: true}, false},
 		{"Drop index", "DROP SEQUENCE test", statement.DropSequenceStmt{SequenceName: "test"}, false},
 		{"Drop index if exists", "DROP SEQUENCE IF EXISTS test", statement.DropSequenceStmt{SequenceName: "test", IfExists: true}, false},
+		{"Drop view", "DROP VIEW test", statement.DropViewStmt{ViewName: "test"}, false},
+		{"Drop view if exists", "DROP VIEW IF EXISTS test", statement.DropViewStmt{ViewName: "test", IfExists: true}, false},
+		{"Drop view without name", "DROP VIEW", nil, true},
+		{"Drop trigger", "DROP TRIGGER test", statement.DropTriggerStmt{TriggerName: "test"}, false},
+		{"Drop trigger if exists", "DROP TRIGGER IF EXISTS test", statement.DropTriggerStmt{TriggerName: "test", IfExists: true}, false},
 	}
 
 	for _, test := range tests {
@@ -13,10 +13,22 @@ func (p *Parser) parseExplainStatement() (statement.Statement, error) {
 		return nil, err
 	}
 
+	// Parse optional ANALYZE.
+	analyze, err := p.parseOptional(scanner.ANALYZE)
+	if err != nil {
+		return nil, err
+	}
+
 	// ensure we don't have multiple EXPLAIN keywords
 	tok, pos, lit := p.ScanIgnoreWhitespace()
-	if tok != scanner.SELECT && tok != scanner.UPDATE && tok != scanner.DELETE && tok != scanner.INSERT {
-		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"INSERT", "SELECT", "UPDATE", "DELETE"}, pos)
+	if analyze {
+		// only read-only statements can be analyzed
+		// since the statement is actually executed.
+		if tok != scanner.SELECT && tok != scanner.WITH {
+			return nil, newParseError(scanner.Tokstr(tok, lit), []string{"SELECT", "WITH"}, pos)
+		}
+	} else if tok != scanner.SELECT && tok != scanner.WITH && tok != scanner.UPDATE && tok != scanner.DELETE && tok != scanner.INSERT {
+		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"INSERT", "SELECT", "WITH", "UPDATE", "DELETE"}, pos)
 	}
 	p.Unscan()
 
@@ -25,5 +37,5 @@ func (p *Parser) parseExplainStatement() (statement.Statement, error) {
 		return nil, err
 	}
 
-	return &statement.ExplainStmt{Statement: innerStmt.(statement.Preparer)}, nil
+	return &statement.ExplainStmt{Statement: innerStmt.(statement.Preparer), Analyze: analyze}, nil
 }
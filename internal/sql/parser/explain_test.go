@@ -24,6 +24,9 @@ func TestParserExplain(t *testing.T) {
 	}{
 		{"Explain select", "EXPLAIN SELECT * FROM test", &statement.ExplainStmt{Statement: slct}, false},
 		{"Multiple Explains", "EXPLAIN EXPLAIN CREATE TABLE test", nil, true},
+		{"Explain analyze select", "EXPLAIN ANALYZE SELECT * FROM test", &statement.ExplainStmt{Statement: slct, Analyze: true}, false},
+		{"Explain analyze delete", "EXPLAIN ANALYZE DELETE FROM test", nil, true},
+		{"Explain analyze explain", "EXPLAIN ANALYZE EXPLAIN SELECT * FROM test", nil, true},
 	}
 
 	for _, test := range tests {
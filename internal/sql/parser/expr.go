@@ -11,6 +11,7 @@ import (
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/expr/functions"
+	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/sql/scanner"
 	"github.com/genjidb/genji/types"
 )
@@ -61,7 +62,12 @@ func (p *Parser) parseExprWithMinPrecedence(precedence int, allowed ...scanner.T
 
 		var rhs expr.Expr
 
-		if rhs, err = p.parseUnaryExpr(allowed...); err != nil {
+		if tok == scanner.IN || tok == scanner.NIN {
+			rhs, err = p.parseInOperand(allowed...)
+		} else {
+			rhs, err = p.parseUnaryExpr(allowed...)
+		}
+		if err != nil {
 			return nil, err
 		}
 
@@ -106,11 +112,21 @@ func (p *Parser) parseOperator(minPrecedence int, allowed ...scanner.Token) (fun
 			case tok == scanner.IN && tok.Precedence() >= minPrecedence:
 				return expr.NotIn, scanner.NIN, nil
 			case tok == scanner.LIKE && tok.Precedence() >= minPrecedence:
+				if p.opts.CaseSensitiveLike {
+					return expr.CaseSensitiveNotLike, scanner.NLIKE, nil
+				}
 				return expr.NotLike, scanner.NLIKE, nil
+			case tok == scanner.BETWEEN && tok.Precedence() >= minPrecedence:
+				a, err := p.parseBetweenLowerBound()
+				if err != nil {
+					return nil, scanner.NBETWEEN, err
+				}
+
+				return expr.NotBetween(a), scanner.NBETWEEN, nil
 			}
 		}
 
-		return nil, 0, newParseError(scanner.Tokstr(tok, lit), []string{"IN, LIKE"}, pos)
+		return nil, 0, newParseError(scanner.Tokstr(tok, lit), []string{"IN, LIKE, BETWEEN"}, pos)
 	}
 
 	if op.Precedence() < minPrecedence {
@@ -160,15 +176,14 @@ func (p *Parser) parseOperator(minPrecedence int, allowed ...scanner.Token) (fun
 		p.Unscan()
 		return expr.Is, op, nil
 	case scanner.LIKE:
+		if p.opts.CaseSensitiveLike {
+			return expr.CaseSensitiveLike, op, nil
+		}
 		return expr.Like, op, nil
 	case scanner.CONCAT:
 		return expr.Concat, op, nil
 	case scanner.BETWEEN:
-		a, err := p.parseExprWithMinPrecedence(op.Precedence())
-		if err != nil {
-			return nil, op, err
-		}
-		err = p.parseTokens(scanner.AND)
+		a, err := p.parseBetweenLowerBound()
 		if err != nil {
 			return nil, op, err
 		}
@@ -181,6 +196,122 @@ func (p *Parser) parseOperator(minPrecedence int, allowed ...scanner.Token) (fun
 	return nil, 0, nil
 }
 
+// parseBetweenLowerBound parses the lower bound of the BETWEEN and NOT BETWEEN operators,
+// and the AND token that follows it.
+// The upper bound is parsed as the right hand side of the operator.
+func (p *Parser) parseBetweenLowerBound() (expr.Expr, error) {
+	a, err := p.parseExprWithMinPrecedence(scanner.BETWEEN.Precedence())
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.parseTokens(scanner.AND)
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// subquery is the SELECT statement of a subquery.
+// It implements the statement.ViewQuery interface
+// by parsing its SQL representation.
+type subquery struct {
+	sql string
+	// number of positional parameters preceding the subquery
+	params int
+	opts   *Options
+}
+
+func (q *subquery) String() string {
+	return q.sql
+}
+
+// SelectStmt implements the statement.ViewQuery interface.
+func (q *subquery) SelectStmt() (*statement.SelectStmt, error) {
+	// identifiers were already folded when the subquery was parsed
+	opts := *q.opts
+	opts.CaseInsensitiveIdentifiers = false
+
+	p := NewParserWithOptions(strings.NewReader(q.sql), &opts)
+	p.orderedParams = q.params
+
+	stmt, err := p.parseSelectStatement()
+	if err != nil {
+		return nil, err
+	}
+
+	return stmt, nil
+}
+
+// parseInOperand parses the right operand of the IN and NOT IN operators.
+// A SELECT statement between parentheses is parsed as a subquery,
+// and a single expression between parentheses as a list of one expression.
+func (p *Parser) parseInOperand(allowed ...scanner.Token) (expr.Expr, error) {
+	if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.LPAREN || !tokenIsAllowed(tok, allowed...) {
+		p.Unscan()
+		return p.parseUnaryExpr(allowed...)
+	}
+
+	if tok, _, _ := p.ScanIgnoreWhitespace(); tok == scanner.SELECT {
+		p.Unscan()
+
+		params := p.orderedParams
+		stmt, err := p.parseSelectStatement()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.parseTokens(scanner.RPAREN); err != nil {
+			return nil, err
+		}
+
+		return &statement.SubqueryExpr{
+			Stmt:  stmt,
+			Query: &subquery{sql: stmt.String(), params: params, opts: p.opts},
+		}, nil
+	}
+	p.Unscan()
+
+	e, err := p.parseParenthesizedExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if pe, ok := e.(expr.Parentheses); ok {
+		return expr.LiteralExprList{pe.E}, nil
+	}
+
+	return e, nil
+}
+
+// parseParenthesizedExpr parses an expression or a list of expressions
+// between parentheses. This function assumes the left parenthesis
+// has already been consumed.
+func (p *Parser) parseParenthesizedExpr() (expr.Expr, error) {
+	e, err := p.ParseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	switch tok {
+	case scanner.RPAREN:
+		return expr.Parentheses{E: e}, nil
+	case scanner.COMMA:
+		exprList, err := p.parseExprListUntil(scanner.RPAREN)
+		if err != nil {
+			return nil, err
+		}
+
+		// prepend first parsed expression
+		exprList = append([]expr.Expr{e}, exprList...)
+		return exprList, nil
+	}
+
+	return nil, newParseError(scanner.Tokstr(tok, lit), []string{")", ","}, pos)
+}
+
 // parseUnaryExpr parses an non-binary expression.
 func (p *Parser) parseUnaryExpr(allowed ...scanner.Token) (expr.Expr, error) {
 	tok, pos, lit := p.ScanIgnoreWhitespace()
@@ -194,40 +325,43 @@ func (p *Parser) parseUnaryExpr(allowed ...scanner.Token) (expr.Expr, error) {
 	case scanner.CAST:
 		p.Unscan()
 		return p.parseCastExpression()
+	case scanner.CASE:
+		p.Unscan()
+		return p.parseCaseExpression()
 	case scanner.IDENT:
-		tok1, _, _ := p.ScanIgnoreWhitespace()
-		// if the next token is a left parenthesis, this is a global function
-		if tok1 == scanner.LPAREN {
-			p.Unscan()
-			if tk, _, _ := p.s.Curr(); tk == scanner.WS {
-				p.Unscan()
-			}
-			p.Unscan()
-			return p.parseFunction()
-		} else if tok1 == scanner.DOT {
+		// look ahead to determine if this is a function call or a path.
+		// only raw tokens are scanned so that they can all be unscanned afterwards,
+		// regardless of the comments that may follow the identifier.
+		var n int
+		scan := func() scanner.Token {
+			n++
+			tok, _, _ := p.Scan()
+			return tok
+		}
+
+		var isFunc bool
+		tok1 := scan()
+		if tok1 == scanner.WS {
+			tok1 = scan()
+		}
+		switch {
+		case tok1 == scanner.LPAREN:
+			// if the next token is a left parenthesis, this is a global function
+			isFunc = true
+		case tok1 == scanner.DOT && n == 1:
 			// it may be a package function instead.
-			if tok2, _, _ := p.Scan(); tok2 == scanner.IDENT {
-				if tok3, _, _ := p.Scan(); tok3 == scanner.LPAREN {
-					p.Unscan()
-					p.Unscan()
-					p.Unscan()
-					p.Unscan()
-					return p.parseFunction()
-				} else {
-					p.Unscan()
-					p.Unscan()
-				}
-			} else {
-				p.Unscan()
-			}
+			isFunc = scan() == scanner.IDENT && scan() == scanner.LPAREN
 		}
-		p.Unscan()
-		if tk, _, _ := p.s.Curr(); tk == scanner.WS {
+
+		for ; n > 0; n-- {
 			p.Unscan()
 		}
-
 		p.Unscan()
 
+		if isFunc {
+			return p.parseFunction()
+		}
+
 		field, err := p.parsePath()
 		if err != nil {
 			return nil, err
@@ -262,6 +396,14 @@ func (p *Parser) parseUnaryExpr(allowed ...scanner.Token) (expr.Expr, error) {
 			return expr.LiteralValue{Value: types.NewBlobValue(blob)}, nil
 		}
 		return expr.LiteralValue{Value: types.NewTextValue(lit)}, nil
+	case scanner.BLOB:
+		blob, err := hex.DecodeString(lit)
+		if err != nil {
+			return nil, err
+		}
+		return expr.LiteralValue{Value: types.NewBlobValue(blob)}, nil
+	case scanner.BADBLOB:
+		return nil, errors.WithStack(&ParseError{Message: "invalid hexadecimal blob literal", Pos: pos})
 	case scanner.NUMBER:
 		v, err := strconv.ParseFloat(lit, 64)
 		if err != nil {
@@ -300,33 +442,31 @@ func (p *Parser) parseUnaryExpr(allowed ...scanner.Token) (expr.Expr, error) {
 		p.Unscan()
 		return p.parseExprList(scanner.LSBRACKET, scanner.RSBRACKET)
 	case scanner.LPAREN:
+		return p.parseParenthesizedExpr()
+	case scanner.NOT:
 		e, err := p.ParseExpr()
 		if err != nil {
 			return nil, err
 		}
-
-		tok, pos, lit := p.ScanIgnoreWhitespace()
-		switch tok {
-		case scanner.RPAREN:
-			return expr.Parentheses{E: e}, nil
-		case scanner.COMMA:
-			exprList, err := p.parseExprListUntil(scanner.RPAREN)
-			if err != nil {
-				return nil, err
-			}
-
-			// prepend first parsed expression
-			exprList = append([]expr.Expr{e}, exprList...)
-			return exprList, nil
+		return expr.Not(e), nil
+	case scanner.EXISTS:
+		// EXISTS is a keyword, exists(path) must be parsed explicitly.
+		if err := p.parseTokens(scanner.LPAREN); err != nil {
+			return nil, err
 		}
-
-		return nil, newParseError(scanner.Tokstr(tok, lit), []string{")", ","}, pos)
-	case scanner.NOT:
 		e, err := p.ParseExpr()
 		if err != nil {
 			return nil, err
 		}
-		return expr.Not(e), nil
+		if err := p.parseTokens(scanner.RPAREN); err != nil {
+			return nil, err
+		}
+
+		def, err := p.packagesTable.GetFunc("", "exists")
+		if err != nil {
+			return nil, err
+		}
+		return def.Function(e)
 	case scanner.NEXT:
 		err := p.parseTokens(scanner.VALUE, scanner.FOR)
 		if err != nil {
@@ -374,7 +514,19 @@ func (p *Parser) parseIdent() (string, error) {
 		return "", newParseError(scanner.Tokstr(tok, lit), []string{"identifier"}, pos)
 	}
 
-	return lit, nil
+	return p.foldIdent(lit), nil
+}
+
+// foldIdent returns the identifier in lower case if identifiers
+// are case-insensitive, or unchanged otherwise.
+// It must be called right after scanning the identifier, which is
+// left unchanged if it was quoted with backticks.
+func (p *Parser) foldIdent(ident string) string {
+	if p.opts.CaseInsensitiveIdentifiers && !p.s.Quoted() {
+		return strings.ToLower(ident)
+	}
+
+	return ident
 }
 
 // parseIdentList parses a comma delimited list of identifiers.
@@ -425,53 +577,78 @@ func (p *Parser) parseParam() (expr.Expr, error) {
 	}
 }
 
+// parseType parses a type. Lengths are only enforced on the fields of a table,
+// types with a length are rejected.
 func (p *Parser) parseType() (types.ValueType, error) {
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	p.Unscan()
+
+	tp, n, err := p.parseTypeWithLength()
+	if err != nil {
+		return 0, err
+	}
+	if n > 0 {
+		return 0, newParseError(scanner.Tokstr(tok, lit)+"("+strconv.Itoa(n)+")", []string{"type without length"}, pos)
+	}
+
+	return tp, nil
+}
+
+// parseTypeWithLength parses a type and the optional length
+// of VARCHAR and CHARACTER types. If omitted, the returned length is 0.
+func (p *Parser) parseTypeWithLength() (types.ValueType, int, error) {
 	tok, pos, lit := p.ScanIgnoreWhitespace()
 	switch tok {
 	case scanner.TYPEANY:
-		return types.AnyValue, nil
+		return types.AnyValue, 0, nil
 	case scanner.TYPEARRAY:
-		return types.ArrayValue, nil
+		return types.ArrayValue, 0, nil
 	case scanner.TYPEBLOB, scanner.TYPEBYTES:
-		return types.BlobValue, nil
+		return types.BlobValue, 0, nil
 	case scanner.TYPEBOOL, scanner.TYPEBOOLEAN:
-		return types.BooleanValue, nil
+		return types.BooleanValue, 0, nil
 	case scanner.TYPEDOCUMENT:
-		return types.DocumentValue, nil
+		return types.DocumentValue, 0, nil
 	case scanner.TYPEREAL:
-		return types.DoubleValue, nil
+		return types.DoubleValue, 0, nil
 	case scanner.TYPEDOUBLE:
 		tok, _, _ := p.ScanIgnoreWhitespace()
 		if tok == scanner.PRECISION {
-			return types.DoubleValue, nil
+			return types.DoubleValue, 0, nil
 		}
 		p.Unscan()
-		return types.DoubleValue, nil
+		return types.DoubleValue, 0, nil
 	case scanner.TYPEINTEGER, scanner.TYPEINT, scanner.TYPEINT2, scanner.TYPEINT8, scanner.TYPETINYINT,
 		scanner.TYPEBIGINT, scanner.TYPEMEDIUMINT, scanner.TYPESMALLINT:
-		return types.IntegerValue, nil
+		return types.IntegerValue, 0, nil
 	case scanner.TYPETEXT:
-		return types.TextValue, nil
+		return types.TextValue, 0, nil
 	case scanner.TYPETIMESTAMP:
-		return types.TimestampValue, nil
+		return types.TimestampValue, 0, nil
 	case scanner.TYPEVARCHAR, scanner.TYPECHARACTER:
-		if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != scanner.LPAREN {
-			return 0, newParseError(scanner.Tokstr(tok, lit), []string{"("}, pos)
+		// the length is optional
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.LPAREN {
+			p.Unscan()
+			return types.TextValue, 0, nil
 		}
 
-		// The value between parentheses is not used.
-		if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != scanner.INTEGER {
-			return 0, newParseError(scanner.Tokstr(tok, lit), []string{"integer"}, pos)
+		tok, pos, lit := p.ScanIgnoreWhitespace()
+		if tok != scanner.INTEGER {
+			return 0, 0, newParseError(scanner.Tokstr(tok, lit), []string{"integer"}, pos)
+		}
+		n, err := strconv.Atoi(lit)
+		if err != nil || n <= 0 {
+			return 0, 0, newParseError(scanner.Tokstr(tok, lit), []string{"positive integer"}, pos)
 		}
 
 		if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != scanner.RPAREN {
-			return 0, newParseError(scanner.Tokstr(tok, lit), []string{")"}, pos)
+			return 0, 0, newParseError(scanner.Tokstr(tok, lit), []string{")"}, pos)
 		}
 
-		return types.TextValue, nil
+		return types.TextValue, n, nil
 	}
 
-	return 0, newParseError(scanner.Tokstr(tok, lit), []string{"type"}, pos)
+	return 0, 0, newParseError(scanner.Tokstr(tok, lit), []string{"type"}, pos)
 }
 
 // ParseDocument parses a document
@@ -514,9 +691,12 @@ func (p *Parser) parseKV() (expr.KVPair, error) {
 	var k string
 
 	tok, pos, lit := p.ScanIgnoreWhitespace()
-	if tok == scanner.IDENT || tok == scanner.STRING {
+	switch tok {
+	case scanner.IDENT:
+		k = p.foldIdent(lit)
+	case scanner.STRING:
 		k = lit
-	} else {
+	default:
 		return expr.KVPair{}, newParseError(scanner.Tokstr(tok, lit), []string{"ident", "string"}, pos)
 	}
 
@@ -562,7 +742,7 @@ LOOP:
 				return nil, newParseError(lit, []string{"identifier"}, pos)
 			}
 			path = append(path, document.PathFragment{
-				FieldName: lit,
+				FieldName: p.foldIdent(lit),
 			})
 		case scanner.LSBRACKET:
 			// the next token can be either an integer or a quoted string
@@ -670,7 +850,7 @@ func (p *Parser) parseFunction() (expr.Expr, error) {
 	}
 
 	// Special case: If the function is COUNT, support the special case COUNT(*)
-	if tok, pos, lit := p.ScanIgnoreWhitespace(); tok == scanner.MUL {
+	if tok, pos, lit := p.ScanIgnoreWhitespace(); tok == scanner.MUL && pkgName == "" && strings.EqualFold(funcName, "count") {
 		if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.RPAREN {
 			return nil, newParseError(scanner.Tokstr(tok, lit), []string{")"}, pos)
 		}
@@ -750,6 +930,78 @@ func (p *Parser) parseCastExpression() (expr.Expr, error) {
 	return expr.Cast{Expr: e, CastAs: tp}, nil
 }
 
+// parseCaseExpression parses a string of the form
+// CASE [expr] WHEN expr THEN expr [WHEN expr THEN expr]* [ELSE expr] END.
+func (p *Parser) parseCaseExpression() (expr.Expr, error) {
+	// Parse required CASE token.
+	if err := p.parseTokens(scanner.CASE); err != nil {
+		return nil, err
+	}
+
+	var c expr.CaseExpr
+
+	// Parse optional operand.
+	if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.WHEN {
+		p.Unscan()
+
+		e, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+		c.Operand = e
+	} else {
+		p.Unscan()
+	}
+
+	for {
+		tok, pos, lit := p.ScanIgnoreWhitespace()
+		if tok != scanner.WHEN {
+			if len(c.Whens) == 0 {
+				return nil, newParseError(scanner.Tokstr(tok, lit), []string{"WHEN"}, pos)
+			}
+
+			p.Unscan()
+			break
+		}
+
+		when, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		// Parse required THEN token.
+		if err := p.parseTokens(scanner.THEN); err != nil {
+			return nil, err
+		}
+
+		then, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		c.Whens = append(c.Whens, expr.CaseWhen{When: when, Then: then})
+	}
+
+	// Parse optional ELSE clause.
+	if tok, _, _ := p.ScanIgnoreWhitespace(); tok == scanner.ELSE {
+		e, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		c.Else = e
+	} else {
+		p.Unscan()
+	}
+
+	// Parse required END token.
+	if err := p.parseTokens(scanner.END); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
 // tokenIsAllowed is a helper function that determines if a token is allowed.
 func tokenIsAllowed(tok scanner.Token, allowed ...scanner.Token) bool {
 	if allowed == nil {
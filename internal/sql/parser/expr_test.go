@@ -117,6 +117,13 @@ func TestParserExpr(t *testing.T) {
 		{"<", "age < 10", expr.Lt(testutil.ParsePath(t, "age"), testutil.IntegerValue(10)), false},
 		{"<=", "age <= 10", expr.Lte(testutil.ParsePath(t, "age"), testutil.IntegerValue(10)), false},
 		{"BETWEEN", "1 BETWEEN 10 AND 11", expr.Between(testutil.IntegerValue(10))(testutil.IntegerValue(1), testutil.IntegerValue(11)), false},
+		{"NOT BETWEEN", "1 NOT BETWEEN 10 AND 11", expr.NotBetween(testutil.IntegerValue(10))(testutil.IntegerValue(1), testutil.IntegerValue(11)), false},
+		{"BETWEEN with AND", "a BETWEEN 1 AND 2 + 1 AND b", expr.And(
+			expr.Between(testutil.IntegerValue(1))(testutil.ParsePath(t, "a"), expr.Add(testutil.IntegerValue(2), testutil.IntegerValue(1))),
+			testutil.ParsePath(t, "b"),
+		), false},
+		{"BETWEEN: missing AND", "1 BETWEEN 10", nil, true},
+		{"NOT BETWEEN: missing AND", "1 NOT BETWEEN 10 OR 11", nil, true},
 		{"+", "age + 10", expr.Add(testutil.ParsePath(t, "age"), testutil.IntegerValue(10)), false},
 		{"-", "age - 10", expr.Sub(testutil.ParsePath(t, "age"), testutil.IntegerValue(10)), false},
 		{"*", "age * 10", expr.Mul(testutil.ParsePath(t, "age"), testutil.IntegerValue(10)), false},
@@ -126,6 +133,11 @@ func TestParserExpr(t *testing.T) {
 		{"||", "name || 'foo'", expr.Concat(testutil.ParsePath(t, "name"), testutil.TextValue("foo")), false},
 		{"IN", "age IN ages", expr.In(testutil.ParsePath(t, "age"), testutil.ParsePath(t, "ages")), false},
 		{"NOT IN", "age NOT IN ages", expr.NotIn(testutil.ParsePath(t, "age"), testutil.ParsePath(t, "ages")), false},
+		{"IN list", "age IN (1, 2)", expr.In(testutil.ParsePath(t, "age"), expr.LiteralExprList{testutil.IntegerValue(1), testutil.IntegerValue(2)}), false},
+		{"IN single element", "age IN (1)", expr.In(testutil.ParsePath(t, "age"), expr.LiteralExprList{testutil.IntegerValue(1)}), false},
+		{"NOT IN single element", "age NOT IN (1 + 1)", expr.NotIn(testutil.ParsePath(t, "age"), expr.LiteralExprList{expr.Add(testutil.IntegerValue(1), testutil.IntegerValue(1))}), false},
+		{"IN subquery: missing right parenthesis", "age IN (SELECT a FROM foo", nil, true},
+		{"subquery outside of IN", "age = (SELECT a FROM foo)", nil, true},
 		{"IS", "age IS NULL", expr.Is(testutil.ParsePath(t, "age"), testutil.NullValue()), false},
 		{"IS NOT", "age IS NOT NULL", expr.IsNot(testutil.ParsePath(t, "age"), testutil.NullValue()), false},
 		{"LIKE", "name LIKE 'foo'", expr.Like(testutil.ParsePath(t, "name"), testutil.TextValue("foo")), false},
@@ -166,6 +178,28 @@ func TestParserExpr(t *testing.T) {
 		{"NEXT VALUE FOR", "NEXT VALUE FOR hello", expr.NextValueFor{SeqName: "hello"}, false},
 		{"NEXT VALUE FOR", "NEXT VALUE FOR `good morning`", expr.NextValueFor{SeqName: "good morning"}, false},
 		{"NEXT VALUE FOR", "NEXT VALUE FOR 10", nil, true},
+		{"CASE", "CASE WHEN a > 1 THEN 'big' ELSE 'small' END", &expr.CaseExpr{
+			Whens: []expr.CaseWhen{{When: expr.Gt(testutil.ParsePath(t, "a"), testutil.IntegerValue(1)), Then: testutil.TextValue("big")}},
+			Else:  testutil.TextValue("small"),
+		}, false},
+		{"CASE", "CASE WHEN a THEN 1 WHEN b THEN 2 END", &expr.CaseExpr{
+			Whens: []expr.CaseWhen{
+				{When: testutil.ParsePath(t, "a"), Then: testutil.IntegerValue(1)},
+				{When: testutil.ParsePath(t, "b"), Then: testutil.IntegerValue(2)},
+			},
+		}, false},
+		{"CASE", "CASE a WHEN 1 THEN 'one' WHEN 2 THEN 'two' ELSE 'many' END", &expr.CaseExpr{
+			Operand: testutil.ParsePath(t, "a"),
+			Whens: []expr.CaseWhen{
+				{When: testutil.IntegerValue(1), Then: testutil.TextValue("one")},
+				{When: testutil.IntegerValue(2), Then: testutil.TextValue("two")},
+			},
+			Else: testutil.TextValue("many"),
+		}, false},
+		{"CASE", "CASE ELSE 1 END", nil, true},
+		{"CASE", "CASE a END", nil, true},
+		{"CASE", "CASE WHEN a 1 END", nil, true},
+		{"CASE", "CASE WHEN a THEN 1", nil, true},
 
 		// functions
 		{"pk() function", "pk()", &functions.PK{}, false},
@@ -173,6 +207,13 @@ func TestParserExpr(t *testing.T) {
 		{"count(*) function", "count(*)", &functions.Count{Wildcard: true}, false},
 		{"count (*) function with spaces", "count      (*)", &functions.Count{Wildcard: true}, false},
 		{"packaged function", "math.floor(1.2)", testutil.FunctionExpr(t, "math.floor", testutil.DoubleValue(1.2)), false},
+		{"zero-arg function", "now()", &functions.Now{}, false},
+		{"multi-arg function", "coalesce(a, 1, 'b')", &functions.Coalesce{Exprs: []expr.Expr{testutil.ParsePath(t, "a"), testutil.IntegerValue(1), testutil.TextValue("b")}}, false},
+		{"nested function calls", "lower(upper(a))", testutil.FunctionExpr(t, "strings.lower", testutil.FunctionExpr(t, "strings.upper", testutil.ParsePath(t, "a"))), false},
+		{"wildcard only allowed for count", "lower(*)", nil, true},
+		{"unknown function", "foo(a)", nil, true},
+		{"unknown package", "foo.bar(a)", nil, true},
+		{"missing closing parenthesis", "lower(a", nil, true},
 	}
 
 	for _, test := range tests {
@@ -190,6 +231,88 @@ func TestParserExpr(t *testing.T) {
 	}
 }
 
+func TestParserFunctionErrors(t *testing.T) {
+	tests := []struct {
+		s   string
+		err string
+	}{
+		{"foo(a)", `no such function: "foo"`},
+		{"foo.bar(a)", `no such package: "foo"`},
+		{"math.foo(a)", `no such function: "math"."foo"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			_, err := parser.ParseExpr(test.s)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestParserType(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected types.ValueType
+		fails    bool
+	}{
+		{"ANY", types.AnyValue, false},
+		{"ARRAY", types.ArrayValue, false},
+		{"BLOB", types.BlobValue, false},
+		{"BYTES", types.BlobValue, false},
+		{"BOOL", types.BooleanValue, false},
+		{"BOOLEAN", types.BooleanValue, false},
+		{"DOCUMENT", types.DocumentValue, false},
+		{"REAL", types.DoubleValue, false},
+		{"DOUBLE", types.DoubleValue, false},
+		{"DOUBLE PRECISION", types.DoubleValue, false},
+		{"INTEGER", types.IntegerValue, false},
+		{"INT", types.IntegerValue, false},
+		{"INT2", types.IntegerValue, false},
+		{"INT8", types.IntegerValue, false},
+		{"TINYINT", types.IntegerValue, false},
+		{"SMALLINT", types.IntegerValue, false},
+		{"MEDIUMINT", types.IntegerValue, false},
+		{"BIGINT", types.IntegerValue, false},
+		{"TEXT", types.TextValue, false},
+		{"VARCHAR", types.TextValue, false},
+		{"VARCHAR(255)", 0, true},
+		{"CHARACTER", types.TextValue, false},
+		{"CHARACTER(10)", 0, true},
+		{"TIMESTAMP", types.TimestampValue, false},
+		{"varchar", types.TextValue, false},
+		{"VARCHAR(", 0, true},
+		{"VARCHAR(a)", 0, true},
+		{"VARCHAR(10", 0, true},
+		{"VARCHAR(0)", 0, true},
+		{"VARCHAR(-1)", 0, true},
+		{"STRING", 0, true},
+		{"10", 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			ex, err := parser.ParseExpr("CAST(a AS " + test.s + ")")
+			if test.fails {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			require.Equal(t, test.expected, ex.(expr.Cast).CastAs)
+		})
+	}
+
+	t.Run("Unknown type", func(t *testing.T) {
+		_, err := parser.ParseExpr("CAST(a AS STRING)")
+		require.EqualError(t, err, "found STRING, expected type at line 1, char 11")
+	})
+
+	t.Run("Length", func(t *testing.T) {
+		_, err := parser.ParseExpr("CAST(a AS VARCHAR(3))")
+		require.EqualError(t, err, "found VARCHAR(3), expected type without length at line 1, char 11")
+	})
+}
+
 func TestParsePath(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/query/statement"
@@ -53,8 +54,20 @@ func (p *Parser) parseInsertStatement() (*statement.InsertStmt, error) {
 		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"VALUES", "SELECT"}, pos)
 	}
 
+	// Parse WITH KEY clause
+	stmt.ContentKeyPaths, err = p.parseContentKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse TTL clause
+	stmt.TTLExpr, err = p.parseTTL()
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse ON CONFLICT clause
-	stmt.OnConflict, err = p.parseOnConflictClause()
+	err = p.parseOnConflictClause(stmt)
 	if err != nil {
 		return nil, err
 	}
@@ -67,6 +80,16 @@ func (p *Parser) parseInsertStatement() (*statement.InsertStmt, error) {
 	return stmt, nil
 }
 
+// parseTTL parses the "TTL expr" clause of the query, if it exists.
+// TTL is not a reserved keyword.
+func (p *Parser) parseTTL() (expr.Expr, error) {
+	if ok, err := p.parseOptionalWords("TTL"); !ok || err != nil {
+		return nil, err
+	}
+
+	return p.ParseExpr()
+}
+
 // parseFieldList parses a list of fields in the form: (path, path, ...), if exists.
 // If the list is empty, it returns an error.
 func (p *Parser) parseFieldList() ([]string, error) {
@@ -215,34 +238,85 @@ func (p *Parser) parseParamOrDocument() (expr.Expr, error) {
 	return p.ParseDocument()
 }
 
-func (p *Parser) parseOnConflictClause() (database.OnConflictAction, error) {
-	// Parse ON CONFLICT DO clause: ON CONFLICT DO action
+// parseContentKey parses the WITH KEY (path, ...) clause, if it exists.
+// The key of every inserted document is derived from the values stored at these paths.
+func (p *Parser) parseContentKey() ([]document.Path, error) {
+	if ok, err := p.parseOptional(scanner.WITH, scanner.KEY); !ok || err != nil {
+		return nil, err
+	}
+
+	tok, pos, lit := p.ScanIgnoreWhitespace()
+	p.Unscan()
+
+	paths, order, err := p.parsePathList()
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, newParseError(scanner.Tokstr(tok, lit), []string{"("}, pos)
+	}
+	if order != 0 {
+		return nil, &ParseError{Message: "sort order is not allowed in WITH KEY clause", Pos: pos}
+	}
+
+	return paths, nil
+}
+
+func (p *Parser) parseOnConflictClause(stmt *statement.InsertStmt) error {
+	// Parse ON CONFLICT DO clause: ON CONFLICT [(path, ...)] DO action
 	if ok, err := p.parseOptional(scanner.ON, scanner.CONFLICT); !ok || err != nil {
-		return 0, err
+		return err
 	}
 
+	// Parse the optional conflict target
+	target, _, err := p.parsePathList()
+	if err != nil {
+		return err
+	}
+	stmt.OnConflictTarget = target
+
 	tok, pos, lit := p.ScanIgnoreWhitespace()
 	// SQLite compatibility: ON CONFLICT [IGNORE | REPLACE]
 	switch tok {
 	case scanner.IGNORE:
-		return database.OnConflictDoNothing, nil
+		stmt.OnConflict = database.OnConflictDoNothing
+		return nil
 	case scanner.REPLACE:
-		return database.OnConflictDoReplace, nil
+		stmt.OnConflict = database.OnConflictDoReplace
+		return nil
 	}
 
-	// DO [NOTHING | REPLACE]
+	// DO [NOTHING | REPLACE | UPDATE]
 	if tok != scanner.DO {
-		return 0, newParseError(scanner.Tokstr(tok, lit), []string{scanner.DO.String()}, pos)
+		return newParseError(scanner.Tokstr(tok, lit), []string{scanner.DO.String()}, pos)
 	}
 
 	tok, pos, lit = p.ScanIgnoreWhitespace()
 	switch tok {
 	case scanner.NOTHING:
-		return database.OnConflictDoNothing, nil
+		stmt.OnConflict = database.OnConflictDoNothing
+		return nil
 	case scanner.REPLACE:
-		return database.OnConflictDoReplace, nil
+		stmt.OnConflict = database.OnConflictDoReplace
+		return nil
+	case scanner.UPDATE:
+		stmt.OnConflict = database.OnConflictDoUpdate
+
+		// Parse SET clause: SET path = expr, ...
+		if err := p.parseTokens(scanner.SET); err != nil {
+			return err
+		}
+
+		stmt.OnConflictSetPairs, err = p.parseSetClause()
+		if err != nil {
+			return err
+		}
+
+		// Parse condition: "WHERE EXPR".
+		stmt.OnConflictWhere, err = p.parseCondition()
+		return err
 	}
-	return 0, newParseError(scanner.Tokstr(tok, lit), []string{scanner.NOTHING.String(), scanner.REPLACE.String()}, pos)
+	return newParseError(scanner.Tokstr(tok, lit), []string{scanner.NOTHING.String(), scanner.REPLACE.String(), scanner.UPDATE.String()}, pos)
 }
 
 func (p *Parser) parseReturning() ([]expr.Expr, error) {
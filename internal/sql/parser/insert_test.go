@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/query"
 	"github.com/genjidb/genji/internal/query/statement"
@@ -40,6 +41,26 @@ func TestParserInsert(t *testing.T) {
 				Pipe(table.Insert("test")).
 				Pipe(stream.Discard()),
 			false},
+		{"Documents / Nested arrays", `INSERT INTO test VALUES {a: [1, {b: [true, "c"]}], d: {e: {f: []}}}`,
+			stream.New(docs.Emit(
+				&expr.KVPairs{SelfReferenced: true, Pairs: []expr.KVPair{
+					{K: "a", V: expr.LiteralExprList{
+						testutil.IntegerValue(1),
+						&expr.KVPairs{SelfReferenced: true, Pairs: []expr.KVPair{
+							{K: "b", V: expr.LiteralExprList{testutil.BoolValue(true), testutil.TextValue("c")}},
+						}},
+					}},
+					{K: "d", V: &expr.KVPairs{SelfReferenced: true, Pairs: []expr.KVPair{
+						{K: "e", V: &expr.KVPairs{SelfReferenced: true, Pairs: []expr.KVPair{
+							{K: "f", V: expr.LiteralExprList{}},
+						}}},
+					}}},
+				}},
+			)).
+				Pipe(table.Validate("test")).
+				Pipe(table.Insert("test")).
+				Pipe(stream.Discard()),
+			false},
 		{"Documents / Multiple", `INSERT INTO test VALUES {"a": 'a', b: -2.3}, {a: 1, d: true}`,
 			stream.New(docs.Emit(
 				&expr.KVPairs{SelfReferenced: true, Pairs: []expr.KVPair{
@@ -109,6 +130,21 @@ func TestParserInsert(t *testing.T) {
 				Pipe(table.Insert("test")).
 				Pipe(docs.Project(expr.Wildcard{}, testutil.ParseNamedExpr(t, "a"), testutil.ParseNamedExpr(t, "b", "B"), testutil.ParseNamedExpr(t, "c"))),
 			false},
+		{"Values / With key", "INSERT INTO test (a, b) VALUES ('c', 'd') WITH KEY (a, b)",
+			stream.New(docs.Emit(
+				&expr.KVPairs{Pairs: []expr.KVPair{
+					{K: "a", V: testutil.TextValue("c")},
+					{K: "b", V: testutil.TextValue("d")},
+				}},
+			)).
+				Pipe(table.Validate("test")).
+				Pipe(table.InsertWithContentKey("test", testutil.ParseDocumentPath(t, "a"), testutil.ParseDocumentPath(t, "b"))).
+				Pipe(stream.Discard()),
+			false},
+		{"Values / With key / Empty", "INSERT INTO test (a, b) VALUES ('c', 'd') WITH KEY ()",
+			nil, true},
+		{"Values / With key / Order", "INSERT INTO test (a, b) VALUES ('c', 'd') WITH KEY (a DESC)",
+			nil, true},
 		{"Values / With fields / Wrong values", "INSERT INTO test (a, b) VALUES {a: 1}, ('e', 'f')",
 			nil, true},
 		{"Values / Without fields / Wrong values", "INSERT INTO test VALUES {a: 1}, ('e', 'f')",
@@ -156,6 +192,24 @@ func TestParserInsert(t *testing.T) {
 				Pipe(stream.OnConflict(stream.New(table.Replace("test")))).
 				Pipe(table.Insert("test")),
 			false},
+		{"Values / ON CONFLICT DO UPDATE", "INSERT INTO test (a, b) VALUES ('c', 'd') ON CONFLICT DO UPDATE SET a = excluded.a, b = 'e' WHERE b != 'f' RETURNING *",
+			stream.New(docs.Emit(
+				&expr.KVPairs{Pairs: []expr.KVPair{
+					{K: "a", V: testutil.TextValue("c")},
+					{K: "b", V: testutil.TextValue("d")},
+				}},
+			)).
+				Pipe(table.Validate("test")).
+				Pipe(stream.OnConflict(stream.New(table.Fetch("test")).
+					Pipe(docs.Filter(parser.MustParseExpr("b != 'f'"))).
+					Pipe(path.Set(document.Path(testutil.ParsePath(t, "a")), testutil.ParsePath(t, "excluded.a"))).
+					Pipe(path.Set(document.Path(testutil.ParsePath(t, "b")), testutil.TextValue("e"))).
+					Pipe(table.Validate("test")).
+					Pipe(table.Replace("test")))).
+				Pipe(table.Insert("test")),
+			false},
+		{"Values / ON CONFLICT DO UPDATE without SET", "INSERT INTO test (a, b) VALUES ('c', 'd') ON CONFLICT DO UPDATE",
+			nil, true},
 		{"Values / ON CONFLICT BLA", "INSERT INTO test (a, b) VALUES ('c', 'd') ON CONFLICT BLA RETURNING *",
 			nil, true},
 		{"Values / ON CONFLICT DO BLA", "INSERT INTO test (a, b) VALUES ('c', 'd') ON CONFLICT DO BLA RETURNING *",
@@ -8,6 +8,17 @@ import (
 type Options struct {
 	// A table of function packages.
 	Packages functions.Packages
+
+	// CaseSensitiveLike makes LIKE and NOT LIKE compare
+	// characters case-sensitively. By default, LIKE is case-insensitive.
+	CaseSensitiveLike bool
+
+	// CaseInsensitiveIdentifiers folds every identifier (table, index and field names)
+	// to lower case, making them match regardless of their case.
+	// Identifiers quoted with backticks are not folded.
+	// Since names are folded when parsed, this option must be used consistently
+	// for creating and querying tables.
+	CaseInsensitiveIdentifiers bool
 }
 
 func defaultOptions() *Options {
@@ -0,0 +1,80 @@
+package parser_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/sql/parser"
+	"github.com/genjidb/genji/internal/testutil/assert"
+	"github.com/genjidb/genji/types"
+)
+
+func TestParserOptionsCaseSensitiveLike(t *testing.T) {
+	tests := []struct {
+		s                      string
+		insensitive, sensitive bool
+	}{
+		{"'Hello' LIKE 'hello'", true, false},
+		{"'Hello' LIKE 'H%'", true, true},
+		{"'Hello' LIKE 'h%'", true, false},
+		{"'Hello' NOT LIKE 'h%'", false, true},
+		{"'Hello' NOT LIKE 'x%'", true, true},
+	}
+
+	eval := func(t *testing.T, s string, opts *parser.Options) bool {
+		t.Helper()
+
+		e, err := parser.NewParserWithOptions(strings.NewReader(s), opts).ParseExpr()
+		assert.NoError(t, err)
+
+		v, err := e.Eval(&environment.Environment{})
+		assert.NoError(t, err)
+		require.Equal(t, types.BooleanValue, v.Type())
+		return types.As[bool](v)
+	}
+
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			require.Equal(t, test.insensitive, eval(t, test.s, nil))
+			require.Equal(t, test.sensitive, eval(t, test.s, &parser.Options{CaseSensitiveLike: true}))
+		})
+	}
+}
+
+func TestParserOptionsCaseInsensitiveIdentifiers(t *testing.T) {
+	tests := []struct {
+		s, folded string
+	}{
+		{"SELECT Foo.Bar, COUNT(*) FROM Test WHERE A.b[0].C = 'Value' GROUP BY Foo.Bar", "SELECT foo.bar, COUNT(*) FROM test WHERE a.b[0].c = 'Value' GROUP BY foo.bar"},
+		{"SELECT `Foo`, `Bar`.Baz FROM `Test`", "SELECT `Foo`, `Bar`.baz FROM `Test`"},
+		{"SELECT a.`B` AS `C`, D FROM test", "SELECT a.`B` AS `C`, d FROM test"},
+		{"SELECT a['Foo'] FROM test", "SELECT a['Foo'] FROM test"},
+		{"INSERT INTO Test (A, B) VALUES (1, {C: 1, 'D': 2})", "INSERT INTO test (a, b) VALUES (1, {c: 1, 'D': 2})"},
+		{"CREATE TABLE Test(A INT, B (C TEXT), CONSTRAINT Pk PRIMARY KEY (A))", "CREATE TABLE test(a INT, b (c TEXT), CONSTRAINT pk PRIMARY KEY (a))"},
+		{"CREATE INDEX Idx ON Test(A)", "CREATE INDEX idx ON test(a)"},
+		{"UPDATE Test SET A = 1 WHERE B = 2", "UPDATE test SET a = 1 WHERE b = 2"},
+		{"REINDEX Test", "REINDEX test"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			q, err := parser.NewParserWithOptions(strings.NewReader(test.s), &parser.Options{CaseInsensitiveIdentifiers: true}).ParseQuery()
+			assert.NoError(t, err)
+
+			expected, err := parser.ParseQuery(test.folded)
+			assert.NoError(t, err)
+
+			require.EqualValues(t, expected.Statements, q.Statements)
+		})
+	}
+
+	// identifiers are case-sensitive by default
+	q, err := parser.ParseQuery("SELECT Foo FROM Test")
+	assert.NoError(t, err)
+	expected, err := parser.ParseQuery("SELECT foo FROM test")
+	assert.NoError(t, err)
+	require.NotEqualValues(t, expected.Statements, q.Statements)
+}
@@ -5,28 +5,48 @@ import (
 
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/sql/scanner"
+	"github.com/genjidb/genji/internal/tree"
 )
 
-func (p *Parser) parseOrderBy() (expr.Path, scanner.Token, error) {
+// parseOrderBy parses an ORDER BY clause in the form: ORDER BY path [ASC|DESC], path [ASC|DESC], ...
+// The returned sort order indicates which paths must be sorted in descending order.
+func (p *Parser) parseOrderBy() ([]expr.Path, tree.SortOrder, error) {
 	// parse ORDER token
 	ok, err := p.parseOptional(scanner.ORDER, scanner.BY)
 	if err != nil || !ok {
 		return nil, 0, err
 	}
 
-	// parse path
-	path, err := p.parsePath()
-	if err != nil {
-		return nil, 0, err
-	}
+	var paths []expr.Path
+	var order tree.SortOrder
 
-	// parse optional ASC or DESC
-	if tok, _, _ := p.ScanIgnoreWhitespace(); tok == scanner.ASC || tok == scanner.DESC {
-		return expr.Path(path), tok, nil
+	for i := 0; ; i++ {
+		// parse path
+		path, err := p.parsePath()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if i > 63 {
+			return nil, 0, errors.New("ORDER BY clause cannot have more than 64 sort keys")
+		}
+
+		paths = append(paths, expr.Path(path))
+
+		// parse optional ASC or DESC
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok == scanner.DESC {
+			order = order.SetDesc(i)
+		} else if tok != scanner.ASC {
+			p.Unscan()
+		}
+
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.COMMA {
+			p.Unscan()
+			break
+		}
 	}
-	p.Unscan()
 
-	return expr.Path(path), 0, nil
+	return paths, order, nil
 }
 
 func (p *Parser) parseLimit() (expr.Expr, error) {
@@ -21,6 +21,7 @@ type Parser struct {
 	orderedParams int
 	namedParams   int
 	packagesTable functions.Packages
+	opts          *Options
 }
 
 // NewParser returns a new instance of Parser.
@@ -34,7 +35,12 @@ func NewParserWithOptions(r io.Reader, opts *Options) *Parser {
 		opts = defaultOptions()
 	}
 
-	return &Parser{s: scanner.NewScanner(r), packagesTable: opts.Packages}
+	packages := opts.Packages
+	if packages == nil {
+		packages = functions.DefaultPackages()
+	}
+
+	return &Parser{s: scanner.NewScanner(r), packagesTable: packages, opts: opts}
 }
 
 // ParseQuery parses a query string and returns its AST representation.
@@ -42,6 +48,17 @@ func ParseQuery(s string) (query.Query, error) {
 	return NewParser(strings.NewReader(s)).ParseQuery()
 }
 
+// ParseStatements parses a list of statements separated by semicolons.
+// Empty statements are ignored.
+func ParseStatements(s string) ([]statement.Statement, error) {
+	q, err := ParseQuery(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.Statements, nil
+}
+
 // ParsePath parses a path to a value in a document.
 func ParsePath(s string) (document.Path, error) {
 	return NewParser(strings.NewReader(s)).parsePath()
@@ -116,18 +133,32 @@ func (p *Parser) Parse(fn func(statement.Statement) error) error {
 }
 
 // ParseStatement parses a Genji SQL string and returns a statement.
+// Any error returned by this method is a *ParseError.
 func (p *Parser) ParseStatement() (statement.Statement, error) {
+	stmt, err := p.parseStatement()
+	if err != nil {
+		return nil, p.toParseError(err)
+	}
+
+	return stmt, nil
+}
+
+func (p *Parser) parseStatement() (statement.Statement, error) {
 	tok, pos, lit := p.ScanIgnoreWhitespace()
 	p.Unscan()
 	switch tok {
 	case scanner.ALTER:
 		return p.parseAlterStatement()
+	case scanner.ANALYZE:
+		return p.parseAnalyzeStatement()
 	case scanner.BEGIN:
 		return p.parseBeginStatement()
 	case scanner.COMMIT:
 		return p.parseCommitStatement()
 	case scanner.SELECT:
 		return p.parseSelectStatement()
+	case scanner.WITH:
+		return p.parseWithStatement()
 	case scanner.DELETE:
 		return p.parseDeleteStatement()
 	case scanner.UPDATE:
@@ -147,7 +178,7 @@ func (p *Parser) ParseStatement() (statement.Statement, error) {
 	}
 
 	return nil, newParseError(scanner.Tokstr(tok, lit), []string{
-		"ALTER", "BEGIN", "COMMIT", "SELECT", "DELETE", "UPDATE", "INSERT", "CREATE", "DROP", "EXPLAIN", "REINDEX", "ROLLBACK",
+		"ALTER", "ANALYZE", "BEGIN", "COMMIT", "SELECT", "WITH", "DELETE", "UPDATE", "INSERT", "CREATE", "DROP", "EXPLAIN", "REINDEX", "ROLLBACK",
 	}, pos)
 }
 
@@ -301,6 +332,32 @@ func (p *Parser) parseOptional(tokens ...scanner.Token) (bool, error) {
 	return err == nil, err
 }
 
+// isWord returns true if the token is an identifier matching word
+// case-insensitively. It is used to parse words that only act as
+// keywords in a specific position of the grammar.
+func isWord(tok scanner.Token, lit, word string) bool {
+	return tok == scanner.IDENT && strings.EqualFold(lit, word)
+}
+
+// parseOptionalWords is like parseOptional but matches identifiers
+// case-insensitively, which allows words to act as keywords in a
+// specific position of the grammar without being reserved everywhere.
+func (p *Parser) parseOptionalWords(words ...string) (bool, error) {
+	// Parse optional first word
+	if tok, _, lit := p.ScanIgnoreWhitespace(); !isWord(tok, lit, words[0]) {
+		p.Unscan()
+		return false, nil
+	}
+
+	for _, w := range words[1:] {
+		if tok, pos, lit := p.ScanIgnoreWhitespace(); !isWord(tok, lit, w) {
+			return false, newParseError(scanner.Tokstr(tok, lit), []string{strings.ToUpper(w)}, pos)
+		}
+	}
+
+	return true, nil
+}
+
 // ParseError represents an error that occurred during parsing.
 type ParseError struct {
 	Message  string
@@ -309,6 +366,18 @@ type ParseError struct {
 	Pos      scanner.Pos
 }
 
+// toParseError turns errors that are not already parse errors
+// into a *ParseError positioned at the last read token.
+func (p *Parser) toParseError(err error) error {
+	var pErr *ParseError
+	if errors.As(err, &pErr) {
+		return err
+	}
+
+	_, pos, _ := p.s.Curr()
+	return errors.WithStack(&ParseError{Message: err.Error(), Pos: pos})
+}
+
 // newParseError returns a new instance of ParseError.
 func newParseError(found string, expected []string, pos scanner.Pos) error {
 	return errors.WithStack(&ParseError{Found: found, Expected: expected, Pos: pos})
@@ -1,6 +1,7 @@
 package parser_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -8,6 +9,7 @@ import (
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/sql/parser"
+	"github.com/genjidb/genji/internal/sql/scanner"
 	"github.com/genjidb/genji/internal/testutil/assert"
 )
 
@@ -37,6 +39,10 @@ func TestParserMultiStatement(t *testing.T) {
 			q, err := parser.ParseQuery(test.s)
 			assert.NoError(t, err)
 			require.EqualValues(t, test.expected, q.Statements)
+
+			stmts, err := parser.ParseStatements(test.s)
+			assert.NoError(t, err)
+			require.EqualValues(t, test.expected, stmts)
 		})
 	}
 }
@@ -47,3 +53,143 @@ func TestParserDivideByZero(t *testing.T) {
 		_, _ = parser.ParseQuery("SELECT * FROM t LIMIT 0 % .5")
 	})
 }
+
+func TestParserErrorPosition(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected scanner.Pos
+	}{
+		{"Newlines", "SELECT a\nFROM test\nWHERE a = = 1", scanner.Pos{Line: 2, Char: 10}},
+		{"CRLF", "SELECT a\r\nFROM test\r\nWHERE a = = 1", scanner.Pos{Line: 2, Char: 10}},
+		{"Tabs", "SELECT a\n\tFROM test\n\t\tWHERE a = = 1", scanner.Pos{Line: 2, Char: 12}},
+		{"Comments", "SELECT a -- comment\n/* multi\nline */ FROM test WHERE a = = 1", scanner.Pos{Line: 2, Char: 28}},
+		{"String", "SELECT a\nFROM test\nWHERE a = 'b' 'c'", scanner.Pos{Line: 2, Char: 14}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parser.ParseQuery(test.s)
+			require.Error(t, err)
+
+			var perr *parser.ParseError
+			require.True(t, errors.As(err, &perr))
+			require.Equal(t, test.expected, perr.Pos)
+		})
+	}
+}
+
+func TestParserErrorType(t *testing.T) {
+	t.Run("Unexpected token", func(t *testing.T) {
+		_, err := parser.ParseQuery("SELECT a FROM test WHERE")
+
+		var perr *parser.ParseError
+		require.True(t, errors.As(err, &perr))
+		require.Equal(t, "EOF", perr.Found)
+	})
+
+	t.Run("Unknown statement", func(t *testing.T) {
+		_, err := parser.ParseQuery("SELEC a FROM test")
+
+		var perr *parser.ParseError
+		require.True(t, errors.As(err, &perr))
+		require.Equal(t, "SELEC", perr.Found)
+		require.Contains(t, perr.Expected, "SELECT")
+	})
+
+	t.Run("Other errors", func(t *testing.T) {
+		_, err := parser.ParseQuery("SELECT foo(a) FROM test")
+
+		var perr *parser.ParseError
+		require.True(t, errors.As(err, &perr))
+		require.Equal(t, `no such function: "foo"`, perr.Message)
+		require.EqualError(t, err, `no such function: "foo" at line 1, char 13`)
+	})
+}
+
+func TestParserComments(t *testing.T) {
+	tests := []string{
+		"SELECT a /* comment */ FROM test",
+		"SELECT a -- comment\nFROM test",
+		"SELECT a.b /* comment */, c FROM test /* comment */ WHERE d = 1",
+		"SELECT COUNT(a) /* comment */ FROM test",
+		"SELECT a /* comment */ + 1 FROM test",
+	}
+
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			_, err := parser.ParseQuery(test)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParserStatementString(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected string
+	}{
+		{"Select", "select * from foo", "SELECT * FROM foo"},
+		{"Select/Clauses",
+			"SELECT DISTINCT a, b.c AS d FROM foo WHERE a > 1 AND b = 'x' ORDER BY a DESC LIMIT 10 OFFSET 2",
+			`SELECT DISTINCT a, b.c AS d FROM foo WHERE a > 1 AND b = "x" ORDER BY a DESC LIMIT 10 OFFSET 2`},
+		{"Select/GroupBy", "SELECT a, COUNT(*) FROM foo GROUP BY a HAVING COUNT(*) > 1", "SELECT a, COUNT(*) FROM foo GROUP BY a HAVING COUNT(*) > 1"},
+		{"Select/GroupByMultiple", "SELECT a, b FROM foo GROUP BY a, b", "SELECT a, b FROM foo GROUP BY a, b"},
+		{"Select/Union", "SELECT a FROM foo UNION ALL SELECT a FROM bar UNION SELECT a FROM baz", "SELECT a FROM foo UNION ALL SELECT a FROM bar UNION SELECT a FROM baz"},
+		{"Select/Join", "SELECT * FROM foo INNER JOIN bar ON foo.a = bar.b LEFT OUTER JOIN baz ON bar.b = baz.c", "SELECT * FROM foo JOIN bar ON foo.a = bar.b LEFT JOIN baz ON bar.b = baz.c"},
+		{"Select/With", "WITH c AS (SELECT a FROM foo WHERE a > 1), d AS (SELECT * FROM c) SELECT * FROM c JOIN d ON c.a = d.a", "WITH c AS (SELECT a FROM foo WHERE a > 1), d AS (SELECT * FROM c) SELECT * FROM c JOIN d ON c.a = d.a"},
+		{"Select/IntersectExcept", "SELECT a FROM foo INTERSECT SELECT a FROM bar EXCEPT SELECT a FROM baz", "SELECT a FROM foo INTERSECT SELECT a FROM bar EXCEPT SELECT a FROM baz"},
+		{"Select/InSubquery", "select * from foo where a not in (select b from bar where c > 1)", "SELECT * FROM foo WHERE a NOT IN (SELECT b FROM bar WHERE c > 1)"},
+		{"Select/QuotedIdentifiers", "SELECT a AS `c d` FROM `my table`", "SELECT a AS `c d` FROM `my table`"},
+		{"Select/QuotedPaths",
+			"SELECT `my field`, a.`b c`, `d e` + 1 AS x FROM foo WHERE `my field` > 1 ORDER BY a.`b c`",
+			"SELECT `my field`, a.`b c`, `d e` + 1 AS x FROM foo WHERE `my field` > 1 ORDER BY a.`b c`"},
+		{"Select/Is", "SELECT * FROM foo WHERE a IS NULL OR `b c` IS NOT NULL", "SELECT * FROM foo WHERE a IS NULL OR `b c` IS NOT NULL"},
+		{"Insert/Values", "insert into foo (a, b) values (1, 'hello'), (2, 'world') on conflict do nothing returning a", `INSERT INTO foo (a, b) VALUES (1, "hello"), (2, "world") ON CONFLICT DO NOTHING RETURNING a`},
+		{"Insert/Positional", "INSERT INTO foo VALUES (1, 2)", "INSERT INTO foo VALUES (1, 2)"},
+		{"Insert/Documents", `INSERT INTO foo VALUES {a: 1, "b c": 2}, ?`, `INSERT INTO foo VALUES {a: 1, "b c": 2}, ?`},
+		{"Insert/Select", "INSERT INTO foo SELECT * FROM bar ON CONFLICT REPLACE", "INSERT INTO foo SELECT * FROM bar ON CONFLICT DO REPLACE"},
+		{"Insert/WithKey", "INSERT INTO foo (a, b) VALUES (1, 2) WITH KEY (a, b) ON CONFLICT DO NOTHING", "INSERT INTO foo (a, b) VALUES (1, 2) WITH KEY (a, b) ON CONFLICT DO NOTHING"},
+		{"Insert/SelectTTL", "INSERT INTO foo (a) SELECT 'x' TTL 3600", `INSERT INTO foo (a) SELECT "x" TTL 3600`},
+		{"Insert/SelectQuotedTTL", "INSERT INTO foo SELECT 'x' `ttl` TTL 3600", "INSERT INTO foo SELECT \"x\" AS ttl TTL 3600"},
+		{"Insert/TTL", "INSERT INTO foo (a) SELECT a FROM bar WHERE a > 1 ttl 60 * 60 RETURNING a", "INSERT INTO foo (a) SELECT a FROM bar WHERE a > 1 TTL 60 * 60 RETURNING a"},
+		{"Update/Set", "UPDATE foo SET a = a + 1, b.c = 'x' WHERE a < 10", `UPDATE foo SET a = a + 1, b.c = "x" WHERE a < 10`},
+		{"Update/Unset", "UPDATE foo UNSET a, b", "UPDATE foo UNSET a, b"},
+		{"Update/QuotedPaths", "UPDATE foo SET `my field` = 1 WHERE a.`b c` IS NULL", "UPDATE foo SET `my field` = 1 WHERE a.`b c` IS NULL"},
+		{"Update/Limit", "UPDATE foo SET a = 1 ORDER BY b LIMIT 2 OFFSET 1", "UPDATE foo SET a = 1 ORDER BY b LIMIT 2 OFFSET 1"},
+		{"Delete", "DELETE FROM foo WHERE a >= 1 ORDER BY b DESC LIMIT 5 OFFSET 1", "DELETE FROM foo WHERE a >= 1 ORDER BY b DESC LIMIT 5 OFFSET 1"},
+		{"Update/DryRun", "UPDATE foo SET a = 1 WHERE b > 2 dry run", "UPDATE foo SET a = 1 WHERE b > 2 DRY RUN"},
+		{"Delete/DryRun", "DELETE FROM foo LIMIT 5 DRY RUN", "DELETE FROM foo LIMIT 5 DRY RUN"},
+		// table constraints are named and primary keys are NOT NULL in the canonical form
+		{"CreateTable", "CREATE TABLE IF NOT EXISTS foo(a INT PRIMARY KEY, b TEXT NOT NULL DEFAULT 'x', c (d DOUBLE), UNIQUE (b))",
+			`CREATE TABLE IF NOT EXISTS foo (a INTEGER NOT NULL, b TEXT NOT NULL DEFAULT "x", c (d DOUBLE), CONSTRAINT foo_pk PRIMARY KEY (a), CONSTRAINT foo_b_unique UNIQUE (b))`},
+		{"CreateTable/NamedConstraints", "CREATE TABLE foo(a INT, b INT, CONSTRAINT my_pk PRIMARY KEY (a, b DESC), CONSTRAINT positive CHECK (a > 0))",
+			"CREATE TABLE foo (a INTEGER NOT NULL, b INTEGER NOT NULL, CONSTRAINT my_pk PRIMARY KEY (a, b DESC), CONSTRAINT positive CHECK (a > 0))"},
+		{"CreateTable/NoConstraints", "CREATE TABLE foo", "CREATE TABLE foo (...)"},
+		{"CreateIndex", "CREATE UNIQUE INDEX IF NOT EXISTS idx ON foo(a, b DESC)", "CREATE UNIQUE INDEX IF NOT EXISTS idx ON foo (a, b DESC)"},
+		{"CreateIndex/NoName", "CREATE INDEX ON foo(a)", "CREATE INDEX ON foo (a)"},
+		{"CreateIndex/QuotedPaths", "CREATE INDEX idx ON foo(`my field`, a.`b c`)", "CREATE INDEX idx ON foo (`my field`, a.`b c`)"},
+		{"DropTable", "DROP TABLE IF EXISTS foo", "DROP TABLE IF EXISTS foo"},
+		{"AlterTable", "ALTER TABLE foo RENAME TO bar", "ALTER TABLE foo RENAME TO bar"},
+		{"AlterTable/DropField", "ALTER TABLE IF EXISTS foo DROP FIELD bar", "ALTER TABLE IF EXISTS foo DROP FIELD bar"},
+		{"AlterTable/RenameField", "ALTER TABLE foo RENAME FIELD bar TO baz", "ALTER TABLE foo RENAME FIELD bar TO baz"},
+		{"Explain", "EXPLAIN SELECT * FROM foo WHERE a = 1", "EXPLAIN SELECT * FROM foo WHERE a = 1"},
+		{"Begin", "BEGIN TRANSACTION READ ONLY", "BEGIN READ ONLY"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stmts, err := parser.ParseStatements(test.s)
+			assert.NoError(t, err)
+			require.Len(t, stmts, 1)
+			require.Equal(t, test.expected, stmts[0].String())
+
+			// the representation must be stable
+			stmts, err = parser.ParseStatements(test.expected)
+			assert.NoError(t, err)
+			require.Len(t, stmts, 1)
+			require.Equal(t, test.expected, stmts[0].String())
+		})
+	}
+}
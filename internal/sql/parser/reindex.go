@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"github.com/cockroachdb/errors"
+
 	"github.com/genjidb/genji/internal/query/statement"
 	"github.com/genjidb/genji/internal/sql/scanner"
 )
@@ -14,9 +16,27 @@ func (p *Parser) parseReIndexStatement() (statement.Statement, error) {
 		return nil, err
 	}
 
+	var err error
+	stmt.IfExists, err = p.parseOptional(scanner.IF, scanner.EXISTS)
+	if err != nil {
+		return nil, err
+	}
+
+	// IF EXISTS requires a table or index name
+	if stmt.IfExists {
+		stmt.TableOrIndexName, err = p.parseIdent()
+		if err != nil {
+			pErr := errors.Unwrap(err).(*ParseError)
+			pErr.Expected = []string{"table_name", "index_name"}
+			return nil, pErr
+		}
+
+		return stmt, nil
+	}
+
 	tok, _, lit := p.ScanIgnoreWhitespace()
 	if tok == scanner.IDENT {
-		stmt.TableOrIndexName = lit
+		stmt.TableOrIndexName = p.foldIdent(lit)
 	} else {
 		p.Unscan()
 	}
@@ -13,6 +13,9 @@ func TestParserReIndex(t *testing.T) {
 	r1 := statement.NewReIndexStatement()
 	r2 := statement.NewReIndexStatement()
 	r2.TableOrIndexName = "tableOrIndex"
+	r3 := statement.NewReIndexStatement()
+	r3.TableOrIndexName = "tableOrIndex"
+	r3.IfExists = true
 	tests := []struct {
 		name     string
 		s        string
@@ -22,6 +25,8 @@ func TestParserReIndex(t *testing.T) {
 		{"All", "REINDEX", r1, false},
 		{"With ident", "REINDEX tableOrIndex", r2, false},
 		{"With extra", "REINDEX tableOrIndex tableOrIndex", nil, true},
+		{"With IF EXISTS", "REINDEX IF EXISTS tableOrIndex", r3, false},
+		{"With IF EXISTS and no ident", "REINDEX IF EXISTS", nil, true},
 	}
 
 	for _, test := range tests {
@@ -12,13 +12,13 @@ import (
 func (p *Parser) parseSelectStatement() (*statement.SelectStmt, error) {
 	stmt := statement.NewSelectStatement()
 
-	// Parse SELECT ... [UNION | UNION ALL | INTERSECT] SELECT ...
+	// Parse SELECT ... [UNION | UNION ALL | INTERSECT | EXCEPT] SELECT ...
 	err := p.parseCompoundSelectStatement(stmt)
 	if err != nil {
 		return nil, err
 	}
 
-	// Parse order by: "ORDER BY path [ASC|DESC]?"
+	// Parse order by: "ORDER BY path [ASC|DESC]?, ..."
 	stmt.OrderBy, stmt.OrderByDirection, err = p.parseOrderBy()
 	if err != nil {
 		return nil, err
@@ -39,6 +39,53 @@ func (p *Parser) parseSelectStatement() (*statement.SelectStmt, error) {
 	return stmt, nil
 }
 
+// parseWithStatement parses a select statement preceded by a list of common table expressions:
+// "WITH name AS (SELECT ...) [, name AS (SELECT ...)]* SELECT ..."
+func (p *Parser) parseWithStatement() (*statement.SelectStmt, error) {
+	if err := p.parseTokens(scanner.WITH); err != nil {
+		return nil, err
+	}
+
+	var ctes []*statement.CommonTableExpr
+	for {
+		var cte statement.CommonTableExpr
+		var err error
+
+		cte.Name, err = p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.parseTokens(scanner.AS, scanner.LPAREN); err != nil {
+			return nil, err
+		}
+
+		cte.Stmt, err = p.parseSelectStatement()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.parseTokens(scanner.RPAREN); err != nil {
+			return nil, err
+		}
+
+		ctes = append(ctes, &cte)
+
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.COMMA {
+			p.Unscan()
+			break
+		}
+	}
+
+	stmt, err := p.parseSelectStatement()
+	if err != nil {
+		return nil, err
+	}
+	stmt.With = ctes
+
+	return stmt, nil
+}
+
 func (p *Parser) parseCompoundSelectStatement(stmt *statement.SelectStmt) error {
 	for {
 		core, err := p.parseSelectCore()
@@ -60,7 +107,7 @@ func (p *Parser) parseCompoundSelectStatement(stmt *statement.SelectStmt) error
 
 		stmt.CompoundSelect = append(stmt.CompoundSelect, core)
 
-		if tok != scanner.UNION && tok != scanner.ALL {
+		if tok != scanner.UNION && tok != scanner.ALL && tok != scanner.INTERSECT && tok != scanner.EXCEPT {
 			p.Unscan()
 			break
 		}
@@ -85,6 +132,11 @@ func (p *Parser) parseSelectCore() (*statement.SelectCoreStmt, error) {
 		return nil, err
 	}
 
+	stmt.Only, err = p.parseOptional(scanner.ONLY)
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse path list or query.Wildcard
 	stmt.ProjectionExprs, err = p.parseProjectedExprs()
 	if err != nil {
@@ -97,18 +149,32 @@ func (p *Parser) parseSelectCore() (*statement.SelectCoreStmt, error) {
 		return nil, err
 	}
 
+	// Parse joins: "[INNER | LEFT [OUTER]] JOIN table_name ON expr"
+	if stmt.TableName != "" {
+		stmt.Joins, err = p.parseJoins()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Parse condition: "WHERE expr".
 	stmt.WhereExpr, err = p.parseCondition()
 	if err != nil {
 		return nil, err
 	}
 
-	// Parse group by: "GROUP BY expr"
+	// Parse group by: "GROUP BY expr [, expr]*"
 	stmt.GroupByExpr, err = p.parseGroupBy()
 	if err != nil {
 		return nil, err
 	}
 
+	// Parse having: "HAVING expr"
+	stmt.HavingExpr, err = p.parseHaving()
+	if err != nil {
+		return nil, err
+	}
+
 	return &stmt, nil
 }
 
@@ -153,17 +219,31 @@ func (p *Parser) parseProjectedExpr() (expr.Expr, error) {
 	ne := &expr.NamedExpr{Expr: pe}
 
 	// Check if the AS token exists.
-	if tok, _, _ := p.ScanIgnoreWhitespace(); tok == scanner.AS {
+	// It can be omitted if the alias directly follows the expression.
+	switch tok, _, lit := p.ScanIgnoreWhitespace(); tok {
+	case scanner.AS:
 		ne.ExprName, err = p.parseIdent()
 		if err != nil {
 			return nil, err
 		}
 
 		return ne, nil
+	case scanner.IDENT:
+		// TTL can follow the projection of an INSERT ... SELECT statement
+		if !isWord(tok, lit, "TTL") || p.s.Quoted() {
+			ne.ExprName = p.foldIdent(lit)
+			return ne, nil
+		}
 	}
 	p.Unscan()
 
-	ne.ExprName = pe.String()
+	// top-level fields are named after the field itself,
+	// without the quotes used to select them
+	if pp, ok := pe.(expr.Path); ok && len(pp) == 1 && pp[0].FieldName != "" {
+		ne.ExprName = pp[0].FieldName
+	} else {
+		ne.ExprName = pe.String()
+	}
 
 	return ne, nil
 }
@@ -184,13 +264,100 @@ func (p *Parser) parseFrom() (string, error) {
 	return ident, nil
 }
 
+// parseJoins parses the optional list of JOIN clauses following the FROM clause.
+func (p *Parser) parseJoins() ([]*statement.JoinClause, error) {
+	var joins []*statement.JoinClause
+
+	for {
+		var join statement.JoinClause
+
+		tok, _, _ := p.ScanIgnoreWhitespace()
+		switch tok {
+		case scanner.JOIN:
+		case scanner.INNER:
+			if err := p.parseTokens(scanner.JOIN); err != nil {
+				return nil, err
+			}
+		case scanner.LEFT:
+			join.Left = true
+			if _, err := p.parseOptional(scanner.OUTER); err != nil {
+				return nil, err
+			}
+			if err := p.parseTokens(scanner.JOIN); err != nil {
+				return nil, err
+			}
+		default:
+			p.Unscan()
+			return joins, nil
+		}
+
+		// Parse table name
+		var err error
+		join.TableName, err = p.parseIdent()
+		if err != nil {
+			pErr := errors.Unwrap(err).(*ParseError)
+			pErr.Expected = []string{"table_name"}
+			return nil, pErr
+		}
+
+		// Parse "ON expr"
+		if err := p.parseTokens(scanner.ON); err != nil {
+			return nil, err
+		}
+
+		join.On, err = p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		joins = append(joins, &join)
+	}
+}
+
+// parseHaving parses the optional HAVING clause.
+func (p *Parser) parseHaving() (expr.Expr, error) {
+	ok, err := p.parseOptional(scanner.HAVING)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return p.ParseExpr()
+}
+
 func (p *Parser) parseGroupBy() (expr.Expr, error) {
 	ok, err := p.parseOptional(scanner.GROUP, scanner.BY)
 	if err != nil || !ok {
 		return nil, err
 	}
 
-	// parse expr
+	// parse first expr
 	e, err := p.ParseExpr()
-	return e, err
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := expr.LiteralExprList{e}
+
+	// parse remaining (optional) exprs
+	for {
+		if tok, _, _ := p.ScanIgnoreWhitespace(); tok != scanner.COMMA {
+			p.Unscan()
+			break
+		}
+
+		e, err := p.ParseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		exprs = append(exprs, e)
+	}
+
+	// a single expression is grouped by its value,
+	// multiple expressions are grouped by the list of their values
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+
+	return exprs, nil
 }
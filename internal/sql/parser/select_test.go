@@ -15,6 +15,7 @@ import (
 	"github.com/genjidb/genji/internal/stream/table"
 	"github.com/genjidb/genji/internal/testutil"
 	"github.com/genjidb/genji/internal/testutil/assert"
+	"github.com/genjidb/genji/internal/tree"
 	"github.com/stretchr/testify/require"
 )
 
@@ -65,10 +66,18 @@ func TestParserSelect(t *testing.T) {
 			stream.New(table.Scan("test")).Pipe(docs.Project(testutil.ParseNamedExpr(t, "a", "A"), testutil.ParseNamedExpr(t, "b"))),
 			true, false,
 		},
+		{"WithAliasWithoutAs", "SELECT a A, b FROM test",
+			stream.New(table.Scan("test")).Pipe(docs.Project(testutil.ParseNamedExpr(t, "a", "A"), testutil.ParseNamedExpr(t, "b"))),
+			true, false,
+		},
 		{"WithFields and wildcard", "SELECT a, b, * FROM test",
 			stream.New(table.Scan("test")).Pipe(docs.Project(testutil.ParseNamedExpr(t, "a"), testutil.ParseNamedExpr(t, "b"), expr.Wildcard{})),
 			true, false,
 		},
+		{"WithOnly", "SELECT ONLY a, b FROM test",
+			stream.New(table.Scan("test")).Pipe(docs.StrictProject(testutil.ParseNamedExpr(t, "a"), testutil.ParseNamedExpr(t, "b"))),
+			true, false,
+		},
 		{"WithExpr", "SELECT a    > 1 FROM test",
 			stream.New(table.Scan("test")).Pipe(docs.Project(testutil.ParseNamedExpr(t, "a > 1"))),
 			true, false,
@@ -78,6 +87,25 @@ func TestParserSelect(t *testing.T) {
 				Pipe(docs.Filter(parser.MustParseExpr("age = 10"))),
 			true, false,
 		},
+		{"WithJoin", "SELECT * FROM test1 JOIN test2 ON test1.a = test2.b WHERE test2.c > 10",
+			stream.New(table.Scan("test1")).
+				Pipe(table.Join("test1", "test2", parser.MustParseExpr("test1.a = test2.b"))).
+				Pipe(docs.Filter(parser.MustParseExpr("test2.c > 10"))),
+			true, false,
+		},
+		{"WithInnerJoin", "SELECT * FROM test1 INNER JOIN test2 ON test1.a = test2.b",
+			stream.New(table.Scan("test1")).
+				Pipe(table.Join("test1", "test2", parser.MustParseExpr("test1.a = test2.b"))),
+			true, false,
+		},
+		{"WithLeftJoins", "SELECT * FROM test1 LEFT JOIN test2 ON test1.a = test2.b LEFT OUTER JOIN test3 ON test2.b = test3.c",
+			stream.New(table.Scan("test1")).
+				Pipe(table.LeftJoin("test1", "test2", parser.MustParseExpr("test1.a = test2.b"))).
+				Pipe(table.LeftJoin("", "test3", parser.MustParseExpr("test2.b = test3.c"))),
+			true, false,
+		},
+		{"WithJoinWithoutOn", "SELECT * FROM test1 JOIN test2", nil, true, true},
+		{"WithJoinWithoutTable", "SELECT 1 JOIN test2 ON a = b", nil, true, true},
 		{"WithGroupBy", "SELECT a.b.c FROM test WHERE age = 10 GROUP BY a.b.c",
 			stream.New(table.Scan("test")).
 				Pipe(docs.Filter(parser.MustParseExpr("age = 10"))).
@@ -86,6 +114,18 @@ func TestParserSelect(t *testing.T) {
 				Pipe(docs.Project(&expr.NamedExpr{ExprName: "a.b.c", Expr: expr.Path(document.NewPath("a.b.c"))})),
 			true, false,
 		},
+		{"WithGroupByMultiple", "SELECT a, b.c FROM test GROUP BY a, b.c",
+			stream.New(table.Scan("test")).
+				Pipe(docs.TempTreeSort(parser.MustParseExpr("[a, b.c]"))).
+				Pipe(docs.GroupAggregate(parser.MustParseExpr("[a, b.c]"))).
+				Pipe(docs.Project(
+					&expr.NamedExpr{ExprName: "a", Expr: expr.Path{document.PathFragment{FieldName: "[a, b.c]"}, document.PathFragment{ArrayIndex: 0}}},
+					&expr.NamedExpr{ExprName: "b.c", Expr: expr.Path{document.PathFragment{FieldName: "[a, b.c]"}, document.PathFragment{ArrayIndex: 1}}},
+				)),
+			true, false,
+		},
+		{"WithGroupByTrailingComma", "SELECT a FROM test GROUP BY a,", nil, true, true},
+		{"WithHavingMissingExpr", "SELECT a FROM test GROUP BY a HAVING", nil, true, true},
 		{"WithOrderBy", "SELECT * FROM test WHERE age = 10 ORDER BY a.b.c",
 			stream.New(table.Scan("test")).
 				Pipe(docs.Filter(parser.MustParseExpr("age = 10"))).
@@ -189,6 +229,33 @@ func TestParserSelect(t *testing.T) {
 			true, false,
 		},
 
+		{"WithCommonTableExpr", "WITH c AS (SELECT * FROM test1) SELECT * FROM c",
+			stream.New(stream.With(
+				stream.New(stream.CTEScan("c")),
+				&stream.CommonTableExpr{Name: "c", Stream: stream.New(table.Scan("test1"))},
+			)),
+			true, false,
+		},
+		{"WithCommonTableExprWithoutParens", "WITH c AS SELECT * FROM test1 SELECT * FROM c", nil, true, true},
+		{"WithIntersect", "SELECT * FROM test1 INTERSECT SELECT * FROM test2",
+			stream.New(stream.Intersect(
+				stream.New(table.Scan("test1")),
+				stream.New(table.Scan("test2")),
+			)),
+			true, false,
+		},
+		{"WithExcept", "SELECT * FROM test1 EXCEPT SELECT * FROM test2 EXCEPT SELECT * FROM test",
+			stream.New(stream.Except(
+				stream.New(table.Scan("test1")),
+				stream.New(table.Scan("test2")),
+				stream.New(table.Scan("test")),
+			)),
+			true, false,
+		},
+		{"WithExceptAfterOrderBy", "SELECT * FROM test1 ORDER BY a EXCEPT SELECT * FROM test2",
+			nil,
+			true, true,
+		},
 		{"WithUnion", "SELECT * FROM test1 UNION SELECT * FROM test2",
 			stream.New(stream.Union(
 				stream.New(table.Scan("test1")),
@@ -322,6 +389,7 @@ func TestParserSelect(t *testing.T) {
 					CREATE TABLE test;
 					CREATE TABLE test1;
 					CREATE TABLE test2;
+					CREATE TABLE test3;
 					CREATE TABLE a;
 					CREATE TABLE b;
 					CREATE TABLE c;
@@ -344,6 +412,53 @@ func TestParserSelect(t *testing.T) {
 	}
 }
 
+func TestParserOrderBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		paths    []expr.Path
+		order    tree.SortOrder
+		mustFail bool
+	}{
+		{"Single", "SELECT * FROM test ORDER BY a", []expr.Path{testutil.ParsePath(t, "a")}, 0, false},
+		{"Single ASC", "SELECT * FROM test ORDER BY a ASC", []expr.Path{testutil.ParsePath(t, "a")}, 0, false},
+		{"Single DESC", "SELECT * FROM test ORDER BY a.b DESC", []expr.Path{testutil.ParsePath(t, "a.b")}, tree.SortOrder(0).SetDesc(0), false},
+		{"Multiple", "SELECT * FROM test ORDER BY a, b", []expr.Path{testutil.ParsePath(t, "a"), testutil.ParsePath(t, "b")}, 0, false},
+		{"Multiple mixed", "SELECT * FROM test ORDER BY a DESC, b ASC, c DESC",
+			[]expr.Path{testutil.ParsePath(t, "a"), testutil.ParsePath(t, "b"), testutil.ParsePath(t, "c")},
+			tree.SortOrder(0).SetDesc(0).SetDesc(2), false},
+		{"Multiple with limit", "SELECT * FROM test ORDER BY a, b DESC LIMIT 10",
+			[]expr.Path{testutil.ParsePath(t, "a"), testutil.ParsePath(t, "b")}, tree.SortOrder(0).SetDesc(1), false},
+		{"Delete", "DELETE FROM test ORDER BY a DESC, b", []expr.Path{testutil.ParsePath(t, "a"), testutil.ParsePath(t, "b")}, tree.SortOrder(0).SetDesc(0), false},
+		{"Trailing comma", "SELECT * FROM test ORDER BY a,", nil, 0, true},
+		{"No path", "SELECT * FROM test ORDER BY", nil, 0, true},
+		{"Expression", "SELECT * FROM test ORDER BY a + 1", nil, 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := parser.ParseQuery(test.s)
+			if test.mustFail {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			require.Len(t, q.Statements, 1)
+
+			switch stmt := q.Statements[0].(type) {
+			case *statement.SelectStmt:
+				require.Equal(t, test.paths, stmt.OrderBy)
+				require.Equal(t, test.order, stmt.OrderByDirection)
+			case *statement.DeleteStmt:
+				require.Equal(t, test.paths, stmt.OrderBy)
+				require.Equal(t, test.order, stmt.OrderByDirection)
+			default:
+				t.Fatalf("unexpected statement %T", stmt)
+			}
+		})
+	}
+}
+
 func BenchmarkSelect(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_, _ = parser.ParseQuery("SELECT a, b.c[100].d AS `foo` FROM `some table` WHERE d.e[100] >= 12 AND c.d IN ([1, true], [2, false]) GROUP BY d.e[0] LIMIT 10 + 10 OFFSET 20 - 20 ORDER BY d DESC")
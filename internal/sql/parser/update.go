@@ -44,6 +44,33 @@ func (p *Parser) parseUpdateStatement() (*statement.UpdateStmt, error) {
 		return nil, err
 	}
 
+	// Parse order by: "ORDER BY path [ASC|DESC]?, ..."
+	stmt.OrderBy, stmt.OrderByDirection, err = p.parseOrderBy()
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse limit: "LIMIT expr"
+	stmt.LimitExpr, err = p.parseLimit()
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse offset: "OFFSET expr"
+	stmt.OffsetExpr, err = p.parseOffset()
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse dry run: "DRY RUN"
+	dryRun, err := p.parseOptionalWords("DRY", "RUN")
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		stmt.DryRun()
+	}
+
 	return stmt, nil
 }
 
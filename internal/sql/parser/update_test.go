@@ -76,6 +76,18 @@ func TestParserUpdate(t *testing.T) {
 				Pipe(stream.Discard()),
 			false,
 		},
+		{"SET/With order by, limit and offset", "UPDATE test SET a = 1 WHERE age = 10 ORDER BY age LIMIT 10 OFFSET 20",
+			stream.New(table.Scan("test")).
+				Pipe(docs.Filter(parser.MustParseExpr("age = 10"))).
+				Pipe(docs.TempTreeSort(parser.MustParseExpr("age"))).
+				Pipe(docs.Skip(parser.MustParseExpr("20"))).
+				Pipe(docs.Take(parser.MustParseExpr("10"))).
+				Pipe(path.Set(document.Path(testutil.ParsePath(t, "a")), testutil.IntegerValue(1))).
+				Pipe(table.Validate("test")).
+				Pipe(table.Replace("test")).
+				Pipe(stream.Discard()),
+			false,
+		},
 		{"Trailing comma", "UPDATE test SET a = 1, WHERE age = 10", nil, true},
 		{"No SET", "UPDATE test WHERE age = 10", nil, true},
 		{"No pair", "UPDATE test SET WHERE age = 10", nil, true},
@@ -3,6 +3,7 @@ package scanner
 import (
 	"bufio"
 	"bytes"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"strings"
@@ -26,6 +27,8 @@ func init() {
 // scanner represents a lexical scanner for Genji.
 type scanner struct {
 	r *reader
+	// set if the last scanned identifier was quoted with backticks.
+	quoted bool
 }
 
 // newScanner returns a new instance of Scanner.
@@ -40,6 +43,14 @@ func (s *scanner) Scan() (tok Token, pos Pos, lit string) {
 	// Read next code point.
 	ch0, pos := s.r.read()
 
+	// A quote following an x starts a hexadecimal blob literal.
+	if ch0 == 'x' || ch0 == 'X' {
+		if ch1, _ := s.r.read(); ch1 == '\'' {
+			return s.scanBlob(pos)
+		}
+		s.r.unread()
+	}
+
 	// If we see whitespace then consume all contiguous whitespace.
 	// If we see a letter, or certain acceptable special characters, then consume
 	// as an ident or reserved word.
@@ -249,6 +260,7 @@ func (s *scanner) scanIdent(doLookup bool) (tok Token, pos Pos, lit string) {
 			if tok0 == BADSTRING || tok0 == BADESCAPE {
 				return tok0, pos0, lit0
 			}
+			s.quoted = true
 			return IDENT, pos, lit0
 		} else if isIdentChar(ch) {
 			s.r.unread()
@@ -272,8 +284,9 @@ func (s *scanner) scanIdent(doLookup bool) (tok Token, pos Pos, lit string) {
 // scanString consumes a contiguous string of non-quote characters.
 // Quote characters can be consumed if they're first escaped with a backslash.
 func (s *scanner) scanString() (tok Token, pos Pos, lit string) {
-	s.r.unread()
+	// Save the position of the opening quote before pushing it back.
 	_, pos = s.r.curr()
+	s.r.unread()
 
 	lit, err := scanString(s.r)
 
@@ -286,6 +299,24 @@ func (s *scanner) scanString() (tok Token, pos Pos, lit string) {
 	return STRING, pos, lit
 }
 
+// scanBlob consumes a hexadecimal blob literal of the form x'DEADBEEF'.
+// It assumes the x and the opening quote have already been consumed.
+func (s *scanner) scanBlob(pos Pos) (tok Token, _ Pos, lit string) {
+	// push back the opening quote.
+	s.r.unread()
+
+	lit, err := scanString(s.r)
+	if err != nil || len(lit)%2 != 0 {
+		return BADBLOB, pos, lit
+	}
+
+	if _, err := hex.DecodeString(lit); err != nil {
+		return BADBLOB, pos, lit
+	}
+
+	return BLOB, pos, lit
+}
+
 // ScanRegex consumes a token to find escapes
 func (s *scanner) ScanRegex() (tok Token, pos Pos, lit string) {
 	_, pos = s.r.curr()
@@ -406,9 +437,10 @@ type Scanner struct {
 	i   int // buffer index
 	n   int // buffer size
 	buf [4]struct {
-		tok Token
-		pos Pos
-		lit string
+		tok    Token
+		pos    Pos
+		lit    string
+		quoted bool
 	}
 }
 
@@ -438,11 +470,19 @@ func (s *Scanner) scanFunc(scan func() (Token, Pos, string)) (tok Token, pos Pos
 	// Move buffer position forward and save the token.
 	s.i = (s.i + 1) % len(s.buf)
 	buf := &s.buf[s.i]
+	s.s.quoted = false
 	buf.tok, buf.pos, buf.lit = scan()
+	buf.quoted = s.s.quoted
 
 	return s.Curr()
 }
 
+// Quoted returns whether the last read token is an identifier
+// quoted with backticks.
+func (s *Scanner) Quoted() bool {
+	return s.buf[(s.i-s.n+len(s.buf))%len(s.buf)].quoted
+}
+
 // Unscan pushes the previously token back onto the buffer.
 func (s *Scanner) Unscan() { s.n++ }
 
@@ -80,7 +80,7 @@ func TestScanner_Scan(t *testing.T) {
 		{s: "`foo\bar`", tok: IDENT, lit: "foo\bar"},
 		{s: "`foo\\bar`", tok: BADESCAPE, lit: `\b`, pos: Pos{Line: 0, Char: 5}},
 		{s: "`foo\\`bar\\``", tok: IDENT, lit: "foo`bar`"},
-		{s: "test`", tok: BADSTRING, lit: "", pos: Pos{Line: 0, Char: 3}},
+		{s: "test`", tok: BADSTRING, lit: "", pos: Pos{Line: 0, Char: 4}},
 		{s: "`test", tok: BADSTRING, lit: "test"},
 		{s: "$host", tok: NAMEDPARAM, lit: "$host"},
 		{s: "$`host param`", tok: NAMEDPARAM, lit: "$host param"},
@@ -107,6 +107,14 @@ func TestScanner_Scan(t *testing.T) {
 		{s: `"test`, tok: BADSTRING, lit: `test`},
 		{s: "\"test\nfoo", tok: BADSTRING, lit: `test`},
 		{s: `"test\g"`, tok: BADESCAPE, lit: `\g`, pos: Pos{Line: 0, Char: 6}},
+		{s: `x'DEADBEEF'`, tok: BLOB, lit: `DEADBEEF`},
+		{s: `X'00ff'`, tok: BLOB, lit: `00ff`},
+		{s: `x''`, tok: BLOB, lit: ``},
+		{s: `x'DEADBEE'`, tok: BADBLOB, lit: `DEADBEE`},
+		{s: `x'GG'`, tok: BADBLOB, lit: `GG`},
+		{s: `x'AB`, tok: BADBLOB, lit: `AB`},
+		{s: `x`, tok: IDENT, lit: `x`},
+		{s: `xyz`, tok: IDENT, lit: `xyz`},
 
 		// Numbers
 		{s: `100`, tok: INTEGER, lit: `100`},
@@ -121,6 +129,8 @@ func TestScanner_Scan(t *testing.T) {
 		// Keywords
 		{s: `ADD`, tok: ADD_KEYWORD},
 		{s: `ALTER`, tok: ALTER},
+		{s: `ANALYZE`, tok: ANALYZE},
+		{s: `AUTOINCREMENT`, tok: AUTOINCREMENT},
 		{s: `AS`, tok: AS},
 		{s: `ASC`, tok: ASC},
 		{s: `ALL`, tok: ALL},
@@ -128,6 +138,7 @@ func TestScanner_Scan(t *testing.T) {
 		{s: `BEGIN`, tok: BEGIN},
 		{s: `BETWEEN`, tok: BETWEEN},
 		{s: `CACHE`, tok: CACHE},
+		{s: `CASE`, tok: CASE},
 		{s: `CAST`, tok: CAST},
 		{s: `CHECK`, tok: CHECK},
 		{s: `COMMIT`, tok: COMMIT},
@@ -141,8 +152,11 @@ func TestScanner_Scan(t *testing.T) {
 		{s: `DO`, tok: DO},
 		{s: `DISTINCT`, tok: DISTINCT},
 		{s: `DROP`, tok: DROP},
+		{s: `ELSE`, tok: ELSE},
+		{s: `END`, tok: END},
 		{s: `EXPLAIN`, tok: EXPLAIN},
 		{s: `GROUP`, tok: GROUP},
+		{s: `HAVING`, tok: HAVING},
 		{s: `FIELD`, tok: FIELD},
 		{s: `FOR`, tok: FOR},
 		{s: `FROM`, tok: FROM},
@@ -173,6 +187,7 @@ func TestScanner_Scan(t *testing.T) {
 		{s: `SET`, tok: SET},
 		{s: `START`, tok: START},
 		{s: `TABLE`, tok: TABLE},
+		{s: `THEN`, tok: THEN},
 		{s: `TO`, tok: TO},
 		{s: `TRANSACTION`, tok: TRANSACTION},
 		{s: `UPDATE`, tok: UPDATE},
@@ -180,6 +195,8 @@ func TestScanner_Scan(t *testing.T) {
 		{s: `UNSET`, tok: UNSET},
 		{s: `VALUE`, tok: VALUE},
 		{s: `VALUES`, tok: VALUES},
+		{s: `VIEW`, tok: VIEW},
+		{s: `WHEN`, tok: WHEN},
 		{s: `WITH`, tok: WITH},
 		{s: `WHERE`, tok: WHERE},
 		{s: `WRITE`, tok: WRITE},
@@ -232,7 +249,7 @@ func TestScanner_Scan_Multi(t *testing.T) {
 		{tok: WS, pos: Pos{Line: 0, Char: 32}, lit: " "},
 		{tok: EQ, pos: Pos{Line: 0, Char: 33}, lit: ""},
 		{tok: WS, pos: Pos{Line: 0, Char: 34}, lit: " "},
-		{tok: STRING, pos: Pos{Line: 0, Char: 34}, lit: "b"},
+		{tok: STRING, pos: Pos{Line: 0, Char: 35}, lit: "b"},
 		{tok: EOF, pos: Pos{Line: 0, Char: 38}, lit: ""},
 	}
 
@@ -262,6 +279,57 @@ func TestScanner_Scan_Multi(t *testing.T) {
 	}
 }
 
+// Ensure positions are accurate across newlines, tabs and comments.
+func TestScanner_Scan_MultiLine(t *testing.T) {
+	type result struct {
+		tok Token
+		pos Pos
+		lit string
+	}
+
+	exp := []result{
+		{tok: SELECT, pos: Pos{Line: 0, Char: 0}, lit: ""},
+		{tok: WS, pos: Pos{Line: 0, Char: 6}, lit: " "},
+		{tok: IDENT, pos: Pos{Line: 0, Char: 7}, lit: "a"},
+		{tok: WS, pos: Pos{Line: 0, Char: 8}, lit: "\n\t"},
+		{tok: FROM, pos: Pos{Line: 1, Char: 1}, lit: ""},
+		{tok: WS, pos: Pos{Line: 1, Char: 5}, lit: " "},
+		{tok: IDENT, pos: Pos{Line: 1, Char: 6}, lit: "test"},
+		{tok: WS, pos: Pos{Line: 1, Char: 10}, lit: " "},
+		{tok: COMMENT, pos: Pos{Line: 1, Char: 11}, lit: ""},
+		{tok: COMMENT, pos: Pos{Line: 2, Char: 0}, lit: ""},
+		{tok: WS, pos: Pos{Line: 3, Char: 7}, lit: " "},
+		{tok: WHERE, pos: Pos{Line: 3, Char: 8}, lit: ""},
+		{tok: WS, pos: Pos{Line: 3, Char: 13}, lit: " "},
+		{tok: STRING, pos: Pos{Line: 3, Char: 14}, lit: "b"},
+		{tok: WS, pos: Pos{Line: 3, Char: 17}, lit: "\n"},
+		{tok: INTEGER, pos: Pos{Line: 4, Char: 0}, lit: "10"},
+		{tok: EOF, pos: Pos{Line: 4, Char: 2}, lit: ""},
+	}
+
+	v := "SELECT a\n\tFROM test -- comment\r\n/* multi\nline */ WHERE 'b'\r\n10"
+	s := newScanner(strings.NewReader(v))
+
+	var act []result
+	for {
+		tok, pos, lit := s.Scan()
+		act = append(act, result{tok, pos, lit})
+		if tok == EOF {
+			break
+		}
+	}
+
+	if len(exp) != len(act) {
+		t.Fatalf("token count mismatch: exp=%d, got=%d\n\ngot=%#v", len(exp), len(act), act)
+	}
+
+	for i := range exp {
+		if !reflect.DeepEqual(exp[i], act[i]) {
+			t.Fatalf("%d. token mismatch:\n\nexp=%#v\n\ngot=%#v", i, exp[i], act[i])
+		}
+	}
+}
+
 // Ensure the library can correctly scan strings.
 func TestScanString(t *testing.T) {
 	var tests = []struct {
@@ -24,6 +24,8 @@ const (
 	STRING          // "abc"
 	BADSTRING       // "abc
 	BADESCAPE       // \q
+	BLOB            // x'DEADBEEF'
+	BADBLOB         // x'DEADBEE
 	TRUE            // true
 	FALSE           // false
 	NULL            // NULL
@@ -62,6 +64,7 @@ const (
 	NLIKE    // NOT LIKE
 	CONCAT   // ||
 	BETWEEN  // BETWEEN
+	NBETWEEN // NOT BETWEEN
 	operatorEnd
 
 	LPAREN      // (
@@ -81,11 +84,14 @@ const (
 	ADD_KEYWORD
 	ALL
 	ALTER
+	ANALYZE
 	AS
 	ASC
+	AUTOINCREMENT
 	BEGIN
 	BY
 	CACHE
+	CASE
 	CAST
 	CHECK
 	COMMIT
@@ -99,19 +105,27 @@ const (
 	DISTINCT
 	DO
 	DROP
+	ELSE
+	END
+	EXCEPT
 	EXISTS
 	EXPLAIN
 	FIELD
 	FOR
 	FROM
 	GROUP
+	HAVING
 	IF
 	IGNORE
 	INCREMENT
 	INDEX
+	INNER
 	INSERT
+	INTERSECT
 	INTO
+	JOIN
 	KEY
+	LEFT
 	LIMIT
 	MAXVALUE
 	MINVALUE
@@ -123,6 +137,7 @@ const (
 	ON
 	ONLY
 	ORDER
+	OUTER
 	PRECISION
 	PRIMARY
 	READ
@@ -136,6 +151,7 @@ const (
 	SET
 	START
 	TABLE
+	THEN
 	TO
 	TRANSACTION
 	UNION
@@ -144,6 +160,8 @@ const (
 	UPDATE
 	VALUE
 	VALUES
+	VIEW
+	WHEN
 	WITH
 	WHERE
 	WRITE
@@ -185,6 +203,8 @@ var tokens = [...]string{
 	STRING:          "STRING",
 	BADSTRING:       "BADSTRING",
 	BADESCAPE:       "BADESCAPE",
+	BLOB:            "BLOB",
+	BADBLOB:         "BADBLOB",
 	TRUE:            "TRUE",
 	FALSE:           "FALSE",
 	REGEX:           "REGEX",
@@ -214,6 +234,7 @@ var tokens = [...]string{
 	IN:       "IN",
 	IS:       "IS",
 	LIKE:     "LIKE",
+	CONCAT:   "||",
 
 	LPAREN:      "(",
 	RPAREN:      ")",
@@ -227,75 +248,90 @@ var tokens = [...]string{
 	SEMICOLON:   ";",
 	DOT:         ".",
 
-	ADD_KEYWORD: "ADD",
-	ALL:         "ALL",
-	ALTER:       "ALTER",
-	AS:          "AS",
-	ASC:         "ASC",
-	BEGIN:       "BEGIN",
-	BY:          "BY",
-	CACHE:       "CACHE",
-	CAST:        "CAST",
-	CHECK:       "CHECK",
-	COMMIT:      "COMMIT",
-	CONFLICT:    "CONFLICT",
-	CONSTRAINT:  "CONSTRAINT",
-	CREATE:      "CREATE",
-	CYCLE:       "CYCLE",
-	DO:          "DO",
-	DEFAULT:     "DEFAULT",
-	DELETE:      "DELETE",
-	DESC:        "DESC",
-	DISTINCT:    "DISTINCT",
-	DROP:        "DROP",
-	EXISTS:      "EXISTS",
-	EXPLAIN:     "EXPLAIN",
-	GROUP:       "GROUP",
-	KEY:         "KEY",
-	FIELD:       "FIELD",
-	FOR:         "FOR",
-	FROM:        "FROM",
-	IF:          "IF",
-	IGNORE:      "IGNORE",
-	INCREMENT:   "INCREMENT",
-	INDEX:       "INDEX",
-	INSERT:      "INSERT",
-	INTO:        "INTO",
-	LIMIT:       "LIMIT",
-	MAXVALUE:    "MAXVALUE",
-	MINVALUE:    "MINVALUE",
-	NEXT:        "NEXT",
-	NO:          "NO",
-	NOT:         "NOT",
-	NOTHING:     "NOTHING",
-	OFFSET:      "OFFSET",
-	ON:          "ON",
-	ONLY:        "ONLY",
-	ORDER:       "ORDER",
-	PRECISION:   "PRECISION",
-	PRIMARY:     "PRIMARY",
-	READ:        "READ",
-	REINDEX:     "REINDEX",
-	RENAME:      "RENAME",
-	RETURNING:   "RETURNING",
-	REPLACE:     "REPLACE",
-	ROLLBACK:    "ROLLBACK",
-	START:       "START",
-	SELECT:      "SELECT",
-	SET:         "SET",
-	SEQUENCE:    "SEQUENCE",
-	TABLE:       "TABLE",
-	TO:          "TO",
-	TRANSACTION: "TRANSACTION",
-	UNION:       "UNION",
-	UNIQUE:      "UNIQUE",
-	UNSET:       "UNSET",
-	UPDATE:      "UPDATE",
-	VALUE:       "VALUE",
-	VALUES:      "VALUES",
-	WITH:        "WITH",
-	WHERE:       "WHERE",
-	WRITE:       "WRITE",
+	ADD_KEYWORD:   "ADD",
+	ALL:           "ALL",
+	ALTER:         "ALTER",
+	ANALYZE:       "ANALYZE",
+	AS:            "AS",
+	ASC:           "ASC",
+	AUTOINCREMENT: "AUTOINCREMENT",
+	BEGIN:         "BEGIN",
+	BY:            "BY",
+	CACHE:         "CACHE",
+	CASE:          "CASE",
+	CAST:          "CAST",
+	CHECK:         "CHECK",
+	COMMIT:        "COMMIT",
+	CONFLICT:      "CONFLICT",
+	CONSTRAINT:    "CONSTRAINT",
+	CREATE:        "CREATE",
+	CYCLE:         "CYCLE",
+	DO:            "DO",
+	DEFAULT:       "DEFAULT",
+	DELETE:        "DELETE",
+	DESC:          "DESC",
+	DISTINCT:      "DISTINCT",
+	DROP:          "DROP",
+	ELSE:          "ELSE",
+	END:           "END",
+	EXCEPT:        "EXCEPT",
+	EXISTS:        "EXISTS",
+	EXPLAIN:       "EXPLAIN",
+	GROUP:         "GROUP",
+	HAVING:        "HAVING",
+	KEY:           "KEY",
+	FIELD:         "FIELD",
+	FOR:           "FOR",
+	FROM:          "FROM",
+	IF:            "IF",
+	IGNORE:        "IGNORE",
+	INCREMENT:     "INCREMENT",
+	INDEX:         "INDEX",
+	INNER:         "INNER",
+	INSERT:        "INSERT",
+	INTERSECT:     "INTERSECT",
+	INTO:          "INTO",
+	JOIN:          "JOIN",
+	LEFT:          "LEFT",
+	LIMIT:         "LIMIT",
+	MAXVALUE:      "MAXVALUE",
+	MINVALUE:      "MINVALUE",
+	NEXT:          "NEXT",
+	NO:            "NO",
+	NOT:           "NOT",
+	NOTHING:       "NOTHING",
+	OFFSET:        "OFFSET",
+	ON:            "ON",
+	ONLY:          "ONLY",
+	ORDER:         "ORDER",
+	OUTER:         "OUTER",
+	PRECISION:     "PRECISION",
+	PRIMARY:       "PRIMARY",
+	READ:          "READ",
+	REINDEX:       "REINDEX",
+	RENAME:        "RENAME",
+	RETURNING:     "RETURNING",
+	REPLACE:       "REPLACE",
+	ROLLBACK:      "ROLLBACK",
+	START:         "START",
+	SELECT:        "SELECT",
+	SET:           "SET",
+	SEQUENCE:      "SEQUENCE",
+	TABLE:         "TABLE",
+	THEN:          "THEN",
+	TO:            "TO",
+	TRANSACTION:   "TRANSACTION",
+	UNION:         "UNION",
+	UNIQUE:        "UNIQUE",
+	UNSET:         "UNSET",
+	UPDATE:        "UPDATE",
+	VALUE:         "VALUE",
+	VALUES:        "VALUES",
+	VIEW:          "VIEW",
+	WHEN:          "WHEN",
+	WITH:          "WITH",
+	WHERE:         "WHERE",
+	WRITE:         "WRITE",
 
 	TYPEANY:       "ANY",
 	TYPEARRAY:     "ARRAY",
@@ -339,7 +375,7 @@ func (tok Token) Precedence() int {
 		return 2
 	case NOT:
 		return 3
-	case EQ, NEQ, IS, ISN, IN, NIN, LIKE, NLIKE, EQREGEX, NEQREGEX, BETWEEN:
+	case EQ, NEQ, IS, ISN, IN, NIN, LIKE, NLIKE, EQREGEX, NEQREGEX, BETWEEN, NBETWEEN:
 		return 4
 	case LT, LTE, GT, GTE:
 		return 5
@@ -374,6 +410,11 @@ func lookup(ident string) Token {
 	return IDENT
 }
 
+// IsKeyword returns true if ident is a reserved keyword.
+func IsKeyword(ident string) bool {
+	return lookup(ident) != IDENT
+}
+
 // Pos specifies the line and character position of a token.
 // The Char and Line are both zero-based indexes.
 type Pos struct {
@@ -0,0 +1,43 @@
+package stream
+
+import (
+	"time"
+
+	"github.com/genjidb/genji/internal/environment"
+)
+
+// AnalyzeOperator records the number of documents output by the previous operator
+// and the time spent producing them, including the time spent in the operators before it.
+// It is only used by EXPLAIN ANALYZE and is never part of a regular stream.
+type AnalyzeOperator struct {
+	BaseOperator
+	Rows    int64
+	Elapsed time.Duration
+}
+
+// Analyze creates an AnalyzeOperator.
+func Analyze() *AnalyzeOperator {
+	return &AnalyzeOperator{}
+}
+
+// Iterate implements the Operator interface.
+func (op *AnalyzeOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	var downstream time.Duration
+
+	start := time.Now()
+	err := op.Prev.Iterate(in, func(out *environment.Environment) error {
+		op.Rows++
+
+		t := time.Now()
+		err := fn(out)
+		downstream += time.Since(t)
+		return err
+	})
+	op.Elapsed += time.Since(start) - downstream
+
+	return err
+}
+
+func (op *AnalyzeOperator) String() string {
+	return "analyze()"
+}
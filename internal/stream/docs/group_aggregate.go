@@ -91,7 +91,12 @@ func (op *GroupAggregateOperator) Iterate(in *environment.Environment, f func(ou
 	// Ex: For `SELECT COUNT(*) FROM foo`, if `foo` is empty
 	// we want the following result:
 	// {"COUNT(*)": 0}
+	// When grouping by an expression, an empty stream has no groups.
 	if ga == nil {
+		if op.E != nil {
+			return nil
+		}
+
 		ga = newGroupAggregator(nil, "", op.Builders)
 	}
 
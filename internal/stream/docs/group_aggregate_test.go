@@ -51,6 +51,18 @@ func TestAggregate(t *testing.T) {
 			[]types.Document{testutil.MakeDocument(t, `{"a % 2": 0, "COUNT(a)": 5, "AVG(a)": 4.0}`), testutil.MakeDocument(t, `{"a % 2": 1, "COUNT(a)": 5, "AVG(a)": 5.0}`)},
 			false,
 		},
+		{
+			"sum/groupBy",
+			parser.MustParseExpr("a % 3"),
+			[]expr.AggregatorBuilder{&functions.Count{Wildcard: true}, &functions.Sum{Expr: parser.MustParseExpr("a")}},
+			generateSeqDocs(t, 10),
+			testutil.MakeDocuments(t,
+				`{"a % 3": 0, "COUNT(*)": 4, "SUM(a)": 18}`,
+				`{"a % 3": 1, "COUNT(*)": 3, "SUM(a)": 12}`,
+				`{"a % 3": 2, "COUNT(*)": 3, "SUM(a)": 15}`,
+			),
+			false,
+		},
 		{
 			"count/noInput",
 			nil,
@@ -59,6 +71,14 @@ func TestAggregate(t *testing.T) {
 			[]types.Document{testutil.MakeDocument(t, `{"COUNT(a)": 0, "AVG(a)": 0.0}`)},
 			false,
 		},
+		{
+			"count/groupBy/noInput",
+			parser.MustParseExpr("a % 2"),
+			[]expr.AggregatorBuilder{&functions.Count{Wildcard: true}},
+			nil,
+			nil,
+			false,
+		},
 		{
 			"no aggregator",
 			parser.MustParseExpr("a % 2"),
@@ -117,6 +137,7 @@ func TestAggregate(t *testing.T) {
 		require.Equal(t, `docs.GroupAggregate(a % 2, a(), b())`, docs.GroupAggregate(parser.MustParseExpr("a % 2"), makeAggregatorBuilders("a()", "b()")...).String())
 		require.Equal(t, `docs.GroupAggregate(NULL, a(), b())`, docs.GroupAggregate(nil, makeAggregatorBuilders("a()", "b()")...).String())
 		require.Equal(t, `docs.GroupAggregate(a % 2)`, docs.GroupAggregate(parser.MustParseExpr("a % 2")).String())
+		require.Equal(t, `docs.GroupAggregate(a % 3, COUNT(*), SUM(a))`, docs.GroupAggregate(parser.MustParseExpr("a % 3"), &functions.Count{Wildcard: true}, &functions.Sum{Expr: parser.MustParseExpr("a")}).String())
 	})
 }
 
@@ -16,6 +16,9 @@ import (
 type ProjectOperator struct {
 	stream.BaseOperator
 	Exprs []expr.Expr
+	// If true, projecting a path that doesn't exist
+	// in the document returns an error instead of null.
+	Strict bool
 }
 
 // Project creates a ProjectOperator.
@@ -23,6 +26,12 @@ func Project(exprs ...expr.Expr) *ProjectOperator {
 	return &ProjectOperator{Exprs: exprs}
 }
 
+// StrictProject creates a ProjectOperator that returns an error
+// if any of the projected paths is missing from the document.
+func StrictProject(exprs ...expr.Expr) *ProjectOperator {
+	return &ProjectOperator{Exprs: exprs, Strict: true}
+}
+
 // Iterate implements the Operator interface.
 func (op *ProjectOperator) Iterate(in *environment.Environment, f func(out *environment.Environment) error) error {
 	var mask MaskDocument
@@ -31,6 +40,7 @@ func (op *ProjectOperator) Iterate(in *environment.Environment, f func(out *envi
 	if op.Prev == nil {
 		mask.Env = in
 		mask.Exprs = op.Exprs
+		mask.Strict = op.Strict
 		newEnv.SetDocument(&mask)
 		newEnv.SetOuter(in)
 		return f(&newEnv)
@@ -39,6 +49,7 @@ func (op *ProjectOperator) Iterate(in *environment.Environment, f func(out *envi
 	return op.Prev.Iterate(in, func(env *environment.Environment) error {
 		mask.Env = env
 		mask.Exprs = op.Exprs
+		mask.Strict = op.Strict
 		newEnv.SetDocument(&mask)
 		newEnv.SetOuter(env)
 		return f(&newEnv)
@@ -48,7 +59,11 @@ func (op *ProjectOperator) Iterate(in *environment.Environment, f func(out *envi
 func (op *ProjectOperator) String() string {
 	var b strings.Builder
 
-	b.WriteString("docs.Project(")
+	if op.Strict {
+		b.WriteString("docs.StrictProject(")
+	} else {
+		b.WriteString("docs.Project(")
+	}
 	for i, e := range op.Exprs {
 		b.WriteString(e.(fmt.Stringer).String())
 		if i+1 < len(op.Exprs) {
@@ -60,8 +75,9 @@ func (op *ProjectOperator) String() string {
 }
 
 type MaskDocument struct {
-	Env   *environment.Environment
-	Exprs []expr.Expr
+	Env    *environment.Environment
+	Exprs  []expr.Expr
+	Strict bool
 }
 
 func (d *MaskDocument) GetByField(field string) (v types.Value, err error) {
@@ -80,11 +96,11 @@ func (d *MaskDocument) GetByField(field string) (v types.Value, err error) {
 		}
 
 		if ne, ok := e.(*expr.NamedExpr); ok && ne.Name() == field {
-			return e.Eval(d.Env)
+			return d.eval(e)
 		}
 
 		if e.(fmt.Stringer).String() == field {
-			return e.Eval(d.Env)
+			return d.eval(e)
 		}
 	}
 
@@ -115,7 +131,7 @@ func (d *MaskDocument) Iterate(fn func(field string, value types.Value) error) e
 			field = e.(fmt.Stringer).String()
 		}
 
-		v, err := e.Eval(d.Env)
+		v, err := d.eval(e)
 		if err != nil {
 			return err
 		}
@@ -129,6 +145,28 @@ func (d *MaskDocument) Iterate(fn func(field string, value types.Value) error) e
 	return nil
 }
 
+// eval evaluates e. In strict mode, it returns an error if e
+// is a path that doesn't exist in the current document.
+func (d *MaskDocument) eval(e expr.Expr) (types.Value, error) {
+	if d.Strict {
+		pe := e
+		if ne, ok := e.(*expr.NamedExpr); ok {
+			pe = ne.Expr
+		}
+
+		if p, ok := pe.(expr.Path); ok {
+			if doc, ok := d.Env.GetDocument(); ok {
+				_, err := document.Path(p).GetValueFromDocument(doc)
+				if errors.Is(err, types.ErrFieldNotFound) {
+					return nil, errors.Errorf("field %q not found", p)
+				}
+			}
+		}
+	}
+
+	return e.Eval(d.Env)
+}
+
 func (d *MaskDocument) String() string {
 	b, _ := types.NewDocumentValue(d).MarshalText()
 	return string(b)
@@ -44,6 +44,20 @@ func TestProject(t *testing.T) {
 			`{"a":1,"b":[true],"a":1,"b":[true],"10":10}`,
 			false,
 		},
+		{
+			"Fields",
+			[]expr.Expr{parser.MustParseExpr("c"), parser.MustParseExpr("a")},
+			testutil.MakeDocument(t, `{"a":1,"b":[true],"c":"foo"}`),
+			`{"c":"foo","a":1}`,
+			false,
+		},
+		{
+			"Expression",
+			[]expr.Expr{parser.MustParseExpr("a"), parser.MustParseExpr("a + 1")},
+			testutil.MakeDocument(t, `{"a":1,"b":[true]}`),
+			`{"a":1,"a + 1":2}`,
+			false,
+		},
 		{
 			"Named",
 			[]expr.Expr{&expr.NamedExpr{Expr: parser.MustParseExpr("10"), ExprName: "foo"}},
@@ -92,6 +106,34 @@ func TestProject(t *testing.T) {
 		).String())
 	})
 
+	t.Run("Strict", func(t *testing.T) {
+		var inEnv environment.Environment
+		inEnv.SetDocument(testutil.MakeDocument(t, `{"a":1,"b":null}`))
+
+		err := docs.StrictProject(testutil.ParseNamedExpr(t, "a"), testutil.ParseNamedExpr(t, "b")).Iterate(&inEnv, func(out *environment.Environment) error {
+			d, ok := out.GetDocument()
+			require.True(t, ok)
+			enc, err := document.MarshalJSON(d)
+			assert.NoError(t, err)
+			require.JSONEq(t, `{"a": 1, "b": null}`, string(enc))
+			return nil
+		})
+		assert.NoError(t, err)
+
+		err = docs.StrictProject(testutil.ParseNamedExpr(t, "a"), testutil.ParseNamedExpr(t, "z")).Iterate(&inEnv, func(out *environment.Environment) error {
+			d, ok := out.GetDocument()
+			require.True(t, ok)
+			_, err := document.MarshalJSON(d)
+			return err
+		})
+		require.EqualError(t, err, `field "z" not found`)
+
+		require.Equal(t, "docs.StrictProject(a, z)", docs.StrictProject(
+			testutil.ParseNamedExpr(t, "a"),
+			testutil.ParseNamedExpr(t, "z"),
+		).String())
+	})
+
 	t.Run("No input", func(t *testing.T) {
 		docs.Project(parser.MustParseExpr("1 + 1")).Iterate(new(environment.Environment), func(out *environment.Environment) error {
 			d, ok := out.GetDocument()
@@ -39,14 +39,30 @@ func (op *TakeOperator) Iterate(in *environment.Environment, f func(out *environ
 	}
 
 	n := types.As[int64](v)
+	// a negative limit means no limit
+	if n < 0 {
+		return op.Prev.Iterate(in, f)
+	}
+
 	var count int64
 	return op.Prev.Iterate(in, func(out *environment.Environment) error {
-		if count < n {
-			count++
-			return f(out)
+		if count >= n {
+			return errors.WithStack(stream.ErrStreamClosed)
+		}
+
+		count++
+		err := f(out)
+		if err != nil {
+			return err
+		}
+
+		// close the stream as soon as the last value has passed
+		// so that the previous operators don't read another one
+		if count >= n {
+			return errors.WithStack(stream.ErrStreamClosed)
 		}
 
-		return errors.WithStack(stream.ErrStreamClosed)
+		return nil
 	})
 }
 
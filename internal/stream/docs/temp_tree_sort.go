@@ -1,7 +1,10 @@
 package docs
 
 import (
+	"container/heap"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/database"
@@ -18,10 +21,28 @@ type TempTreeSortOperator struct {
 	stream.BaseOperator
 	Expr expr.Expr
 	Desc bool
+	// Additional sort keys, used to order the values
+	// for which the previous keys are equal.
+	ThenBy []SortKey
+}
+
+// A SortKey is an expression used to sort values, and its direction.
+type SortKey struct {
+	Expr expr.Expr
+	Desc bool
+}
+
+func (k SortKey) String() string {
+	if k.Desc {
+		return fmt.Sprintf("%s DESC", k.Expr)
+	}
+
+	return k.Expr.String()
 }
 
 // TempTreeSort consumes every value of the stream, sorts them by the given expr and outputs them in order.
-// It creates a temporary index and uses it to sort the stream.
+// Values are sorted in memory, unless they exceed the sort buffer size of the database,
+// in which case they are sorted in runs written to temporary trees and merged on output.
 func TempTreeSort(e expr.Expr) *TempTreeSortOperator {
 	return &TempTreeSortOperator{Expr: e}
 }
@@ -31,37 +52,73 @@ func TempTreeSortReverse(e expr.Expr) *TempTreeSortOperator {
 	return &TempTreeSortOperator{Expr: e, Desc: true}
 }
 
+// TempTreeSortBy consumes every value of the stream, sorts them by the given keys and outputs them in order.
+// Values are sorted by the first key, then values for which the first key is equal are sorted by the second key, and so on.
+func TempTreeSortBy(keys ...SortKey) *TempTreeSortOperator {
+	op := TempTreeSortOperator{Expr: keys[0].Expr, Desc: keys[0].Desc}
+	if len(keys) > 1 {
+		op.ThenBy = keys[1:]
+	}
+
+	return &op
+}
+
+// keys returns all the sort keys of the operator.
+func (op *TempTreeSortOperator) keys() []SortKey {
+	return append([]SortKey{{Expr: op.Expr, Desc: op.Desc}}, op.ThenBy...)
+}
+
 func (op *TempTreeSortOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
 	db := in.GetDB()
 
+	keys := op.keys()
+
+	// the tree is iterated in the direction of the first key,
+	// the other keys are encoded in the opposite order if
+	// their direction differs from the first one.
+	var order tree.SortOrder
+	for i := range op.ThenBy {
+		if op.ThenBy[i].Desc != op.Desc {
+			order = order.SetDesc(i + 1)
+		}
+	}
+
 	catalog := in.GetTx().Catalog
-	tns := catalog.GetFreeTransientNamespace()
-	tr, cleanup, err := tree.NewTransient(db.Store.NewTransientSession(), tns, 0)
-	if err != nil {
-		return err
+
+	s := externalSorter{
+		db:      db,
+		catalog: catalog,
+		order:   order,
 	}
-	defer cleanup()
+	defer s.cleanup()
 
 	var counter int64
 
 	var buf []byte
-	err = op.Prev.Iterate(in, func(out *environment.Environment) error {
+	values := make([]types.Value, len(keys), len(keys)+3)
+	err := op.Prev.Iterate(in, func(out *environment.Environment) error {
 		buf = buf[:0]
-		// evaluate the sort expression
-		v, err := op.Expr.Eval(out)
-		if err != nil {
-			return err
-		}
+		values = values[:len(keys)]
 
-		if types.IsNull(v) {
-			// the expression might be pointing to the original document.
-			v, err = op.Expr.Eval(out.Outer)
+		// evaluate the sort expressions
+		for i, k := range keys {
+			v, err := k.Expr.Eval(out)
 			if err != nil {
-				// the only valid error here is a missing field.
-				if !errors.Is(err, types.ErrFieldNotFound) {
-					return err
+				return err
+			}
+
+			if types.IsNull(v) {
+				// the expression might be pointing to the original document.
+				v, err = k.Expr.Eval(out.Outer)
+				if err != nil {
+					// the only valid error here is a missing field.
+					if !errors.Is(err, types.ErrFieldNotFound) {
+						return err
+					}
 				}
 			}
+
+			values[i] = v
 		}
 
 		doc, ok := out.GetDocument()
@@ -81,6 +138,7 @@ func (op *TempTreeSortOperator) Iterate(in *environment.Environment, fn func(out
 				return err
 			}
 		} else {
+			var err error
 			buf, err = encoding.EncodeDocument(buf, doc)
 			if err != nil {
 				return err
@@ -93,11 +151,16 @@ func (op *TempTreeSortOperator) Iterate(in *environment.Environment, fn func(out
 			encKey = key.Encoded
 		}
 
-		tk := tree.NewKey(v, tableName, types.NewBlobValue(encKey), types.NewIntegerValue(counter))
+		// the key is encoded without namespace, which is only
+		// added if the document is written to disk.
+		tk, err := tree.NewKey(append(values, tableName, types.NewBlobValue(encKey), types.NewIntegerValue(counter))...).Encode(0, order)
+		if err != nil {
+			return err
+		}
 
 		counter++
 
-		return tr.Put(tk, buf)
+		return s.add(tk, buf)
 	})
 	if err != nil {
 		return err
@@ -106,18 +169,15 @@ func (op *TempTreeSortOperator) Iterate(in *environment.Environment, fn func(out
 	var newEnv environment.Environment
 	newEnv.SetOuter(in)
 
-	return tr.IterateOnRange(nil, op.Desc, func(k *tree.Key, data []byte) error {
-		kv, err := k.Decode()
-		if err != nil {
-			return err
-		}
+	return s.iterate(op.Desc, func(k []byte, data []byte) error {
+		kv := decodeSortKey(k)
 
-		tableName := kv[1]
+		tableName := kv[len(keys)]
 		if tableName.Type() != types.NullValue {
 			newEnv.Set(environment.TableKey, tableName)
 		}
 
-		docKey := kv[2]
+		docKey := kv[len(keys)+1]
 		if docKey.Type() != types.NullValue {
 			newEnv.SetKey(tree.NewEncodedKey(types.As[[]byte](docKey)))
 		}
@@ -138,9 +198,277 @@ func (op *TempTreeSortOperator) Iterate(in *environment.Environment, fn func(out
 }
 
 func (op *TempTreeSortOperator) String() string {
+	if len(op.ThenBy) > 0 {
+		var sb strings.Builder
+
+		sb.WriteString("docs.TempTreeSort(")
+		for i, k := range op.keys() {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(k.String())
+		}
+		sb.WriteString(")")
+
+		return sb.String()
+	}
+
 	if op.Desc {
 		return fmt.Sprintf("docs.TempTreeSortReverse(%s)", op.Expr)
 	}
 
 	return fmt.Sprintf("docs.TempTreeSort(%s)", op.Expr)
 }
+
+// decodeSortKey decodes a sort key encoded without namespace.
+func decodeSortKey(b []byte) []types.Value {
+	var values []types.Value
+
+	for len(b) > 0 {
+		v, n := encoding.DecodeValue(b, false /* intAsDouble */)
+		b = b[n:]
+
+		values = append(values, v)
+	}
+
+	return values
+}
+
+// A sortEntry is an encoded document associated with its sort key.
+type sortEntry struct {
+	key   []byte
+	value []byte
+}
+
+// An externalSorter sorts entries in memory until their size exceeds
+// the sort buffer size of the database. The entries are then written to
+// a temporary tree as a sorted run, and the sorter starts a new run.
+// Runs are merged with the entries left in memory when iterating.
+type externalSorter struct {
+	db      *database.Database
+	catalog *database.Catalog
+	order   tree.SortOrder
+
+	entries []sortEntry
+	size    int
+
+	runs     []*tree.Tree
+	cleanups []func() error
+}
+
+// add an entry to the sorter. The key must be encoded without namespace.
+func (s *externalSorter) add(key, value []byte) error {
+	s.entries = append(s.entries, sortEntry{
+		key:   key,
+		value: append([]byte(nil), value...),
+	})
+	s.size += len(key) + len(value)
+
+	if s.size < s.db.SortBufferSize {
+		return nil
+	}
+
+	return s.spill()
+}
+
+func (s *externalSorter) sort() {
+	sort.Slice(s.entries, func(i, j int) bool {
+		return encoding.Compare(s.entries[i].key, s.entries[j].key) < 0
+	})
+}
+
+// spill writes the entries kept in memory to disk as a sorted run.
+func (s *externalSorter) spill() error {
+	s.sort()
+
+	session := s.db.Store.NewTransientSession()
+	ns := s.catalog.GetFreeTransientNamespace()
+	tr, cleanup, err := tree.NewTransient(session, ns, s.order)
+	if err != nil {
+		return err
+	}
+	s.runs = append(s.runs, tr)
+	s.cleanups = append(s.cleanups, cleanup)
+
+	var buf []byte
+	prefix := encoding.EncodeUint(nil, uint64(ns))
+	for i := range s.entries {
+		buf = append(append(buf[:0], prefix...), s.entries[i].key...)
+		err = tr.Put(tree.NewEncodedKey(buf), s.entries[i].value)
+		if err != nil {
+			return err
+		}
+
+		s.entries[i] = sortEntry{}
+	}
+
+	s.entries = s.entries[:0]
+	s.size = 0
+
+	return session.Spill()
+}
+
+// iterate merges the runs written to disk and the entries kept in memory
+// and calls fn for each entry, in order.
+// The key passed to fn is encoded without namespace.
+func (s *externalSorter) iterate(reverse bool, fn func(key, value []byte) error) (err error) {
+	s.sort()
+
+	mem := memoryRunIterator{entries: s.entries, reverse: reverse}
+	if reverse {
+		mem.i = len(s.entries) - 1
+	}
+	iterators := []runIterator{&mem}
+	defer func() {
+		for _, it := range iterators {
+			if cerr := it.close(); err == nil {
+				err = cerr
+			}
+		}
+	}()
+
+	for _, tr := range s.runs {
+		it, err := tr.Iterator(reverse)
+		if err != nil {
+			return err
+		}
+
+		iterators = append(iterators, &treeRunIterator{it: it})
+	}
+
+	h := mergeHeap{reverse: reverse}
+	for _, it := range iterators {
+		if it.valid() {
+			h.iterators = append(h.iterators, it)
+		}
+	}
+
+	heap.Init(&h)
+	for h.Len() > 0 {
+		it := h.iterators[0]
+
+		v, err := it.value()
+		if err != nil {
+			return err
+		}
+
+		err = fn(it.key(), v)
+		if err != nil {
+			return err
+		}
+
+		it.next()
+		if it.valid() {
+			heap.Fix(&h, 0)
+		} else {
+			heap.Pop(&h)
+		}
+	}
+
+	return nil
+}
+
+func (s *externalSorter) cleanup() {
+	for _, cleanup := range s.cleanups {
+		_ = cleanup()
+	}
+}
+
+// A runIterator iterates over the entries of a sorted run.
+type runIterator interface {
+	valid() bool
+	next()
+	// key returns the key of the current entry, without namespace.
+	key() []byte
+	value() ([]byte, error)
+	close() error
+}
+
+type memoryRunIterator struct {
+	entries []sortEntry
+	i       int
+	reverse bool
+}
+
+func (it *memoryRunIterator) valid() bool {
+	return it.i >= 0 && it.i < len(it.entries)
+}
+
+func (it *memoryRunIterator) next() {
+	if !it.reverse {
+		it.i++
+	} else {
+		it.i--
+	}
+}
+
+func (it *memoryRunIterator) key() []byte {
+	return it.entries[it.i].key
+}
+
+func (it *memoryRunIterator) value() ([]byte, error) {
+	return it.entries[it.i].value, nil
+}
+
+func (it *memoryRunIterator) close() error {
+	return nil
+}
+
+type treeRunIterator struct {
+	it *tree.Iterator
+}
+
+func (it *treeRunIterator) valid() bool {
+	return it.it.Valid()
+}
+
+func (it *treeRunIterator) next() {
+	it.it.Next()
+}
+
+func (it *treeRunIterator) key() []byte {
+	k := it.it.Key().Encoded
+	// skip the namespace
+	return k[encoding.Skip(k):]
+}
+
+func (it *treeRunIterator) value() ([]byte, error) {
+	return it.it.Value()
+}
+
+func (it *treeRunIterator) close() error {
+	return it.it.Close()
+}
+
+// mergeHeap orders run iterators by their current key,
+// implementing a k-way merge of the runs.
+type mergeHeap struct {
+	iterators []runIterator
+	reverse   bool
+}
+
+func (h *mergeHeap) Len() int { return len(h.iterators) }
+
+func (h *mergeHeap) Less(i, j int) bool {
+	cmp := encoding.Compare(h.iterators[i].key(), h.iterators[j].key())
+	if h.reverse {
+		return cmp > 0
+	}
+
+	return cmp < 0
+}
+
+func (h *mergeHeap) Swap(i, j int) {
+	h.iterators[i], h.iterators[j] = h.iterators[j], h.iterators[i]
+}
+
+func (h *mergeHeap) Push(x any) {
+	h.iterators = append(h.iterators, x.(runIterator))
+}
+
+func (h *mergeHeap) Pop() any {
+	n := len(h.iterators)
+	it := h.iterators[n-1]
+	h.iterators = h.iterators[:n-1]
+	return it
+}
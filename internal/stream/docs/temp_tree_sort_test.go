@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/database/catalogstore"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/expr"
 	"github.com/genjidb/genji/internal/sql/parser"
@@ -104,7 +106,138 @@ func TestTempTreeSort(t *testing.T) {
 		})
 	}
 
+	t.Run("Spill", func(t *testing.T) {
+		pdb := testutil.NewMemPebble(t)
+		db, err := database.New(pdb, &database.Options{
+			CatalogLoader: catalogstore.LoadCatalog,
+			// force the documents to be sorted in multiple runs
+			SortBufferSize: 1 << 8,
+		})
+		assert.NoError(t, err)
+		defer db.Close()
+
+		tx, err := db.Begin(true)
+		assert.NoError(t, err)
+		defer tx.Rollback()
+
+		testutil.MustExec(t, db, tx, "CREATE TABLE test(a int, b text)")
+		for i := 0; i < 100; i++ {
+			testutil.MustExec(t, db, tx, "INSERT INTO test (a, b) VALUES (?, 'foo')", environment.Param{Value: (i * 37) % 100})
+		}
+		assert.NoError(t, tx.Commit())
+
+		countKeys := func() int {
+			it, err := pdb.NewIter(nil)
+			assert.NoError(t, err)
+			defer it.Close()
+
+			var n int
+			for it.First(); it.Valid(); it.Next() {
+				n++
+			}
+			return n
+		}
+		before := countKeys()
+
+		for _, desc := range []bool{false, true} {
+			tx, err = db.Begin(false)
+			assert.NoError(t, err)
+			defer tx.Rollback()
+
+			var env environment.Environment
+			env.DB = db
+			env.Tx = tx
+
+			op := docs.TempTreeSort(parser.MustParseExpr("a"))
+			op.Desc = desc
+			s := stream.New(table.Scan("test")).Pipe(op)
+
+			var got []int64
+			err = s.Iterate(&env, func(env *environment.Environment) error {
+				if len(got) == 0 {
+					// the runs must have been written to disk
+					require.Greater(t, countKeys(), before+50)
+				}
+
+				d, ok := env.GetDocument()
+				require.True(t, ok)
+
+				v, err := d.GetByField("a")
+				assert.NoError(t, err)
+				got = append(got, types.As[int64](v))
+				return nil
+			})
+			assert.NoError(t, err)
+			require.Len(t, got, 100)
+			for i := range got {
+				want := int64(i)
+				if desc {
+					want = int64(99 - i)
+				}
+				require.Equal(t, want, got[i])
+			}
+
+			// the spilled data must have been removed
+			require.Equal(t, before, countKeys())
+		}
+	})
+
+	t.Run("Multiple keys", func(t *testing.T) {
+		tests := []struct {
+			name string
+			keys []docs.SortKey
+			want []string
+		}{
+			{"ASC, ASC", []docs.SortKey{{Expr: parser.MustParseExpr("a")}, {Expr: parser.MustParseExpr("b")}},
+				[]string{`{"a": 1, "b": 1}`, `{"a": 1, "b": 2}`, `{"a": 2, "b": 1}`, `{"a": 2, "b": 2}`}},
+			{"ASC, DESC", []docs.SortKey{{Expr: parser.MustParseExpr("a")}, {Expr: parser.MustParseExpr("b"), Desc: true}},
+				[]string{`{"a": 1, "b": 2}`, `{"a": 1, "b": 1}`, `{"a": 2, "b": 2}`, `{"a": 2, "b": 1}`}},
+			{"DESC, ASC", []docs.SortKey{{Expr: parser.MustParseExpr("a"), Desc: true}, {Expr: parser.MustParseExpr("b")}},
+				[]string{`{"a": 2, "b": 1}`, `{"a": 2, "b": 2}`, `{"a": 1, "b": 1}`, `{"a": 1, "b": 2}`}},
+			{"DESC, DESC", []docs.SortKey{{Expr: parser.MustParseExpr("a"), Desc: true}, {Expr: parser.MustParseExpr("b"), Desc: true}},
+				[]string{`{"a": 2, "b": 2}`, `{"a": 2, "b": 1}`, `{"a": 1, "b": 2}`, `{"a": 1, "b": 1}`}},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				db, tx, cleanup := testutil.NewTestTx(t)
+				defer cleanup()
+
+				testutil.MustExec(t, db, tx, `
+					CREATE TABLE test(a int, b int);
+					INSERT INTO test (a, b) VALUES (2, 1), (1, 2), (2, 2), (1, 1);
+				`)
+
+				var env environment.Environment
+				env.DB = db
+				env.Tx = tx
+
+				s := stream.New(table.Scan("test")).Pipe(docs.TempTreeSortBy(test.keys...))
+
+				var got []types.Document
+				err := s.Iterate(&env, func(env *environment.Environment) error {
+					d, ok := env.GetDocument()
+					require.True(t, ok)
+
+					fb := document.NewFieldBuffer()
+					fb.Copy(d)
+					got = append(got, fb)
+					return nil
+				})
+				assert.NoError(t, err)
+				require.Equal(t, len(test.want), len(got))
+				for i := range got {
+					testutil.RequireDocJSONEq(t, got[i], test.want[i])
+				}
+			})
+		}
+	})
+
 	t.Run("String", func(t *testing.T) {
 		require.Equal(t, `docs.TempTreeSort(a)`, docs.TempTreeSort(parser.MustParseExpr("a")).String())
+		require.Equal(t, `docs.TempTreeSort(a DESC, b)`, docs.TempTreeSortBy(
+			docs.SortKey{Expr: parser.MustParseExpr("a"), Desc: true},
+			docs.SortKey{Expr: parser.MustParseExpr("b")},
+		).String())
 	})
 }
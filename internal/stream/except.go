@@ -0,0 +1,98 @@
+package stream
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/kv"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+)
+
+// ExceptOperator is an operator that returns the documents of its first stream
+// that are not returned by any of the other streams.
+type ExceptOperator struct {
+	BaseOperator
+	Streams []*Stream
+}
+
+// Except returns a new ExceptOperator.
+func Except(s ...*Stream) *ExceptOperator {
+	return &ExceptOperator{Streams: s}
+}
+
+// Iterate iterates over all the streams and returns the documents of the first
+// stream that are not returned by the others, without duplicates.
+func (it *ExceptOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) (err error) {
+	if len(it.Streams) == 0 {
+		return nil
+	}
+
+	db := in.GetDB()
+	tns := in.GetTx().Catalog.GetFreeTransientNamespace()
+	temp, cleanup, err := tree.NewTransient(db.Store.NewTransientSession(), tns, 0)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		e := cleanup()
+		if err == nil {
+			err = e
+		}
+	}()
+
+	// store the documents of the first stream and remove
+	// those returned by the other streams
+	for i, s := range it.Streams {
+		err := s.Iterate(in, func(out *environment.Environment) error {
+			doc, ok := out.GetDocument()
+			if !ok {
+				return errors.New("missing document")
+			}
+
+			key := tree.NewKey(types.NewDocumentValue(doc))
+			if i == 0 {
+				return temp.Put(key, nil)
+			}
+
+			err := temp.Delete(key)
+			if errors.Is(err, kv.ErrKeyNotFound) {
+				return nil
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	var newEnv environment.Environment
+	newEnv.SetOuter(in)
+
+	// iterate over the temporary tree
+	return temp.IterateOnRange(nil, false, func(key *tree.Key, _ []byte) error {
+		values, err := key.Decode()
+		if err != nil {
+			return err
+		}
+
+		newEnv.SetDocument(types.As[types.Document](values[0]))
+		return fn(&newEnv)
+	})
+}
+
+func (it *ExceptOperator) String() string {
+	var s strings.Builder
+
+	s.WriteString("except(")
+	for i, st := range it.Streams {
+		if i > 0 {
+			s.WriteString(", ")
+		}
+		s.WriteString(st.String())
+	}
+	s.WriteRune(')')
+
+	return s.String()
+}
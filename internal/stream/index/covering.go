@@ -0,0 +1,115 @@
+package index
+
+import (
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+)
+
+// CoveredFields returns the top-level fields of the documents of the table
+// whose values can be read from the entries of the index: the indexed fields
+// and the fields of the primary key.
+// Only fields with a scalar type constraint are returned, as the values of the
+// other fields may not be decoded to the value stored in the documents.
+// Indexed fields must also be NOT NULL, as missing fields are indexed as null.
+func CoveredFields(tb *database.TableInfo, info *database.IndexInfo) []string {
+	var r coveredFieldsReader
+	r.init(tb, info)
+
+	fields := make([]string, 0, len(r.fromIndex)+len(r.fromKey))
+	for _, f := range r.fromIndex {
+		fields = append(fields, f.name)
+	}
+	for _, f := range r.fromKey {
+		fields = append(fields, f.name)
+	}
+
+	return fields
+}
+
+// coveredField is a covered field and the position of its value,
+// either in the indexed values or in the primary key.
+type coveredField struct {
+	name string
+	pos  int
+}
+
+// coveredFieldsReader builds documents out of the entries of an index.
+type coveredFieldsReader struct {
+	fromIndex []coveredField
+	fromKey   []coveredField
+}
+
+func newCoveredFieldsReader(tb *database.TableInfo, info *database.IndexInfo) *coveredFieldsReader {
+	var r coveredFieldsReader
+	r.init(tb, info)
+	return &r
+}
+
+func (r *coveredFieldsReader) init(tb *database.TableInfo, info *database.IndexInfo) {
+	seen := make(map[string]bool)
+
+	for i, p := range info.Paths {
+		if len(p) != 1 || seen[p[0].FieldName] {
+			continue
+		}
+
+		fc := tb.FieldConstraints.GetFieldConstraintForPath(p)
+		if fc == nil || !fc.IsNotNull || !isCoveredType(fc.Type) {
+			continue
+		}
+
+		seen[p[0].FieldName] = true
+		r.fromIndex = append(r.fromIndex, coveredField{name: p[0].FieldName, pos: i})
+	}
+
+	pk := tb.GetPrimaryKey()
+	if pk == nil {
+		return
+	}
+
+	for i, p := range pk.Paths {
+		if len(p) != 1 || seen[p[0].FieldName] || !isCoveredType(pk.Types[i]) {
+			continue
+		}
+
+		seen[p[0].FieldName] = true
+		r.fromKey = append(r.fromKey, coveredField{name: p[0].FieldName, pos: i})
+	}
+}
+
+// read resets fb and fills it with the covered fields of the given index entry.
+func (r *coveredFieldsReader) read(fb *document.FieldBuffer, values []types.Value, key *tree.Key) error {
+	fb.Reset()
+
+	for _, f := range r.fromIndex {
+		fb.Add(f.name, values[f.pos])
+	}
+
+	if len(r.fromKey) == 0 {
+		return nil
+	}
+
+	pk, err := key.Decode()
+	if err != nil {
+		return err
+	}
+
+	for _, f := range r.fromKey {
+		fb.Add(f.name, pk[f.pos])
+	}
+
+	return nil
+}
+
+// isCoveredType returns whether values of the given type
+// are decoded from the index as they were indexed.
+func isCoveredType(tp types.ValueType) bool {
+	switch tp {
+	case types.BooleanValue, types.IntegerValue, types.DoubleValue, types.TextValue, types.BlobValue:
+		return true
+	}
+
+	return false
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/stream"
-	"github.com/genjidb/genji/types"
 )
 
 // DeleteOperator reads the input stream and deletes the document from the specified index.
@@ -56,13 +55,9 @@ func (op *DeleteOperator) Iterate(in *environment.Environment, fn func(out *envi
 			return err
 		}
 
-		vs := make([]types.Value, 0, len(info.Paths))
-		for _, path := range info.Paths {
-			v, err := path.GetValueFromDocument(old)
-			if err != nil {
-				v = types.NewNullValue()
-			}
-			vs = append(vs, v)
+		vs, err := info.Values(tx, old)
+		if err != nil {
+			return err
 		}
 
 		err = idx.Delete(vs, key.Encoded)
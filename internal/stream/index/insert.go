@@ -6,7 +6,6 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/stream"
-	"github.com/genjidb/genji/types"
 )
 
 // InsertOperator reads the input stream and indexes each document.
@@ -46,13 +45,9 @@ func (op *InsertOperator) Iterate(in *environment.Environment, fn func(out *envi
 			return errors.New("missing document key")
 		}
 
-		vs := make([]types.Value, 0, len(info.Paths))
-		for _, path := range info.Paths {
-			v, err := path.GetValueFromDocument(d)
-			if err != nil {
-				v = types.NewNullValue()
-			}
-			vs = append(vs, v)
+		vs, err := info.Values(tx, d)
+		if err != nil {
+			return err
 		}
 
 		err = idx.Set(vs, key.Encoded)
@@ -3,9 +3,11 @@ package index
 import (
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/stream"
@@ -24,6 +26,11 @@ type ScanOperator struct {
 	Ranges stream.Ranges
 	// Reverse indicates the direction used to traverse the index.
 	Reverse bool
+	// If true, documents are built from the entries of the index
+	// and only contain the fields returned by CoveredFields.
+	// It is set by the planner when the rest of the stream
+	// doesn't need the other fields of the documents.
+	Covering bool
 }
 
 // Scan creates an iterator that iterates over each document of the given table.
@@ -65,36 +72,76 @@ func (it *ScanOperator) Iterate(in *environment.Environment, fn func(out *enviro
 	}
 	newEnv.SetDocument(&ptr)
 
-	if len(it.Ranges) == 0 {
-		return index.IterateOnRange(nil, it.Reverse, func(key *tree.Key) error {
+	// expired documents are skipped until they are deleted
+	now := time.Now()
+	ctx := in.GetContext()
+
+	iterate := func(r *tree.Range) error {
+		return index.IterateOnRange(r, it.Reverse, func(key *tree.Key) error {
+			// the deadline is checked for every entry read,
+			// even if the rest of the stream filters it out
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
 			ptr.key = key
 			ptr.Doc = nil
+
+			if table.Info.TTLField != "" {
+				expired, err := table.Info.IsExpired(&ptr, now)
+				if err != nil || expired {
+					return err
+				}
+			}
+
 			newEnv.SetKey(key)
 
 			return fn(&newEnv)
 		})
 	}
 
+	// values encoded by a codec can't be read from the index
+	if it.Covering && len(table.Info.FieldCodecs) == 0 {
+		var fb document.FieldBuffer
+		newEnv.SetDocument(&fb)
+
+		fields := newCoveredFieldsReader(table.Info, info)
+		iterate = func(r *tree.Range) error {
+			return index.IterateValuesOnRange(r, it.Reverse, func(values []types.Value, key *tree.Key) error {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+
+				err := fields.read(&fb, values, key)
+				if err != nil {
+					return err
+				}
+				newEnv.SetKey(key)
+
+				return fn(&newEnv)
+			})
+		}
+	}
+
+	if len(it.Ranges) == 0 {
+		return iterate(nil)
+	}
+
 	ranges, err := it.Ranges.Eval(in)
 	if err != nil || len(ranges) != len(it.Ranges) {
 		return err
 	}
 
 	for _, rng := range ranges {
-		r, err := rng.ToTreeRange(&table.Info.FieldConstraints, info.Paths)
+		r, err := index.ToTreeRange(rng, &table.Info.FieldConstraints, info.Paths)
 		if err != nil {
 			return err
 		}
 
-		err = index.IterateOnRange(r, it.Reverse, func(key *tree.Key) error {
-			ptr.key = key
-			ptr.Doc = nil
-			newEnv.SetKey(key)
-
-			return fn(&newEnv)
-		})
+		err = iterate(r)
+		// the other ranges are not read once the stream is closed
 		if errors.Is(err, stream.ErrStreamClosed) {
-			err = nil
+			return nil
 		}
 		if err != nil {
 			return err
@@ -107,7 +154,11 @@ func (it *ScanOperator) Iterate(in *environment.Environment, fn func(out *enviro
 func (it *ScanOperator) String() string {
 	var s strings.Builder
 
-	s.WriteString("index.Scan")
+	s.WriteString("index.")
+	if it.Covering {
+		s.WriteString("Covering")
+	}
+	s.WriteString("Scan")
 	if it.Reverse {
 		s.WriteString("Reverse")
 	}
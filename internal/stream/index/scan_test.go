@@ -133,9 +133,10 @@ func testIndexScan(t *testing.T, getOp func(db *database.Database, tx *database.
 			false, false,
 		},
 		{
+			// (a, b) <= (1.1, 2) -> (a, b) <= (1, 2)
 			"max:[1.1, 2]", "a, b",
 			testutil.MakeDocuments(t, `{"a": 1, "b": 2}`, `{"a": 2, "b": 2}`),
-			testutil.MakeDocuments(t),
+			testutil.MakeDocuments(t, `{"a": 1, "b": 2}`),
 			stream.Ranges{
 				stream.Range{Max: testutil.ExprList(t, `[1.1, 2]`), Paths: testutil.ParseDocumentPaths(t, "a", "b")},
 			},
@@ -0,0 +1,48 @@
+package index
+
+import (
+	"fmt"
+
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/stream"
+)
+
+// TruncateOperator deletes all the entries of an index
+// before iterating over the previous operator, if any.
+type TruncateOperator struct {
+	stream.BaseOperator
+
+	IndexName string
+}
+
+// Truncate creates an operator that deletes all the entries of the given index
+// when the stream is iterated.
+func Truncate(indexName string) *TruncateOperator {
+	return &TruncateOperator{
+		IndexName: indexName,
+	}
+}
+
+func (op *TruncateOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	tx := in.GetTx()
+
+	idx, err := tx.Catalog.GetIndex(tx, op.IndexName)
+	if err != nil {
+		return err
+	}
+
+	err = idx.Truncate()
+	if err != nil {
+		return err
+	}
+
+	if op.Prev == nil {
+		return nil
+	}
+
+	return op.Prev.Iterate(in, fn)
+}
+
+func (op *TruncateOperator) String() string {
+	return fmt.Sprintf("index.Truncate(%q)", op.IndexName)
+}
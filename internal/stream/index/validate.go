@@ -46,22 +46,20 @@ func (op *ValidateOperator) Iterate(in *environment.Environment, fn func(out *en
 			return errors.New("missing document")
 		}
 
-		vs := make([]types.Value, 0, len(info.Paths))
+		vs, err := info.Values(tx, doc)
+		if err != nil {
+			return err
+		}
 
 		// if the indexes values contain NULL somewhere,
 		// we don't check for unicity.
 		// cf: https://sqlite.org/lang_createindex.html#unique_indexes
 		var hasNull bool
-		for _, path := range info.Paths {
-			v, err := path.GetValueFromDocument(doc)
-			if err != nil {
-				hasNull = true
-				v = types.NewNullValue()
-			} else if v.Type() == types.NullValue {
+		for _, v := range vs {
+			if v.Type() == types.NullValue {
 				hasNull = true
+				break
 			}
-
-			vs = append(vs, v)
 		}
 
 		if !hasNull {
@@ -0,0 +1,111 @@
+package stream
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/internal/encoding"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/kv"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+)
+
+// IntersectOperator is an operator that returns the documents
+// returned by all of its streams.
+type IntersectOperator struct {
+	BaseOperator
+	Streams []*Stream
+}
+
+// Intersect returns a new IntersectOperator.
+func Intersect(s ...*Stream) *IntersectOperator {
+	return &IntersectOperator{Streams: s}
+}
+
+// Iterate iterates over all the streams and returns the documents
+// they have in common, without duplicates.
+func (it *IntersectOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) (err error) {
+	if len(it.Streams) == 0 {
+		return nil
+	}
+
+	db := in.GetDB()
+	tns := in.GetTx().Catalog.GetFreeTransientNamespace()
+	temp, cleanup, err := tree.NewTransient(db.Store.NewTransientSession(), tns, 0)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		e := cleanup()
+		if err == nil {
+			err = e
+		}
+	}()
+
+	// each document is stored with the position of the last stream
+	// that returned it, if all the previous streams returned it as well
+	for i, s := range it.Streams {
+		err := s.Iterate(in, func(out *environment.Environment) error {
+			doc, ok := out.GetDocument()
+			if !ok {
+				return errors.New("missing document")
+			}
+
+			key := tree.NewKey(types.NewDocumentValue(doc))
+			if i > 0 {
+				v, err := temp.Get(key)
+				if errors.Is(err, kv.ErrKeyNotFound) {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+
+				if n, _ := encoding.DecodeInt(v); n != int64(i-1) {
+					return nil
+				}
+			}
+
+			return temp.Put(key, encoding.EncodeInt(nil, int64(i)))
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	var newEnv environment.Environment
+	newEnv.SetOuter(in)
+
+	last := int64(len(it.Streams) - 1)
+
+	// iterate over the temporary tree
+	return temp.IterateOnRange(nil, false, func(key *tree.Key, v []byte) error {
+		if n, _ := encoding.DecodeInt(v); n != last {
+			return nil
+		}
+
+		values, err := key.Decode()
+		if err != nil {
+			return err
+		}
+
+		newEnv.SetDocument(types.As[types.Document](values[0]))
+		return fn(&newEnv)
+	})
+}
+
+func (it *IntersectOperator) String() string {
+	var s strings.Builder
+
+	s.WriteString("intersect(")
+	for i, st := range it.Streams {
+		if i > 0 {
+			s.WriteString(", ")
+		}
+		s.WriteString(st.String())
+	}
+	s.WriteRune(')')
+
+	return s.String()
+}
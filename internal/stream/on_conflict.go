@@ -2,18 +2,33 @@ package stream
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/types"
 )
 
+// ExcludedPath is the path of the variable holding the conflicting
+// document in the environment of the OnConflict stream.
+var ExcludedPath = document.NewPath("excluded")
+
 // OnConflictOperator handles any conflicts that occur during the iteration.
 type OnConflictOperator struct {
 	BaseOperator
 
 	OnConflict *Stream
+	// If set, only conflicts on a constraint
+	// defined on these paths, in the same order, are handled.
+	Paths []document.Path
 }
 
+// OnConflict creates an operator that runs the given stream when
+// the next operators return a constraint violation error.
+// The stream is evaluated with the key of the existing document,
+// and the conflicting document is available under the "excluded" variable.
+// If onConflict is nil, conflicting documents are ignored.
 func OnConflict(onConflict *Stream) *OnConflictOperator {
 	return &OnConflictOperator{
 		OnConflict: onConflict,
@@ -26,13 +41,16 @@ func (op *OnConflictOperator) Iterate(in *environment.Environment, fn func(out *
 	return op.Prev.Iterate(in, func(out *environment.Environment) error {
 		err := fn(out)
 		if err != nil {
-			if cerr, ok := err.(*database.ConstraintViolationError); ok {
+			if cerr, ok := err.(*database.ConstraintViolationError); ok && op.handles(cerr) {
 				if op.OnConflict == nil {
 					return nil
 				}
 
 				newEnv.SetOuter(out)
 				newEnv.SetKey(cerr.Key)
+				if d, ok := out.GetDocument(); ok {
+					newEnv.Set(ExcludedPath, types.NewDocumentValue(d))
+				}
 
 				err = op.OnConflict.Iterate(&newEnv, func(out *environment.Environment) error { return nil })
 			}
@@ -41,10 +59,29 @@ func (op *OnConflictOperator) Iterate(in *environment.Environment, fn func(out *
 	})
 }
 
+// handles returns whether the given error must trigger the OnConflict stream.
+func (op *OnConflictOperator) handles(cerr *database.ConstraintViolationError) bool {
+	if len(op.Paths) == 0 {
+		return true
+	}
+
+	return document.Paths(op.Paths).IsEqual(cerr.Paths)
+}
+
 func (op *OnConflictOperator) String() string {
+	var s strings.Builder
+
+	s.WriteString("stream.OnConflict(")
+	if len(op.Paths) > 0 {
+		fmt.Fprintf(&s, "(%s), ", document.Paths(op.Paths))
+	}
+
 	if op.OnConflict == nil {
-		return "stream.OnConflict(NULL)"
+		s.WriteString("NULL")
+	} else {
+		fmt.Fprintf(&s, "%s", op.OnConflict)
 	}
+	s.WriteString(")")
 
-	return fmt.Sprintf("stream.OnConflict(%s)", op.OnConflict)
+	return s.String()
 }
@@ -73,6 +73,35 @@ func TestFilter(t *testing.T) {
 		})
 	}
 
+	t.Run("Table", func(t *testing.T) {
+		db, tx, cleanup := testutil.NewTestTx(t)
+		defer cleanup()
+
+		testutil.MustExec(t, db, tx, "CREATE TABLE test(a int, b text)")
+		testutil.MustExec(t, db, tx, `INSERT INTO test (a, b) VALUES (1, 'foo'), (2, 'bar'), (3, 'baz'), (4, 'foo')`)
+
+		var env environment.Environment
+		env.DB = db
+		env.Tx = tx
+
+		s := stream.New(table.Scan("test")).Pipe(docs.Filter(parser.MustParseExpr("a > 1 AND b = 'foo'")))
+
+		var got []types.Document
+		err := s.Iterate(&env, func(out *environment.Environment) error {
+			d, ok := out.GetDocument()
+			require.True(t, ok)
+
+			fb := document.NewFieldBuffer()
+			err := fb.Copy(d)
+			require.NoError(t, err)
+			got = append(got, fb)
+			return nil
+		})
+		assert.NoError(t, err)
+		require.Len(t, got, 1)
+		testutil.RequireDocEqual(t, testutil.MakeDocument(t, `{"a": 4, "b": "foo"}`), got[0])
+	})
+
 	t.Run("String", func(t *testing.T) {
 		require.Equal(t, docs.Filter(parser.MustParseExpr("1")).String(), "docs.Filter(1)")
 	})
@@ -87,7 +116,7 @@ func TestTake(t *testing.T) {
 	}{
 		{5, 1, 1, false},
 		{5, 7, 5, false},
-		{5, -1, 0, false},
+		{5, -1, 5, false},
 	}
 
 	for _, test := range tests {
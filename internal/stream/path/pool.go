@@ -0,0 +1,27 @@
+package path
+
+import (
+	"sync"
+
+	"github.com/genjidb/genji/document"
+)
+
+// fieldBufferPool reuses the buffers operators of this package
+// use to build their output documents.
+// A buffer must only be put back in the pool once the operator
+// is done iterating, since it is passed to the rest of the stream
+// until then.
+var fieldBufferPool = sync.Pool{
+	New: func() any {
+		return document.NewFieldBuffer()
+	},
+}
+
+func getFieldBuffer() *document.FieldBuffer {
+	return fieldBufferPool.Get().(*document.FieldBuffer)
+}
+
+func putFieldBuffer(fb *document.FieldBuffer) {
+	fb.Reset()
+	fieldBufferPool.Put(fb)
+}
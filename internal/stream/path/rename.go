@@ -28,7 +28,9 @@ func PathsRename(fieldNames ...string) *RenameOperator {
 
 // Iterate implements the Operator interface.
 func (op *RenameOperator) Iterate(in *environment.Environment, f func(out *environment.Environment) error) error {
-	var fb document.FieldBuffer
+	fb := getFieldBuffer()
+	defer putFieldBuffer(fb)
+
 	var newEnv environment.Environment
 
 	return op.Prev.Iterate(in, func(out *environment.Environment) error {
@@ -68,7 +70,7 @@ func (op *RenameOperator) Iterate(in *environment.Environment, f func(out *envir
 		}
 
 		newEnv.SetOuter(out)
-		newEnv.SetDocument(&fb)
+		newEnv.SetDocument(fb)
 
 		return f(&newEnv)
 	})
@@ -77,3 +79,66 @@ func (op *RenameOperator) Iterate(in *environment.Environment, f func(out *envir
 func (op *RenameOperator) String() string {
 	return fmt.Sprintf("paths.Rename(%s)", strings.Join(op.FieldNames, ", "))
 }
+
+// A RenameFieldOperator renames a top-level field of the incoming documents.
+type RenameFieldOperator struct {
+	stream.BaseOperator
+	Field   string
+	NewName string
+}
+
+// RenameField renames a top-level field of the incoming documents, keeping its position.
+// Documents without the field are returned as is.
+func RenameField(field, newName string) *RenameFieldOperator {
+	return &RenameFieldOperator{
+		Field:   field,
+		NewName: newName,
+	}
+}
+
+// Iterate implements the Operator interface.
+func (op *RenameFieldOperator) Iterate(in *environment.Environment, f func(out *environment.Environment) error) error {
+	fb := getFieldBuffer()
+	defer putFieldBuffer(fb)
+
+	var newEnv environment.Environment
+
+	return op.Prev.Iterate(in, func(out *environment.Environment) error {
+		fb.Reset()
+
+		d, ok := out.GetDocument()
+		if !ok {
+			return errors.New("missing document")
+		}
+
+		_, err := d.GetByField(op.Field)
+		if err != nil {
+			if !errors.Is(err, types.ErrFieldNotFound) {
+				return err
+			}
+
+			return f(out)
+		}
+
+		err = d.Iterate(func(field string, value types.Value) error {
+			if field == op.Field {
+				field = op.NewName
+			}
+
+			fb.Add(field, value)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		newEnv.SetOuter(out)
+		newEnv.SetDocument(fb)
+
+		return f(&newEnv)
+	})
+}
+
+func (op *RenameFieldOperator) String() string {
+	return fmt.Sprintf("paths.RenameField(%s, %s)", op.Field, op.NewName)
+}
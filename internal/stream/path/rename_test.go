@@ -0,0 +1,56 @@
+package path_test
+
+import (
+	"testing"
+
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/stream/docs"
+	"github.com/genjidb/genji/internal/stream/path"
+	"github.com/genjidb/genji/internal/testutil"
+	"github.com/genjidb/genji/internal/testutil/assert"
+	"github.com/genjidb/genji/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameField(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		in    []expr.Expr
+		out   []types.Document
+	}{
+		{
+			"a",
+			"a",
+			testutil.ParseExprs(t, `{"a": 10, "b": 20}`),
+			testutil.MakeDocuments(t, `{"c": 10, "b": 20}`),
+		},
+		{
+			"missing field",
+			"d",
+			testutil.ParseExprs(t, `{"a": 10, "b": 20}`),
+			testutil.MakeDocuments(t, `{"a": 10, "b": 20}`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := stream.New(docs.Emit(test.in...)).Pipe(path.RenameField(test.field, "c"))
+			i := 0
+			err := s.Iterate(new(environment.Environment), func(out *environment.Environment) error {
+				d, _ := out.GetDocument()
+				testutil.RequireDocEqual(t, test.out[i], d)
+				i++
+				return nil
+			})
+			assert.NoError(t, err)
+			require.Equal(t, len(test.out), i)
+		})
+	}
+
+	t.Run("String", func(t *testing.T) {
+		require.Equal(t, path.RenameField("a", "b").String(), "paths.RenameField(a, b)")
+	})
+}
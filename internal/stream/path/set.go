@@ -28,7 +28,9 @@ func Set(path document.Path, e expr.Expr) *SetOperator {
 
 // Iterate implements the Operator interface.
 func (op *SetOperator) Iterate(in *environment.Environment, f func(out *environment.Environment) error) error {
-	var fb document.FieldBuffer
+	fb := getFieldBuffer()
+	defer putFieldBuffer(fb)
+
 	var newEnv environment.Environment
 
 	return op.Prev.Iterate(in, func(out *environment.Environment) error {
@@ -57,7 +59,7 @@ func (op *SetOperator) Iterate(in *environment.Environment, f func(out *environm
 		}
 
 		newEnv.SetOuter(out)
-		newEnv.SetDocument(&fb)
+		newEnv.SetDocument(fb)
 
 		return f(&newEnv)
 	})
@@ -25,7 +25,9 @@ func Unset(field string) *UnsetOperator {
 
 // Iterate implements the Operator interface.
 func (op *UnsetOperator) Iterate(in *environment.Environment, f func(out *environment.Environment) error) error {
-	var fb document.FieldBuffer
+	fb := getFieldBuffer()
+	defer putFieldBuffer(fb)
+
 	var newEnv environment.Environment
 
 	return op.Prev.Iterate(in, func(out *environment.Environment) error {
@@ -50,13 +52,15 @@ func (op *UnsetOperator) Iterate(in *environment.Environment, f func(out *enviro
 			return err
 		}
 
+		// declared fields that are missing from the document are
+		// returned as NULL by GetByField but are not copied
 		err = fb.Delete(document.NewPath(op.Field))
-		if err != nil {
+		if err != nil && !errors.Is(err, types.ErrFieldNotFound) {
 			return err
 		}
 
 		newEnv.SetOuter(out)
-		newEnv.SetDocument(&fb)
+		newEnv.SetDocument(fb)
 
 		return f(&newEnv)
 	})
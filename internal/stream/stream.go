@@ -120,14 +120,18 @@ type DiscardOperator struct {
 
 // Discard is an operator that doesn't produce any document.
 // It iterates over the previous operator and discards all the documents.
+// The next function is still called once per document, with an empty environment,
+// to let the caller keep track of the progress of the stream.
 func Discard() *DiscardOperator {
 	return &DiscardOperator{}
 }
 
-// Iterate iterates over all the streams and returns their union.
-func (op *DiscardOperator) Iterate(in *environment.Environment, _ func(out *environment.Environment) error) (err error) {
+// Iterate iterates over the previous operator and calls fn with an empty environment
+// for each document.
+func (op *DiscardOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) (err error) {
+	var newEnv environment.Environment
 	return op.Prev.Iterate(in, func(out *environment.Environment) error {
-		return nil
+		return fn(&newEnv)
 	})
 }
 
@@ -11,6 +11,7 @@ import (
 	"github.com/genjidb/genji/internal/sql/parser"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/docs"
+	"github.com/genjidb/genji/internal/stream/table"
 	"github.com/genjidb/genji/internal/testutil"
 	"github.com/genjidb/genji/internal/testutil/assert"
 	"github.com/genjidb/genji/types"
@@ -38,6 +39,17 @@ func TestStream(t *testing.T) {
 	})
 	assert.NoError(t, err)
 	require.Equal(t, int64(1), count)
+
+	t.Run("String", func(t *testing.T) {
+		require.Equal(t, "", new(stream.Stream).String())
+
+		s := stream.New(table.Scan("test")).
+			Pipe(docs.Filter(parser.MustParseExpr("a > 1"))).
+			Pipe(docs.TempTreeSort(parser.MustParseExpr("a"))).
+			Pipe(docs.Project(parser.MustParseExpr("a + 1")))
+
+		require.Equal(t, `table.Scan("test") | docs.Filter(a > 1) | docs.TempTreeSort(a) | docs.Project(a + 1)`, s.String())
+	})
 }
 
 func TestUnion(t *testing.T) {
@@ -166,3 +178,144 @@ func TestConcatOperator(t *testing.T) {
 		testutil.RequireDocEqual(t, d, got[i])
 	}
 }
+
+func TestIntersectAndExcept(t *testing.T) {
+	tests := []struct {
+		name                 string
+		op                   func(s ...*stream.Stream) stream.Operator
+		first, second, third []expr.Expr
+		expected             testutil.Docs
+	}{
+		{
+			"intersect",
+			func(s ...*stream.Stream) stream.Operator { return stream.Intersect(s...) },
+			testutil.ParseExprs(t, `{"a": 1}`, `{"a": 2}`, `{"a": 2}`, `{"a": 3}`),
+			testutil.ParseExprs(t, `{"a": 2}`, `{"a": 3}`, `{"a": 4}`),
+			nil,
+			testutil.MakeDocuments(t, `{"a": 2}`, `{"a": 3}`),
+		},
+		{
+			"intersect/three streams",
+			func(s ...*stream.Stream) stream.Operator { return stream.Intersect(s...) },
+			testutil.ParseExprs(t, `{"a": 1}`, `{"a": 2}`, `{"a": 3}`),
+			testutil.ParseExprs(t, `{"a": 2}`, `{"a": 3}`),
+			testutil.ParseExprs(t, `{"a": 1}`, `{"a": 3}`),
+			testutil.MakeDocuments(t, `{"a": 3}`),
+		},
+		{
+			"intersect/only one",
+			func(s ...*stream.Stream) stream.Operator { return stream.Intersect(s...) },
+			testutil.ParseExprs(t, `{"a": 1}`, `{"a": 1}`, `{"a": 2}`),
+			nil, nil,
+			testutil.MakeDocuments(t, `{"a": 1}`, `{"a": 2}`),
+		},
+		{
+			"except",
+			func(s ...*stream.Stream) stream.Operator { return stream.Except(s...) },
+			testutil.ParseExprs(t, `{"a": 1}`, `{"a": 2}`, `{"a": 1}`, `{"a": 3}`),
+			testutil.ParseExprs(t, `{"a": 2}`, `{"a": 4}`),
+			nil,
+			testutil.MakeDocuments(t, `{"a": 1}`, `{"a": 3}`),
+		},
+		{
+			"except/three streams",
+			func(s ...*stream.Stream) stream.Operator { return stream.Except(s...) },
+			testutil.ParseExprs(t, `{"a": 1}`, `{"a": 2}`, `{"a": 3}`),
+			testutil.ParseExprs(t, `{"a": 2}`),
+			testutil.ParseExprs(t, `{"a": 3}`),
+			testutil.MakeDocuments(t, `{"a": 1}`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, tx, cleanup := testutil.NewTestTx(t)
+			defer cleanup()
+
+			var streams []*stream.Stream
+			for _, in := range [][]expr.Expr{test.first, test.second, test.third} {
+				if in != nil {
+					streams = append(streams, stream.New(docs.Emit(in...)))
+				}
+			}
+
+			st := stream.New(test.op(streams...))
+			var env environment.Environment
+			env.Tx = tx
+			env.DB = db
+
+			var got testutil.Docs
+			err := st.Iterate(&env, func(env *environment.Environment) error {
+				d, ok := env.GetDocument()
+				require.True(t, ok)
+
+				clone, err := document.CloneValue(types.NewDocumentValue(d))
+				if err != nil {
+					return err
+				}
+
+				got = append(got, types.As[types.Document](clone))
+				return nil
+			})
+			assert.NoError(t, err)
+			require.Equal(t, len(test.expected), len(got))
+			test.expected.RequireEqual(t, got)
+		})
+	}
+
+	t.Run("String", func(t *testing.T) {
+		s1 := stream.New(docs.Emit(testutil.ParseExprs(t, `{"a": 1}`)...))
+		s2 := stream.New(docs.Emit(testutil.ParseExprs(t, `{"a": 2}`)...))
+
+		require.Equal(t, `intersect(docs.Emit({a: 1}), docs.Emit({a: 2}))`, stream.New(stream.Intersect(s1, s2)).String())
+		require.Equal(t, `except(docs.Emit({a: 1}), docs.Emit({a: 2}))`, stream.New(stream.Except(s1, s2)).String())
+	})
+}
+
+func TestWith(t *testing.T) {
+	db, tx, cleanup := testutil.NewTestTx(t)
+	defer cleanup()
+
+	cte := &stream.CommonTableExpr{
+		Name:   "c",
+		Stream: stream.New(docs.Emit(testutil.ParseExprs(t, `{"a": 1}`, `{"a": 2}`)...)),
+	}
+
+	// the common table expression is read twice
+	st := stream.New(stream.With(
+		stream.New(stream.Concat(stream.New(stream.CTEScan("c")), stream.New(stream.CTEScan("c")))),
+		cte,
+	))
+
+	var env environment.Environment
+	env.Tx = tx
+	env.DB = db
+
+	var got testutil.Docs
+	err := st.Iterate(&env, func(env *environment.Environment) error {
+		d, ok := env.GetDocument()
+		require.True(t, ok)
+
+		clone, err := document.CloneValue(types.NewDocumentValue(d))
+		if err != nil {
+			return err
+		}
+
+		got = append(got, types.As[types.Document](clone))
+		return nil
+	})
+	assert.NoError(t, err)
+	testutil.MakeDocuments(t, `{"a": 1}`, `{"a": 2}`, `{"a": 1}`, `{"a": 2}`).RequireEqual(t, got)
+
+	t.Run("Unknown", func(t *testing.T) {
+		err := stream.New(stream.CTEScan("c")).Iterate(&env, func(env *environment.Environment) error {
+			return nil
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("String", func(t *testing.T) {
+		require.Equal(t, `with("c" = docs.Emit({a: 1}, {a: 2}), cteScan("c") | docs.Project(a))`,
+			stream.New(stream.With(stream.New(stream.CTEScan("c")).Pipe(docs.Project(testutil.ParseNamedExpr(t, "a"))), cte)).String())
+	})
+}
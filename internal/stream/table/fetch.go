@@ -0,0 +1,63 @@
+package table
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/stream"
+)
+
+// A FetchOperator reads the document stored in the table
+// under the key of the incoming environment.
+type FetchOperator struct {
+	stream.BaseOperator
+	Name string
+}
+
+// Fetch reads the document stored under the key of the incoming environment
+// from the given table. If it has no previous operator, it reads the document
+// of the environment passed to Iterate.
+func Fetch(tableName string) *FetchOperator {
+	return &FetchOperator{Name: tableName}
+}
+
+// Iterate implements the Operator interface.
+func (op *FetchOperator) Iterate(in *environment.Environment, f func(out *environment.Environment) error) error {
+	tx := in.GetTx()
+
+	table, err := tx.Catalog.GetTable(tx, op.Name)
+	if err != nil {
+		return err
+	}
+
+	var newEnv environment.Environment
+
+	it := func(out *environment.Environment) error {
+		key, ok := out.GetKey()
+		if !ok {
+			return errors.New("missing key")
+		}
+
+		d, err := table.GetDocument(key)
+		if err != nil {
+			return err
+		}
+
+		newEnv.SetOuter(out)
+		newEnv.SetKey(key)
+		newEnv.SetDocument(d)
+
+		return f(&newEnv)
+	}
+
+	if op.Prev == nil {
+		return it(in)
+	}
+
+	return op.Prev.Iterate(in, it)
+}
+
+func (op *FetchOperator) String() string {
+	return fmt.Sprintf("table.Fetch(%q)", op.Name)
+}
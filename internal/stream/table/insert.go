@@ -2,11 +2,14 @@ package table
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/tree"
 	"github.com/genjidb/genji/types"
 )
 
@@ -14,6 +17,9 @@ import (
 type InsertOperator struct {
 	stream.BaseOperator
 	Name string
+	// If set, the key of each document is derived
+	// from a hash of the values stored at these paths.
+	KeyPaths []document.Path
 }
 
 // Insert inserts incoming documents to the table.
@@ -21,6 +27,14 @@ func Insert(tableName string) *InsertOperator {
 	return &InsertOperator{Name: tableName}
 }
 
+// InsertWithContentKey inserts incoming documents to the table,
+// using a hash of the values stored at the given paths as key.
+// Inserting two documents with the same values at these paths
+// returns a conflict.
+func InsertWithContentKey(tableName string, paths ...document.Path) *InsertOperator {
+	return &InsertOperator{Name: tableName, KeyPaths: paths}
+}
+
 // Iterate implements the Operator interface.
 func (op *InsertOperator) Iterate(in *environment.Environment, f func(out *environment.Environment) error) error {
 	var newEnv environment.Environment
@@ -43,7 +57,23 @@ func (op *InsertOperator) Iterate(in *environment.Environment, f func(out *envir
 			}
 		}
 
-		key, d, err := table.Insert(d)
+		var key *tree.Key
+		if len(op.KeyPaths) > 0 {
+			key, err = database.ContentKey(op.KeyPaths, d)
+			if err != nil {
+				return err
+			}
+
+			key, d, err = table.InsertWithKey(key, d)
+
+			// the conflict is caused by the content key, not by a primary key
+			var cerr *database.ConstraintViolationError
+			if errors.As(err, &cerr) && cerr.Constraint == "PRIMARY KEY" {
+				cerr.Paths = op.KeyPaths
+			}
+		} else {
+			key, d, err = table.Insert(d)
+		}
 		if err != nil {
 			return err
 		}
@@ -56,5 +86,16 @@ func (op *InsertOperator) Iterate(in *environment.Environment, f func(out *envir
 }
 
 func (op *InsertOperator) String() string {
+	if len(op.KeyPaths) > 0 {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "table.InsertWithContentKey(%q", op.Name)
+		for _, p := range op.KeyPaths {
+			sb.WriteString(", ")
+			sb.WriteString(p.String())
+		}
+		sb.WriteByte(')')
+		return sb.String()
+	}
+
 	return fmt.Sprintf("table.Insert(%q)", op.Name)
 }
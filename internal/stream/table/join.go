@@ -0,0 +1,154 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/types"
+)
+
+// A JoinOperator combines each incoming document with the documents of another table.
+// Joined documents hold the combined documents under the name of their table.
+// Example:
+//
+//	{"t1": {"a": 1}, "t2": {"a": 1, "b": 2}}
+type JoinOperator struct {
+	stream.BaseOperator
+	// Name under which the incoming documents are stored in the joined documents.
+	// If empty, the incoming documents are already joined documents
+	// and their fields are copied as is.
+	LeftName string
+	// Name of the joined table.
+	TableName string
+	// Stream returning the documents of the joined table.
+	// It is evaluated for every incoming document, which can be referenced
+	// by the stream using LeftName, e.g. in the boundaries of an index scan.
+	Right *stream.Stream
+	// Condition that must be truthy for two documents to be combined.
+	On expr.Expr
+	// If true, incoming documents that don't match any document
+	// of the joined table are returned with a NULL value for it.
+	Outer bool
+	// If true, the document of the joined table is stored before the
+	// incoming document. It is set by the planner when it swaps the tables
+	// of an inner join, to keep the order of the FROM clause.
+	Swapped bool
+}
+
+// Join creates an operator that combines each incoming document with
+// every document of the given table for which the on condition is truthy.
+// If leftName is empty, incoming documents must be documents returned by
+// another join operator.
+func Join(leftName, tableName string, on expr.Expr) *JoinOperator {
+	return &JoinOperator{LeftName: leftName, TableName: tableName, Right: stream.New(Scan(tableName)), On: on}
+}
+
+// LeftJoin works like Join, but also returns incoming documents that don't
+// match any document of the given table, combined with a NULL value.
+func LeftJoin(leftName, tableName string, on expr.Expr) *JoinOperator {
+	op := Join(leftName, tableName, on)
+	op.Outer = true
+	return op
+}
+
+// Iterate implements the Operator interface.
+func (op *JoinOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	var left, joined document.FieldBuffer
+
+	// the environment passed to the joined table, holding the incoming document
+	var leftEnv environment.Environment
+	leftEnv.SetOuter(in)
+
+	var newEnv environment.Environment
+	newEnv.SetOuter(in)
+
+	combine := func(v types.Value) {
+		joined.Reset()
+		if op.Swapped {
+			joined.Add(op.TableName, v)
+			joined.ScanDocument(&left)
+		} else {
+			joined.ScanDocument(&left)
+			joined.Add(op.TableName, v)
+		}
+		newEnv.SetDocument(&joined)
+	}
+
+	return op.Prev.Iterate(in, func(out *environment.Environment) error {
+		d, ok := out.GetDocument()
+		if !ok {
+			return nil
+		}
+
+		left.Reset()
+		if op.LeftName != "" {
+			left.Add(op.LeftName, types.NewDocumentValue(d))
+		} else if err := left.ScanDocument(d); err != nil {
+			return err
+		}
+		leftEnv.SetDocument(&left)
+
+		// scans of the joined table swallow stream.ErrStreamClosed,
+		// errors returned by fn are kept to be propagated
+		var matched bool
+		var fnErr error
+		err := op.Right.Iterate(&leftEnv, func(out *environment.Environment) error {
+			r, ok := out.GetDocument()
+			if !ok {
+				return nil
+			}
+
+			combine(types.NewDocumentValue(r))
+
+			if op.On != nil {
+				v, err := op.On.Eval(&newEnv)
+				if err != nil {
+					return err
+				}
+
+				ok, err := types.IsTruthy(v)
+				if err != nil || !ok {
+					return err
+				}
+			}
+
+			matched = true
+			fnErr = fn(&newEnv)
+			return fnErr
+		})
+		if fnErr != nil {
+			return fnErr
+		}
+		if err != nil || matched || !op.Outer {
+			return err
+		}
+
+		combine(types.NewNullValue())
+		return fn(&newEnv)
+	})
+}
+
+func (op *JoinOperator) String() string {
+	var s strings.Builder
+
+	if op.Outer {
+		s.WriteString("table.LeftJoin(")
+	} else {
+		s.WriteString("table.Join(")
+	}
+
+	if op.LeftName != "" {
+		fmt.Fprintf(&s, "%q, ", op.LeftName)
+	}
+	fmt.Fprintf(&s, "%q, %s", op.TableName, op.Right)
+	if op.On != nil {
+		fmt.Fprintf(&s, ", %s", op.On)
+	}
+	s.WriteString(")")
+
+	return s.String()
+}
@@ -0,0 +1,190 @@
+package table
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+	"golang.org/x/sync/errgroup"
+)
+
+// A ParallelScanOperator iterates over the documents of a table
+// using multiple goroutines.
+type ParallelScanOperator struct {
+	stream.BaseOperator
+	TableName string
+	Workers   int
+	// If set, each goroutine filters out documents
+	// for which Filter is not truthy.
+	Filter expr.Expr
+}
+
+// ParallelScan creates an iterator that splits the table into partitions
+// and scans each of them in its own goroutine, filtering documents with the given filter, if any.
+// The order of the documents is unspecified.
+// Within a writable transaction, the table is scanned sequentially.
+func ParallelScan(tableName string, workers int, filter expr.Expr) *ParallelScanOperator {
+	return &ParallelScanOperator{TableName: tableName, Workers: workers, Filter: filter}
+}
+
+type parallelScanResult struct {
+	key *tree.Key
+	doc types.Document
+}
+
+// Iterate over the documents of the table. Each document is stored in the environment
+// that is passed to the fn function, using SetCurrentValue.
+func (it *ParallelScanOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	var newEnv environment.Environment
+	newEnv.SetOuter(in)
+	newEnv.Set(environment.TableKey, types.NewTextValue(it.TableName))
+
+	tx := in.GetTx()
+	table, err := tx.Catalog.GetTable(tx, it.TableName)
+	if err != nil {
+		return err
+	}
+
+	// writable sessions can't be read concurrently
+	workers := it.Workers
+	if tx.Writable {
+		workers = 1
+	}
+
+	partitions, err := table.Tree.Partition(workers)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(in.GetContext())
+	defer cancel()
+
+	g, ctx := errgroup.WithContext(ctx)
+	results := make(chan parallelScanResult, len(partitions)*64)
+
+	for _, p := range partitions {
+		p := p
+		g.Go(func() error {
+			return it.scanPartition(ctx, in, table, p, results)
+		})
+	}
+
+	go func() {
+		_ = g.Wait()
+		close(results)
+	}()
+
+	// documents are passed to fn from the calling goroutine only,
+	// the rest of the stream doesn't need to be safe for concurrent use.
+	for r := range results {
+		if err != nil {
+			// drain the channel until every goroutine has returned
+			continue
+		}
+
+		newEnv.SetKey(r.key)
+		newEnv.SetDocument(r.doc)
+
+		err = fn(&newEnv)
+		if err != nil {
+			cancel()
+		}
+	}
+
+	if err != nil {
+		if errors.Is(err, stream.ErrStreamClosed) {
+			return nil
+		}
+		return err
+	}
+
+	return g.Wait()
+}
+
+func (it *ParallelScanOperator) scanPartition(ctx context.Context, in *environment.Environment, table *database.Table, p tree.Partition, results chan<- parallelScanResult) error {
+	// each goroutine evaluates the filter against its own environment,
+	// the values cached by memoized expressions are never shared.
+	// The outer environment must only be read.
+	var env environment.Environment
+	env.SetOuter(in)
+	env.Set(environment.TableKey, types.NewTextValue(it.TableName))
+
+	fcs := &table.Info.FieldConstraints
+	d := database.NewEncodedDocument(fcs, nil)
+	dd := database.DecodeFields(table.Info, d)
+
+	// expired documents are skipped until they are deleted
+	now := time.Now()
+
+	return table.Tree.IterateOnPartition(p, func(key *tree.Key, enc []byte) error {
+		// stop reading once another goroutine failed or the deadline
+		// of the stream is exceeded, even if the filter drops every document
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if table.Info.TTLField != "" {
+			*d = *database.NewEncodedDocument(fcs, enc)
+
+			expired, err := table.Info.IsExpired(dd, now)
+			if err != nil || expired {
+				return err
+			}
+		}
+
+		if it.Filter != nil {
+			*d = *database.NewEncodedDocument(fcs, enc)
+			env.SetKey(key)
+			env.SetDocument(dd)
+
+			v, err := it.Filter.Eval(&env)
+			if err != nil {
+				return err
+			}
+
+			ok, err := types.IsTruthy(v)
+			if err != nil || !ok {
+				return err
+			}
+		}
+
+		// the key and the document are only valid until the next iteration,
+		// copy them before sending them to the calling goroutine
+		r := parallelScanResult{
+			key: tree.NewEncodedKey(append([]byte{}, key.Encoded...)),
+			doc: database.DecodeFields(table.Info, database.NewEncodedDocument(fcs, append([]byte{}, enc...))),
+		}
+
+		select {
+		case results <- r:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+func (it *ParallelScanOperator) String() string {
+	var s strings.Builder
+
+	s.WriteString("table.ParallelScan(")
+	s.WriteString(strconv.Quote(it.TableName))
+	s.WriteString(", ")
+	s.WriteString(strconv.Itoa(it.Workers))
+	if it.Filter != nil {
+		s.WriteString(", ")
+		s.WriteString(fmt.Sprint(it.Filter))
+	}
+	s.WriteString(")")
+
+	return s.String()
+}
@@ -3,6 +3,7 @@ package table
 import (
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/database"
@@ -25,6 +26,7 @@ type ScanOperator struct {
 
 // Scan creates an iterator that iterates over each document of the given table that match the given ranges.
 // If no ranges are provided, it iterates over all documents.
+// The documents matching each range are returned in key order.
 func Scan(tableName string, ranges ...stream.Range) *ScanOperator {
 	return &ScanOperator{TableName: tableName, Ranges: ranges}
 }
@@ -61,15 +63,31 @@ func (it *ScanOperator) Iterate(in *environment.Environment, fn func(out *enviro
 		}
 	}
 
+	// expired documents are skipped until they are deleted
+	now := time.Now()
+	ctx := in.GetContext()
+
 	for _, rng := range ranges {
 		err = table.IterateOnRange(rng, it.Reverse, func(key *tree.Key, d types.Document) error {
+			// the deadline is checked for every document read,
+			// even if the rest of the stream filters it out
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			expired, err := table.Info.IsExpired(d, now)
+			if err != nil || expired {
+				return err
+			}
+
 			newEnv.SetKey(key)
 			newEnv.SetDocument(d)
 
 			return fn(&newEnv)
 		})
+		// the other ranges are not read once the stream is closed
 		if errors.Is(err, stream.ErrStreamClosed) {
-			err = nil
+			return nil
 		}
 		if err != nil {
 			return err
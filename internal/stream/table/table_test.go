@@ -1,10 +1,14 @@
 package table_test
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/genjidb/genji/document"
 	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/expr"
+	"github.com/genjidb/genji/internal/sql/parser"
 	"github.com/genjidb/genji/internal/stream"
 	"github.com/genjidb/genji/internal/stream/table"
 	"github.com/genjidb/genji/internal/testutil"
@@ -92,9 +96,11 @@ func TestTableScan(t *testing.T) {
 			false, false,
 		},
 		{
+			// double boundaries are rounded towards the inside of the range:
+			// 0.5 <= a <= 1.5 -> 1 <= a <= 1
 			"min/max:0.5/1.5",
 			testutil.MakeDocuments(t, `{"a": 1}`, `{"a": 2}`),
-			testutil.MakeDocuments(t, `{"a": 1}`, `{"a": 2}`),
+			testutil.MakeDocuments(t, `{"a": 1}`),
 			stream.Ranges{
 				stream.Range{Min: testutil.ExprList(t, `[0.5]`), Max: testutil.ExprList(t, `[1.5]`)},
 			},
@@ -188,3 +194,105 @@ func TestTableScan(t *testing.T) {
 		require.Equal(t, `table.ScanReverse("test", [{"min": [1], "max": [2], "exclusive": true}, {"min": [10], "exact": true}, {"min": [100]}])`, op.String())
 	})
 }
+
+func TestTableParallelScan(t *testing.T) {
+	db := testutil.NewTestDB(t)
+
+	tx, err := db.Begin(true)
+	assert.NoError(t, err)
+	testutil.MustExec(t, db, tx, "CREATE TABLE test (a INTEGER NOT NULL PRIMARY KEY)")
+	for i := 0; i < 1000; i++ {
+		testutil.MustExec(t, db, tx, "INSERT INTO test (a) VALUES (?)", environment.Param{Value: i})
+	}
+	assert.NoError(t, tx.Commit())
+
+	tests := []struct {
+		name     string
+		workers  int
+		filter   expr.Expr
+		writable bool
+		expected int
+	}{
+		{"one worker", 1, nil, false, 1000},
+		{"four workers", 4, nil, false, 1000},
+		{"more workers than documents", 2000, nil, false, 1000},
+		{"filter", 4, parser.MustParseExpr("a % 3 = 0"), false, 334},
+		{"memoized filter", 4, expr.MemoizeDuplicates(parser.MustParseExpr("a % 7 + 1 > 3 AND a % 7 + 1 < 6"))[0], false, 286},
+		{"writable", 4, parser.MustParseExpr("a < 10"), true, 10},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tx, err := db.Begin(test.writable)
+			assert.NoError(t, err)
+			defer tx.Rollback()
+
+			var env environment.Environment
+			env.Tx = tx
+
+			seen := make(map[int64]bool)
+			err = table.ParallelScan("test", test.workers, test.filter).Iterate(&env, func(env *environment.Environment) error {
+				d, ok := env.GetDocument()
+				require.True(t, ok)
+
+				v, err := d.GetByField("a")
+				assert.NoError(t, err)
+				a := types.As[int64](v)
+				require.False(t, seen[a])
+				seen[a] = true
+
+				k, ok := env.GetKey()
+				require.True(t, ok)
+				require.Equal(t, fmt.Sprintf("[%d]", a), k.String())
+				return nil
+			})
+			assert.NoError(t, err)
+			require.Len(t, seen, test.expected)
+		})
+	}
+
+	t.Run("stop", func(t *testing.T) {
+		tx, err := db.Begin(false)
+		assert.NoError(t, err)
+		defer tx.Rollback()
+
+		var env environment.Environment
+		env.Tx = tx
+
+		var i int
+		err = table.ParallelScan("test", 4, nil).Iterate(&env, func(env *environment.Environment) error {
+			i++
+			if i == 10 {
+				return stream.ErrStreamClosed
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		require.Equal(t, 10, i)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		tx, err := db.Begin(false)
+		assert.NoError(t, err)
+		defer tx.Rollback()
+
+		var env environment.Environment
+		env.Tx = tx
+
+		// missing param
+		err = table.ParallelScan("test", 4, parser.MustParseExpr("a = ?")).Iterate(&env, func(env *environment.Environment) error {
+			return nil
+		})
+		assert.Error(t, err)
+
+		err = table.ParallelScan("test", 4, nil).Iterate(&env, func(env *environment.Environment) error {
+			return errors.New("some error")
+		})
+		require.EqualError(t, err, "some error")
+	})
+
+	t.Run("String", func(t *testing.T) {
+		require.Equal(t, `table.ParallelScan("test", 4)`, table.ParallelScan("test", 4, nil).String())
+		require.Equal(t, `table.ParallelScan("test", 4, a > 1)`, table.ParallelScan("test", 4, parser.MustParseExpr("a > 1")).String())
+	})
+}
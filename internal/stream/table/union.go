@@ -0,0 +1,99 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+)
+
+// A UnionOperator merges the documents returned by multiple streams reading
+// from the same table. Documents are deduplicated by key, which means
+// two different documents with the same content are both returned.
+type UnionOperator struct {
+	stream.BaseOperator
+	TableName string
+	Streams   []*stream.Stream
+}
+
+// Union returns the documents of the given table returned by at least one of the streams.
+// Each stream must output documents of the given table, along with their key.
+// Documents are returned in key order.
+func Union(tableName string, s ...*stream.Stream) *UnionOperator {
+	return &UnionOperator{TableName: tableName, Streams: s}
+}
+
+// Iterate implements the Operator interface.
+func (op *UnionOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	tx := in.GetTx()
+
+	table, err := tx.Catalog.GetTable(tx, op.TableName)
+	if err != nil {
+		return err
+	}
+
+	tns := tx.Catalog.GetFreeTransientNamespace()
+	temp, cleanup, err := tree.NewTransient(in.GetDB().Store.NewTransientSession(), tns, 0)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	// store the key of every document in a temporary tree
+	// to deduplicate them.
+	for _, s := range op.Streams {
+		err := s.Iterate(in, func(out *environment.Environment) error {
+			key, ok := out.GetKey()
+			if !ok || key.Encoded == nil {
+				return errors.New("missing key")
+			}
+
+			return temp.Put(tree.NewKey(types.NewBlobValue(key.Encoded)), nil)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	var newEnv environment.Environment
+	newEnv.SetOuter(in)
+	newEnv.Set(environment.TableKey, types.NewTextValue(op.TableName))
+
+	return temp.IterateOnRange(nil, false, func(k *tree.Key, _ []byte) error {
+		values, err := k.Decode()
+		if err != nil {
+			return err
+		}
+
+		enc := types.As[[]byte](values[0])
+		key := tree.NewEncodedKey(append([]byte(nil), enc...))
+
+		d, err := table.GetDocument(key)
+		if err != nil {
+			return err
+		}
+
+		newEnv.SetKey(key)
+		newEnv.SetDocument(d)
+
+		return fn(&newEnv)
+	})
+}
+
+func (op *UnionOperator) String() string {
+	var s strings.Builder
+
+	s.WriteString("table.Union(")
+	s.WriteString(strconv.Quote(op.TableName))
+	for _, st := range op.Streams {
+		s.WriteString(", ")
+		s.WriteString(st.String())
+	}
+	s.WriteRune(')')
+
+	return s.String()
+}
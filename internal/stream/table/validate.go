@@ -2,11 +2,13 @@ package table
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/genjidb/genji/internal/database"
 	"github.com/genjidb/genji/internal/environment"
 	"github.com/genjidb/genji/internal/stream"
+	"github.com/genjidb/genji/types"
 )
 
 // ValidateOperator validates and converts incoming documents against table and field constraints.
@@ -46,6 +48,13 @@ func (op *ValidateOperator) Iterate(in *environment.Environment, fn func(out *en
 			return errors.New("missing document")
 		}
 
+		if tx.StrictFields {
+			err = validateFields(&info.FieldConstraints, doc)
+			if err != nil {
+				return err
+			}
+		}
+
 		// generate default values, validate and encode document
 		buf, err = info.EncodeDocument(tx, buf, doc)
 		if err != nil {
@@ -67,6 +76,26 @@ func (op *ValidateOperator) Iterate(in *environment.Environment, fn func(out *en
 	})
 }
 
+// validateFields returns an error listing all the fields of d
+// that are not declared in the table schema.
+func validateFields(fcs *database.FieldConstraints, d types.Document) error {
+	paths, err := fcs.ExtraFields(d)
+	if err != nil || len(paths) == 0 {
+		return err
+	}
+
+	names := make([]string, len(paths))
+	for i, p := range paths {
+		names[i] = p.String()
+	}
+
+	if len(names) == 1 {
+		return errors.Errorf("field %s not in schema", names[0])
+	}
+
+	return errors.Errorf("fields %s not in schema", strings.Join(names, ", "))
+}
+
 func (op *ValidateOperator) String() string {
 	return fmt.Sprintf("table.Validate(%q)", op.tableName)
 }
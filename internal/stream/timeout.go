@@ -0,0 +1,59 @@
+package stream
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/internal/environment"
+)
+
+// ErrQueryTimeout is returned when a stream runs longer than its timeout.
+var ErrQueryTimeout = errors.New("query timeout")
+
+// A TimeoutOperator aborts the stream once a given duration has elapsed.
+type TimeoutOperator struct {
+	BaseOperator
+	Duration time.Duration
+}
+
+// Timeout returns an operator that forwards every value of the stream
+// until d has elapsed since the beginning of the iteration,
+// after which it returns ErrQueryTimeout.
+// The deadline is passed to the previous operators through the context
+// of the environment, the operators reading the documents check it
+// for every document read, whether or not it reaches the end of the stream.
+func Timeout(d time.Duration) *TimeoutOperator {
+	return &TimeoutOperator{Duration: d}
+}
+
+// Iterate implements the Operator interface.
+func (op *TimeoutOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	parent := in.GetContext()
+	ctx, cancel := context.WithTimeout(parent, op.Duration)
+	defer cancel()
+
+	var newEnv environment.Environment
+	newEnv.SetOuter(in)
+	newEnv.SetContext(ctx)
+
+	err := op.Prev.Iterate(&newEnv, func(out *environment.Environment) error {
+		// documents can be emitted without reading the source,
+		// i.e. when they are read back from a temporary tree
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		return fn(out)
+	})
+	// the errors of the parent context are returned as is
+	if errors.Is(err, context.DeadlineExceeded) && parent.Err() == nil {
+		return errors.WithStack(ErrQueryTimeout)
+	}
+
+	return err
+}
+
+func (op *TimeoutOperator) String() string {
+	return "timeout(" + op.Duration.String() + ")"
+}
@@ -0,0 +1,125 @@
+package stream
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/document"
+	"github.com/genjidb/genji/internal/database"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/types"
+)
+
+var (
+	// NewPath is the path of the variable holding the inserted or updated
+	// document in the environment of the action of a trigger.
+	NewPath = document.NewPath("new")
+	// OldPath is the path of the variable holding the updated or deleted
+	// document in the environment of the action of a trigger.
+	OldPath = document.NewPath("old")
+)
+
+const oldDocumentStateKey = "trigger.old"
+
+// A TriggerOperator runs the action of a trigger for every incoming document.
+type TriggerOperator struct {
+	BaseOperator
+
+	Name   string
+	Event  database.TriggerEvent
+	Action *Stream
+}
+
+// Trigger creates an operator that runs the action of the given trigger
+// for every incoming document, before passing it to the next operator.
+// The action can reference the incoming document using the "new" variable for
+// INSERT and UPDATE events, and the document before the modification using
+// the "old" variable for UPDATE and DELETE events.
+// For UPDATE events, the old document must be captured by a TriggerOld operator.
+func Trigger(name string, event database.TriggerEvent, action *Stream) *TriggerOperator {
+	return &TriggerOperator{
+		Name:   name,
+		Event:  event,
+		Action: action,
+	}
+}
+
+func (op *TriggerOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	var newEnv environment.Environment
+
+	return op.Prev.Iterate(in, func(out *environment.Environment) error {
+		d, ok := out.GetDocument()
+		if !ok {
+			return fn(out)
+		}
+
+		newDoc, oldDoc := types.NewNullValue(), types.NewNullValue()
+		switch op.Event {
+		case database.TriggerInsert:
+			newDoc = types.NewDocumentValue(d)
+		case database.TriggerUpdate:
+			newDoc = types.NewDocumentValue(d)
+			if v, ok := out.GetState(oldDocumentStateKey); ok {
+				oldDoc = types.NewDocumentValue(v.(types.Document))
+			}
+		case database.TriggerDelete:
+			oldDoc = types.NewDocumentValue(d)
+		}
+
+		newEnv.SetOuter(out)
+		newEnv.Set(NewPath, newDoc)
+		newEnv.Set(OldPath, oldDoc)
+
+		err := op.Action.Iterate(&newEnv, func(out *environment.Environment) error { return nil })
+		if err != nil {
+			// errors returned by the action must not be mistaken
+			// for errors of the statement, e.g. by the OnConflict operator
+			return errors.Wrapf(err, "trigger %s", op.Name)
+		}
+
+		return fn(out)
+	})
+}
+
+func (op *TriggerOperator) String() string {
+	return fmt.Sprintf("stream.Trigger(%q, %s, %s)", op.Name, op.Event, op.Action)
+}
+
+// A TriggerOldOperator stores a copy of every incoming document,
+// to be referenced as the old document by the UPDATE triggers
+// that are fired further down the stream.
+type TriggerOldOperator struct {
+	BaseOperator
+}
+
+// TriggerOld creates a TriggerOldOperator.
+func TriggerOld() *TriggerOldOperator {
+	return &TriggerOldOperator{}
+}
+
+func (op *TriggerOldOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	var newEnv environment.Environment
+	var fb document.FieldBuffer
+
+	return op.Prev.Iterate(in, func(out *environment.Environment) error {
+		d, ok := out.GetDocument()
+		if !ok {
+			return fn(out)
+		}
+
+		fb.Reset()
+		err := fb.Copy(d)
+		if err != nil {
+			return err
+		}
+
+		newEnv.SetOuter(out)
+		newEnv.SetDocument(d)
+		newEnv.SetState(oldDocumentStateKey, types.Document(&fb))
+		return fn(&newEnv)
+	})
+}
+
+func (op *TriggerOldOperator) String() string {
+	return "stream.TriggerOld()"
+}
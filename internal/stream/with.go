@@ -0,0 +1,173 @@
+package stream
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/genjidb/genji/internal/encoding"
+	"github.com/genjidb/genji/internal/environment"
+	"github.com/genjidb/genji/internal/tree"
+	"github.com/genjidb/genji/types"
+)
+
+// A CommonTableExpr is a named stream whose documents
+// can be read by CTEScan operators.
+type CommonTableExpr struct {
+	Name   string
+	Stream *Stream
+}
+
+// A WithOperator iterates over a stream that reads
+// the documents of common table expressions.
+type WithOperator struct {
+	BaseOperator
+	CTEs   []*CommonTableExpr
+	Stream *Stream
+}
+
+// With returns a new WithOperator.
+// Each common table expression is materialized in a temporary tree
+// the first time it is read by s, and read from that tree afterwards.
+func With(s *Stream, ctes ...*CommonTableExpr) *WithOperator {
+	return &WithOperator{CTEs: ctes, Stream: s}
+}
+
+// Iterate iterates over the stream, and removes the
+// materialized common table expressions once it is done.
+func (op *WithOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) (err error) {
+	var newEnv environment.Environment
+	newEnv.SetOuter(in)
+
+	ms := make([]*materializedCTE, len(op.CTEs))
+	for i, cte := range op.CTEs {
+		ms[i] = &materializedCTE{cte: cte, env: &newEnv}
+		newEnv.SetState(cteStateKey(cte.Name), ms[i])
+	}
+
+	defer func() {
+		for _, m := range ms {
+			if m.cleanup == nil {
+				continue
+			}
+
+			if e := m.cleanup(); err == nil {
+				err = e
+			}
+		}
+	}()
+
+	return op.Stream.Iterate(&newEnv, fn)
+}
+
+func (op *WithOperator) String() string {
+	var s strings.Builder
+
+	s.WriteString("with(")
+	for _, cte := range op.CTEs {
+		fmt.Fprintf(&s, "%s = %s, ", strconv.Quote(cte.Name), cte.Stream)
+	}
+	s.WriteString(op.Stream.String())
+	s.WriteRune(')')
+
+	return s.String()
+}
+
+// A CTEScanOperator iterates over the documents of a common table expression.
+type CTEScanOperator struct {
+	BaseOperator
+	Name string
+}
+
+// CTEScan creates an operator that iterates over the documents of the
+// common table expression with the given name, in the order in which
+// its stream returned them.
+// It must be used in a stream iterated by a WithOperator declaring it.
+func CTEScan(name string) *CTEScanOperator {
+	return &CTEScanOperator{Name: name}
+}
+
+// Iterate implements the Operator interface.
+func (op *CTEScanOperator) Iterate(in *environment.Environment, fn func(out *environment.Environment) error) error {
+	v, ok := in.GetState(cteStateKey(op.Name))
+	if !ok {
+		return errors.Errorf("unknown common table expression %q", op.Name)
+	}
+
+	m := v.(*materializedCTE)
+	if m.tree == nil {
+		err := m.materialize()
+		if err != nil {
+			return err
+		}
+	}
+
+	var newEnv environment.Environment
+	newEnv.SetOuter(in)
+
+	err := m.tree.IterateOnRange(nil, false, func(_ *tree.Key, data []byte) error {
+		newEnv.SetDocument(encoding.DecodeDocument(data, false /* intAsDouble */))
+
+		return fn(&newEnv)
+	})
+	if errors.Is(err, ErrStreamClosed) {
+		err = nil
+	}
+	return err
+}
+
+func (op *CTEScanOperator) String() string {
+	return fmt.Sprintf("cteScan(%s)", strconv.Quote(op.Name))
+}
+
+func cteStateKey(name string) string {
+	return "cte:" + name
+}
+
+// materializedCTE holds the documents of a common table expression
+// for the duration of the execution of a WithOperator.
+type materializedCTE struct {
+	cte     *CommonTableExpr
+	env     *environment.Environment
+	tree    *tree.Tree
+	cleanup func() error
+}
+
+// materialize stores the documents of the common table expression in a temporary tree.
+func (m *materializedCTE) materialize() error {
+	db := m.env.GetDB()
+	tns := m.env.GetTx().Catalog.GetFreeTransientNamespace()
+	tr, cleanup, err := tree.NewTransient(db.Store.NewTransientSession(), tns, 0)
+	if err != nil {
+		return err
+	}
+	m.cleanup = cleanup
+
+	var counter int64
+	var buf []byte
+	err = m.cte.Stream.Iterate(m.env, func(out *environment.Environment) error {
+		d, ok := out.GetDocument()
+		if !ok {
+			return errors.New("missing document")
+		}
+
+		buf, err = encoding.EncodeDocument(buf[:0], d)
+		if err != nil {
+			return err
+		}
+
+		// documents are stored in the order of the stream
+		counter++
+		return tr.Put(tree.NewKey(types.NewIntegerValue(counter)), buf)
+	})
+	if errors.Is(err, ErrStreamClosed) {
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+
+	m.tree = tr
+	return nil
+}
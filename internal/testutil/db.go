@@ -101,6 +101,14 @@ func NewTestTx(t testing.TB) (*database.Database, *database.Transaction, func())
 	tx, err := db.Begin(true)
 	assert.NoError(t, err)
 
+	// the transaction is also rolled back when the test ends,
+	// e.g. if it panics, otherwise closing the database
+	// would wait forever for the write transaction to end.
+	// Rolling back a closed transaction is a no-op.
+	t.Cleanup(func() {
+		tx.Rollback()
+	})
+
 	return db, tx, func() {
 		tx.Rollback()
 	}
@@ -1,8 +1,10 @@
 package tree
 
 import (
+	"bytes"
 	"fmt"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -72,6 +74,8 @@ func New(session kv.Session, ns Namespace, order SortOrder) *Tree {
 	}
 }
 
+// NewTransient creates a temporary tree using the given session.
+// The returned cleanup function removes the content of the tree and closes the session.
 func NewTransient(session kv.Session, ns Namespace, order SortOrder) (*Tree, func() error, error) {
 	t := Tree{
 		Namespace: ns,
@@ -87,7 +91,14 @@ func NewTransient(session kv.Session, ns Namespace, order SortOrder) (*Tree, fun
 		return nil, nil, err
 	}
 
-	return &t, t.Truncate, nil
+	return &t, func() error {
+		err := t.Truncate()
+		if err != nil {
+			return err
+		}
+
+		return session.Close()
+	}, nil
 }
 
 var defaultValue = []byte{0}
@@ -184,6 +195,214 @@ func (t *Tree) IterateOnRange(rng *Range, reverse bool, fn func(*Key, []byte) er
 		return err
 	}
 
+	return t.iterate(start, end, reverse, fn)
+}
+
+// IterateAfter iterates in order on the keys of the tree greater than the given key.
+// If key is nil, all the keys of the tree are iterated.
+// It allows resuming an iteration from the last key returned by a previous one.
+func (t *Tree) IterateAfter(key *Key, fn func(*Key, []byte) error) error {
+	var start []byte
+	var err error
+
+	if key == nil {
+		start, err = t.buildFirstKey()
+	} else {
+		start, err = t.buildStartKeyExclusive(key, false)
+	}
+	if err != nil {
+		return err
+	}
+
+	return t.iterate(start, t.buildLastKey(), false, fn)
+}
+
+// A Partition is a contiguous part of the key space of a tree,
+// delimited by encoded keys. Start is inclusive and End is exclusive.
+type Partition struct {
+	Start, End []byte
+}
+
+// Partition splits the key space of the tree into at most n contiguous partitions.
+// Split keys are interpolated between the first and the last key of the tree,
+// which produces partitions of similar sizes when keys are evenly distributed.
+// Only trees whose keys start with a number can be split, other trees are
+// returned as a single partition.
+func (t *Tree) Partition(n int) ([]Partition, error) {
+	start, err := t.buildFirstKey()
+	if err != nil {
+		return nil, err
+	}
+	end := t.buildLastKey()
+
+	if n <= 1 {
+		return []Partition{{Start: start, End: end}}, nil
+	}
+
+	it, err := t.Session.Iterator(&pebble.IterOptions{
+		LowerBound: start,
+		UpperBound: end,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var first, last []byte
+	if it.First() {
+		first = append([]byte{}, it.Key()...)
+	}
+	if it.Last() {
+		last = append([]byte{}, it.Key()...)
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	if first == nil || bytes.Equal(first, last) {
+		return []Partition{{Start: start, End: end}}, nil
+	}
+
+	splits, err := t.interpolateKeys(first, last, n)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(splits, func(i, j int) bool {
+		return encoding.Compare(splits[i], splits[j]) < 0
+	})
+
+	partitions := make([]Partition, 0, n)
+	prev := start
+	for _, split := range splits {
+		if encoding.Compare(split, prev) <= 0 || encoding.Compare(split, end) >= 0 {
+			continue
+		}
+
+		partitions = append(partitions, Partition{Start: prev, End: split})
+		prev = split
+	}
+
+	return append(partitions, Partition{Start: prev, End: end}), nil
+}
+
+// interpolateKeys returns up to n-1 keys evenly spread between first and last.
+// Keys can only be interpolated if their first value is a number of the same type
+// in both keys, otherwise no key is returned.
+func (t *Tree) interpolateKeys(first, last []byte, n int) ([][]byte, error) {
+	fv, err := NewEncodedKey(first).Decode()
+	if err != nil {
+		return nil, err
+	}
+	lv, err := NewEncodedKey(last).Decode()
+	if err != nil {
+		return nil, err
+	}
+
+	if fv[0].Type() != lv[0].Type() || !fv[0].Type().IsNumber() {
+		return nil, nil
+	}
+
+	splits := make([][]byte, 0, n-1)
+	var v types.Value
+
+	for j := 1; j < n; j++ {
+		if fv[0].Type() == types.IntegerValue {
+			lo, hi := types.As[int64](fv[0]), types.As[int64](lv[0])
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			// compute the distance using unsigned integers to avoid overflows
+			step := (uint64(hi) - uint64(lo)) / uint64(n)
+			v = types.NewIntegerValue(lo + int64(step*uint64(j)))
+		} else {
+			lo, hi := types.As[float64](fv[0]), types.As[float64](lv[0])
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			v = types.NewDoubleValue(lo + (hi-lo)/float64(n)*float64(j))
+		}
+
+		k, err := NewKey(v).Encode(t.Namespace, t.Order)
+		if err != nil {
+			return nil, err
+		}
+		splits = append(splits, k)
+	}
+
+	return splits, nil
+}
+
+// IterateOnPartition iterates on all keys of the given partition, in order.
+func (t *Tree) IterateOnPartition(p Partition, fn func(*Key, []byte) error) error {
+	return t.iterate(p.Start, p.End, false, fn)
+}
+
+// An Iterator iterates over the keys of a tree one at a time.
+type Iterator struct {
+	it      *pebble.Iterator
+	reverse bool
+	key     Key
+}
+
+// Iterator returns an iterator positioned on the first key of the tree,
+// or on the last one if reverse is true.
+// The iterator must be closed after use.
+func (t *Tree) Iterator(reverse bool) (*Iterator, error) {
+	start, err := t.buildFirstKey()
+	if err != nil {
+		return nil, err
+	}
+
+	it, err := t.Session.Iterator(&pebble.IterOptions{
+		LowerBound: start,
+		UpperBound: t.buildLastKey(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !reverse {
+		it.First()
+	} else {
+		it.Last()
+	}
+
+	return &Iterator{it: it, reverse: reverse}, nil
+}
+
+// Valid returns whether the iterator is positioned on a key.
+func (it *Iterator) Valid() bool {
+	return it.it.Valid()
+}
+
+// Next moves the iterator to the next key.
+func (it *Iterator) Next() {
+	if !it.reverse {
+		it.it.Next()
+	} else {
+		it.it.Prev()
+	}
+}
+
+// Key returns the current key. It is only valid until the next call to Next.
+func (it *Iterator) Key() *Key {
+	it.key.Encoded = it.it.Key()
+	it.key.Values = nil
+	return &it.key
+}
+
+// Value returns the value of the current key.
+// It is only valid until the next call to Next.
+func (it *Iterator) Value() ([]byte, error) {
+	return it.it.ValueAndErr()
+}
+
+// Close the iterator. It returns any error encountered during the iteration.
+func (it *Iterator) Close() error {
+	return it.it.Close()
+}
+
+func (t *Tree) iterate(start, end []byte, reverse bool, fn func(*Key, []byte) error) error {
 	opts := pebble.IterOptions{
 		LowerBound: start,
 		UpperBound: end,
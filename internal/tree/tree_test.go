@@ -399,3 +399,153 @@ func TestTreeIterateOnRange(t *testing.T) {
 		}
 	}
 }
+
+func TestTreeIterateAfter(t *testing.T) {
+	tt := testutil.NewTestTree(t, 10)
+	for _, k := range intKeys(10) {
+		err := tt.Put(k, nil)
+		assert.NoError(t, err)
+	}
+
+	// keys of other namespaces must not be returned
+	other := tree.New(tt.Session, 11, 0)
+	err := other.Put(tree.NewKey(types.NewIntegerValue(100)), nil)
+	assert.NoError(t, err)
+
+	iterateAfter := func(key *tree.Key) []string {
+		var got []string
+		err := tt.IterateAfter(key, func(k *tree.Key, _ []byte) error {
+			got = append(got, k.String())
+			return nil
+		})
+		assert.NoError(t, err)
+		return got
+	}
+
+	all := iterateAfter(nil)
+	require.Len(t, all, 10)
+	require.Equal(t, all[1:], iterateAfter(tree.NewKey(types.NewIntegerValue(0))))
+	require.Equal(t, all[5:], iterateAfter(tree.NewKey(types.NewIntegerValue(4))))
+	require.Empty(t, iterateAfter(tree.NewKey(types.NewIntegerValue(9))))
+}
+
+func TestTreeIterator(t *testing.T) {
+	tt := testutil.NewTestTree(t, 10)
+	for _, k := range intKeys(10) {
+		err := tt.Put(k, nil)
+		assert.NoError(t, err)
+	}
+
+	// keys of other namespaces must not be returned
+	other := tree.New(tt.Session, 11, 0)
+	err := other.Put(tree.NewKey(types.NewIntegerValue(100)), nil)
+	assert.NoError(t, err)
+
+	iterate := func(reverse bool) []string {
+		it, err := tt.Iterator(reverse)
+		assert.NoError(t, err)
+
+		var got []string
+		for ; it.Valid(); it.Next() {
+			got = append(got, it.Key().String())
+		}
+		assert.NoError(t, it.Close())
+		return got
+	}
+
+	var want []string
+	err = tt.IterateOnRange(nil, false, func(k *tree.Key, _ []byte) error {
+		want = append(want, k.String())
+		return nil
+	})
+	assert.NoError(t, err)
+	require.Len(t, want, 10)
+	require.Equal(t, want, iterate(false))
+
+	got := iterate(true)
+	for i := range got {
+		require.Equal(t, want[len(want)-1-i], got[i])
+	}
+	require.Len(t, got, 10)
+}
+
+func TestTreePartition(t *testing.T) {
+	tests := []struct {
+		name       string
+		keys       []*tree.Key
+		n          int
+		partitions int
+		desc       bool
+	}{
+		{"empty", nil, 4, 1, false},
+		{"single key", []*tree.Key{tree.NewKey(types.NewIntegerValue(1))}, 4, 1, false},
+		{"one partition", intKeys(100), 1, 1, false},
+		{"integers", intKeys(1000), 4, 4, false},
+		{"integers desc", intKeys(1000), 4, 4, true},
+		{"texts", func() []*tree.Key {
+			var keys []*tree.Key
+			for i := 0; i < 100; i++ {
+				keys = append(keys, tree.NewKey(types.NewTextValue(fmt.Sprintf("key-%03d", i))))
+			}
+			return keys
+		}(), 3, 1, false},
+		{"mixed types", append(intKeys(10), tree.NewKey(types.NewTextValue("a")), tree.NewKey(types.NewBoolValue(true))), 8, 1, false},
+		{"doubles", func() []*tree.Key {
+			var keys []*tree.Key
+			for i := 0; i < 100; i++ {
+				keys = append(keys, tree.NewKey(types.NewDoubleValue(float64(i)/10)))
+			}
+			return keys
+		}(), 5, 5, false},
+		{"composite", func() []*tree.Key {
+			var keys []*tree.Key
+			for i := 0; i < 100; i++ {
+				keys = append(keys, tree.NewKey(types.NewIntegerValue(int64(i/10)), types.NewTextValue(fmt.Sprint(i))))
+			}
+			return keys
+		}(), 3, 3, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tt := testutil.NewTestTree(t, 10)
+			if test.desc {
+				tt.Order = tt.Order.SetDesc(0)
+			}
+
+			var want []string
+			for _, k := range test.keys {
+				err := tt.Put(k, nil)
+				assert.NoError(t, err)
+			}
+			err := tt.IterateOnRange(nil, false, func(k *tree.Key, _ []byte) error {
+				want = append(want, k.String())
+				return nil
+			})
+			assert.NoError(t, err)
+
+			partitions, err := tt.Partition(test.n)
+			assert.NoError(t, err)
+			require.Len(t, partitions, test.partitions)
+
+			// partitions must cover every key exactly once, in order
+			var got []string
+			for _, p := range partitions {
+				err := tt.IterateOnPartition(p, func(k *tree.Key, _ []byte) error {
+					got = append(got, k.String())
+					return nil
+				})
+				assert.NoError(t, err)
+			}
+			require.Equal(t, want, got)
+		})
+	}
+}
+
+func intKeys(n int) []*tree.Key {
+	keys := make([]*tree.Key, n)
+	for i := range keys {
+		keys[i] = tree.NewKey(types.NewIntegerValue(int64(i)))
+	}
+	return keys
+}
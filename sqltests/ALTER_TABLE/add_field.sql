@@ -141,4 +141,13 @@ ALTER TABLE test ADD FIELD;
 
 -- test: bad syntax: missing FIELD keyword
 ALTER TABLE test ADD a int;
--- error:
\ No newline at end of file
+-- error:
+-- test: non-existing table
+ALTER TABLE unknown ADD FIELD a int;
+-- error:
+
+-- test: IF EXISTS, non-existing table
+ALTER TABLE IF EXISTS unknown ADD FIELD a int;
+SELECT name FROM __genji_catalog WHERE name = "unknown";
+/* result:
+*/
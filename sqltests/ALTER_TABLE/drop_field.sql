@@ -0,0 +1,93 @@
+-- setup:
+CREATE TABLE test(a int PRIMARY KEY, b int UNIQUE, c text, d double, CHECK (d > 0));
+CREATE INDEX test_c_idx ON test (c);
+INSERT INTO test (a, b, c, d) VALUES (1, 10, 'x', 1.5), (2, 20, 'y', 2.5);
+
+-- test: documents are updated
+ALTER TABLE test DROP FIELD c;
+SELECT * FROM test;
+/* result:
+{
+  "a": 1,
+  "b": 10,
+  "d": 1.5
+}
+{
+  "a": 2,
+  "b": 20,
+  "d": 2.5
+}
+*/
+
+-- test: field constraints are updated
+ALTER TABLE test DROP FIELD c;
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a INTEGER NOT NULL, b INTEGER, d DOUBLE, CONSTRAINT test_pk PRIMARY KEY (a), CONSTRAINT test_b_unique UNIQUE (b), CONSTRAINT test_check CHECK (d > 0))"
+}
+*/
+
+-- test: indexes are dropped
+ALTER TABLE test DROP FIELD b;
+ALTER TABLE test DROP FIELD c;
+SELECT name FROM __genji_catalog WHERE type = "index" AND owner.table_name = "test";
+/* result:
+*/
+
+-- test: new documents
+ALTER TABLE test DROP FIELD c;
+INSERT INTO test (a, b, d) VALUES (3, 30, 3.5);
+SELECT * FROM test WHERE a = 3;
+/* result:
+{
+  "a": 3,
+  "b": 30,
+  "d": 3.5
+}
+*/
+
+-- test: primary key
+ALTER TABLE test DROP FIELD a;
+-- error:
+
+-- test: check constraint
+ALTER TABLE test DROP FIELD d;
+-- error:
+
+-- test: unknown field
+ALTER TABLE test DROP FIELD e;
+-- error:
+
+-- test: unknown table
+ALTER TABLE unknown DROP FIELD a;
+-- error:
+
+-- test: IF EXISTS, unknown table
+ALTER TABLE IF EXISTS unknown DROP FIELD a;
+SELECT COUNT(*) FROM test;
+/* result:
+{
+  "COUNT(*)": 2
+}
+*/
+
+-- test: bad syntax: no field name
+ALTER TABLE test DROP FIELD;
+-- error:
+
+-- test: bad syntax: no FIELD
+ALTER TABLE test DROP c;
+-- error:
+
+-- test: documents without the field
+INSERT INTO test (a, b) VALUES (3, 30);
+ALTER TABLE test DROP FIELD c;
+SELECT * FROM test WHERE a = 3;
+/* result:
+{
+  "a": 3,
+  "b": 30
+}
+*/
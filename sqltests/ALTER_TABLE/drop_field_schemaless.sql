@@ -0,0 +1,32 @@
+-- setup:
+CREATE TABLE test(a int, ...);
+INSERT INTO test (a, b) VALUES (1, {c: 1}), (2, 2), (3, null);
+INSERT INTO test (a) VALUES (4);
+
+-- test: documents are updated
+ALTER TABLE test DROP FIELD b;
+SELECT * FROM test;
+/* result:
+{
+  "a": 1
+}
+{
+  "a": 2
+}
+{
+  "a": 3
+}
+{
+  "a": 4
+}
+*/
+
+-- test: field constraints are not modified
+ALTER TABLE test DROP FIELD b;
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a INTEGER, ...)"
+}
+*/
@@ -46,3 +46,21 @@ ALTER TABLE test test2 RENAME TO test3;
 
 
 
+
+-- test: IF EXISTS
+ALTER TABLE IF EXISTS test RENAME TO test2;
+SELECT name FROM __genji_catalog WHERE type = "table" AND (name = "test2" OR name = "test");
+/* result:
+{
+  "name": "test2"
+}
+*/
+
+-- test: IF EXISTS, non-existing
+ALTER TABLE IF EXISTS unknown RENAME TO test2;
+SELECT name FROM __genji_catalog WHERE type = "table" AND (name = "test2" OR name = "test");
+/* result:
+{
+  "name": "test"
+}
+*/
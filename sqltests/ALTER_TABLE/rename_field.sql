@@ -0,0 +1,90 @@
+-- setup:
+CREATE TABLE test(a int PRIMARY KEY, b int UNIQUE, c text, d double, CHECK (d > 0));
+CREATE INDEX test_c_idx ON test (c);
+INSERT INTO test (a, b, c, d) VALUES (1, 10, 'x', 1.5), (2, 20, 'y', 2.5);
+
+-- test: documents are updated
+ALTER TABLE test RENAME FIELD c TO e;
+SELECT * FROM test;
+/* result:
+{
+  "a": 1,
+  "b": 10,
+  "e": "x",
+  "d": 1.5
+}
+{
+  "a": 2,
+  "b": 20,
+  "e": "y",
+  "d": 2.5
+}
+*/
+
+-- test: constraints are updated
+ALTER TABLE test RENAME FIELD a TO pk;
+ALTER TABLE test RENAME FIELD b TO u;
+SELECT name, sql FROM __genji_catalog WHERE (type = "table" AND name = "test") OR (type = "index" AND owner.table_name = "test");
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (pk INTEGER NOT NULL, u INTEGER, c TEXT, d DOUBLE, CONSTRAINT test_pk PRIMARY KEY (pk), CONSTRAINT test_b_unique UNIQUE (u), CONSTRAINT test_check CHECK (d > 0))"
+}
+{
+  "name": "test_b_idx",
+  "sql": "CREATE UNIQUE INDEX test_b_idx ON test (u)"
+}
+{
+  "name": "test_c_idx",
+  "sql": "CREATE INDEX test_c_idx ON test (c)"
+}
+*/
+
+-- test: renamed primary key
+ALTER TABLE test RENAME FIELD a TO pk;
+SELECT pk, c FROM test WHERE pk = 2;
+/* result:
+{
+  "pk": 2,
+  "c": "y"
+}
+*/
+
+-- test: renamed index
+ALTER TABLE test RENAME FIELD c TO e;
+SELECT a FROM test WHERE e = 'y';
+/* result:
+{
+  "a": 2
+}
+*/
+
+-- test: renamed unique constraint is enforced
+ALTER TABLE test RENAME FIELD b TO u;
+INSERT INTO test (a, u, c, d) VALUES (3, 10, 'z', 3.5);
+-- error: UNIQUE constraint error: [u]
+
+-- test: existing field
+ALTER TABLE test RENAME FIELD c TO b;
+-- error:
+
+-- test: check constraint
+ALTER TABLE test RENAME FIELD d TO e;
+-- error:
+
+-- test: unknown field
+ALTER TABLE test RENAME FIELD e TO f;
+-- error:
+
+-- test: IF EXISTS, unknown table
+ALTER TABLE IF EXISTS unknown RENAME FIELD a TO b;
+SELECT COUNT(*) FROM test;
+/* result:
+{
+  "COUNT(*)": 2
+}
+*/
+
+-- test: bad syntax: no new name
+ALTER TABLE test RENAME FIELD c TO;
+-- error:
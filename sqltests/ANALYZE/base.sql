@@ -0,0 +1,102 @@
+-- setup:
+CREATE TABLE test(a INT, b INT);
+CREATE INDEX test_a ON test(a);
+CREATE INDEX test_a_b ON test(a, b);
+CREATE TABLE other(a INT);
+INSERT INTO test (a, b) VALUES (1, 1), (1, 2), (2, 2), (3, 3);
+INSERT INTO other (a) VALUES (1);
+
+-- test: table
+ANALYZE test;
+SELECT table_name, document_count, indexes FROM __genji_stats;
+/* result:
+{
+    "table_name": "test",
+    "document_count": 4,
+    "indexes": [
+        {"name": "test_a", "entry_count": 4.0, "distinct_count": 3.0},
+        {"name": "test_a_b", "entry_count": 4.0, "distinct_count": 4.0}
+    ]
+}
+*/
+
+-- test: fields
+ANALYZE test;
+SELECT fields FROM __genji_stats;
+/* result:
+{
+    "fields": [
+        {
+            "name": "a",
+            "count": 4.0,
+            "distinct_count": 3.0,
+            "min": 1.0,
+            "histogram": [
+                {"max": 1.0, "count": 2.0, "distinct_count": 1.0},
+                {"max": 2.0, "count": 1.0, "distinct_count": 1.0},
+                {"max": 3.0, "count": 1.0, "distinct_count": 1.0}
+            ]
+        },
+        {
+            "name": "b",
+            "count": 4.0,
+            "distinct_count": 3.0,
+            "min": 1.0,
+            "histogram": [
+                {"max": 1.0, "count": 1.0, "distinct_count": 1.0},
+                {"max": 2.0, "count": 2.0, "distinct_count": 1.0},
+                {"max": 3.0, "count": 1.0, "distinct_count": 1.0}
+            ]
+        }
+    ]
+}
+*/
+
+-- test: all tables
+ANALYZE;
+SELECT table_name, document_count FROM __genji_stats;
+/* result:
+{
+    "table_name": "other",
+    "document_count": 1
+}
+{
+    "table_name": "test",
+    "document_count": 4
+}
+*/
+
+-- test: updated statistics
+ANALYZE test;
+INSERT INTO test (a, b) VALUES (4, 4);
+ANALYZE test;
+SELECT document_count FROM __genji_stats;
+/* result:
+{
+    "document_count": 5
+}
+*/
+
+-- test: drop table
+ANALYZE;
+DROP TABLE other;
+SELECT table_name FROM __genji_stats;
+/* result:
+{
+    "table_name": "test"
+}
+*/
+
+-- test: rename table
+ANALYZE test;
+ALTER TABLE test RENAME TO foo;
+SELECT COUNT(*) FROM __genji_stats;
+/* result:
+{
+    "COUNT(*)": 0
+}
+*/
+
+-- test: unknown table
+ANALYZE unknown;
+-- error:
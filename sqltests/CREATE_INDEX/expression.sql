@@ -0,0 +1,80 @@
+-- setup:
+CREATE TABLE users(id INT PRIMARY KEY, email TEXT, age INT);
+INSERT INTO users (id, email, age) VALUES (1, 'Foo@Example.com', 20), (2, 'bar@example.com', 10), (3, 'BAZ@example.com', 30);
+
+-- test: catalog
+CREATE INDEX ON users (LOWER(email));
+CREATE INDEX users_age_idx ON users (age * 2 DESC, id);
+SELECT name, sql FROM __genji_catalog WHERE type = "index" ORDER BY name;
+/* result:
+{
+  "name": "users_age_idx",
+  "sql": "CREATE INDEX users_age_idx ON users (age * 2 DESC, id)"
+}
+{
+  "name": "users_expr_idx",
+  "sql": "CREATE INDEX users_expr_idx ON users (LOWER(email))"
+}
+*/
+
+-- test: existing documents are indexed
+CREATE INDEX users_lower_email ON users (LOWER(email));
+SELECT id FROM users WHERE LOWER(email) = 'foo@example.com';
+/* result:
+{
+  "id": 1
+}
+*/
+
+-- test: inserted documents are indexed
+CREATE INDEX users_lower_email ON users (LOWER(email));
+INSERT INTO users (id, email, age) VALUES (4, 'QUX@example.com', 40);
+SELECT id FROM users WHERE LOWER(email) = 'qux@example.com';
+/* result:
+{
+  "id": 4
+}
+*/
+
+-- test: updated documents are reindexed
+CREATE INDEX users_lower_email ON users (LOWER(email));
+UPDATE users SET email = 'New@example.com' WHERE id = 2;
+SELECT id FROM users WHERE LOWER(email) IN ('bar@example.com', 'new@example.com');
+/* result:
+{
+  "id": 2
+}
+*/
+
+-- test: deleted documents are removed
+CREATE INDEX users_lower_email ON users (LOWER(email));
+DELETE FROM users WHERE id = 3;
+SELECT id FROM users WHERE LOWER(email) = 'baz@example.com';
+/* result:
+*/
+
+-- test: range
+CREATE INDEX users_age_idx ON users (age * 2);
+SELECT id FROM users WHERE age * 2 >= 40;
+/* result:
+{
+  "id": 1
+}
+{
+  "id": 3
+}
+*/
+
+-- test: unique
+CREATE UNIQUE INDEX users_lower_email ON users (LOWER(email));
+INSERT INTO users (id, email, age) VALUES (4, 'foo@EXAMPLE.com', 40);
+-- error:
+
+-- test: field used by an index
+CREATE INDEX users_lower_email ON users (LOWER(email));
+ALTER TABLE users DROP FIELD email;
+-- error:
+
+-- test: aggregate function
+CREATE INDEX ON users (COUNT(email));
+-- error:
@@ -0,0 +1,42 @@
+-- setup:
+CREATE TABLE test (a int, b text);
+INSERT INTO test (a, b) VALUES (1, 'foo'), (2, 'bar'), (2, 'baz'), (3, 'qux'), (NULL, 'x'), (NULL, 'y');
+
+-- test: existing documents are indexed
+CREATE INDEX test_a_idx ON test(a);
+EXPLAIN SELECT b FROM test WHERE a = 2;
+/* result:
+{
+    "plan": 'index.Scan("test_a_idx", [{"min": [2], "exact": true}]) | docs.Project(b)'
+}
+*/
+
+-- test: query using the index
+CREATE INDEX test_a_idx ON test(a);
+SELECT b FROM test WHERE a = 2;
+/* result:
+{"b": "bar"}
+{"b": "baz"}
+*/
+
+-- test: range using the index
+CREATE INDEX test_a_idx ON test(a);
+SELECT a, b FROM test WHERE a >= 2;
+/* result:
+{"a": 2, "b": "bar"}
+{"a": 2, "b": "baz"}
+{"a": 3, "b": "qux"}
+*/
+
+-- test: unique index with duplicates
+CREATE UNIQUE INDEX test_a_idx ON test(a);
+-- error:
+
+-- test: unique index ignores NULL duplicates
+CREATE UNIQUE INDEX test_b_idx ON test(b);
+DELETE FROM test WHERE a = 2;
+CREATE UNIQUE INDEX test_a_idx ON test(a);
+SELECT b FROM test WHERE a = 3;
+/* result:
+{"b": "qux"}
+*/
@@ -0,0 +1,44 @@
+-- test: basic
+CREATE TABLE test(a INT PRIMARY KEY AUTOINCREMENT, b TEXT);
+SELECT name, sql FROM __genji_catalog WHERE name = "test" OR owner.table_name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a INTEGER NOT NULL DEFAULT NEXT VALUE FOR test_a_seq, b TEXT, CONSTRAINT test_pk PRIMARY KEY (a))"
+}
+{
+  "name": "test_a_seq",
+  "sql": "CREATE SEQUENCE test_a_seq CACHE 64"
+}
+*/
+
+-- test: with DESC order
+CREATE TABLE test(a INT PRIMARY KEY DESC AUTOINCREMENT);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a INTEGER NOT NULL DEFAULT NEXT VALUE FOR test_a_seq, CONSTRAINT test_pk PRIMARY KEY (a DESC))"
+}
+*/
+
+-- test: no type
+CREATE TABLE test(a PRIMARY KEY AUTOINCREMENT);
+-- error:
+
+-- test: non-integer type
+CREATE TABLE test(a TEXT PRIMARY KEY AUTOINCREMENT);
+-- error:
+
+-- test: with default
+CREATE TABLE test(a INT PRIMARY KEY AUTOINCREMENT DEFAULT 10);
+-- error:
+
+-- test: without primary key
+CREATE TABLE test(a INT AUTOINCREMENT);
+-- error:
+
+-- test: sequence cannot be dropped
+CREATE TABLE test(a INT PRIMARY KEY AUTOINCREMENT);
+DROP SEQUENCE test_a_seq;
+-- error:
@@ -11,7 +11,12 @@ SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
 -- test: duplicate
 CREATE TABLE test;
 CREATE TABLE test;
--- error:
+-- error: "test" already exists
+
+-- test: duplicate with unique constraint
+CREATE TABLE test(a int UNIQUE);
+CREATE TABLE test(a int UNIQUE);
+-- error: "test" already exists
 
 -- test: if not exists
 CREATE TABLE test;
@@ -34,3 +39,14 @@ SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
   "sql": "CREATE TABLE test (...)"
 }
 */
+
+-- test: if not exists, with existing table
+CREATE TABLE test(a int UNIQUE);
+INSERT INTO test (a) VALUES (1);
+CREATE TABLE IF NOT EXISTS test(b text);
+SELECT name FROM __genji_catalog WHERE type = "sequence" AND owner.table_name = "test";
+/* result:
+{
+  "name": "test_seq"
+}
+*/
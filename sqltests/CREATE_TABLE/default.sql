@@ -43,9 +43,37 @@ CREATE TABLE test(a DOUBLE DEFAULT 'hello');
 -- error:
 
 -- test: function
+CREATE TABLE test(a TIMESTAMP DEFAULT now());
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a TIMESTAMP DEFAULT NOW())"
+}
+*/
+
+-- test: function with arguments
+CREATE TABLE test(a TEXT DEFAULT lower('FOO') || uuid());
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a TEXT DEFAULT LOWER(\"FOO\") || UUID())"
+}
+*/
+
+-- test: forbidden function: pk
 CREATE TABLE test(a DOUBLE DEFAULT pk());
 -- error:
 
+-- test: forbidden function: aggregator
+CREATE TABLE test(a INT DEFAULT count(1));
+-- error:
+
+-- test: forbidden function: path argument
+CREATE TABLE test(a TEXT DEFAULT lower(b));
+-- error:
+
 -- test: incompatible expr
 CREATE TABLE test(a BLOB DEFAULT 1 + 4 / 4);
 -- error:
@@ -21,3 +21,23 @@ SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
 -- test: not null twice
 CREATE TABLE test(a INT NOT NULL NOT NULL);
 -- error:
+
+-- test: with unique
+CREATE TABLE test(a INT NOT NULL UNIQUE);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a INTEGER NOT NULL, CONSTRAINT test_a_unique UNIQUE (a))"
+}
+*/
+
+-- test: nested field
+CREATE TABLE test(a (b INT NOT NULL));
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a (b INTEGER NOT NULL))"
+}
+*/
@@ -0,0 +1,51 @@
+-- test: basic
+CREATE TABLE test(a INT, expires_at TIMESTAMP) WITH (ttl_field = expires_at);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a INTEGER, expires_at TIMESTAMP) WITH (ttl_field = expires_at)"
+}
+*/
+
+-- test: with extra fields
+CREATE TABLE test(expires_at TIMESTAMP, ...) WITH (ttl_field = expires_at);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (expires_at TIMESTAMP, ...) WITH (ttl_field = expires_at)"
+}
+*/
+
+-- test: undeclared field
+CREATE TABLE test(a INT) WITH (ttl_field = expires_at);
+-- error:
+
+-- test: non-timestamp field
+CREATE TABLE test(a INT, expires_at TEXT) WITH (ttl_field = expires_at);
+-- error:
+
+-- test: unknown option
+CREATE TABLE test(a INT, expires_at TIMESTAMP) WITH (foo = expires_at);
+-- error:
+
+-- test: redundant option
+CREATE TABLE test(a INT, expires_at TIMESTAMP, b TIMESTAMP) WITH (ttl_field = expires_at, ttl_field = b);
+-- error:
+
+-- test: ttl field cannot be dropped
+CREATE TABLE test(a INT, expires_at TIMESTAMP) WITH (ttl_field = expires_at);
+ALTER TABLE test DROP FIELD expires_at;
+-- error:
+
+-- test: rename ttl field
+CREATE TABLE test(a INT, expires_at TIMESTAMP) WITH (ttl_field = expires_at);
+ALTER TABLE test RENAME FIELD expires_at TO exp;
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a INTEGER, exp TIMESTAMP) WITH (ttl_field = exp)"
+}
+*/
@@ -58,6 +58,16 @@ SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
 }
 */
 
+-- test: TIMESTAMP
+CREATE TABLE test (a TIMESTAMP);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a TIMESTAMP)"
+}
+*/
+
 -- test: DOCUMENT
 CREATE TABLE test (a DOCUMENT);
 SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
@@ -80,7 +90,25 @@ SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
 
 -- test: duplicate type
 CREATE TABLE test (a INT, a TEXT);
--- error:
+-- error: duplicate field name "a" at line 1, char 27
+
+-- test: duplicate field without type
+CREATE TABLE test (a, b INT, a);
+-- error: duplicate field name "a" at line 1, char 30
+
+-- test: duplicate nested field
+CREATE TABLE test (a (b INT, b TEXT));
+-- error: duplicate field name "a.b" at line 1, char 30
+
+-- test: field names are case-sensitive
+CREATE TABLE test (a INT, A TEXT);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a INTEGER, A TEXT)"
+}
+*/
 
 -- test: INTEGER ALIAS: INT
 CREATE TABLE test (a INT);
@@ -93,7 +121,17 @@ SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
 */
 
 -- test: INT ALIAS: TINYINT
-CREATE TABLE test (a INTEGER);
+CREATE TABLE test (a TINYINT);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a INTEGER)"
+}
+*/
+
+-- test: INT ALIAS: SMALLINT
+CREATE TABLE test (a SMALLINT);
 SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
 /* result:
 {
@@ -142,6 +180,26 @@ SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
 }
 */
 
+-- test: DOUBLE ALIAS: REAL
+CREATE TABLE test (a REAL);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a DOUBLE)"
+}
+*/
+
+-- test: DOUBLE ALIAS: DOUBLE PRECISION
+CREATE TABLE test (a DOUBLE PRECISION);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a DOUBLE)"
+}
+*/
+
 -- test: BOOLEAN ALIAS: BOOL
 CREATE TABLE test (a BOOL);
 SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
@@ -152,13 +210,33 @@ SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
 }
 */
 
+-- test: BLOB ALIAS: BYTES
+CREATE TABLE test (a BYTES);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a BLOB)"
+}
+*/
+
+-- test: TEXT ALIAS: VARCHAR
+CREATE TABLE test (a VARCHAR);
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a TEXT)"
+}
+*/
+
 -- test: TEXT ALIAS: VARCHAR(n)
 CREATE TABLE test (a VARCHAR(255));
 SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
 /* result:
 {
   "name": "test",
-  "sql": "CREATE TABLE test (a TEXT)"
+  "sql": "CREATE TABLE test (a VARCHAR(255))"
 }
 */
 
@@ -168,6 +246,28 @@ SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
 /* result:
 {
   "name": "test",
-  "sql": "CREATE TABLE test (a TEXT)"
+  "sql": "CREATE TABLE test (a CHARACTER(255))"
 }
 */
+
+-- test: VARCHAR(n) with constraints
+CREATE TABLE test (a VARCHAR(10) NOT NULL DEFAULT "foo", b (c VARCHAR(3)));
+SELECT name, sql FROM __genji_catalog WHERE type = "table" AND name = "test";
+/* result:
+{
+  "name": "test",
+  "sql": "CREATE TABLE test (a VARCHAR(10) NOT NULL DEFAULT \"foo\", b (c VARCHAR(3)))"
+}
+*/
+
+-- test: VARCHAR(0)
+CREATE TABLE test (a VARCHAR(0));
+-- error: found 0, expected positive integer at line 1, char 30
+
+-- test: VARCHAR(n) without length
+CREATE TABLE test (a VARCHAR());
+-- error: found ), expected integer at line 1, char 30
+
+-- test: unknown type
+CREATE TABLE test (a STRING);
+-- error: found STRING, expected type at line 1, char 22
@@ -0,0 +1,99 @@
+-- setup:
+CREATE TABLE test(a INT PRIMARY KEY, b INT);
+CREATE TABLE log(a INT, b INT);
+
+-- test: basic
+CREATE TRIGGER tr AFTER INSERT ON test INSERT INTO log VALUES (new.a, new.b);
+SELECT name, type, sql, owner.table_name AS table_name FROM __genji_catalog WHERE type = "trigger";
+/* result:
+{
+  "name": "tr",
+  "type": "trigger",
+  "sql": "CREATE TRIGGER tr AFTER INSERT ON test INSERT INTO log VALUES (new.a, new.b)",
+  "table_name": "test"
+}
+*/
+
+-- test: timings and events
+CREATE TRIGGER tr1 BEFORE INSERT ON test DELETE FROM log WHERE a = new.a;
+CREATE TRIGGER tr2 AFTER UPDATE ON test UPDATE log SET b = new.b WHERE a = old.a;
+CREATE TRIGGER tr3 BEFORE DELETE ON test DELETE FROM log WHERE a = old.a;
+SELECT name, sql FROM __genji_catalog WHERE type = "trigger";
+/* result:
+{
+  "name": "tr1",
+  "sql": "CREATE TRIGGER tr1 BEFORE INSERT ON test DELETE FROM log WHERE a = new.a"
+}
+{
+  "name": "tr2",
+  "sql": "CREATE TRIGGER tr2 AFTER UPDATE ON test UPDATE log SET b = new.b WHERE a = old.a"
+}
+{
+  "name": "tr3",
+  "sql": "CREATE TRIGGER tr3 BEFORE DELETE ON test DELETE FROM log WHERE a = old.a"
+}
+*/
+
+-- test: IF NOT EXISTS
+CREATE TRIGGER tr AFTER INSERT ON test INSERT INTO log VALUES (new.a, new.b);
+CREATE TRIGGER IF NOT EXISTS tr AFTER DELETE ON test DELETE FROM log;
+SELECT name, sql FROM __genji_catalog WHERE type = "trigger";
+/* result:
+{
+  "name": "tr",
+  "sql": "CREATE TRIGGER tr AFTER INSERT ON test INSERT INTO log VALUES (new.a, new.b)"
+}
+*/
+
+-- test: duplicate
+CREATE TRIGGER tr AFTER INSERT ON test DELETE FROM log;
+CREATE TRIGGER tr AFTER DELETE ON test DELETE FROM log;
+-- error:
+
+-- test: same name as a table
+CREATE TRIGGER log AFTER INSERT ON test DELETE FROM log;
+-- error:
+
+-- test: unknown table
+CREATE TRIGGER tr AFTER INSERT ON unknown DELETE FROM log;
+-- error:
+
+-- test: action on unknown table
+CREATE TRIGGER tr AFTER INSERT ON test DELETE FROM unknown;
+-- error:
+
+-- test: read-only table
+CREATE TRIGGER tr AFTER INSERT ON __genji_catalog DELETE FROM log;
+-- error:
+
+-- test: SELECT action
+CREATE TRIGGER tr AFTER INSERT ON test SELECT * FROM log;
+-- error:
+
+-- test: dropping the table drops its triggers
+CREATE TRIGGER tr AFTER INSERT ON test DELETE FROM log;
+DROP TABLE test;
+SELECT name FROM __genji_catalog WHERE type = "trigger";
+/* result:
+*/
+
+-- test: renaming the table keeps its triggers
+CREATE TRIGGER tr AFTER INSERT ON test INSERT INTO log VALUES (new.a, new.b);
+ALTER TABLE test RENAME TO test2;
+INSERT INTO test2 (a, b) VALUES (1, 10);
+SELECT * FROM log;
+/* result:
+{
+  "a": 1,
+  "b": 10
+}
+*/
+
+-- test: trigger words are not reserved
+CREATE TABLE trigger(before INT, after INT, execute INT, function INT);
+CREATE TRIGGER after AFTER INSERT ON trigger INSERT INTO log (a, b) VALUES (new.before, new.after);
+INSERT INTO trigger (before, after, execute, function) VALUES (1, 2, 3, 4);
+SELECT * FROM log;
+/* result:
+{"a": 1, "b": 2}
+*/
@@ -0,0 +1,46 @@
+-- setup:
+CREATE TABLE test(a INT, b INT);
+
+-- test: basic
+CREATE VIEW v AS SELECT a FROM test WHERE b > 1;
+SELECT * FROM __genji_catalog WHERE type = "view";
+/* result:
+{
+  "name": "v",
+  "type": "view",
+  "sql": "CREATE VIEW v AS SELECT a FROM test WHERE b > 1"
+}
+*/
+
+-- test: IF NOT EXISTS
+CREATE VIEW v AS SELECT a FROM test;
+CREATE VIEW IF NOT EXISTS v AS SELECT b FROM test;
+SELECT name, sql FROM __genji_catalog WHERE type = "view";
+/* result:
+{
+  "name": "v",
+  "sql": "CREATE VIEW v AS SELECT a FROM test"
+}
+*/
+
+-- test: duplicate
+CREATE VIEW v AS SELECT a FROM test;
+CREATE VIEW v AS SELECT b FROM test;
+-- error:
+
+-- test: same name as a table
+CREATE VIEW test AS SELECT a FROM test;
+-- error:
+
+-- test: table with the same name as a view
+CREATE VIEW v AS SELECT a FROM test;
+CREATE TABLE v;
+-- error:
+
+-- test: unknown table
+CREATE VIEW v AS SELECT a FROM unknown;
+-- error:
+
+-- test: not a SELECT
+CREATE VIEW v AS DELETE FROM test;
+-- error:
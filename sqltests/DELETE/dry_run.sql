@@ -0,0 +1,29 @@
+-- setup:
+CREATE TABLE test(id INTEGER PRIMARY KEY, a TEXT);
+INSERT INTO test (id, a) VALUES (1, 'foo'), (2, 'bar'), (3, 'foo');
+
+-- test: returns the matched documents
+DELETE FROM test WHERE a = 'foo' DRY RUN;
+/* result:
+{"pk()": [1], "id": 1, "a": "foo"}
+{"pk()": [3], "id": 3, "a": "foo"}
+*/
+
+-- test: does not delete anything
+DELETE FROM test DRY RUN;
+SELECT id FROM test;
+/* result:
+{"id": 1}
+{"id": 2}
+{"id": 3}
+*/
+
+-- test: limit
+DELETE FROM test ORDER BY id DESC LIMIT 1 dry run;
+/* result:
+{"pk()": [3], "id": 3, "a": "foo"}
+*/
+
+-- test: incomplete
+DELETE FROM test DRY;
+-- error:
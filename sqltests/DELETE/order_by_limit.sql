@@ -0,0 +1,66 @@
+-- setup:
+CREATE TABLE test(id INTEGER PRIMARY KEY, priority INTEGER, created_at INTEGER);
+CREATE INDEX test_created_at ON test(created_at);
+INSERT INTO test (id, priority, created_at) VALUES (1, 2, 40), (2, 1, 30), (3, 2, 10), (4, 3, 20), (5, 1, 50);
+
+-- test: limit
+DELETE FROM test LIMIT 2;
+SELECT id FROM test;
+/* result:
+{"id": 3}
+{"id": 4}
+{"id": 5}
+*/
+
+-- test: order by, limit
+DELETE FROM test ORDER BY priority, id LIMIT 2;
+SELECT id FROM test;
+/* result:
+{"id": 1}
+{"id": 3}
+{"id": 4}
+*/
+
+-- test: order by desc, limit
+DELETE FROM test ORDER BY priority DESC LIMIT 1;
+SELECT id FROM test;
+/* result:
+{"id": 1}
+{"id": 2}
+{"id": 3}
+{"id": 5}
+*/
+
+-- test: where, order by, limit, offset
+DELETE FROM test WHERE priority < 3 ORDER BY created_at LIMIT 2 OFFSET 1;
+SELECT id FROM test;
+/* result:
+{"id": 3}
+{"id": 4}
+{"id": 5}
+*/
+
+-- test: indexed order by
+EXPLAIN DELETE FROM test WHERE created_at < 45 ORDER BY created_at LIMIT 2;
+/* result:
+{
+    "plan": 'index.Scan("test_created_at", [{"max": [45], "exclusive": true}]) | docs.Take(2) | index.Delete("test_created_at") | table.Delete(\'test\') | discard()'
+}
+*/
+
+-- test: indexed order by / result
+DELETE FROM test WHERE created_at < 45 ORDER BY created_at LIMIT 2;
+SELECT id, created_at FROM test ORDER BY created_at;
+/* result:
+{"id": 2, "created_at": 30}
+{"id": 1, "created_at": 40}
+{"id": 5, "created_at": 50}
+*/
+
+-- test: repeated batches
+DELETE FROM test ORDER BY created_at LIMIT 2;
+DELETE FROM test ORDER BY created_at LIMIT 2;
+SELECT id FROM test;
+/* result:
+{"id": 5}
+*/
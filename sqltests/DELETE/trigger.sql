@@ -0,0 +1,57 @@
+-- setup:
+CREATE TABLE test(a INT PRIMARY KEY, b INT);
+CREATE TABLE log(event TEXT, a INT, b INT);
+INSERT INTO test (a, b) VALUES (1, 1), (2, 2), (3, 3);
+
+-- test: AFTER DELETE
+CREATE TRIGGER tr AFTER DELETE ON test INSERT INTO log VALUES ('delete', old.a, old.b);
+DELETE FROM test WHERE a > 1;
+SELECT * FROM log;
+/* result:
+{
+  "event": "delete",
+  "a": 2,
+  "b": 2
+}
+{
+  "event": "delete",
+  "a": 3,
+  "b": 3
+}
+*/
+
+-- test: BEFORE DELETE
+CREATE TRIGGER tr BEFORE DELETE ON test INSERT INTO log VALUES ('delete', old.a, old.b);
+DELETE FROM test WHERE a = 1;
+SELECT * FROM log;
+/* result:
+{
+  "event": "delete",
+  "a": 1,
+  "b": 1
+}
+*/
+
+-- test: new is NULL
+CREATE TRIGGER tr AFTER DELETE ON test INSERT INTO log (event, a) VALUES (typeof(new), old.a);
+DELETE FROM test WHERE a = 1;
+SELECT event, a FROM log;
+/* result:
+{
+  "event": "null",
+  "a": 1
+}
+*/
+
+-- test: cascade
+CREATE TABLE children(id INT PRIMARY KEY, parent INT);
+INSERT INTO children VALUES (1, 1), (2, 1), (3, 2);
+CREATE TRIGGER tr AFTER DELETE ON test DELETE FROM children WHERE parent = old.a;
+DELETE FROM test WHERE a = 1;
+SELECT * FROM children;
+/* result:
+{
+  "id": 3,
+  "parent": 2
+}
+*/
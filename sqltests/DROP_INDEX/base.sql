@@ -1 +1,50 @@
--- TODO
\ No newline at end of file
+-- setup:
+CREATE TABLE test(a int UNIQUE, b int);
+CREATE INDEX test_b_idx ON test (b);
+INSERT INTO test (a, b) VALUES (1, 1), (2, 2);
+
+-- test: index is removed
+DROP INDEX test_b_idx;
+SELECT name FROM __genji_catalog WHERE type = "index";
+/* result:
+{
+  "name": "test_a_idx"
+}
+*/
+
+-- test: table is not modified
+DROP INDEX test_b_idx;
+SELECT * FROM test WHERE b = 2;
+/* result:
+{
+  "a": 2,
+  "b": 2
+}
+*/
+
+-- test: index can be recreated
+DROP INDEX test_b_idx;
+CREATE INDEX test_b_idx ON test (b);
+SELECT a FROM test WHERE b = 1;
+/* result:
+{
+  "a": 1
+}
+*/
+
+-- test: index owned by a constraint
+DROP INDEX test_a_idx;
+-- error:
+
+-- test: IF EXISTS, index owned by a constraint
+DROP INDEX IF EXISTS test_a_idx;
+-- error:
+
+-- test: twice
+DROP INDEX test_b_idx;
+DROP INDEX test_b_idx;
+-- error:
+
+-- test: bad syntax: no index name
+DROP INDEX;
+-- error:
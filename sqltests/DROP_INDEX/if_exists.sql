@@ -0,0 +1,12 @@
+-- setup:
+CREATE TABLE test(a int);
+
+-- test: non-existing
+DROP INDEX unknown;
+-- error:
+
+-- test: IF EXISTS, non-existing
+DROP INDEX IF EXISTS unknown;
+SELECT name FROM __genji_catalog WHERE type = "index";
+/* result:
+*/
@@ -1 +1,61 @@
--- TODO
\ No newline at end of file
+-- setup:
+CREATE TABLE test(a int UNIQUE, b int);
+CREATE INDEX test_b_idx ON test (b);
+INSERT INTO test (a, b) VALUES (1, 1), (2, 2);
+
+-- test: table, indexes and sequence are removed
+DROP TABLE test;
+SELECT name FROM __genji_catalog WHERE name = "test" OR owner.table_name = "test";
+/* result:
+*/
+
+-- test: table can be recreated
+DROP TABLE test;
+CREATE TABLE test(a int);
+INSERT INTO test (a) VALUES (10);
+SELECT * FROM test;
+/* result:
+{
+  "a": 10
+}
+*/
+
+-- test: index names can be reused
+DROP TABLE test;
+CREATE TABLE test2(b int);
+CREATE INDEX test_b_idx ON test2 (b);
+SELECT name FROM __genji_catalog WHERE type = "index";
+/* result:
+{
+  "name": "test_b_idx"
+}
+*/
+
+-- test: dropped table cannot be queried
+DROP TABLE test;
+SELECT * FROM test;
+-- error:
+
+-- test: twice
+DROP TABLE test;
+DROP TABLE test;
+-- error:
+
+-- test: non-existing
+DROP TABLE unknown;
+-- error:
+
+-- test: read-only table
+DROP TABLE __genji_catalog;
+-- error:
+
+-- test: bad syntax: no table name
+DROP TABLE;
+-- error:
+
+-- test: AUTOINCREMENT sequence is removed
+CREATE TABLE test2(a INT PRIMARY KEY AUTOINCREMENT);
+DROP TABLE test2;
+SELECT name FROM __genji_catalog WHERE name = "test2" OR owner.table_name = "test2";
+/* result:
+*/
@@ -0,0 +1,16 @@
+-- test: non-existing
+DROP TABLE unknown;
+-- error:
+
+-- test: IF EXISTS, non-existing
+DROP TABLE IF EXISTS unknown;
+SELECT name FROM __genji_catalog WHERE name = "unknown";
+/* result:
+*/
+
+-- test: IF EXISTS
+CREATE TABLE test(a int);
+DROP TABLE IF EXISTS test;
+SELECT name FROM __genji_catalog WHERE name = "test";
+/* result:
+*/
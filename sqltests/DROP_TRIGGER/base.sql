@@ -0,0 +1,37 @@
+-- setup:
+CREATE TABLE test(a INT, b INT);
+CREATE TABLE log(a INT);
+CREATE TRIGGER tr AFTER INSERT ON test INSERT INTO log VALUES (new.a);
+
+-- test: basic
+DROP TRIGGER tr;
+SELECT name FROM __genji_catalog WHERE type = "trigger";
+/* result:
+*/
+
+-- test: trigger is not fired anymore
+DROP TRIGGER tr;
+INSERT INTO test (a, b) VALUES (1, 1);
+SELECT * FROM log;
+/* result:
+*/
+
+-- test: twice
+DROP TRIGGER tr;
+DROP TRIGGER tr;
+-- error:
+
+-- test: IF EXISTS
+DROP TRIGGER tr;
+DROP TRIGGER IF EXISTS tr;
+SELECT name FROM __genji_catalog WHERE type = "trigger";
+/* result:
+*/
+
+-- test: table
+DROP TRIGGER test;
+-- error:
+
+-- test: trigger with DROP TABLE
+DROP TABLE tr;
+-- error:
@@ -0,0 +1,54 @@
+-- setup:
+CREATE TABLE test(a INT, b INT);
+CREATE VIEW v AS SELECT a FROM test;
+
+-- test: basic
+DROP VIEW v;
+SELECT name FROM __genji_catalog WHERE type = "view";
+/* result:
+*/
+
+-- test: view cannot be queried
+DROP VIEW v;
+SELECT * FROM v;
+-- error:
+
+-- test: view can be recreated
+DROP VIEW v;
+CREATE VIEW v AS SELECT b FROM test;
+SELECT name, sql FROM __genji_catalog WHERE type = "view";
+/* result:
+{
+  "name": "v",
+  "sql": "CREATE VIEW v AS SELECT b FROM test"
+}
+*/
+
+-- test: twice
+DROP VIEW v;
+DROP VIEW v;
+-- error:
+
+-- test: IF EXISTS
+DROP VIEW v;
+DROP VIEW IF EXISTS v;
+SELECT name FROM __genji_catalog WHERE type = "view";
+/* result:
+*/
+
+-- test: table
+DROP VIEW test;
+-- error:
+
+-- test: view with DROP TABLE
+DROP TABLE v;
+-- error:
+
+-- test: dropping the table doesn't drop the view
+DROP TABLE test;
+SELECT name FROM __genji_catalog WHERE type = "view";
+/* result:
+{
+  "name": "v"
+}
+*/
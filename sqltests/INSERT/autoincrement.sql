@@ -0,0 +1,31 @@
+-- setup:
+CREATE TABLE test(id INT PRIMARY KEY AUTOINCREMENT, a TEXT);
+
+-- test: generated keys
+INSERT INTO test (a) VALUES ('a'), ('b');
+INSERT INTO test (a) VALUES ('c');
+SELECT id, a FROM test;
+/* result:
+{id: 1, a: "a"}
+{id: 2, a: "b"}
+{id: 3, a: "c"}
+*/
+
+-- test: explicit key
+INSERT INTO test (id, a) VALUES (10, 'a');
+INSERT INTO test (a) VALUES ('b');
+SELECT id, a FROM test;
+/* result:
+{id: 1, a: "b"}
+{id: 10, a: "a"}
+*/
+
+-- test: NULL key
+INSERT INTO test (id, a) VALUES (NULL, 'a');
+-- error:
+
+-- test: returning
+INSERT INTO test (a) VALUES ('a') RETURNING id;
+/* result:
+{id: 1}
+*/
@@ -0,0 +1,35 @@
+-- setup:
+CREATE TABLE test(k BLOB PRIMARY KEY, v TEXT);
+INSERT INTO test (k, v) VALUES (x'DEADBEEF', 'a'), (X'00ff', 'b');
+
+-- test: select by blob key
+SELECT v FROM test WHERE k = x'deadbeef';
+/* result:
+{"v": "a"}
+*/
+
+-- test: type
+SELECT typeof(k) AS t FROM test WHERE v = 'b';
+/* result:
+{"t": "blob"}
+*/
+
+-- test: value
+SELECT k FROM test WHERE v = 'a';
+/* result:
+{"k": '\xDEADBEEF'}
+*/
+
+-- test: same as escaped string literal
+SELECT x'DEADBEEF' = '\xdeadbeef' AS eq;
+/* result:
+{"eq": true}
+*/
+
+-- test: odd length
+INSERT INTO test (k, v) VALUES (x'ABC', 'c');
+-- error:
+
+-- test: invalid hexadecimal digit
+INSERT INTO test (k, v) VALUES (x'GG', 'c');
+-- error:
@@ -0,0 +1,53 @@
+-- test: literal default
+CREATE TABLE test (a INT DEFAULT 10, b INT);
+INSERT INTO test (b) VALUES (1);
+SELECT a, b FROM test;
+/* result:
+{
+  "a": 10,
+  "b": 1
+}
+*/
+
+-- test: function default
+CREATE TABLE test (a TIMESTAMP DEFAULT now(), b INT);
+INSERT INTO test (b) VALUES (1);
+SELECT typeof(a) AS t, b FROM test;
+/* result:
+{
+  "t": "timestamp",
+  "b": 1
+}
+*/
+
+-- test: function default is evaluated for each document
+CREATE TABLE test (id TEXT PRIMARY KEY DEFAULT uuid(), b INT);
+INSERT INTO test (b) VALUES (1), (2), (3);
+SELECT COUNT(*) AS n FROM test;
+/* result:
+{
+  "n": 3
+}
+*/
+
+-- test: function default is not used when the field is set
+CREATE TABLE test (a TEXT DEFAULT uuid(), b INT);
+INSERT INTO test (a, b) VALUES ('foo', 1);
+SELECT a, b FROM test;
+/* result:
+{
+  "a": "foo",
+  "b": 1
+}
+*/
+
+-- test: function default with arguments
+CREATE TABLE test (a TEXT DEFAULT lower('FOO') || '-bar', b INT);
+INSERT INTO test (b) VALUES (1);
+SELECT a, b FROM test;
+/* result:
+{
+  "a": "foo-bar",
+  "b": 1
+}
+*/
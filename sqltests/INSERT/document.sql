@@ -60,3 +60,64 @@ SELECT pk(), * FROM test;
     "b":1600
 }
 */
+
+-- test: document, nested document
+CREATE TABLE test;
+INSERT INTO test VALUES {name: "a", address: {city: "x", zip: "1"}};
+SELECT name, address, address.city FROM test;
+/* result:
+{
+  "name": "a",
+  "address": {
+    "city": "x",
+    "zip": "1"
+  },
+  "address.city": "x"
+}
+*/
+
+-- test: document, deeply nested documents and arrays
+CREATE TABLE test;
+INSERT INTO test VALUES {a: {b: {c: [1, {d: [true, {e: "f"}]}]}}};
+SELECT a.b.c[1].d[1].e, typeof(a.b), typeof(a.b.c), typeof(a.b.c[1].d) FROM test;
+/* result:
+{
+  "a.b.c[1].d[1].e": "f",
+  "typeof(a.b)": "document",
+  "typeof(a.b.c)": "array",
+  "typeof(a.b.c[1].d)": "array"
+}
+*/
+
+-- test: document, nested document with schema
+CREATE TABLE test (name TEXT, address (city TEXT, zip INT));
+INSERT INTO test VALUES {name: "a", address: {city: "x", zip: "1"}};
+SELECT * FROM test;
+/* result:
+{
+  "name": "a",
+  "address": {
+    "city": "x",
+    "zip": 1
+  }
+}
+*/
+
+-- test: document, nested document violating schema
+CREATE TABLE test (name TEXT, address (city TEXT, zip INT));
+INSERT INTO test VALUES {name: "a", address: {city: "x", zip: "not a number"}};
+-- error:
+
+-- test: values, nested document
+CREATE TABLE test;
+INSERT INTO test (name, address) VALUES ("a", {city: "x", tags: ["y", "z"]});
+SELECT * FROM test;
+/* result:
+{
+  "name": "a",
+  "address": {
+    "city": "x",
+    "tags": ["y", "z"]
+  }
+}
+*/
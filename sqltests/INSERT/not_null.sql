@@ -28,3 +28,14 @@ SELECT a, b FROM test;
 CREATE TABLE test (a INT NOT NULL DEFAULT 10, b INT);
 INSERT INTO test (a, b) VALUES (NULL, 1);
 -- error:
+
+-- test: nested field with null
+CREATE TABLE test (a (b INT NOT NULL), c INT);
+INSERT INTO test (a, c) VALUES ({b: NULL}, 1);
+-- error:
+
+-- test: with unique
+CREATE TABLE test (a INT NOT NULL UNIQUE, b INT);
+INSERT INTO test (a, b) VALUES (1, 1);
+INSERT INTO test (b) VALUES (2);
+-- error:
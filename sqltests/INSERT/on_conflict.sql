@@ -0,0 +1,116 @@
+-- setup:
+CREATE TABLE test(a INTEGER PRIMARY KEY, b INTEGER UNIQUE, c INTEGER);
+INSERT INTO test (a, b, c) VALUES (1, 10, 100), (2, 20, 200);
+
+-- test: do update, pk
+INSERT INTO test (a, b, c) VALUES (1, 30, 300) ON CONFLICT (a) DO UPDATE SET c = excluded.c;
+SELECT * FROM test;
+/* result:
+{
+  a: 1,
+  b: 10,
+  c: 300
+}
+{
+  a: 2,
+  b: 20,
+  c: 200
+}
+*/
+
+-- test: do update, unique
+INSERT INTO test (a, b, c) VALUES (3, 20, 300) ON CONFLICT (b) DO UPDATE SET c = c + excluded.c;
+SELECT * FROM test;
+/* result:
+{
+  a: 1,
+  b: 10,
+  c: 100
+}
+{
+  a: 2,
+  b: 20,
+  c: 500
+}
+*/
+
+-- test: do update, no target
+INSERT INTO test (a, b, c) VALUES (2, 40, 0) ON CONFLICT DO UPDATE SET b = excluded.b;
+SELECT * FROM test WHERE b = 40;
+/* result:
+{
+  a: 2,
+  b: 40,
+  c: 200
+}
+*/
+
+-- test: do update, no conflict
+INSERT INTO test (a, b, c) VALUES (3, 30, 300) ON CONFLICT (a) DO UPDATE SET c = excluded.c;
+SELECT * FROM test WHERE a = 3;
+/* result:
+{
+  a: 3,
+  b: 30,
+  c: 300
+}
+*/
+
+-- test: do update, where
+INSERT INTO test (a, b, c) VALUES (1, 30, 300), (2, 40, 400) ON CONFLICT (a) DO UPDATE SET c = excluded.c WHERE b > 10;
+SELECT a, c FROM test;
+/* result:
+{
+  a: 1,
+  c: 100
+}
+{
+  a: 2,
+  c: 400
+}
+*/
+
+-- test: do update, other constraint
+INSERT INTO test (a, b, c) VALUES (3, 10, 300) ON CONFLICT (a) DO UPDATE SET c = excluded.c;
+-- error:
+
+-- test: do update, violation by the update
+INSERT INTO test (a, b, c) VALUES (1, 0, 0) ON CONFLICT (a) DO UPDATE SET b = 20;
+-- error:
+
+-- test: do update, unknown target
+INSERT INTO test (a, b, c) VALUES (1, 0, 0) ON CONFLICT (c) DO UPDATE SET c = 0;
+-- error:
+
+-- test: do nothing, target
+INSERT INTO test (a, b, c) VALUES (1, 30, 300) ON CONFLICT (a) DO NOTHING;
+SELECT COUNT(*) FROM test;
+/* result:
+{
+  "COUNT(*)": 2
+}
+*/
+
+-- test: do nothing, other constraint
+INSERT INTO test (a, b, c) VALUES (3, 10, 300) ON CONFLICT (a) DO NOTHING;
+-- error:
+
+-- test: do update, indexes
+CREATE INDEX test_c ON test(c);
+INSERT INTO test (a, b, c) VALUES (1, 30, 300) ON CONFLICT (a) DO UPDATE SET c = excluded.c;
+SELECT a FROM test WHERE c = 300;
+/* result:
+{
+  a: 1
+}
+*/
+
+-- test: do update, indexes / old entry
+CREATE INDEX test_c ON test(c);
+INSERT INTO test (a, b, c) VALUES (1, 30, 300) ON CONFLICT (a) DO UPDATE SET c = excluded.c;
+SELECT COUNT(*) FROM test WHERE c = 100;
+/* result:
+{
+  "COUNT(*)": 0
+}
+*/
@@ -41,3 +41,25 @@ INSERT INTO test (b) VALUES (1);
 CREATE TABLE test (a PRIMARY KEY, b INT);
 INSERT INTO test (a, b) VALUES (NULL, 1);
 -- error:
+
+-- test: WITH KEY should derive the key from the given fields
+CREATE TABLE test (a INT, b TEXT);
+INSERT INTO test (a, b) VALUES (1, "foo"), (2, "bar") WITH KEY (a, b);
+INSERT INTO test (a, b) VALUES (1, "foo") WITH KEY (a, b) ON CONFLICT DO NOTHING;
+SELECT COUNT(*) FROM test;
+/* result:
+{
+  "COUNT(*)": 2
+}
+*/
+
+-- test: WITH KEY should fail on duplicate content
+CREATE TABLE test (a INT, b TEXT);
+INSERT INTO test (a, b) VALUES (1, "foo") WITH KEY (a, b);
+INSERT INTO test (a, b) VALUES (1, "foo") WITH KEY (a, b);
+-- error: PRIMARY KEY constraint error: [a b]
+
+-- test: WITH KEY should fail on tables with a primary key
+CREATE TABLE test (a INT PRIMARY KEY, b TEXT);
+INSERT INTO test (a, b) VALUES (1, "foo") WITH KEY (a, b);
+-- error:
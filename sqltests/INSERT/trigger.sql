@@ -0,0 +1,110 @@
+-- setup:
+CREATE TABLE test(a INT PRIMARY KEY, b INT DEFAULT 10);
+CREATE TABLE log(event TEXT, a INT, b INT);
+
+-- test: AFTER INSERT
+CREATE TRIGGER tr AFTER INSERT ON test INSERT INTO log VALUES ('insert', new.a, new.b);
+INSERT INTO test (a, b) VALUES (1, 1), (2, 2);
+SELECT * FROM log;
+/* result:
+{
+  "event": "insert",
+  "a": 1,
+  "b": 1
+}
+{
+  "event": "insert",
+  "a": 2,
+  "b": 2
+}
+*/
+
+-- test: BEFORE INSERT sees default values
+CREATE TRIGGER tr BEFORE INSERT ON test INSERT INTO log VALUES ('insert', new.a, new.b);
+INSERT INTO test (a) VALUES (1);
+SELECT * FROM log;
+/* result:
+{
+  "event": "insert",
+  "a": 1,
+  "b": 10
+}
+*/
+
+-- test: old is NULL
+CREATE TRIGGER tr AFTER INSERT ON test INSERT INTO log (event, a) VALUES (typeof(old), new.a);
+INSERT INTO test (a) VALUES (1);
+SELECT event, a FROM log;
+/* result:
+{
+  "event": "null",
+  "a": 1
+}
+*/
+
+-- test: AFTER INSERT is not fired for ignored documents
+CREATE TRIGGER tr AFTER INSERT ON test INSERT INTO log VALUES ('insert', new.a, new.b);
+INSERT INTO test (a, b) VALUES (1, 1);
+INSERT INTO test (a, b) VALUES (1, 2) ON CONFLICT DO NOTHING;
+SELECT * FROM log;
+/* result:
+{
+  "event": "insert",
+  "a": 1,
+  "b": 1
+}
+*/
+
+-- test: ON CONFLICT DO UPDATE fires UPDATE triggers
+CREATE TRIGGER tr AFTER UPDATE ON test INSERT INTO log VALUES ('update', old.b, new.b);
+INSERT INTO test (a, b) VALUES (1, 1);
+INSERT INTO test (a, b) VALUES (1, 2) ON CONFLICT DO UPDATE SET b = excluded.b;
+SELECT * FROM log;
+/* result:
+{
+  "event": "update",
+  "a": 1,
+  "b": 2
+}
+*/
+
+-- test: error in the action aborts the statement
+CREATE TABLE log2(a INT PRIMARY KEY);
+CREATE TRIGGER tr AFTER INSERT ON test INSERT INTO log2 VALUES (1);
+INSERT INTO test (a) VALUES (1), (2);
+-- error:
+
+-- test: error in the action is not handled by ON CONFLICT
+CREATE TABLE log2(a INT PRIMARY KEY);
+INSERT INTO log2 VALUES (1);
+CREATE TRIGGER tr AFTER INSERT ON test INSERT INTO log2 VALUES (1);
+INSERT INTO test (a) VALUES (1) ON CONFLICT DO NOTHING;
+-- error:
+
+-- test: a trigger doesn't fire itself
+CREATE TRIGGER tr AFTER INSERT ON test INSERT INTO test (a, b) VALUES (new.a + 100, new.b);
+INSERT INTO test (a, b) VALUES (1, 1);
+SELECT * FROM test;
+/* result:
+{
+  "a": 1,
+  "b": 1
+}
+{
+  "a": 101,
+  "b": 1
+}
+*/
+
+-- test: triggers on other tables are fired
+CREATE TABLE counts(n INT);
+INSERT INTO counts VALUES (0);
+CREATE TRIGGER tr1 AFTER INSERT ON test INSERT INTO log VALUES ('insert', new.a, new.b);
+CREATE TRIGGER tr2 AFTER INSERT ON log UPDATE counts SET n = n + 1;
+INSERT INTO test (a, b) VALUES (1, 1), (2, 2);
+SELECT * FROM counts;
+/* result:
+{
+  "n": 2
+}
+*/
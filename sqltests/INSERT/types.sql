@@ -228,6 +228,37 @@ CREATE TABLE test_e (a CHARACTER(64) NOT NULL);
 INSERT INTO test_e {};
 -- error:
 
+-- test: varchar / length
+CREATE TABLE test_e (a VARCHAR(5));
+INSERT INTO test_e (a) VALUES ("hello"), ("héllo"), ("");
+SELECT a FROM test_e;
+/* result:
+{
+  "a": "hello"
+}
+{
+  "a": "héllo"
+}
+{
+  "a": ""
+}
+*/
+
+-- test: varchar / length exceeded
+CREATE TABLE test_e (a VARCHAR(5));
+INSERT INTO test_e (a) VALUES ("hello!");
+-- error: VARCHAR(5) constraint error: [a]
+
+-- test: varchar / length of converted values
+CREATE TABLE test_e (a VARCHAR(2));
+INSERT INTO test_e (a) VALUES (100);
+-- error: VARCHAR(2) constraint error: [a]
+
+-- test: character / length exceeded
+CREATE TABLE test_e (a CHARACTER(2));
+INSERT INTO test_e (a) VALUES ("foo");
+-- error: CHARACTER(2) constraint error: [a]
+
 -- test: Should fail if the fields cannot be converted to specified field constraints
 CREATE TABLE test (a DOUBLE);
 INSERT INTO test VALUES ([1]);
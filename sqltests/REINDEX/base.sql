@@ -1 +1,39 @@
--- TODO
\ No newline at end of file
+-- setup:
+CREATE TABLE test(a int, b text);
+CREATE INDEX test_a_idx ON test(a);
+CREATE INDEX test_b_idx ON test(b);
+INSERT INTO test (a, b) VALUES (1, 'foo'), (2, 'bar');
+
+-- test: all
+REINDEX;
+SELECT a FROM test WHERE a = 2;
+/* result:
+{"a": 2}
+*/
+
+-- test: table
+REINDEX test;
+SELECT b FROM test WHERE b = 'foo';
+/* result:
+{"b": "foo"}
+*/
+
+-- test: index
+REINDEX test_b_idx;
+SELECT a, b FROM test WHERE b = 'bar';
+/* result:
+{"a": 2, "b": "bar"}
+*/
+
+-- test: twice
+REINDEX test_a_idx;
+REINDEX test_a_idx;
+SELECT a FROM test WHERE a > 0;
+/* result:
+{"a": 1}
+{"a": 2}
+*/
+
+-- test: non-existing
+REINDEX unknown;
+-- error:
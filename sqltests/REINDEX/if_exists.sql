@@ -0,0 +1,33 @@
+-- setup:
+CREATE TABLE test(a int);
+CREATE INDEX test_a_idx ON test(a);
+INSERT INTO test (a) VALUES (1), (2);
+
+-- test: index
+REINDEX IF EXISTS test_a_idx;
+SELECT a FROM test WHERE a = 2;
+/* result:
+{"a": 2}
+*/
+
+-- test: table
+REINDEX IF EXISTS test;
+SELECT a FROM test WHERE a = 1;
+/* result:
+{"a": 1}
+*/
+
+-- test: non-existing
+REINDEX unknown;
+-- error:
+
+-- test: IF EXISTS, non-existing
+REINDEX IF EXISTS unknown;
+SELECT a FROM test WHERE a = 1;
+/* result:
+{"a": 1}
+*/
+
+-- test: IF EXISTS without name
+REINDEX IF EXISTS;
+-- error:
@@ -0,0 +1,78 @@
+-- setup:
+CREATE TABLE test(id INT PRIMARY KEY, a INT, b DOUBLE, c TEXT, d TIMESTAMP, e ANY);
+CREATE INDEX test_a ON test(a);
+CREATE INDEX test_c ON test(c);
+INSERT INTO test (id, a, b, c, d, e) VALUES (1, 10, 1.5, 'apple', '2021-01-01', 1);
+INSERT INTO test (id, a, b, c, d, e) VALUES (2, 20, 2.5, 'banana', '2021-06-01', 'foo');
+INSERT INTO test (id, a, b, c, d, e) VALUES (3, 30, 3.5, 'cherry', '2022-01-01', [1, 2]);
+INSERT INTO test (id) VALUES (4);
+
+-- test: integers
+SELECT id FROM test WHERE a BETWEEN 10 AND 20;
+/* result:
+{"id": 1}
+{"id": 2}
+*/
+
+-- test: doubles
+SELECT id FROM test WHERE b BETWEEN 2 AND 3.5;
+/* result:
+{"id": 2}
+{"id": 3}
+*/
+
+-- test: texts
+SELECT id FROM test WHERE c BETWEEN 'b' AND 'c';
+/* result:
+{"id": 2}
+*/
+
+-- test: timestamps
+SELECT id FROM test WHERE d BETWEEN '2021-03-01' AND '2021-12-31';
+/* result:
+{"id": 2}
+*/
+
+-- test: mixed types
+SELECT id FROM test WHERE e BETWEEN 0 AND 5;
+/* result:
+{"id": 1}
+*/
+
+-- test: arrays
+SELECT id FROM test WHERE e BETWEEN [1] AND [2];
+/* result:
+{"id": 3}
+*/
+
+-- test: expressions
+SELECT id FROM test WHERE a * 2 BETWEEN id + 18 AND 40;
+/* result:
+{"id": 1}
+{"id": 2}
+*/
+
+-- test: empty range
+SELECT id FROM test WHERE a BETWEEN 20 AND 10;
+/* result:
+*/
+
+-- test: NOT BETWEEN
+SELECT id FROM test WHERE a NOT BETWEEN 10 AND 20;
+/* result:
+{"id": 3}
+*/
+
+-- test: NULL
+SELECT id, a BETWEEN 15 AND NULL AS r, a NOT BETWEEN 15 AND NULL AS nr FROM test;
+/* result:
+{"id": 1, "r": false, "nr": true}
+{"id": 2, "r": null, "nr": null}
+{"id": 3, "r": null, "nr": null}
+{"id": 4, "r": null, "nr": null}
+*/
+
+-- test: NULL boundary with index
+SELECT id FROM test WHERE a BETWEEN NULL AND 20;
+/* result:
+*/
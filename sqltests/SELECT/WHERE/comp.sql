@@ -2019,4 +2019,44 @@ SELECT * FROM test WHERE g NOT IN ([1], [3]);
         f: {a: 4},
         g: [4.0]
     }
-*/
\ No newline at end of file
+*/
+-- test: int = double
+SELECT id FROM test WHERE a = 10.0;
+/* result:
+{
+    id: 1
+}
+*/
+
+-- test: int > double
+SELECT id FROM test WHERE a > 15.5;
+/* result:
+{
+    id: 2
+}
+{
+    id: 3
+}
+{
+    id: 4
+}
+*/
+
+-- test: double = int
+SELECT id FROM test WHERE b = 2;
+/* result:
+{
+    id: 2
+}
+*/
+
+-- test: double <= int
+SELECT id FROM test WHERE b <= 2;
+/* result:
+{
+    id: 1
+}
+{
+    id: 2
+}
+*/
@@ -0,0 +1,45 @@
+-- setup:
+CREATE TABLE test(id int PRIMARY KEY, ...);
+INSERT INTO test (id, nickname) VALUES (1, 'foo');
+INSERT INTO test (id, nickname) VALUES (2, null);
+INSERT INTO test (id) VALUES (3);
+INSERT INTO test (id, a) VALUES (4, {nickname: 'bar'});
+
+-- test: present
+SELECT id FROM test WHERE exists(nickname);
+/* result:
+{"id": 1}
+{"id": 2}
+*/
+
+-- test: absent
+SELECT id FROM test WHERE NOT exists(nickname);
+/* result:
+{"id": 3}
+{"id": 4}
+*/
+
+-- test: differs from IS NOT NULL
+SELECT id FROM test WHERE nickname IS NOT NULL;
+/* result:
+{"id": 1}
+*/
+
+-- test: nested path
+SELECT id FROM test WHERE exists(a.nickname);
+/* result:
+{"id": 4}
+*/
+
+-- test: projection
+SELECT id, exists(nickname) AS e FROM test;
+/* result:
+{"id": 1, "e": true}
+{"id": 2, "e": true}
+{"id": 3, "e": false}
+{"id": 4, "e": false}
+*/
+
+-- test: not a path
+SELECT id FROM test WHERE exists(1);
+-- error:
@@ -0,0 +1,61 @@
+-- Without a TTL field, expiry is modeled with a timestamp field
+-- compared to NOW(), and expired records are reclaimed with a DELETE.
+-- Tables declaring a TTL field can set it with INSERT ... TTL,
+-- see also SELECT/ttl.sql.
+
+-- setup:
+CREATE TABLE entries(k TEXT PRIMARY KEY, v INT, expires_at TIMESTAMP NOT NULL);
+CREATE INDEX ON entries(expires_at);
+INSERT INTO entries (k, v, expires_at) VALUES ('a', 1, "2000"), ('b', 2, "2999"), ('c', 3, "2001");
+CREATE TABLE sessions(k TEXT PRIMARY KEY, expires_at TIMESTAMP) WITH (ttl_field = expires_at);
+
+-- test: skip expired records
+SELECT k, v FROM entries WHERE expires_at > NOW();
+/* result:
+{"k": "b", "v": 2}
+*/
+
+-- test: reclaim expired records
+DELETE FROM entries WHERE expires_at <= NOW();
+SELECT k FROM entries;
+/* result:
+{"k": "b"}
+*/
+
+-- test: insert with a TTL
+INSERT INTO sessions (k) VALUES ('a') TTL 3600;
+INSERT INTO sessions (k) VALUES ('b') TTL 0;
+INSERT INTO sessions (k) VALUES ('c'), ('d') TTL -1.5;
+SELECT k, expires_at > NOW() AS valid FROM sessions;
+/* result:
+{"k": "a", "valid": true}
+*/
+
+-- test: insert with a NULL TTL
+INSERT INTO sessions (k) VALUES ('a') TTL NULL;
+SELECT k, expires_at FROM sessions;
+/* result:
+{"k": "a", "expires_at": null}
+*/
+
+-- test: TTL overrides the inserted expiry
+INSERT INTO sessions (k, expires_at) VALUES ('a', "2999") TTL -1;
+SELECT k FROM sessions;
+/* result:
+*/
+
+-- test: insert select with a TTL
+INSERT INTO sessions (k) SELECT k FROM entries WHERE v > 1 TTL 3600;
+SELECT k FROM sessions;
+/* result:
+{"k": "b"}
+{"k": "c"}
+*/
+
+-- test: TTL on a table without TTL field
+INSERT INTO entries (k, v, expires_at) VALUES ('d', 4, "2999") TTL 3600;
+-- error:
+
+-- test: invalid TTL
+INSERT INTO sessions (k) VALUES ('a') TTL 'foo';
+-- error:
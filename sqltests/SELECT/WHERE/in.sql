@@ -0,0 +1,92 @@
+-- setup:
+CREATE TABLE test(id INT PRIMARY KEY, a INT, b TEXT);
+CREATE TABLE other(x INT, y TEXT);
+INSERT INTO test (id, a, b) VALUES (1, 10, 'foo'), (2, 20, 'bar'), (3, 30, 'baz');
+INSERT INTO test (id, b) VALUES (4, 'qux');
+INSERT INTO other (x, y) VALUES (10, 'foo'), (30, 'baz'), (30, 'baz'), (40, 'quux');
+
+-- test: list
+SELECT id FROM test WHERE a IN (10, 30);
+/* result:
+{"id": 1}
+{"id": 3}
+*/
+
+-- test: single element
+SELECT id FROM test WHERE a IN (20);
+/* result:
+{"id": 2}
+*/
+
+-- test: list of expressions
+SELECT id FROM test WHERE a IN (5 + 5, id * 10);
+/* result:
+{"id": 1}
+{"id": 2}
+{"id": 3}
+*/
+
+-- test: duplicate values
+SELECT id FROM test WHERE a IN (10, 10, 10.0);
+/* result:
+{"id": 1}
+*/
+
+-- test: NULL in the list
+SELECT id, a IN (10, NULL) AS r FROM test;
+/* result:
+{"id": 1, "r": true}
+{"id": 2, "r": null}
+{"id": 3, "r": null}
+{"id": 4, "r": null}
+*/
+
+-- test: NOT IN
+SELECT id FROM test WHERE a NOT IN (10, 30);
+/* result:
+{"id": 2}
+*/
+
+-- test: NOT IN with NULL in the list
+SELECT id FROM test WHERE a NOT IN (10, NULL);
+/* result:
+*/
+
+-- test: subquery
+SELECT id FROM test WHERE a IN (SELECT x FROM other);
+/* result:
+{"id": 1}
+{"id": 3}
+*/
+
+-- test: NOT IN subquery
+SELECT id FROM test WHERE b NOT IN (SELECT y FROM other WHERE x > 10);
+/* result:
+{"id": 1}
+{"id": 2}
+{"id": 4}
+*/
+
+-- test: empty subquery
+SELECT id FROM test WHERE a IN (SELECT x FROM other WHERE x > 100);
+/* result:
+*/
+
+-- test: subquery with a WHERE clause
+SELECT id FROM test WHERE a IN (SELECT x FROM other WHERE y = 'foo');
+/* result:
+{"id": 1}
+*/
+
+-- test: subquery in projection
+SELECT id, a IN (SELECT x FROM other) AS r FROM test;
+/* result:
+{"id": 1, "r": true}
+{"id": 2, "r": false}
+{"id": 3, "r": true}
+{"id": 4, "r": null}
+*/
+
+-- test: subquery with more than one field
+SELECT id FROM test WHERE a IN (SELECT * FROM other);
+-- error:
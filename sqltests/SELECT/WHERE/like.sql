@@ -0,0 +1,71 @@
+-- setup:
+CREATE TABLE test(id int PRIMARY KEY, name TEXT, code TEXT);
+INSERT INTO test (id, name, code) VALUES
+    (1, 'Alice', '2021-01'),
+    (2, 'alfred', '2021-02'),
+    (3, 'Bob', '2022-01'),
+    (4, 'al_bert', '20211'),
+    (5, null, '2021');
+
+-- suite: no index
+
+-- suite: with index
+CREATE INDEX ON test(name);
+CREATE INDEX ON test(code);
+
+-- test: prefix
+SELECT id FROM test WHERE code LIKE '2021-%';
+/* result:
+{"id": 1}
+{"id": 2}
+*/
+
+-- test: prefix, case-insensitive
+SELECT id FROM test WHERE name LIKE 'al%';
+/* result:
+{"id": 1}
+{"id": 2}
+{"id": 4}
+*/
+
+-- test: single character wildcard
+SELECT id FROM test WHERE code LIKE '202_-01';
+/* result:
+{"id": 1}
+{"id": 3}
+*/
+
+-- test: escaped wildcard
+SELECT id FROM test WHERE name LIKE 'al\\_%';
+/* result:
+{"id": 4}
+*/
+
+-- test: suffix
+SELECT id FROM test WHERE name LIKE '%e';
+/* result:
+{"id": 1}
+*/
+
+-- test: exact
+SELECT id FROM test WHERE code LIKE '2021';
+/* result:
+{"id": 5}
+*/
+
+-- test: NOT LIKE
+SELECT id FROM test WHERE code NOT LIKE '2021%';
+/* result:
+{"id": 3}
+*/
+
+-- test: NOT LIKE with NULL
+SELECT id FROM test WHERE name NOT LIKE 'al%';
+/* result:
+{"id": 3}
+*/
+
+-- test: non-text
+SELECT id FROM test WHERE id LIKE '1%';
+/* result:
+*/
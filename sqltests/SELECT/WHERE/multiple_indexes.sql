@@ -0,0 +1,69 @@
+-- This file tests queries whose WHERE clause contains multiple
+-- indexable conditions. Only one index is used to read the table,
+-- the remaining conditions must still be applied as a filter.
+
+-- setup:
+CREATE TABLE test(id int primary key, a int, b int, c int);
+
+INSERT INTO test (id, a, b, c) VALUES
+    (1, 1, 1, 1),
+    (2, 1, 2, 2),
+    (3, 1, 2, 3),
+    (4, 2, 2, 4),
+    (5, 2, 3, 5);
+
+-- suite: no index
+
+-- suite: index on a
+CREATE INDEX ON test(a);
+
+-- suite: index on b
+CREATE INDEX ON test(b);
+
+-- suite: index on a and b
+CREATE INDEX ON test(a);
+CREATE INDEX ON test(b);
+
+-- suite: unique index on c, index on a and b
+CREATE INDEX ON test(a);
+CREATE INDEX ON test(b);
+CREATE UNIQUE INDEX ON test(c);
+
+-- suite: composite index on a, b
+CREATE INDEX ON test(a, b);
+
+-- test: = AND =
+SELECT id FROM test WHERE a = 1 AND b = 2;
+/* sorted-result:
+{ id: 2 }
+{ id: 3 }
+*/
+
+-- test: = AND = AND =
+SELECT id FROM test WHERE a = 1 AND b = 2 AND c = 3;
+/* result:
+{ id: 3 }
+*/
+
+-- test: = AND >
+SELECT id FROM test WHERE a = 2 AND b > 2;
+/* result:
+{ id: 5 }
+*/
+
+-- test: > AND =
+SELECT id FROM test WHERE a > 1 AND b = 2;
+/* result:
+{ id: 4 }
+*/
+
+-- test: = AND = with no match
+SELECT id FROM test WHERE a = 2 AND b = 1;
+/* result:
+*/
+
+-- test: = AND non-indexable condition
+SELECT id FROM test WHERE a = 1 AND b + c > 4;
+/* result:
+{ id: 3 }
+*/
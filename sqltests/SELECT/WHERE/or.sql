@@ -0,0 +1,100 @@
+-- This file tests OR conditions. Results must be the same
+-- whether the conditions are read from a full table scan
+-- or from the union of multiple index scans.
+
+-- setup:
+CREATE TABLE test(a int, b int, c int);
+
+INSERT INTO test (a, b, c) VALUES
+    (1, 1, 1),
+    (1, 2, 2),
+    (2, 2, 3),
+    (3, 3, 4),
+    (3, 3, 4),
+    (4, 4, 5);
+
+-- suite: no index
+
+-- suite: index on a
+CREATE INDEX ON test(a);
+
+-- suite: index on a and b
+CREATE INDEX ON test(a);
+CREATE INDEX ON test(b);
+
+-- suite: indexes on a, b and c
+CREATE INDEX ON test(a);
+CREATE INDEX ON test(b);
+CREATE INDEX ON test(c);
+
+-- test: = OR =
+SELECT * FROM test WHERE a = 1 OR b = 2;
+/* sorted-result:
+{ a: 1, b: 1, c: 1 }
+{ a: 1, b: 2, c: 2 }
+{ a: 2, b: 2, c: 3 }
+*/
+
+-- test: same path
+SELECT * FROM test WHERE a = 1 OR a = 4;
+/* sorted-result:
+{ a: 1, b: 1, c: 1 }
+{ a: 1, b: 2, c: 2 }
+{ a: 4, b: 4, c: 5 }
+*/
+
+-- test: duplicate documents
+SELECT * FROM test WHERE a = 3 OR b = 3;
+/* sorted-result:
+{ a: 3, b: 3, c: 4 }
+{ a: 3, b: 3, c: 4 }
+*/
+
+-- test: ranges
+SELECT * FROM test WHERE a > 3 OR b < 2;
+/* sorted-result:
+{ a: 1, b: 1, c: 1 }
+{ a: 4, b: 4, c: 5 }
+*/
+
+-- test: OR with AND
+SELECT * FROM test WHERE a = 1 OR (b = 3 AND c = 4) OR c = 5;
+/* sorted-result:
+{ a: 1, b: 1, c: 1 }
+{ a: 1, b: 2, c: 2 }
+{ a: 3, b: 3, c: 4 }
+{ a: 3, b: 3, c: 4 }
+{ a: 4, b: 4, c: 5 }
+*/
+
+-- test: OR inside AND
+SELECT * FROM test WHERE (a = 1 OR b = 2) AND c > 1;
+/* sorted-result:
+{ a: 1, b: 2, c: 2 }
+{ a: 2, b: 2, c: 3 }
+*/
+
+-- test: non-indexable operand
+SELECT * FROM test WHERE a = 4 OR a + b = 3;
+/* sorted-result:
+{ a: 1, b: 2, c: 2 }
+{ a: 4, b: 4, c: 5 }
+*/
+
+-- test: DELETE
+DELETE FROM test WHERE a = 1 OR b = 3;
+SELECT * FROM test;
+/* sorted-result:
+{ a: 2, b: 2, c: 3 }
+{ a: 4, b: 4, c: 5 }
+*/
+
+-- test: UPDATE
+UPDATE test SET c = 10 WHERE a = 1 OR b = 3;
+SELECT * FROM test WHERE c = 10;
+/* sorted-result:
+{ a: 1, b: 1, c: 10 }
+{ a: 1, b: 2, c: 10 }
+{ a: 3, b: 3, c: 10 }
+{ a: 3, b: 3, c: 10 }
+*/
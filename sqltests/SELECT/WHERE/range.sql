@@ -0,0 +1,61 @@
+-- setup:
+CREATE TABLE test(a INT, b INT);
+CREATE INDEX test_a ON test(a);
+INSERT INTO test (a, b) VALUES (1, 1), (2, 2), (3, 3), (4, 4), (5, 5);
+
+-- test: > and <
+SELECT a FROM test WHERE a > 1 AND a < 4;
+/* result:
+{"a": 2}
+{"a": 3}
+*/
+
+-- test: >= and <=
+SELECT a FROM test WHERE a >= 2 AND a <= 4;
+/* result:
+{"a": 2}
+{"a": 3}
+{"a": 4}
+*/
+
+-- test: >= and <
+SELECT a FROM test WHERE a >= 2 AND a < 4;
+/* result:
+{"a": 2}
+{"a": 3}
+*/
+
+-- test: > and <=
+SELECT a FROM test WHERE a > 2 AND a <= 4;
+/* result:
+{"a": 3}
+{"a": 4}
+*/
+
+-- test: operand on the left
+SELECT a FROM test WHERE 3 < a;
+/* result:
+{"a": 4}
+{"a": 5}
+*/
+
+-- test: double boundaries, exclusive
+SELECT a FROM test WHERE a > 1.5 AND a < 4.5;
+/* result:
+{"a": 2}
+{"a": 3}
+{"a": 4}
+*/
+
+-- test: double boundaries, inclusive
+SELECT a FROM test WHERE a >= 1.5 AND a <= 3.5;
+/* result:
+{"a": 2}
+{"a": 3}
+*/
+
+-- test: BETWEEN with double boundaries
+SELECT a FROM test WHERE a BETWEEN 1.1 AND 2.2;
+/* result:
+{"a": 2}
+*/
@@ -0,0 +1,32 @@
+-- setup:
+CREATE TABLE test(id int PRIMARY KEY, email TEXT);
+INSERT INTO test (id, email) VALUES (1, 'A@B.com'), (2, '  a@b.com '), (3, 'c@d.com'), (4, null);
+
+-- test: lower
+SELECT id FROM test WHERE LOWER(email) = 'a@b.com';
+/* result:
+{"id": 1}
+*/
+
+-- test: upper
+SELECT id FROM test WHERE UPPER(email) = 'C@D.COM';
+/* result:
+{"id": 3}
+*/
+
+-- test: trim
+SELECT id FROM test WHERE LOWER(TRIM(email)) = 'a@b.com';
+/* result:
+{"id": 1}
+{"id": 2}
+*/
+
+-- test: null input
+SELECT id, LOWER(email) AS l, UPPER(email) AS u, TRIM(email) AS t FROM test WHERE id = 4;
+/* result:
+{"id": 4, "l": NULL, "u": NULL, "t": NULL}
+*/
+
+-- test: trim without arguments
+SELECT TRIM() FROM test;
+-- error:
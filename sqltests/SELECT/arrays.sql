@@ -0,0 +1,47 @@
+-- setup:
+CREATE TABLE test(id int PRIMARY KEY, tags ARRAY);
+INSERT INTO test (id, tags) VALUES (1, ["urgent", "bug", "ui", "backend"]);
+INSERT INTO test (id, tags) VALUES (2, ["feature"]);
+INSERT INTO test (id, tags) VALUES (3, []);
+INSERT INTO test (id) VALUES (4);
+
+-- test: indexing
+SELECT id, tags[0] FROM test;
+/* result:
+{"id": 1, "tags[0]": "urgent"}
+{"id": 2, "tags[0]": "feature"}
+{"id": 3, "tags[0]": null}
+{"id": 4, "tags[0]": null}
+*/
+
+-- test: indexing in WHERE
+SELECT id FROM test WHERE tags[0] = "urgent";
+/* result:
+{"id": 1}
+*/
+
+-- test: out-of-range index
+SELECT id, tags[3] FROM test WHERE tags[3] IS NOT NULL OR id = 2;
+/* result:
+{"id": 1, "tags[3]": "backend"}
+{"id": 2, "tags[3]": null}
+*/
+
+-- test: array_length
+SELECT id, ARRAY_LENGTH(tags) FROM test;
+/* result:
+{"id": 1, "ARRAY_LENGTH(tags)": 4}
+{"id": 2, "ARRAY_LENGTH(tags)": 1}
+{"id": 3, "ARRAY_LENGTH(tags)": 0}
+{"id": 4, "ARRAY_LENGTH(tags)": null}
+*/
+
+-- test: array_length in WHERE
+SELECT id FROM test WHERE ARRAY_LENGTH(tags) > 3;
+/* result:
+{"id": 1}
+*/
+
+-- test: array_length of non array
+SELECT ARRAY_LENGTH(id) FROM test;
+-- error: ARRAY_LENGTH() expects an array, got integer
@@ -0,0 +1,27 @@
+-- setup:
+CREATE TABLE users;
+INSERT INTO users (name, age) VALUES ('a', 10), ('b', 20), ('c', 18), ('d', null);
+
+-- test: searched case
+SELECT name, CASE WHEN age > 18 THEN 'adult' WHEN age IS NULL THEN 'unknown' ELSE 'minor' END AS category FROM users;
+/* result:
+{"name": "a", "category": "minor"}
+{"name": "b", "category": "adult"}
+{"name": "c", "category": "minor"}
+{"name": "d", "category": "unknown"}
+*/
+
+-- test: simple case
+SELECT name, CASE age WHEN 10 THEN 'ten' WHEN 20 THEN 'twenty' END AS n FROM users;
+/* result:
+{"name": "a", "n": "ten"}
+{"name": "b", "n": "twenty"}
+{"name": "c", "n": null}
+{"name": "d", "n": null}
+*/
+
+-- test: in where clause
+SELECT name FROM users WHERE CASE WHEN age IS NULL THEN false ELSE age < 18 END;
+/* result:
+{"name": "a"}
+*/
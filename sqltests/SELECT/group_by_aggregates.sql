@@ -0,0 +1,55 @@
+-- setup:
+CREATE TABLE users(id INT PRIMARY KEY, city TEXT, age INT);
+CREATE TABLE empty(city TEXT, age INT);
+INSERT INTO users (id, city, age) VALUES
+    (1, 'Lyon', 20),
+    (2, 'Paris', 30),
+    (3, 'Lyon', 40),
+    (4, 'Paris', 50),
+    (5, 'Nantes', 30),
+    (6, 'Paris', 10);
+
+-- test: count and avg
+SELECT city, COUNT(*), AVG(age) FROM users GROUP BY city;
+/* result:
+{"city": "Lyon", "COUNT(*)": 2, "AVG(age)": 30.0}
+{"city": "Nantes", "COUNT(*)": 1, "AVG(age)": 30.0}
+{"city": "Paris", "COUNT(*)": 3, "AVG(age)": 30.0}
+*/
+
+-- test: sum, min and max
+SELECT city, SUM(age), MIN(age), MAX(age) FROM users GROUP BY city;
+/* result:
+{"city": "Lyon", "SUM(age)": 60, "MIN(age)": 20, "MAX(age)": 40}
+{"city": "Nantes", "SUM(age)": 30, "MIN(age)": 30, "MAX(age)": 30}
+{"city": "Paris", "SUM(age)": 90, "MIN(age)": 10, "MAX(age)": 50}
+*/
+
+-- test: aliases
+SELECT city AS c, COUNT(*) AS n FROM users WHERE age > 15 GROUP BY city;
+/* result:
+{"c": "Lyon", "n": 2}
+{"c": "Nantes", "n": 1}
+{"c": "Paris", "n": 2}
+*/
+
+-- test: without GROUP BY
+SELECT COUNT(*), AVG(age), MIN(age), MAX(age), SUM(age) FROM users;
+/* result:
+{"COUNT(*)": 6, "AVG(age)": 30.0, "MIN(age)": 10, "MAX(age)": 50, "SUM(age)": 180}
+*/
+
+-- test: empty table
+SELECT COUNT(*) FROM empty;
+/* result:
+{"COUNT(*)": 0}
+*/
+
+-- test: empty table with GROUP BY
+SELECT city, COUNT(*) FROM empty GROUP BY city;
+/* result:
+*/
+
+-- test: non aggregated field
+SELECT city, age FROM users GROUP BY city;
+-- error:
@@ -0,0 +1,31 @@
+-- setup:
+CREATE TABLE test(a int, b text, c int);
+INSERT INTO test (a, b, c) VALUES (1, 'x', 10), (1, 'y', 20), (2, 'x', 30), (1, 'x', 40), (2, 'x', 50);
+
+-- test: GROUP BY a, b
+SELECT a, b FROM test GROUP BY a, b
+/* result:
+{"a": 1, "b": "x"}
+{"a": 1, "b": "y"}
+{"a": 2, "b": "x"}
+*/
+
+-- test: GROUP BY a, b with aggregators
+SELECT b, a, COUNT(*), SUM(c) FROM test GROUP BY a, b
+/* result:
+{"b": "x", "a": 1, "COUNT(*)": 2, "SUM(c)": 50}
+{"b": "y", "a": 1, "COUNT(*)": 1, "SUM(c)": 20}
+{"b": "x", "a": 2, "COUNT(*)": 2, "SUM(c)": 80}
+*/
+
+-- test: GROUP BY a, b selecting a subset
+SELECT a AS x, COUNT(*) FROM test GROUP BY a, b
+/* result:
+{"x": 1, "COUNT(*)": 2}
+{"x": 1, "COUNT(*)": 1}
+{"x": 2, "COUNT(*)": 2}
+*/
+
+-- test: selected field not in GROUP BY
+SELECT a, c FROM test GROUP BY a, b
+-- error:
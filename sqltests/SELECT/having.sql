@@ -0,0 +1,75 @@
+-- setup:
+CREATE TABLE test(id INT PRIMARY KEY, a INT, b INT);
+INSERT INTO test (id, a, b) VALUES (1, 1, 10), (2, 1, 20), (3, 1, 30), (4, 2, 40), (5, 3, 50), (6, 3, 60);
+
+-- test: aggregate alias
+SELECT a, COUNT(b) AS n FROM test GROUP BY a HAVING n > 1;
+/* result:
+{"a": 1, "n": 3}
+{"a": 3, "n": 2}
+*/
+
+-- test: aggregate function
+SELECT a, COUNT(b) AS n FROM test GROUP BY a HAVING COUNT(b) > 2;
+/* result:
+{"a": 1, "n": 3}
+*/
+
+-- test: aggregate function not projected
+SELECT a FROM test GROUP BY a HAVING SUM(b) >= 100;
+/* result:
+{"a": 3}
+*/
+
+-- test: grouped expression
+SELECT a, MAX(b) FROM test GROUP BY a HAVING a >= 2;
+/* result:
+{"a": 2, "MAX(b)": 40}
+{"a": 3, "MAX(b)": 60}
+*/
+
+-- test: grouped expression alias
+SELECT a % 2 AS odd, COUNT(*) FROM test GROUP BY a % 2 HAVING odd = 1;
+/* result:
+{"odd": 1, "COUNT(*)": 5}
+*/
+
+-- test: with WHERE
+SELECT a, COUNT(b) AS n FROM test WHERE b > 10 GROUP BY a HAVING n > 1;
+/* result:
+{"a": 1, "n": 2}
+{"a": 3, "n": 2}
+*/
+
+-- test: filters groups, not rows
+SELECT a FROM test GROUP BY a HAVING MIN(b) > 20;
+/* result:
+{"a": 2}
+{"a": 3}
+*/
+
+-- test: WHERE filters rows
+SELECT a FROM test WHERE b > 20 GROUP BY a;
+/* result:
+{"a": 1}
+{"a": 2}
+{"a": 3}
+*/
+
+-- test: without GROUP BY
+SELECT COUNT(*) AS n FROM test HAVING n > 5;
+/* result:
+{"n": 6}
+*/
+
+-- test: without GROUP BY, no match
+SELECT COUNT(*) AS n FROM test HAVING n > 10;
+/* result:
+*/
+
+-- test: alias without AS
+SELECT a, COUNT(*) c FROM test GROUP BY a HAVING c > 1;
+/* result:
+{"a": 1, "c": 3}
+{"a": 3, "c": 2}
+*/
@@ -0,0 +1,70 @@
+-- setup:
+CREATE TABLE foo(a INT, b INT);
+CREATE TABLE bar(a INT, b INT);
+CREATE TABLE baz(a INT, b INT);
+INSERT INTO foo (a, b) VALUES (1, 1), (2, 2), (2, 2), (3, 3);
+INSERT INTO bar (a, b) VALUES (2, 2), (3, 3), (4, 4);
+INSERT INTO baz (a, b) VALUES (3, 3);
+
+-- test: intersect
+SELECT * FROM foo
+INTERSECT
+SELECT * FROM bar;
+/* result:
+{"a": 2, "b": 2}
+{"a": 3, "b": 3}
+*/
+
+-- test: intersect with projection
+SELECT a FROM foo WHERE a > 2
+INTERSECT
+SELECT a FROM bar;
+/* result:
+{"a": 3}
+*/
+
+-- test: except
+SELECT * FROM foo
+EXCEPT
+SELECT * FROM bar;
+/* result:
+{"a": 1, "b": 1}
+*/
+
+-- test: except removes duplicates
+SELECT a FROM foo
+EXCEPT
+SELECT a FROM baz;
+/* result:
+{"a": 1}
+{"a": 2}
+*/
+
+-- test: chained from left to right
+SELECT * FROM foo
+UNION ALL
+SELECT * FROM bar
+EXCEPT
+SELECT * FROM baz
+INTERSECT
+SELECT * FROM bar;
+/* result:
+{"a": 2, "b": 2}
+{"a": 4, "b": 4}
+*/
+
+-- test: with order by and limit
+SELECT a FROM foo
+INTERSECT
+SELECT a FROM bar
+ORDER BY a DESC
+LIMIT 1;
+/* result:
+{"a": 3}
+*/
+
+-- test: different number of fields
+SELECT a FROM foo
+EXCEPT
+SELECT a, b FROM bar;
+-- error:
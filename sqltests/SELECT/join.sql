@@ -0,0 +1,86 @@
+-- setup:
+CREATE TABLE users(id INT PRIMARY KEY, name TEXT);
+CREATE TABLE orders(id INT PRIMARY KEY, user_id INT, amount INT);
+CREATE TABLE payments(order_id INT, status TEXT);
+CREATE INDEX orders_user_id ON orders(user_id);
+INSERT INTO users (id, name) VALUES (1, 'alice'), (2, 'bob'), (3, 'carol');
+INSERT INTO orders (id, user_id, amount) VALUES (1, 1, 10), (2, 1, 20), (3, 2, 30), (4, 4, 40);
+INSERT INTO payments (order_id, status) VALUES (1, 'paid'), (3, 'pending');
+
+-- test: join
+SELECT users.name, orders.amount FROM users JOIN orders ON users.id = orders.user_id;
+/* result:
+{"users.name": "alice", "orders.amount": 10}
+{"users.name": "alice", "orders.amount": 20}
+{"users.name": "bob", "orders.amount": 30}
+*/
+
+-- test: inner join with wildcard
+SELECT * FROM users INNER JOIN orders ON orders.user_id = users.id WHERE orders.amount > 15;
+/* result:
+{"users": {"id": 1, "name": "alice"}, "orders": {"id": 2, "user_id": 1, "amount": 20}}
+{"users": {"id": 2, "name": "bob"}, "orders": {"id": 3, "user_id": 2, "amount": 30}}
+*/
+
+-- test: join on primary key
+SELECT orders.id, users.name FROM orders JOIN users ON orders.user_id = users.id;
+/* result:
+{"orders.id": 1, "users.name": "alice"}
+{"orders.id": 2, "users.name": "alice"}
+{"orders.id": 3, "users.name": "bob"}
+*/
+
+-- test: join without index
+SELECT orders.id, payments.status FROM orders JOIN payments ON payments.order_id = orders.id;
+/* result:
+{"orders.id": 1, "payments.status": "paid"}
+{"orders.id": 3, "payments.status": "pending"}
+*/
+
+-- test: left join
+SELECT users.name, orders.amount FROM users LEFT JOIN orders ON users.id = orders.user_id;
+/* result:
+{"users.name": "alice", "orders.amount": 10}
+{"users.name": "alice", "orders.amount": 20}
+{"users.name": "bob", "orders.amount": 30}
+{"users.name": "carol", "orders.amount": null}
+*/
+
+-- test: left outer join with wildcard
+SELECT * FROM orders LEFT OUTER JOIN users ON orders.user_id = users.id WHERE orders.id > 2;
+/* result:
+{"orders": {"id": 3, "user_id": 2, "amount": 30}, "users": {"id": 2, "name": "bob"}}
+{"orders": {"id": 4, "user_id": 4, "amount": 40}, "users": null}
+*/
+
+-- test: multiple joins
+SELECT users.name, orders.id, payments.status FROM users
+JOIN orders ON users.id = orders.user_id
+LEFT JOIN payments ON payments.order_id = orders.id;
+/* result:
+{"users.name": "alice", "orders.id": 1, "payments.status": "paid"}
+{"users.name": "alice", "orders.id": 2, "payments.status": null}
+{"users.name": "bob", "orders.id": 3, "payments.status": "pending"}
+*/
+
+-- test: join with aggregation
+SELECT users.name, SUM(orders.amount) AS total FROM users JOIN orders ON users.id = orders.user_id GROUP BY users.name;
+/* result:
+{"users.name": "alice", "total": 30}
+{"users.name": "bob", "total": 30}
+*/
+
+-- test: join with non equality condition
+SELECT users.name, orders.id FROM users JOIN orders ON orders.amount > 25 AND users.id = 1;
+/* result:
+{"users.name": "alice", "orders.id": 3}
+{"users.name": "alice", "orders.id": 4}
+*/
+
+-- test: same table twice
+SELECT * FROM users JOIN users ON users.id = users.id;
+-- error:
+
+-- test: unknown table
+SELECT * FROM users JOIN unknown ON users.id = unknown.id;
+-- error:
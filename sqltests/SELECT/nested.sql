@@ -0,0 +1,57 @@
+-- setup:
+CREATE TABLE users;
+INSERT INTO users (name, address) VALUES ("a", {city: "New York", zip: "10001", geo: {lat: 40.7}});
+INSERT INTO users (name, address) VALUES ("b", {city: "Paris", zip: "75001"});
+INSERT INTO users (name, address) VALUES ("c", {city: "Boston"});
+INSERT INTO users (name) VALUES ("d");
+INSERT INTO users (name, address) VALUES ("e", "not a document");
+
+-- test: select nested field
+SELECT name, address.city FROM users;
+/* result:
+{"name": "a", "address.city": "New York"}
+{"name": "b", "address.city": "Paris"}
+{"name": "c", "address.city": "Boston"}
+{"name": "d", "address.city": null}
+{"name": "e", "address.city": null}
+*/
+
+-- test: filter on nested field
+SELECT address.city FROM users WHERE address.zip = "10001";
+/* result:
+{"address.city": "New York"}
+*/
+
+-- test: deeply nested field
+SELECT name, address.geo.lat FROM users;
+/* result:
+{"name": "a", "address.geo.lat": 40.7}
+{"name": "b", "address.geo.lat": null}
+{"name": "c", "address.geo.lat": null}
+{"name": "d", "address.geo.lat": null}
+{"name": "e", "address.geo.lat": null}
+*/
+
+-- test: filter on missing intermediate level
+SELECT name FROM users WHERE address.geo.lat > 0;
+/* result:
+{"name": "a"}
+*/
+
+-- test: filter on missing nested field
+SELECT name FROM users WHERE address.zip IS NULL;
+/* result:
+{"name": "c"}
+{"name": "d"}
+{"name": "e"}
+*/
+
+-- test: schemaful nested field
+CREATE TABLE test(a (b (c int)));
+INSERT INTO test (a) VALUES ({b: {c: 1}}), ({b: {}}), ({});
+SELECT a.b.c FROM test WHERE a.b.c = 1 OR a.b.c IS NULL;
+/* result:
+{"a.b.c": 1}
+{"a.b.c": null}
+{"a.b.c": null}
+*/
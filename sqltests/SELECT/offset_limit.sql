@@ -0,0 +1,79 @@
+-- setup:
+CREATE TABLE test(a int PRIMARY KEY, b int);
+INSERT INTO test (a, b) VALUES (1, 10), (2, 20), (3, 30), (4, 40), (5, 50);
+
+-- suite: no index
+
+-- suite: with index
+CREATE INDEX on test(b);
+
+-- test: no offset, no limit
+SELECT a FROM test WHERE b > 10 ORDER BY b;
+/* result:
+{"a": 2}
+{"a": 3}
+{"a": 4}
+{"a": 5}
+*/
+
+-- test: offset
+SELECT a FROM test WHERE b > 10 ORDER BY b OFFSET 2;
+/* result:
+{"a": 4}
+{"a": 5}
+*/
+
+-- test: limit
+SELECT a FROM test WHERE b > 10 ORDER BY b LIMIT 2;
+/* result:
+{"a": 2}
+{"a": 3}
+*/
+
+-- test: limit and offset
+SELECT a FROM test WHERE b > 10 ORDER BY b LIMIT 2 OFFSET 1;
+/* result:
+{"a": 3}
+{"a": 4}
+*/
+
+-- test: offset past the end
+SELECT a FROM test WHERE b > 10 ORDER BY b OFFSET 10;
+/* result:
+*/
+
+-- test: limit zero
+SELECT a FROM test WHERE b > 10 ORDER BY b LIMIT 0;
+/* result:
+*/
+
+-- test: negative offset
+SELECT a FROM test WHERE b > 10 ORDER BY b OFFSET -1;
+/* result:
+{"a": 2}
+{"a": 3}
+{"a": 4}
+{"a": 5}
+*/
+
+-- test: negative limit
+SELECT a FROM test WHERE b > 10 ORDER BY b LIMIT -1;
+/* result:
+{"a": 2}
+{"a": 3}
+{"a": 4}
+{"a": 5}
+*/
+
+-- test: negative limit and offset
+SELECT a FROM test WHERE b > 10 ORDER BY b LIMIT -1 OFFSET 3;
+/* result:
+{"a": 5}
+*/
+
+-- test: descending with limit and offset
+SELECT a FROM test WHERE b > 10 ORDER BY b DESC LIMIT 2 OFFSET 1;
+/* result:
+{"a": 4}
+{"a": 3}
+*/
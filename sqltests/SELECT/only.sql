@@ -0,0 +1,38 @@
+-- setup:
+CREATE TABLE test(a int, ...);
+INSERT INTO test(a, b) VALUES (1, null);
+
+-- test: lenient projection of a missing field
+SELECT a, z FROM test;
+/* result:
+{
+    "a": 1,
+    "z": null
+}
+*/
+
+-- test: strict projection
+SELECT ONLY a, b FROM test;
+/* result:
+{
+    "a": 1,
+    "b": null
+}
+*/
+
+-- test: strict projection with alias and expressions
+SELECT ONLY a AS A, a + 1 FROM test;
+/* result:
+{
+    "A": 1,
+    "a + 1": 2
+}
+*/
+
+-- test: strict projection of a missing field
+SELECT ONLY a, z FROM test;
+-- error: field "z" not found
+
+-- test: strict projection of a missing nested field
+SELECT ONLY a.b FROM test;
+-- error: field "a.b" not found
@@ -0,0 +1,62 @@
+-- setup:
+CREATE TABLE test(a INT, b INT);
+CREATE INDEX test_a_b ON test(a, b DESC);
+INSERT INTO test (a, b) VALUES (1, 2), (1, 3), (2, 1), (1, 1), (2, 4);
+
+-- test: asc on second path
+SELECT a, b FROM test WHERE a = 1 ORDER BY b;
+/* result:
+{
+    a: 1,
+    b: 1
+}
+{
+    a: 1,
+    b: 2
+}
+{
+    a: 1,
+    b: 3
+}
+*/
+
+-- test: asc on second path / explain
+EXPLAIN SELECT a, b FROM test WHERE a = 1 ORDER BY b;
+/* result:
+{
+    plan: "index.ScanReverse(\"test_a_b\", [{\"min\": [1], \"exact\": true}]) | docs.Project(a, b)"
+}
+*/
+
+-- test: desc on second path
+SELECT a, b FROM test WHERE a = 1 ORDER BY b DESC;
+/* result:
+{
+    a: 1,
+    b: 3
+}
+{
+    a: 1,
+    b: 2
+}
+{
+    a: 1,
+    b: 1
+}
+*/
+
+-- test: desc on second path / explain
+EXPLAIN SELECT a, b FROM test WHERE a = 1 ORDER BY b DESC;
+/* result:
+{
+    plan: "index.Scan(\"test_a_b\", [{\"min\": [1], \"exact\": true}]) | docs.Project(a, b)"
+}
+*/
+
+-- test: asc on first path
+EXPLAIN SELECT a, b FROM test ORDER BY a;
+/* result:
+{
+    plan: "index.Scan(\"test_a_b\") | docs.Project(a, b)"
+}
+*/
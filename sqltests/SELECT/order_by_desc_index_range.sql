@@ -0,0 +1,38 @@
+-- setup:
+CREATE TABLE test(a INT, b INT);
+CREATE INDEX test_a ON test(a DESC);
+INSERT INTO test (a, b) VALUES (1, 1), (2, 2), (3, 3), (4, 4), (5, 5);
+
+-- test: native order
+SELECT a FROM test ORDER BY a DESC;
+/* result:
+{"a": 5}
+{"a": 4}
+{"a": 3}
+{"a": 2}
+{"a": 1}
+*/
+
+-- test: native order / explain
+EXPLAIN SELECT a FROM test ORDER BY a DESC;
+/* result:
+{
+    "plan": 'index.Scan("test_a") | docs.Project(a)'
+}
+*/
+
+-- test: range in native order
+SELECT a FROM test WHERE a >= 2 AND a < 5 ORDER BY a DESC;
+/* result:
+{"a": 4}
+{"a": 3}
+{"a": 2}
+*/
+
+-- test: range in reverse order
+SELECT a FROM test WHERE a >= 2 AND a < 5 ORDER BY a;
+/* result:
+{"a": 2}
+{"a": 3}
+{"a": 4}
+*/
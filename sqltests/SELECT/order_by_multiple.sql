@@ -0,0 +1,55 @@
+-- setup:
+CREATE TABLE test(id INT, a INT, b TEXT);
+CREATE INDEX test_a ON test(a);
+INSERT INTO test (id, a, b) VALUES
+    (1, 2, 'x'),
+    (2, 1, 'y'),
+    (3, 2, 'z'),
+    (4, 1, 'x'),
+    (5, 3, 'y');
+
+-- test: ASC, ASC
+SELECT a, b FROM test ORDER BY a, b;
+/* result:
+{"a": 1, "b": "x"}
+{"a": 1, "b": "y"}
+{"a": 2, "b": "x"}
+{"a": 2, "b": "z"}
+{"a": 3, "b": "y"}
+*/
+
+-- test: ASC, DESC
+SELECT a, b FROM test ORDER BY a ASC, b DESC;
+/* result:
+{"a": 1, "b": "y"}
+{"a": 1, "b": "x"}
+{"a": 2, "b": "z"}
+{"a": 2, "b": "x"}
+{"a": 3, "b": "y"}
+*/
+
+-- test: DESC, ASC
+SELECT a, b FROM test ORDER BY a DESC, b;
+/* result:
+{"a": 3, "b": "y"}
+{"a": 2, "b": "x"}
+{"a": 2, "b": "z"}
+{"a": 1, "b": "x"}
+{"a": 1, "b": "y"}
+*/
+
+-- test: with LIMIT
+SELECT id FROM test ORDER BY b DESC, a DESC LIMIT 3;
+/* result:
+{"id": 3}
+{"id": 5}
+{"id": 2}
+*/
+
+-- test: plan
+EXPLAIN SELECT a, b FROM test WHERE a > 1 ORDER BY a, b DESC;
+/* result:
+{
+    "plan": 'index.Scan("test_a", [{"min": [1], "exclusive": true}]) | docs.Project(a, b) | docs.TempTreeSort(a, b DESC)'
+}
+*/
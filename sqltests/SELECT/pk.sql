@@ -11,3 +11,21 @@ SELECT pk(), a FROM test;
 {"pk()": [4], "a": 4.0}
 {"pk()": [5], "a": 5.0}
 */
+
+-- test: delete using pk()
+DELETE FROM test WHERE pk() = [2] OR pk() = [4];
+SELECT pk(), a FROM test;
+/* result:
+{"pk()": [1], "a": 1.0}
+{"pk()": [3], "a": 3.0}
+{"pk()": [5], "a": 5.0}
+*/
+
+-- test: composite primary key
+CREATE TABLE test2(a INT, b TEXT, c INT, PRIMARY KEY (a, b));
+INSERT INTO test2(a, b, c) VALUES (1, 'foo', 10), (1, 'bar', 20);
+SELECT pk(), c FROM test2;
+/* result:
+{"pk()": [1, "bar"], "c": 20}
+{"pk()": [1, "foo"], "c": 10}
+*/
@@ -46,6 +46,18 @@ SELECT CAST(1 AS DOUBLE) AS A;
 {"A": 1.0}
 */
 
+-- test: arithmetic
+SELECT 1 + 2 AS a, 10 % 6 AS b, 2.5 * 2 AS c, 10 / 4 AS d;
+/* result:
+{"a": 3, "b": 4, "c": 5.0, "d": 2}
+*/
+
+-- test: functions
+SELECT UPPER('hi') AS u, LOWER('HI') AS l, typeof(1.5) AS t, COALESCE(NULL, 2) AS c;
+/* result:
+{"u": "HI", "l": "hi", "t": "double", "c": 2}
+*/
+
 -- test: pk()
 SELECT pk();
 /* result:
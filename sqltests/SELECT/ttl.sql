@@ -0,0 +1,77 @@
+-- setup:
+CREATE TABLE test(a INT PRIMARY KEY, b INT, expires_at TIMESTAMP) WITH (ttl_field = expires_at);
+INSERT INTO test (a, b, expires_at) VALUES (1, 10, "2000-01-01"), (2, 20, "2999-01-01"), (3, 30, NULL), (4, 40, "2001-01-01");
+INSERT INTO test (a, b) VALUES (5, 50);
+
+-- suite: no index
+
+-- suite: with index
+CREATE INDEX ON test(b);
+
+-- test: expired documents are skipped
+SELECT a FROM test;
+/* result:
+{
+  a: 2
+}
+{
+  a: 3
+}
+{
+  a: 5
+}
+*/
+
+-- test: primary key
+SELECT a FROM test WHERE a = 1;
+/* result:
+*/
+
+-- test: filter
+SELECT a FROM test WHERE b >= 10 ORDER BY b;
+/* result:
+{
+  a: 2
+}
+{
+  a: 3
+}
+{
+  a: 5
+}
+*/
+
+-- test: count
+SELECT COUNT(*) FROM test;
+/* result:
+{
+  "COUNT(*)": 3
+}
+*/
+
+-- test: update
+UPDATE test SET b = 0;
+SELECT a, b FROM test;
+/* result:
+{
+  a: 2,
+  b: 0
+}
+{
+  a: 3,
+  b: 0
+}
+{
+  a: 5,
+  b: 0
+}
+*/
+
+-- test: delete
+DELETE FROM test;
+SELECT COUNT(*) FROM test;
+/* result:
+{
+  "COUNT(*)": 0
+}
+*/
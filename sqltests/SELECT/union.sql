@@ -135,4 +135,60 @@ SELECT * FROM baz;
 {"a": 2.0, "b": 2.0}
 {"a": 3.0, "b": 3.0}
 {"x": "a", "y": "a"}
-{"x": "b", "y": "b"}
\ No newline at end of file
+{"x": "b", "y": "b"}
+*/
+
+-- test: union with compatible projections
+SELECT a, b FROM foo
+UNION
+SELECT x AS a, y AS b FROM baz;
+/* result:
+{"a": 1.0, "b": 1.0}
+{"a": 2.0, "b": 2.0}
+{"a": "a", "b": "a"}
+{"a": "b", "b": "b"}
+*/
+
+-- test: union dedup with projections
+SELECT a FROM foo
+UNION
+SELECT a FROM bar;
+/* result:
+{"a": 1.0}
+{"a": 2.0}
+{"a": 3.0}
+*/
+
+-- test: union all keeps duplicates with projections
+SELECT a FROM foo
+UNION ALL
+SELECT a FROM bar;
+/* result:
+{"a": 1.0}
+{"a": 2.0}
+{"a": 2.0}
+{"a": 3.0}
+*/
+
+-- test: union with incompatible projections
+SELECT a FROM foo
+UNION
+SELECT a, b FROM bar;
+-- error:
+
+-- test: union all with incompatible projections
+SELECT a, b FROM foo
+UNION ALL
+SELECT x FROM baz;
+-- error:
+
+-- test: union with wildcard and projection
+SELECT * FROM foo
+UNION ALL
+SELECT x FROM baz;
+/* result:
+{"a": 1.0, "b": 1.0}
+{"a": 2.0, "b": 2.0}
+{"x": "a"}
+{"x": "b"}
+*/
@@ -0,0 +1,94 @@
+-- setup:
+CREATE TABLE foo(a INT, b INT);
+CREATE TABLE bar(a INT, c INT);
+INSERT INTO foo (a, b) VALUES (1, 1), (2, 2), (3, 3);
+INSERT INTO bar (a, c) VALUES (2, 20), (3, 30), (4, 40);
+CREATE VIEW big AS SELECT a, b * 10 AS b FROM foo WHERE a > 1;
+
+-- test: basic
+SELECT * FROM big;
+/* result:
+{"a": 2, "b": 20}
+{"a": 3, "b": 30}
+*/
+
+-- test: filter
+SELECT b FROM big WHERE a = 3;
+/* result:
+{"b": 30}
+*/
+
+-- test: changes of the table are visible
+INSERT INTO foo (a, b) VALUES (4, 4);
+SELECT * FROM big;
+/* result:
+{"a": 2, "b": 20}
+{"a": 3, "b": 30}
+{"a": 4, "b": 40}
+*/
+
+-- test: join
+SELECT big.a, bar.c FROM big JOIN bar ON big.a = bar.a;
+/* result:
+{"big.a": 2, "bar.c": 20}
+{"big.a": 3, "bar.c": 30}
+*/
+
+-- test: referenced twice
+SELECT a FROM big
+UNION ALL
+SELECT a FROM big;
+/* result:
+{"a": 2}
+{"a": 3}
+{"a": 2}
+{"a": 3}
+*/
+
+-- test: view of a view
+CREATE VIEW bigger AS SELECT a FROM big WHERE b > 20;
+SELECT * FROM bigger;
+/* result:
+{"a": 3}
+*/
+
+-- test: aggregation
+CREATE VIEW total AS SELECT COUNT(*) AS n, SUM(b) AS s FROM foo;
+SELECT * FROM total;
+/* result:
+{"n": 3, "s": 6}
+*/
+
+-- test: shadowed by WITH
+WITH big AS (SELECT a FROM bar)
+SELECT * FROM big;
+/* result:
+{"a": 2}
+{"a": 3}
+{"a": 4}
+*/
+
+-- test: read by WITH
+WITH small AS (SELECT a FROM big WHERE a < 3)
+SELECT * FROM small;
+/* result:
+{"a": 2}
+*/
+
+-- test: INSERT ... SELECT
+CREATE TABLE baz(a INT, b INT);
+INSERT INTO baz SELECT * FROM big;
+SELECT * FROM baz;
+/* result:
+{"a": 2, "b": 20}
+{"a": 3, "b": 30}
+*/
+
+-- test: table dropped
+DROP TABLE foo;
+SELECT * FROM big;
+-- error:
+
+-- test: not writable
+INSERT INTO big (a, b) VALUES (10, 10);
+-- error:
@@ -0,0 +1,61 @@
+-- test: schemaful
+CREATE TABLE test(a int, b int, c int);
+INSERT INTO test (c, a, b) VALUES (1, 2, 3);
+INSERT INTO test (b, c, a) VALUES (4, 5, 6);
+SELECT * FROM test;
+/* result:
+{"a": 2, "b": 3, "c": 1}
+{"a": 6, "b": 4, "c": 5}
+*/
+
+-- test: schemaful with missing fields
+CREATE TABLE test(a int, b int, c int);
+INSERT INTO test (c, a) VALUES (1, 2);
+INSERT INTO test (b) VALUES (3);
+SELECT * FROM test;
+/* result:
+{"a": 2, "c": 1}
+{"b": 3}
+*/
+
+-- test: schemaful with nested document
+CREATE TABLE test(a int, b (c int, d int));
+INSERT INTO test (b, a) VALUES ({d: 1, c: 2}, 3);
+SELECT * FROM test;
+/* result:
+{"a": 3, "b": {"c": 2, "d": 1}}
+*/
+
+-- test: schemaful with extra fields
+CREATE TABLE test(a int, b int, ...);
+INSERT INTO test (z, b, y, a) VALUES (1, 2, 3, 4);
+SELECT * FROM test;
+/* result:
+{"a": 4, "b": 2, "z": 1.0, "y": 3.0}
+*/
+
+-- test: schemaful after update
+CREATE TABLE test(a int, b int, c int);
+INSERT INTO test (b) VALUES (1);
+UPDATE test SET c = 2, a = 3;
+SELECT * FROM test;
+/* result:
+{"a": 3, "b": 1, "c": 2}
+*/
+
+-- test: schemaful after adding a field
+CREATE TABLE test(b int, ...);
+INSERT INTO test (z, b) VALUES (1, 2);
+ALTER TABLE test ADD FIELD a int DEFAULT 3;
+SELECT * FROM test;
+/* result:
+{"b": 2, "a": 3, "z": 1.0}
+*/
+
+-- test: schemaless
+CREATE TABLE test;
+INSERT INTO test (c, a, b) VALUES (1, 2, 3);
+SELECT * FROM test;
+/* result:
+{"c": 1.0, "a": 2.0, "b": 3.0}
+*/
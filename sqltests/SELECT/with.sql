@@ -0,0 +1,74 @@
+-- setup:
+CREATE TABLE foo(a INT, b INT);
+CREATE TABLE bar(a INT, c INT);
+INSERT INTO foo (a, b) VALUES (1, 1), (2, 2), (3, 3);
+INSERT INTO bar (a, c) VALUES (2, 20), (3, 30), (4, 40);
+
+-- test: basic
+WITH big AS (SELECT * FROM foo WHERE a > 1)
+SELECT * FROM big;
+/* result:
+{"a": 2, "b": 2}
+{"a": 3, "b": 3}
+*/
+
+-- test: projection
+WITH big AS (SELECT a, b * 10 AS b FROM foo WHERE a > 1)
+SELECT b FROM big WHERE a = 3;
+/* result:
+{"b": 30}
+*/
+
+-- test: referenced twice
+WITH big AS (SELECT a FROM foo WHERE a > 1)
+SELECT * FROM big
+UNION ALL
+SELECT * FROM big;
+/* result:
+{"a": 2}
+{"a": 3}
+{"a": 2}
+{"a": 3}
+*/
+
+-- test: self join
+WITH f AS (SELECT a FROM foo)
+SELECT * FROM f JOIN bar ON f.a = bar.a;
+/* result:
+{"f": {"a": 2}, "bar": {"a": 2, "c": 20}}
+{"f": {"a": 3}, "bar": {"a": 3, "c": 30}}
+*/
+
+-- test: multiple
+WITH f AS (SELECT a FROM foo), b AS (SELECT a, c FROM bar WHERE c < 40)
+SELECT f.a, b.c FROM f JOIN b ON f.a = b.a;
+/* result:
+{"f.a": 2, "b.c": 20}
+{"f.a": 3, "b.c": 30}
+*/
+
+-- test: reference previous
+WITH f AS (SELECT a FROM foo WHERE a > 1), g AS (SELECT a FROM f WHERE a < 3)
+SELECT * FROM g;
+/* result:
+{"a": 2}
+*/
+
+-- test: order by and limit
+WITH f AS (SELECT a FROM foo ORDER BY a DESC LIMIT 2)
+SELECT * FROM f ORDER BY a;
+/* result:
+{"a": 2}
+{"a": 3}
+*/
+
+-- test: empty
+WITH f AS (SELECT a FROM foo WHERE a > 10)
+SELECT * FROM f;
+/* result:
+*/
+
+-- test: duplicate name
+WITH f AS (SELECT a FROM foo), f AS (SELECT a FROM bar)
+SELECT * FROM f;
+-- error:
@@ -24,7 +24,7 @@ UPDATE test SET a = "hello";
 -- test: no type constraint, null
 CREATE TABLE test (a CHECK(a > 10));
 INSERT INTO test (a) VALUES (11);
-UPDATE test UNSET a;
+UPDATE test SET a = NULL;
 SELECT * FROM test;
 /* result:
 {}
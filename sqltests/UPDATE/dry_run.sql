@@ -0,0 +1,23 @@
+-- setup:
+CREATE TABLE test(id INTEGER PRIMARY KEY, a TEXT);
+INSERT INTO test (id, a) VALUES (1, 'foo'), (2, 'bar'), (3, 'foo');
+
+-- test: returns the updated documents
+UPDATE test SET a = 'baz' WHERE a = 'foo' DRY RUN;
+/* result:
+{"pk()": [1], "id": 1, "a": "baz"}
+{"pk()": [3], "id": 3, "a": "baz"}
+*/
+
+-- test: does not update anything
+UPDATE test SET a = 'baz' DRY RUN;
+SELECT * FROM test;
+/* result:
+{"id": 1, "a": "foo"}
+{"id": 2, "a": "bar"}
+{"id": 3, "a": "foo"}
+*/
+
+-- test: incomplete
+UPDATE test SET a = 'baz' DRY;
+-- error:
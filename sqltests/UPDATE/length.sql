@@ -0,0 +1,16 @@
+-- test: varchar, valid length
+CREATE TABLE test (a VARCHAR(5));
+INSERT INTO test (a) VALUES ("foo");
+UPDATE test SET a = "hello";
+SELECT * FROM test;
+/* result:
+{
+    a: "hello"
+}
+*/
+
+-- test: varchar, length exceeded
+CREATE TABLE test (a VARCHAR(5));
+INSERT INTO test (a) VALUES ("foo");
+UPDATE test SET a = "hello!";
+-- error: VARCHAR(5) constraint error: [a]
@@ -0,0 +1,18 @@
+-- setup:
+CREATE TABLE test(a INT NOT NULL, b INT);
+INSERT INTO test (a, b) VALUES (1, 1);
+
+-- test: set to NULL
+UPDATE test SET a = NULL;
+-- error:
+
+-- test: unset
+UPDATE test UNSET a;
+-- error:
+
+-- test: set to a value
+UPDATE test SET a = 2;
+SELECT a, b FROM test;
+/* result:
+{a: 2, b: 1}
+*/
@@ -0,0 +1,47 @@
+-- setup:
+CREATE TABLE test(id INTEGER PRIMARY KEY, status TEXT, created_at INTEGER);
+CREATE INDEX test_created_at ON test(created_at);
+INSERT INTO test (id, status, created_at) VALUES (1, 'new', 40), (2, 'new', 30), (3, 'new', 10), (4, 'done', 20), (5, 'new', 50);
+
+-- test: limit
+UPDATE test SET status = 'running' LIMIT 2;
+SELECT id FROM test WHERE status = 'running';
+/* result:
+{"id": 1}
+{"id": 2}
+*/
+
+-- test: where, order by, limit
+UPDATE test SET status = 'running' WHERE status = 'new' ORDER BY created_at LIMIT 2;
+SELECT id FROM test WHERE status = 'running';
+/* result:
+{"id": 2}
+{"id": 3}
+*/
+
+-- test: order by desc, limit, offset
+UPDATE test SET status = 'running' ORDER BY created_at DESC LIMIT 2 OFFSET 1;
+SELECT id FROM test WHERE status = 'running';
+/* result:
+{"id": 1}
+{"id": 2}
+*/
+
+-- test: indexed order by
+EXPLAIN UPDATE test SET status = 'running' ORDER BY created_at LIMIT 1;
+/* result:
+{
+    "plan": 'index.Scan("test_created_at") | docs.Take(1) | paths.Set(status, "running") | table.Validate("test") | index.Delete("test_created_at") | table.Replace("test") | index.Insert("test_created_at") | discard()'
+}
+*/
+
+-- test: update the sorted field
+UPDATE test SET created_at = created_at + 100 ORDER BY created_at LIMIT 2;
+SELECT id, created_at FROM test ORDER BY created_at;
+/* result:
+{"id": 2, "created_at": 30}
+{"id": 1, "created_at": 40}
+{"id": 5, "created_at": 50}
+{"id": 3, "created_at": 110}
+{"id": 4, "created_at": 120}
+*/
@@ -0,0 +1,69 @@
+-- setup:
+CREATE TABLE test(a INT PRIMARY KEY, b INT);
+CREATE TABLE log(event TEXT, a INT, old_b INT, new_b INT);
+INSERT INTO test (a, b) VALUES (1, 1), (2, 2), (3, 3);
+
+-- test: AFTER UPDATE
+CREATE TRIGGER tr AFTER UPDATE ON test INSERT INTO log VALUES ('update', new.a, old.b, new.b);
+UPDATE test SET b = b * 10 WHERE a > 1;
+SELECT * FROM log;
+/* result:
+{
+  "event": "update",
+  "a": 2,
+  "old_b": 2,
+  "new_b": 20
+}
+{
+  "event": "update",
+  "a": 3,
+  "old_b": 3,
+  "new_b": 30
+}
+*/
+
+-- test: BEFORE UPDATE
+CREATE TRIGGER tr BEFORE UPDATE ON test INSERT INTO log VALUES ('update', new.a, old.b, new.b);
+UPDATE test SET b = 0 WHERE a = 1;
+SELECT * FROM log;
+/* result:
+{
+  "event": "update",
+  "a": 1,
+  "old_b": 1,
+  "new_b": 0
+}
+*/
+
+-- test: primary key modified
+CREATE TRIGGER tr AFTER UPDATE ON test INSERT INTO log (event, a, old_b, new_b) VALUES ('update', old.a, old.b, new.a);
+UPDATE test SET a = a + 10 WHERE a = 1;
+SELECT * FROM log;
+/* result:
+{
+  "event": "update",
+  "a": 1,
+  "old_b": 1,
+  "new_b": 11
+}
+*/
+
+-- test: INSERT and DELETE triggers are not fired
+CREATE TRIGGER tr1 AFTER INSERT ON test INSERT INTO log (event) VALUES ('insert');
+CREATE TRIGGER tr2 AFTER DELETE ON test INSERT INTO log (event) VALUES ('delete');
+UPDATE test SET b = 0;
+SELECT * FROM log;
+/* result:
+*/
+
+-- test: denormalization
+CREATE TABLE totals(id INT PRIMARY KEY, total INT);
+INSERT INTO totals VALUES (1, 6);
+CREATE TRIGGER tr AFTER UPDATE ON test UPDATE totals SET total = total - old.b + new.b WHERE id = 1;
+UPDATE test SET b = 10 WHERE a = 2;
+SELECT total FROM totals;
+/* result:
+{
+  "total": 14
+}
+*/
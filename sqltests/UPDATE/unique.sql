@@ -4,4 +4,13 @@ CREATE TABLE test(a int UNIQUE);
 -- test: conflict
 INSERT INTO test VALUES (1), (2);
 UPDATE test SET a = 2 WHERE a = 1;
--- error: UNIQUE constraint error: [a]
\ No newline at end of file
+-- error: UNIQUE constraint error: [a]
+
+-- test: NULL
+INSERT INTO test VALUES (1), (2);
+UPDATE test SET a = NULL;
+SELECT a FROM test;
+/* result:
+{a: NULL}
+{a: NULL}
+*/
@@ -0,0 +1,42 @@
+-- test: schemaless
+CREATE TABLE test;
+INSERT INTO test (a, b, c) VALUES (1, 2, 3), (4, 5, 6);
+UPDATE test UNSET b WHERE a = 1.0;
+SELECT * FROM test;
+/* result:
+{"a": 1.0, "c": 3.0}
+{"a": 4.0, "b": 5.0, "c": 6.0}
+*/
+
+-- test: schemaless, multiple fields
+CREATE TABLE test;
+INSERT INTO test (a, b, c) VALUES (1, 2, 3);
+UPDATE test UNSET a, c;
+SELECT * FROM test;
+/* result:
+{"b": 2.0}
+*/
+
+-- test: schemaless, missing field
+CREATE TABLE test;
+INSERT INTO test (a) VALUES (1);
+UPDATE test UNSET b;
+SELECT * FROM test;
+/* result:
+{"a": 1.0}
+*/
+
+-- test: schemaful, declared field
+CREATE TABLE test (a int, b int);
+INSERT INTO test (a, b) VALUES (1, 2);
+UPDATE test UNSET b;
+-- error: cannot unset declared field "b"
+
+-- test: schemaful, extra field
+CREATE TABLE test (a int, ...);
+INSERT INTO test (a, b) VALUES (1, 2);
+UPDATE test UNSET b;
+SELECT * FROM test;
+/* result:
+{"a": 1}
+*/
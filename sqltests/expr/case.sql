@@ -0,0 +1,34 @@
+-- test: CASE
+> CASE WHEN 1 = 1 THEN 'a' ELSE 'b' END
+'a'
+
+> CASE WHEN 1 = 2 THEN 'a' ELSE 'b' END
+'b'
+
+> CASE WHEN 1 = 2 THEN 'a' END
+NULL
+
+> CASE WHEN 1 = 2 THEN 'a' WHEN 2 = 2 THEN 'b' ELSE 'c' END
+'b'
+
+> CASE WHEN NULL THEN 'a' ELSE 'b' END
+'b'
+
+> CASE WHEN 1 = 1 THEN 1 + 1 END
+2
+
+-- test: CASE with operand
+> CASE 2 WHEN 1 THEN 'a' WHEN 2 THEN 'b' ELSE 'c' END
+'b'
+
+> CASE 3 WHEN 1 THEN 'a' WHEN 2 THEN 'b' ELSE 'c' END
+'c'
+
+> CASE 3 WHEN 1 THEN 'a' END
+NULL
+
+> CASE 1 + 1 WHEN 2.0 THEN 'a' END
+'a'
+
+> CASE NULL WHEN NULL THEN 'a' ELSE 'b' END
+'b'
@@ -71,6 +71,15 @@ true
 > CAST ('a' AS TEXT)
 'a'
 
+> CAST ('a' AS VARCHAR)
+'a'
+
+! CAST ('abc' AS VARCHAR(2))
+'expected type without length'
+
+! CAST ('abc' AS CHARACTER(2))
+'expected type without length'
+
 > CAST ('100' AS INTEGER)
 100
 
@@ -17,3 +17,7 @@
 -- test: with more than one null value with text
 > COALESCE(null, null, null, 'hey')
 'hey'
+
+-- test: with only null values
+> COALESCE(null, null)
+NULL
@@ -0,0 +1,25 @@
+-- test: equal values
+> NULLIF(1, 1)
+NULL
+
+-- test: different values
+> NULLIF(1, 2)
+1
+
+-- test: equal values of different numeric types
+> NULLIF(1, 1.0)
+NULL
+
+-- test: text
+> NULLIF('hey', 'hey')
+NULL
+
+> NULLIF('hey', 'ho')
+'hey'
+
+-- test: with null
+> NULLIF(null, 1)
+NULL
+
+> NULLIF(1, null)
+1
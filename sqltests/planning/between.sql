@@ -26,3 +26,39 @@ EXPLAIN SELECT * FROM test WHERE a = 1 AND b = 10 AND c = 100 AND d BETWEEN 1000
     "plan": 'index.Scan("test_a_b_c_d_idx", [{"min": [1, 10, 100, 1000], "max": [1, 10, 100, 2000]}]) | docs.Filter(e > 10000)'
 }
 */
+
+-- test: NOT BETWEEN
+CREATE TABLE test(a int UNIQUE);
+EXPLAIN SELECT * FROM test WHERE a NOT BETWEEN 1 AND 2;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(a NOT BETWEEN 1 AND 2)'
+}
+*/
+
+-- test: BETWEEN with a NULL boundary
+CREATE TABLE test(a int UNIQUE);
+EXPLAIN SELECT * FROM test WHERE a BETWEEN NULL AND 2;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(a BETWEEN NULL AND 2)'
+}
+*/
+
+-- test: BETWEEN with a path boundary
+CREATE TABLE test(a int UNIQUE, b int);
+EXPLAIN SELECT * FROM test WHERE a BETWEEN 1 AND b;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(a BETWEEN 1 AND b)'
+}
+*/
+
+-- test: BETWEEN on the primary key
+CREATE TABLE test(a int PRIMARY KEY);
+EXPLAIN SELECT * FROM test WHERE a BETWEEN 1 AND 2;
+/* result:
+{
+    "plan": 'table.Scan("test", [{"min": [1], "max": [2]}])'
+}
+*/
@@ -0,0 +1,135 @@
+-- setup:
+CREATE TABLE test(id INT PRIMARY KEY, a INT NOT NULL, b TEXT NOT NULL, c INT, d INT);
+CREATE INDEX test_a_b ON test(a, b);
+CREATE INDEX test_c ON test(c);
+INSERT INTO test (id, a, b, c, d) VALUES (1, 10, 'foo', 100, 1000), (2, 20, 'bar', 200, 2000), (3, 30, 'baz', NULL, 3000);
+
+-- test: indexed fields
+EXPLAIN SELECT a, b FROM test WHERE a > 10;
+/* result:
+{
+    "plan": 'index.CoveringScan("test_a_b", [{"min": [10], "exclusive": true}]) | docs.Project(a, b)'
+}
+*/
+
+-- test: indexed fields / result
+SELECT a, b FROM test WHERE a > 10;
+/* result:
+{
+    "a": 20,
+    "b": "bar"
+}
+{
+    "a": 30,
+    "b": "baz"
+}
+*/
+
+-- test: primary key and expressions
+EXPLAIN SELECT id, a + 1 AS x FROM test WHERE a = 20 AND b != 'foo';
+/* result:
+{
+    "plan": 'index.CoveringScan("test_a_b", [{"min": [20], "exact": true}]) | docs.Filter(b != "foo") | docs.Project(id, a + 1)'
+}
+*/
+
+-- test: primary key and expressions / result
+SELECT id, a + 1 AS x FROM test WHERE a = 20 AND b != 'foo';
+/* result:
+{
+    "id": 2,
+    "x": 21
+}
+*/
+
+-- test: ordered
+EXPLAIN SELECT a FROM test ORDER BY a DESC LIMIT 2;
+/* result:
+{
+    "plan": 'index.CoveringScanReverse("test_a_b") | docs.Project(a) | docs.Take(2)'
+}
+*/
+
+-- test: ordered / result
+SELECT a FROM test ORDER BY a DESC LIMIT 2;
+/* result:
+{
+    "a": 30
+}
+{
+    "a": 20
+}
+*/
+
+-- test: field not in the index
+EXPLAIN SELECT a, d FROM test WHERE a > 10;
+/* result:
+{
+    "plan": 'index.Scan("test_a_b", [{"min": [10], "exclusive": true}]) | docs.Project(a, d)'
+}
+*/
+
+-- test: filter on a field not in the index
+EXPLAIN SELECT a FROM test WHERE a > 10 AND d > 10;
+/* result:
+{
+    "plan": 'index.Scan("test_a_b", [{"min": [10], "exclusive": true}]) | docs.Filter(d > 10) | docs.Project(a)'
+}
+*/
+
+-- test: wildcard
+EXPLAIN SELECT * FROM test WHERE a > 10;
+/* result:
+{
+    "plan": 'index.Scan("test_a_b", [{"min": [10], "exclusive": true}])'
+}
+*/
+
+-- test: nullable field
+EXPLAIN SELECT c FROM test WHERE c > 10;
+/* result:
+{
+    "plan": 'index.Scan("test_c", [{"min": [10], "exclusive": true}]) | docs.Project(c)'
+}
+*/
+
+-- test: sorted by a field not in the index
+CREATE TABLE t(id INT PRIMARY KEY, a INT, b INT);
+CREATE INDEX ON t(a);
+INSERT INTO t (id, a, b) VALUES (1, 2, 1), (2, 3, 3), (3, 4, 2), (4, 5, 4);
+EXPLAIN SELECT id FROM t WHERE a > 1 ORDER BY b;
+/* result:
+{
+    "plan": 'index.Scan("t_a_idx", [{"min": [1], "exclusive": true}]) | docs.Project(id) | docs.TempTreeSort(b)'
+}
+*/
+
+-- test: sorted by a field not in the index / result
+CREATE TABLE t(id INT PRIMARY KEY, a INT, b INT);
+CREATE INDEX ON t(a);
+INSERT INTO t (id, a, b) VALUES (1, 2, 1), (2, 3, 3), (3, 4, 2), (4, 5, 4);
+SELECT id FROM t WHERE a > 1 ORDER BY b;
+/* result:
+{
+    "id": 1
+}
+{
+    "id": 3
+}
+{
+    "id": 2
+}
+{
+    "id": 4
+}
+*/
+
+-- test: sorted by a field in the index
+CREATE TABLE t(id INT PRIMARY KEY, a INT, b INT);
+CREATE INDEX ON t(a);
+EXPLAIN SELECT id FROM t WHERE a > 1 ORDER BY a DESC;
+/* result:
+{
+    "plan": 'index.CoveringScanReverse("t_a_idx", [{"min": [1], "exclusive": true}]) | docs.Project(id)'
+}
+*/
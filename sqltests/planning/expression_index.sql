@@ -0,0 +1,52 @@
+-- setup:
+CREATE TABLE users(id INT PRIMARY KEY, email TEXT, age INT);
+CREATE INDEX users_lower_email ON users (LOWER(email));
+CREATE INDEX users_age_plus ON users (age + 1);
+
+-- test: =
+EXPLAIN SELECT * FROM users WHERE LOWER(email) = 'foo@example.com';
+/* result:
+{
+    "plan": 'index.Scan("users_lower_email", [{"min": ["foo@example.com"], "exact": true}])'
+}
+*/
+
+-- test: = with the expression on the right
+EXPLAIN SELECT * FROM users WHERE 'foo@example.com' = LOWER(email);
+/* result:
+{
+    "plan": 'index.Scan("users_lower_email", [{"min": ["foo@example.com"], "exact": true}])'
+}
+*/
+
+-- test: range
+EXPLAIN SELECT * FROM users WHERE age + 1 > 18;
+/* result:
+{
+    "plan": 'index.Scan("users_age_plus", [{"min": [18], "exclusive": true}])'
+}
+*/
+
+-- test: IN
+EXPLAIN SELECT * FROM users WHERE LOWER(email) IN ('a', 'b');
+/* result:
+{
+    "plan": 'index.Scan("users_lower_email", [{"min": ["a"], "exact": true}, {"min": ["b"], "exact": true}])'
+}
+*/
+
+-- test: different expression
+EXPLAIN SELECT * FROM users WHERE UPPER(email) = 'FOO@EXAMPLE.COM';
+/* result:
+{
+    "plan": 'table.Scan("users") | docs.Filter(UPPER(email) = "FOO@EXAMPLE.COM")'
+}
+*/
+
+-- test: path
+EXPLAIN SELECT * FROM users WHERE email = 'foo@example.com';
+/* result:
+{
+    "plan": 'table.Scan("users") | docs.Filter(email = "foo@example.com")'
+}
+*/
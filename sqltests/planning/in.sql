@@ -0,0 +1,83 @@
+-- setup:
+CREATE TABLE test(a INT PRIMARY KEY, b INT, c INT);
+CREATE INDEX test_b ON test(b);
+INSERT INTO test (a, b, c) VALUES (1, 10, 100), (2, 20, 200), (3, 30, 300), (4, 40, 400);
+
+-- test: list
+EXPLAIN SELECT * FROM test WHERE b IN (10, 20, 30);
+/* result:
+{
+    "plan": 'index.Scan("test_b", [{"min": [10], "exact": true}, {"min": [20], "exact": true}, {"min": [30], "exact": true}])'
+}
+*/
+
+-- test: list / result
+SELECT a FROM test WHERE b IN (10, 20, 30);
+/* result:
+{"a": 1}
+{"a": 2}
+{"a": 3}
+*/
+
+-- test: single element
+EXPLAIN SELECT * FROM test WHERE b IN (20);
+/* result:
+{
+    "plan": 'index.Scan("test_b", [{"min": [20], "exact": true}])'
+}
+*/
+
+-- test: primary key
+EXPLAIN SELECT * FROM test WHERE a IN (1, 3);
+/* result:
+{
+    "plan": 'table.Scan("test", [{"min": [1], "exact": true}, {"min": [3], "exact": true}])'
+}
+*/
+
+-- test: duplicates and NULL are ignored
+EXPLAIN SELECT * FROM test WHERE b IN (10, NULL, 10, 20);
+/* result:
+{
+    "plan": 'index.Scan("test_b", [{"min": [10], "exact": true}, {"min": [20], "exact": true}])'
+}
+*/
+
+-- test: duplicates / result
+SELECT a FROM test WHERE b IN (10, NULL, 10, 20);
+/* result:
+{"a": 1}
+{"a": 2}
+*/
+
+-- test: only NULL
+EXPLAIN SELECT * FROM test WHERE b IN (NULL);
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(b IN [NULL])'
+}
+*/
+
+-- test: not indexed
+EXPLAIN SELECT * FROM test WHERE c IN (100, 200);
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(c IN [100, 200])'
+}
+*/
+
+-- test: NOT IN
+EXPLAIN SELECT * FROM test WHERE b NOT IN (10, 20);
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(b NOT IN [10, 20])'
+}
+*/
+
+-- test: subquery
+EXPLAIN SELECT * FROM test WHERE b IN (SELECT c FROM test);
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(b IN (SELECT c FROM test))'
+}
+*/
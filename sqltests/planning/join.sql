@@ -0,0 +1,36 @@
+-- setup:
+CREATE TABLE users(id INT PRIMARY KEY, name TEXT);
+CREATE TABLE orders(id INT PRIMARY KEY, user_id INT, amount INT);
+CREATE INDEX orders_user_id ON orders(user_id);
+
+-- test: join on an indexed field
+EXPLAIN SELECT * FROM users JOIN orders ON users.id = orders.user_id;
+/* result:
+{
+    "plan": 'table.Scan("users") | table.Join("users", "orders", index.Scan("orders_user_id", [{"min": [users.id], "exact": true}]), users.id = orders.user_id)'
+}
+*/
+
+-- test: join on the primary key
+EXPLAIN SELECT * FROM orders JOIN users ON users.id = orders.user_id;
+/* result:
+{
+    "plan": 'table.Scan("orders") | table.Join("orders", "users", table.Scan("users", [{"min": [orders.user_id], "exact": true}]), users.id = orders.user_id)'
+}
+*/
+
+-- test: join on a field that is not indexed
+EXPLAIN SELECT * FROM users JOIN orders ON users.id = orders.amount;
+/* result:
+{
+    "plan": 'table.Scan("users") | table.Join("users", "orders", table.Scan("orders"), users.id = orders.amount)'
+}
+*/
+
+-- test: filters are not used to select an index of the first table
+EXPLAIN SELECT * FROM users JOIN orders ON users.id = orders.user_id WHERE users.id = 1;
+/* result:
+{
+    "plan": 'table.Scan("users") | table.Join("users", "orders", index.Scan("orders_user_id", [{"min": [users.id], "exact": true}]), users.id = orders.user_id) | docs.Filter(users.id = 1)'
+}
+*/
@@ -0,0 +1,60 @@
+-- setup:
+CREATE TABLE test(a TEXT PRIMARY KEY, b TEXT, c INT);
+CREATE INDEX test_b ON test(b);
+CREATE INDEX test_c ON test(c);
+
+-- test: prefix
+EXPLAIN SELECT * FROM test WHERE b LIKE '2021-%';
+/* result:
+{
+    "plan": 'index.Scan("test_b", [{"min": ["2021-"], "max": ["2021."]}]) | docs.Filter(b LIKE "2021-%")'
+}
+*/
+
+-- test: prefix with wildcards
+EXPLAIN SELECT * FROM test WHERE b LIKE '20_1-%';
+/* result:
+{
+    "plan": 'index.Scan("test_b", [{"min": ["20"], "max": ["21"]}]) | docs.Filter(b LIKE "20_1-%")'
+}
+*/
+
+-- test: primary key
+EXPLAIN SELECT * FROM test WHERE a LIKE '2021-%';
+/* result:
+{
+    "plan": 'table.Scan("test", [{"min": ["2021-"], "max": ["2021."]}]) | docs.Filter(a LIKE "2021-%")'
+}
+*/
+
+-- test: case-insensitive prefix
+EXPLAIN SELECT * FROM test WHERE b LIKE 'abc%';
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(b LIKE "abc%")'
+}
+*/
+
+-- test: no prefix
+EXPLAIN SELECT * FROM test WHERE b LIKE '%2021';
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(b LIKE "%2021")'
+}
+*/
+
+-- test: NOT LIKE
+EXPLAIN SELECT * FROM test WHERE b NOT LIKE '2021%';
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(b NOT LIKE "2021%")'
+}
+*/
+
+-- test: non-text field
+EXPLAIN SELECT * FROM test WHERE c LIKE '2021%';
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(c LIKE "2021%")'
+}
+*/
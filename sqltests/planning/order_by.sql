@@ -44,4 +44,30 @@ EXPLAIN SELECT * FROM test ORDER BY a DESC;
 {
     "plan": 'index.ScanReverse("test_a")'
 }
-*/
\ No newline at end of file
+*/
+-- test: indexed field path, DESC with LIMIT
+EXPLAIN SELECT * FROM test ORDER BY a DESC LIMIT 2;
+/* result:
+{
+    "plan": 'index.ScanReverse("test_a") | docs.Take(2)'
+}
+*/
+
+-- test: indexed field path, DESC with LIMIT / result
+SELECT a FROM test ORDER BY a DESC LIMIT 2;
+/* result:
+{
+    "a": 5
+}
+{
+    "a": 4
+}
+*/
+
+-- test: indexed field path, DESC with filter and LIMIT
+EXPLAIN SELECT a FROM test WHERE c > 1 ORDER BY a DESC LIMIT 2;
+/* result:
+{
+    "plan": 'index.ScanReverse("test_a") | docs.Filter(c > 1) | docs.Project(a) | docs.Take(2)'
+}
+*/
@@ -84,3 +84,33 @@ EXPLAIN SELECT * FROM test WHERE b = 10 ORDER BY a DESC;
     "plan": 'index.ScanReverse("test_a_b") | docs.Filter(b = 10)'
 }
 */
+
+-- test: filtering and sorting: IN on first path, order by second path
+EXPLAIN SELECT * FROM test WHERE a IN (1, 10) ORDER BY b;
+/* result:
+{
+    "plan": 'index.Scan("test_a_b", [{"min": [1], "exact": true}, {"min": [10], "exact": true}]) | docs.TempTreeSort(b)'
+}
+*/
+
+-- test: filtering and sorting: IN on sorted path
+EXPLAIN SELECT * FROM test WHERE a IN (10, 1) ORDER BY a DESC;
+/* result:
+{
+    "plan": 'index.Scan("test_a_b", [{"min": [10], "exact": true}, {"min": [1], "exact": true}]) | docs.TempTreeSortReverse(a)'
+}
+*/
+
+-- test: filtering and sorting: IN on sorted path / result
+SELECT a FROM test WHERE a IN (1, 3, 2) ORDER BY a DESC;
+/* result:
+{
+    "a": 3
+}
+{
+    "a": 2
+}
+{
+    "a": 1
+}
+*/
@@ -0,0 +1,135 @@
+-- setup:
+CREATE TABLE test(a INT, b INT, c INT);
+CREATE INDEX test_a ON test(a);
+CREATE INDEX test_b ON test(b);
+INSERT INTO test (a, b, c) VALUES (1, 1, 1), (1, 2, 2), (1, 3, 3), (1, 4, 4), (1, 5, 5), (1, 6, 6);
+
+-- test: without statistics
+EXPLAIN SELECT * FROM test WHERE a = 1 AND b = 2;
+/* result:
+{
+    "plan": 'index.Scan("test_a", [{"min": [1], "exact": true}]) | docs.Filter(b = 2)'
+}
+*/
+
+-- test: most selective index
+ANALYZE test;
+EXPLAIN SELECT * FROM test WHERE a = 1 AND b = 2;
+/* result:
+{
+    "plan": 'index.Scan("test_b", [{"min": [2], "exact": true}]) | docs.Filter(a = 1)'
+}
+*/
+
+-- test: most selective index / result
+ANALYZE test;
+SELECT * FROM test WHERE a = 1 AND b = 2;
+/* result:
+{
+    "a": 1,
+    "b": 2,
+    "c": 2
+}
+*/
+
+-- test: selective index
+ANALYZE test;
+EXPLAIN SELECT * FROM test WHERE b = 2;
+/* result:
+{
+    "plan": 'index.Scan("test_b", [{"min": [2], "exact": true}])'
+}
+*/
+
+-- test: low cardinality index
+ANALYZE test;
+EXPLAIN SELECT * FROM test WHERE a = 1;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(a = 1)'
+}
+*/
+
+-- test: low cardinality index / result
+ANALYZE test;
+SELECT COUNT(*) FROM test WHERE a = 1;
+/* result:
+{
+    "COUNT(*)": 6
+}
+*/
+
+-- test: index used for sorting
+ANALYZE test;
+EXPLAIN SELECT * FROM test WHERE a = 1 ORDER BY a;
+/* result:
+{
+    "plan": 'index.Scan("test_a", [{"min": [1], "exact": true}])'
+}
+*/
+
+-- test: histogram of a selective range
+ANALYZE test;
+EXPLAIN SELECT * FROM test WHERE b > 5;
+/* result:
+{
+    "plan": 'index.Scan("test_b", [{"min": [5], "exclusive": true}])'
+}
+*/
+
+-- test: histogram of a large range
+ANALYZE test;
+EXPLAIN SELECT * FROM test WHERE b > 1;
+/* result:
+{
+    "plan": 'table.Scan("test") | docs.Filter(b > 1)'
+}
+*/
+
+-- test: join without statistics
+CREATE TABLE users(id INT PRIMARY KEY, name TEXT);
+INSERT INTO users (id, name) VALUES (1, 'a'), (2, 'b');
+EXPLAIN SELECT * FROM test JOIN users ON users.id = test.b;
+/* result:
+{
+    "plan": 'table.Scan("test") | table.Join("test", "users", table.Scan("users", [{"min": [test.b], "exact": true}]), users.id = test.b)'
+}
+*/
+
+-- test: join of a smaller table first
+CREATE TABLE users(id INT PRIMARY KEY, name TEXT);
+INSERT INTO users (id, name) VALUES (1, 'a'), (2, 'b');
+ANALYZE;
+EXPLAIN SELECT * FROM test JOIN users ON users.id = test.b;
+/* result:
+{
+    "plan": 'table.Scan("users") | table.Join("users", "test", index.Scan("test_b", [{"min": [users.id], "exact": true}]), users.id = test.b)'
+}
+*/
+
+-- test: join of a smaller table first / result
+CREATE TABLE users(id INT PRIMARY KEY, name TEXT);
+INSERT INTO users (id, name) VALUES (1, 'a'), (2, 'b');
+ANALYZE;
+SELECT * FROM test JOIN users ON users.id = test.b;
+/* result:
+{
+    "test": {"a": 1, "b": 1, "c": 1},
+    "users": {"id": 1, "name": "a"}
+}
+{
+    "test": {"a": 1, "b": 2, "c": 2},
+    "users": {"id": 2, "name": "b"}
+}
+*/
+
+-- test: left joins keep their order
+CREATE TABLE users(id INT PRIMARY KEY, name TEXT);
+INSERT INTO users (id, name) VALUES (1, 'a'), (2, 'b');
+ANALYZE;
+EXPLAIN SELECT * FROM test LEFT JOIN users ON users.id = test.b;
+/* result:
+{
+    "plan": 'table.Scan("test") | table.LeftJoin("test", "users", table.Scan("users", [{"min": [test.b], "exact": true}]), users.id = test.b)'
+}
+*/
@@ -84,4 +84,68 @@ EXPLAIN SELECT * FROM test WHERE a IN (1, b + 3);
  {
     "plan": 'table.Scan("test") | docs.Filter(a IN [1, b + 3])'
  }
-*/
\ No newline at end of file
+*/
+
+-- test: OR
+EXPLAIN SELECT * FROM test WHERE a = 1 OR b > 3;
+/* result:
+ {
+    "plan": 'table.Union("test", index.Scan("test_a", [{"min": [1], "exact": true}]), index.Scan("test_b", [{"min": [3], "exclusive": true}]))'
+ }
+*/
+
+-- test: OR with non-indexed path
+EXPLAIN SELECT * FROM test WHERE a = 1 OR c > 3;
+/* result:
+ {
+    "plan": 'table.Scan("test") | docs.Filter(a = 1 OR c > 3)'
+ }
+*/
+
+-- test: operand on the left
+EXPLAIN SELECT * FROM test WHERE 10 < a AND c > 5;
+/* result:
+ {
+    "plan": 'index.Scan("test_a", [{"min": [10], "exclusive": true}]) | docs.Filter(c > 5)'
+ }
+*/
+
+-- test: > and <
+EXPLAIN SELECT * FROM test WHERE a > 1 AND a < 10 AND c > 5;
+/* result:
+ {
+    "plan": 'index.Scan("test_a", [{"min": [1], "max": [10], "exclusive": true}]) | docs.Filter(c > 5)'
+ }
+*/
+
+-- test: <= and >=
+EXPLAIN SELECT * FROM test WHERE a <= 10 AND a >= 1;
+/* result:
+ {
+    "plan": 'index.Scan("test_a", [{"min": [1], "max": [10]}])'
+ }
+*/
+
+-- test: >= and <
+EXPLAIN SELECT * FROM test WHERE a >= 1 AND a < 10;
+/* result:
+ {
+    "plan": 'index.Scan("test_a", [{"min": [1], "max": [10]}]) | docs.Filter(a < 10)'
+ }
+*/
+
+-- test: > and <=
+EXPLAIN SELECT * FROM test WHERE a > 1 AND a <= 10;
+/* result:
+ {
+    "plan": 'index.Scan("test_a", [{"min": [1], "max": [10]}]) | docs.Filter(a > 1)'
+ }
+*/
+
+-- test: operand on the left, with both boundaries
+EXPLAIN SELECT * FROM test WHERE 1 < a AND 10 > a;
+/* result:
+ {
+    "plan": 'index.Scan("test_a", [{"min": [1], "max": [10], "exclusive": true}])'
+ }
+*/
@@ -2,6 +2,7 @@ package types
 
 import (
 	"bytes"
+	"math"
 	"sort"
 	"strings"
 	"time"
@@ -198,6 +199,18 @@ func compareIntegers(op operator, l, r int64) bool {
 }
 
 func compareNumbers(op operator, l, r Value) bool {
+	// comparing an integer with a double by converting the integer
+	// to a double loses precision for integers larger than 2^53,
+	// compare them exactly instead.
+	if l.Type() == IntegerValue && r.Type() == DoubleValue {
+		c, ok := compareIntegerWithDouble(As[int64](l), As[float64](r))
+		return ok && matchComparison(op, c)
+	}
+	if l.Type() == DoubleValue && r.Type() == IntegerValue {
+		c, ok := compareIntegerWithDouble(As[int64](r), As[float64](l))
+		return ok && matchComparison(op, -c)
+	}
+
 	l = convertNumberToDouble(l)
 	r = convertNumberToDouble(r)
 
@@ -222,6 +235,57 @@ func compareNumbers(op operator, l, r Value) bool {
 	return ok
 }
 
+// compareIntegerWithDouble returns -1, 0 or 1 if i is respectively lower than,
+// equal to or greater than f, without converting i to a double.
+// It returns false if f is NaN.
+func compareIntegerWithDouble(i int64, f float64) (int, bool) {
+	switch {
+	case math.IsNaN(f):
+		return 0, false
+	// 2^63 is exactly representable as a double
+	case f >= math.MaxInt64:
+		return -1, true
+	case f < math.MinInt64:
+		return 1, true
+	}
+
+	// the integral part of f fits in an int64
+	t := math.Trunc(f)
+	ti := int64(t)
+
+	switch {
+	case i < ti:
+		return -1, true
+	case i > ti:
+		return 1, true
+	case f > t:
+		return -1, true
+	case f < t:
+		return 1, true
+	}
+
+	return 0, true
+}
+
+// matchComparison returns whether the result of a three-way comparison
+// satisfies the operator.
+func matchComparison(op operator, c int) bool {
+	switch op {
+	case operatorEq:
+		return c == 0
+	case operatorGt:
+		return c > 0
+	case operatorGte:
+		return c >= 0
+	case operatorLt:
+		return c < 0
+	case operatorLte:
+		return c <= 0
+	}
+
+	return false
+}
+
 func compareTimes(op operator, l, r time.Time) bool {
 	switch op {
 	case operatorEq:
@@ -3,6 +3,7 @@ package types_test
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -296,6 +297,14 @@ func TestCompareValues(t *testing.T) {
 		return types.NewTimestampValue(tm)
 	}
 
+	integer := func(i int64) types.Value {
+		return types.NewIntegerValue(i)
+	}
+
+	double := func(f float64) types.Value {
+		return types.NewDoubleValue(f)
+	}
+
 	tests := []struct {
 		op   string
 		a, b types.Value
@@ -310,6 +319,29 @@ func TestCompareValues(t *testing.T) {
 		{"=", text("2021-01-01T12:05:59.123456+02:00"), ts(carbon.Parse("2021-01-01 10:05:59.123456", "UTC").ToStdTime()), true},
 		{"=", text("2021-01-01T12:05:59.123456+02:00"), ts(carbon.Parse("2021-01-01T12:05:59.123456+02:00", "UTC").ToStdTime()), true},
 		{"=", text("2021-01-01 10:05:59.123456"), ts(carbon.Parse("2021-01-01T12:05:59.123456+02:00", "UTC").ToStdTime()), true},
+
+		// integer with double
+		{"=", integer(1), double(1), true},
+		{"=", integer(1), double(1.5), false},
+		{"<", integer(1), double(1.5), true},
+		{">", integer(2), double(1.5), true},
+		{"<", integer(-2), double(-1.5), true},
+		{">", integer(-1), double(-1.5), true},
+		{"=", integer(9007199254740993), double(9007199254740992), false},
+		{">", integer(9007199254740993), double(9007199254740992), true},
+		{"<", integer(math.MaxInt64), double(math.MaxInt64), true},
+		{">", integer(math.MinInt64), double(-1e19), true},
+		{"=", integer(math.MinInt64), double(math.MinInt64), true},
+		{"=", integer(1), double(math.NaN()), false},
+		{"<", integer(1), double(math.NaN()), false},
+
+		// double with integer
+		{"=", double(1), integer(1), true},
+		{">", double(1.5), integer(1), true},
+		{"<=", double(1.5), integer(2), true},
+		{"=", double(9007199254740992), integer(9007199254740993), false},
+		{"<", double(9007199254740992), integer(9007199254740993), true},
+		{">=", double(math.Inf(1)), integer(math.MaxInt64), true},
 	}
 
 	for _, test := range tests {